@@ -0,0 +1,96 @@
+package sysconf
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// WithAccessTracking 记录进程生命周期内被读取过的配置键，配合 UnreadKeys 找出从未使用的配置项。
+// 默认关闭，关闭时读取路径仅多一次布尔判断。
+func WithAccessTracking(enabled bool) Option {
+	return func(c *Config) {
+		c.trackAccess = enabled
+	}
+}
+
+// statsOwner 返回记录键读取与类型转换的配置：快照的读取记到创建它的配置上
+func (c *Config) statsOwner() *Config {
+	if c.statsParent != nil {
+		return c.statsParent
+	}
+	return c
+}
+
+// markRead 记录一次键读取
+func (c *Config) markRead(key string) {
+	if c.trackAccess {
+		c.statsOwner().readKeys.Store(key, struct{}{})
+	}
+}
+
+// markSectionRead 将 prefix 下的键记为已读取（Unmarshal 使用），unused 为解码时未匹配任何字段的键
+func (c *Config) markSectionRead(prefix string, unused []string) {
+	if !c.trackAccess {
+		return
+	}
+	readKeys := &c.statsOwner().readKeys
+	skipped := make([]string, 0, len(unused))
+	for _, key := range unused {
+		skipped = append(skipped, normalizeAccessKey(key))
+	}
+	for key := range c.loadData() {
+		relative := key
+		if prefix != "" {
+			if key != prefix && !strings.HasPrefix(key, prefix+".") {
+				continue
+			}
+			relative = strings.TrimPrefix(strings.TrimPrefix(key, prefix), ".")
+		}
+		relative = normalizeAccessKey(relative)
+		if slices.ContainsFunc(skipped, func(s string) bool { return relative == s || strings.HasPrefix(relative, s+".") }) {
+			continue
+		}
+		readKeys.Store(key, struct{}{})
+	}
+}
+
+// normalizeAccessKey 统一字段名与配置键的大小写及分隔符，便于比较解码元数据中的路径
+func normalizeAccessKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+}
+
+// UnreadKeys 返回配置文件（或远程主配置）中存在、但自启动以来从未被读取过的键（已排序）。
+// 读取父键（如 Get("database")）视为读取其全部子键；Unmarshal 仅记录实际映射到结构体字段的键。
+// 需启用 WithAccessTracking，否则返回 nil。
+func (c *Config) UnreadKeys() []string {
+	if !c.trackAccess {
+		return nil
+	}
+	data := c.loadData()
+	base := c.baseSource()
+	var unread []string
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		if source, _ := c.originOf(data, key); source != base {
+			continue
+		}
+		if !c.wasRead(key) {
+			unread = append(unread, key)
+		}
+	}
+	return unread
+}
+
+// wasRead 判断键自身或其任一父键是否被读取过
+func (c *Config) wasRead(key string) bool {
+	for {
+		if _, ok := c.readKeys.Load(key); ok {
+			return true
+		}
+		idx := strings.LastIndexByte(key, '.')
+		if idx < 0 {
+			return false
+		}
+		key = key[:idx]
+	}
+}
@@ -0,0 +1,49 @@
+package sysconf
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestUnreadKeys(t *testing.T) {
+	content := "app:\n  name: demo\n  legacy_mode: true\nserver:\n  host: localhost\n  port: 8080\n  old_timeout: 5\ncache:\n  size: 10\n  ttl: 60\nunused: yes\n"
+	cfg, err := New(WithContent(content), WithAccessTracking(true))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	all := []string{"app.legacy_mode", "app.name", "cache.size", "cache.ttl", "server.host", "server.old_timeout", "server.port", "unused"}
+	if got := cfg.UnreadKeys(); !slices.Equal(got, all) {
+		t.Fatalf("expected all keys unread, got %v", got)
+	}
+
+	_ = cfg.GetString("app.name")
+	_ = cfg.Get("cache") // 读取父键覆盖全部子键
+
+	var server struct {
+		Host string `config:"host"`
+		Port int    `config:"port"`
+	}
+	if err := cfg.Unmarshal(&server, "server"); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	want := []string{"app.legacy_mode", "server.old_timeout", "unused"}
+	if got := cfg.UnreadKeys(); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestUnreadKeysDisabled(t *testing.T) {
+	cfg, err := New(WithContent("app:\n  name: demo\n"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if got := cfg.UnreadKeys(); got != nil {
+		t.Fatalf("expected nil without tracking, got %v", got)
+	}
+}
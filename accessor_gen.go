@@ -0,0 +1,340 @@
+package sysconf
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// AccessorOptions GenerateAccessors 的生成选项
+type AccessorOptions struct {
+	Package  string // 生成代码的包名（必填）
+	TypeName string // 根访问器类型名，默认 "Accessors"
+	Source   string // 写入文件头注释的 schema 来源（如文件名），可为空
+}
+
+// accessorSchema 生成访问器所需的 JSON Schema 子集
+type accessorSchema struct {
+	Type        any                        `json:"type"`
+	Format      string                     `json:"format"`
+	Description string                     `json:"description"`
+	Default     any                        `json:"default"`
+	Properties  map[string]*accessorSchema `json:"properties"`
+	Items       *accessorSchema            `json:"items"`
+}
+
+// schemaType 返回 schema 的类型；type 为数组（如 ["string", "null"]）时取第一个非 null 类型
+func (s *accessorSchema) schemaType() string {
+	switch t := s.Type.(type) {
+	case string:
+		return t
+	case []any:
+		for _, item := range t {
+			if name, ok := item.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	if len(s.Properties) > 0 {
+		return "object"
+	}
+	return ""
+}
+
+// accessorGetter 叶子键对应的 Config 读取方法与 Go 类型
+type accessorGetter struct {
+	method string
+	goType string
+}
+
+// getter 根据 schema 类型与格式选择读取方法
+func (s *accessorSchema) getter() accessorGetter {
+	switch s.schemaType() {
+	case "string":
+		switch s.Format {
+		case "duration":
+			return accessorGetter{"GetDuration", "time.Duration"}
+		case "date-time":
+			return accessorGetter{"GetTime", "time.Time"}
+		}
+		return accessorGetter{"GetString", "string"}
+	case "integer":
+		return accessorGetter{"GetInt", "int"}
+	case "number":
+		return accessorGetter{"GetFloat", "float64"}
+	case "boolean":
+		return accessorGetter{"GetBool", "bool"}
+	case "array":
+		if s.Items != nil {
+			switch s.Items.schemaType() {
+			case "string":
+				return accessorGetter{"GetStringSlice", "[]string"}
+			case "integer":
+				return accessorGetter{"GetIntSlice", "[]int"}
+			case "number":
+				return accessorGetter{"GetFloatSlice", "[]float64"}
+			case "boolean":
+				return accessorGetter{"GetBoolSlice", "[]bool"}
+			}
+		}
+	case "object":
+		return accessorGetter{"GetStringMap", "map[string]any"}
+	}
+	return accessorGetter{"Get", "any"}
+}
+
+// accessorGroup 一个对象层级生成的访问器类型
+type accessorGroup struct {
+	typeName string
+	key      string
+	doc      string
+	methods  []accessorMethod
+}
+
+// accessorMethod 访问器类型上的一个方法
+type accessorMethod struct {
+	name  string
+	key   string
+	doc   string
+	child *accessorGroup // 非空时返回子层级访问器
+	get   accessorGetter
+	def   string // 默认值字面量，为空表示无默认值
+}
+
+// GenerateAccessors 根据 JSON Schema（type/properties/items/format/default/description 子集）生成强类型访问器代码：
+// 每个对象层级生成一个访问器类型，每个键生成一个读取方法，并为每个叶子键生成 Key 常量，
+// 使调用处不再出现字符串键并由编译器检查键名。format 为 "duration"/"date-time" 的字符串分别读取为
+// time.Duration/time.Time，标量的 default 作为读取默认值。生成的代码已经过 gofmt 格式化。
+func GenerateAccessors(schema []byte, opts AccessorOptions) ([]byte, error) {
+	if opts.Package == "" || !token.IsIdentifier(opts.Package) {
+		return nil, fmt.Errorf("invalid package name %q", opts.Package)
+	}
+	if opts.TypeName == "" {
+		opts.TypeName = "Accessors"
+	}
+	if !token.IsIdentifier(opts.TypeName) || !token.IsExported(opts.TypeName) {
+		return nil, fmt.Errorf("invalid accessor type name %q", opts.TypeName)
+	}
+
+	var root accessorSchema
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil, fmt.Errorf("parse schema: %w", err)
+	}
+	if root.schemaType() != "object" || len(root.Properties) == 0 {
+		return nil, errors.New("schema root must be an object with properties")
+	}
+
+	var groups []*accessorGroup
+	rootGroup, err := buildAccessorGroup(&root, opts.TypeName, "", "", &groups)
+	if err != nil {
+		return nil, err
+	}
+	rootGroup.doc = opts.TypeName + " 强类型配置访问器"
+	keyConsts := make(map[string]string)
+	for _, group := range groups {
+		for _, m := range group.methods {
+			if m.child != nil {
+				continue
+			}
+			name := accessorIdent(m.key)
+			if other, dup := keyConsts[name]; dup {
+				return nil, fmt.Errorf("keys %q and %q both map to constant Key%s", other, m.key, name)
+			}
+			keyConsts[name] = m.key
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by sysconf gen accessors; DO NOT EDIT.\n")
+	if opts.Source != "" {
+		fmt.Fprintf(&b, "// Source: %s\n", opts.Source)
+	}
+	fmt.Fprintf(&b, "\npackage %s\n\nimport (\n", opts.Package)
+	if accessorsUseTime(groups) {
+		b.WriteString("\t\"time\"\n\n")
+	}
+	b.WriteString("\t\"github.com/darkit/sysconf\"\n)\n\n")
+
+	writeAccessorKeys(&b, groups)
+
+	fmt.Fprintf(&b, "// New%s 创建基于 cfg 的配置访问器\n", opts.TypeName)
+	fmt.Fprintf(&b, "func New%s(cfg *sysconf.Config) *%s {\n\treturn &%s{cfg: cfg}\n}\n\n", opts.TypeName, opts.TypeName, opts.TypeName)
+	for _, group := range groups {
+		writeAccessorGroup(&b, group, group == rootGroup)
+	}
+
+	formatted, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return formatted, nil
+}
+
+// buildAccessorGroup 递归构建对象层级的访问器，groups 按深度优先顺序收集全部层级
+func buildAccessorGroup(s *accessorSchema, typeName, key, doc string, groups *[]*accessorGroup) (*accessorGroup, error) {
+	group := &accessorGroup{typeName: typeName, key: key, doc: doc}
+	*groups = append(*groups, group)
+
+	seen := make(map[string]string)
+	for _, name := range slices.Sorted(maps.Keys(s.Properties)) {
+		prop := s.Properties[name]
+		if prop == nil {
+			prop = &accessorSchema{}
+		}
+		method := accessorIdent(name)
+		if method == "" {
+			return nil, fmt.Errorf("cannot derive method name for key %q", joinKey(key, name))
+		}
+		if other, dup := seen[method]; dup {
+			return nil, fmt.Errorf("keys %q and %q both map to method %s", joinKey(key, other), joinKey(key, name), method)
+		}
+		seen[method] = name
+
+		fullKey := joinKey(key, name)
+		m := accessorMethod{name: method, key: fullKey, doc: prop.Description}
+		if prop.schemaType() == "object" && len(prop.Properties) > 0 {
+			childType := strings.TrimSuffix(typeName, "Accessors") + method + "Accessors"
+			if key == "" {
+				childType = method + "Accessors"
+			}
+			child, err := buildAccessorGroup(prop, childType, fullKey, prop.Description, groups)
+			if err != nil {
+				return nil, err
+			}
+			m.child = child
+		} else {
+			m.get = prop.getter()
+			m.def = accessorDefault(m.get, prop.Default)
+		}
+		group.methods = append(group.methods, m)
+	}
+	return group, nil
+}
+
+// writeAccessorKeys 为每个叶子键生成 Key 常量
+func writeAccessorKeys(b *bytes.Buffer, groups []*accessorGroup) {
+	b.WriteString("// 配置键常量\nconst (\n")
+	for _, group := range groups {
+		for _, m := range group.methods {
+			if m.child == nil {
+				fmt.Fprintf(b, "\tKey%s = %q\n", accessorIdent(m.key), m.key)
+			}
+		}
+	}
+	b.WriteString(")\n\n")
+}
+
+// writeAccessorGroup 输出一个访问器类型及其方法
+func writeAccessorGroup(b *bytes.Buffer, group *accessorGroup, root bool) {
+	doc := group.doc
+	if doc == "" {
+		doc = fmt.Sprintf("配置键 %q 下的访问器", group.key)
+	}
+	if !root {
+		doc = group.typeName + " " + doc
+	}
+	fmt.Fprintf(b, "// %s\ntype %s struct {\n\tcfg *sysconf.Config\n}\n\n", doc, group.typeName)
+
+	receiver := group.typeName
+	if root {
+		receiver = "*" + receiver
+	}
+	for _, m := range group.methods {
+		doc := m.doc
+		switch {
+		case doc != "":
+		case m.child != nil:
+			doc = fmt.Sprintf("返回配置键 %q 下的访问器", m.key)
+		default:
+			doc = fmt.Sprintf("返回配置键 %q 的值", m.key)
+		}
+		fmt.Fprintf(b, "// %s %s\n", m.name, doc)
+		if m.child != nil {
+			fmt.Fprintf(b, "func (a %s) %s() %s {\n\treturn %s{cfg: a.cfg}\n}\n\n", receiver, m.name, m.child.typeName, m.child.typeName)
+			continue
+		}
+		args := "Key" + accessorIdent(m.key)
+		if m.def != "" {
+			args += ", " + m.def
+		}
+		fmt.Fprintf(b, "func (a %s) %s() %s {\n\treturn a.cfg.%s(%s)\n}\n\n", receiver, m.name, m.get.goType, m.get.method, args)
+	}
+}
+
+// accessorsUseTime 判断生成的代码是否需要导入 time 包
+func accessorsUseTime(groups []*accessorGroup) bool {
+	for _, group := range groups {
+		for _, m := range group.methods {
+			if strings.HasPrefix(m.get.goType, "time.") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// accessorDefault 将标量默认值转换为 Go 字面量；类型不匹配或不支持默认值时返回空
+func accessorDefault(get accessorGetter, value any) string {
+	if value == nil {
+		return ""
+	}
+	switch get.method {
+	case "GetString":
+		if s, ok := value.(string); ok {
+			return strconv.Quote(s)
+		}
+	case "GetInt":
+		if f, ok := value.(float64); ok && f == float64(int64(f)) {
+			return strconv.FormatInt(int64(f), 10)
+		}
+	case "GetFloat":
+		if f, ok := value.(float64); ok {
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+	case "GetBool":
+		if v, ok := value.(bool); ok {
+			return strconv.FormatBool(v)
+		}
+	}
+	return ""
+}
+
+// accessorInitialisms 生成标识符时整体大写的常见缩写
+var accessorInitialisms = map[string]bool{
+	"api": true, "dns": true, "http": true, "https": true, "id": true, "ip": true, "json": true,
+	"sql": true, "tcp": true, "tls": true, "ttl": true, "udp": true, "uri": true, "url": true,
+	"uuid": true, "yaml": true,
+}
+
+// accessorIdent 将配置键（含 "."、"_"、"-" 分隔）转换为导出的 Go 标识符，如 "server.tls_cert" → "ServerTLSCert"
+func accessorIdent(key string) string {
+	words := strings.FieldsFunc(key, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, word := range words {
+		if accessorInitialisms[strings.ToLower(word)] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	ident := b.String()
+	if ident == "" {
+		return ""
+	}
+	if first := []rune(ident)[0]; unicode.IsDigit(first) {
+		ident = "X" + ident
+	}
+	return ident
+}
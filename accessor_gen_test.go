@@ -0,0 +1,73 @@
+package sysconf
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+const accessorTestSchema = `{
+  "type": "object",
+  "properties": {
+    "server": {
+      "type": "object",
+      "properties": {
+        "port": {"type": "integer", "default": 8080, "description": "监听端口"},
+        "read_timeout": {"type": "string", "format": "duration"},
+        "tls": {"type": "object", "properties": {"cert_file": {"type": "string"}}}
+      }
+    },
+    "hosts": {"type": "array", "items": {"type": "string"}},
+    "ratio": {"type": ["number", "null"], "default": 0.5}
+  }
+}`
+
+func TestGenerateAccessors(t *testing.T) {
+	code, err := GenerateAccessors([]byte(accessorTestSchema), AccessorOptions{Package: "config", Source: "schema.json"})
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "accessors_gen.go", code, 0); err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, code)
+	}
+
+	src := string(code)
+	for _, want := range []string{
+		"// Code generated by sysconf gen accessors; DO NOT EDIT.",
+		"package config",
+		`"time"`,
+		`KeyServerTLSCertFile = "server.tls.cert_file"`,
+		"func NewAccessors(cfg *sysconf.Config) *Accessors",
+		"func (a *Accessors) Server() ServerAccessors",
+		"// Port 监听端口",
+		"return a.cfg.GetInt(KeyServerPort, 8080)",
+		"func (a ServerAccessors) ReadTimeout() time.Duration",
+		"func (a ServerAccessors) TLS() ServerTLSAccessors",
+		"func (a ServerTLSAccessors) CertFile() string",
+		"func (a *Accessors) Hosts() []string",
+		"return a.cfg.GetFloat(KeyRatio, 0.5)",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated code missing %q\n%s", want, src)
+		}
+	}
+}
+
+func TestGenerateAccessorsErrors(t *testing.T) {
+	cases := map[string]struct {
+		schema string
+		opts   AccessorOptions
+	}{
+		"invalid package": {accessorTestSchema, AccessorOptions{Package: "my-config"}},
+		"unexported type": {accessorTestSchema, AccessorOptions{Package: "config", TypeName: "accessors"}},
+		"not an object":   {`{"type": "string"}`, AccessorOptions{Package: "config"}},
+		"invalid json":    {`{`, AccessorOptions{Package: "config"}},
+		"method clash":    {`{"properties": {"api_key": {"type": "string"}, "api-key": {"type": "string"}}}`, AccessorOptions{Package: "config"}},
+	}
+	for name, tc := range cases {
+		if _, err := GenerateAccessors([]byte(tc.schema), tc.opts); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
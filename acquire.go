@@ -0,0 +1,111 @@
+package sysconf
+
+import (
+	"time"
+)
+
+// ReadSnapshot 配置的不可变只读快照，由 Acquire 创建，提供与 Config 相同的读取方法。
+// 与供应用器比较新旧配置的 Snapshot 不同，ReadSnapshot 的读取遵循默认值、环境变量覆盖与类型转换规则。
+// 快照内的全部读取都来自同一配置版本，不受之后的 Set 与热重载影响，可在多个 goroutine 间共享。
+type ReadSnapshot struct {
+	cfg        *Config
+	acquiredAt time.Time
+}
+
+// Acquire 获取当前配置的一致性快照。请求处理等需要读取多个相关键的场景应先 Acquire，
+// 再从快照读取，避免请求中途发生的热重载导致读到两个版本混合的值：
+//
+//	snap := cfg.Acquire()
+//	host, port := snap.GetString("db.host"), snap.GetInt("db.port")
+//
+// 快照与配置共享底层不可变数据，创建开销为常数级。环境变量覆盖与 WithTimeLayouts、
+// WithLenientNumbers、WithExtendedBools 等解析选项在快照中保持生效。
+// 经快照的读取同样计入配置的 UnreadKeys 与 CoercionReport。
+func (c *Config) Acquire() *ReadSnapshot {
+	frozen := &Config{logger: c.logger, readOptions: c.readOptions, statsParent: c}
+	c.mu.RLock()
+	frozen.path, frozen.name, frozen.mode, frozen.configFileName = c.path, c.name, c.mode, c.configFileName
+	frozen.envOptions = c.envOptions
+	frozen.envSections = c.envSections
+	frozen.envAllow = c.envAllow
+	frozen.envEnabled.Store(c.envEnabled.Load())
+	c.mu.RUnlock()
+	frozen.copyFrom(&c.publishedState)
+	frozen.closed.Store(true)
+	return &ReadSnapshot{cfg: frozen, acquiredAt: time.Now()}
+}
+
+// AcquiredAt 返回快照的创建时间
+func (s *ReadSnapshot) AcquiredAt() time.Time { return s.acquiredAt }
+
+// Get 获取配置值，语义同 Config.Get
+func (s *ReadSnapshot) Get(key string, def ...any) any { return s.cfg.Get(key, def...) }
+
+// GetString 获取字符串配置
+func (s *ReadSnapshot) GetString(key string, def ...string) string {
+	return s.cfg.GetString(key, def...)
+}
+
+// GetPath 按路径规则读取配置值，语义同 Config.GetPath
+func (s *ReadSnapshot) GetPath(key string, def ...string) string {
+	return s.cfg.GetPath(key, def...)
+}
+
+// GetInt 获取整数配置
+func (s *ReadSnapshot) GetInt(key string, def ...int) int { return s.cfg.GetInt(key, def...) }
+
+// GetFloat 获取浮点数配置
+func (s *ReadSnapshot) GetFloat(key string, def ...float64) float64 {
+	return s.cfg.GetFloat(key, def...)
+}
+
+// GetBool 获取布尔配置
+func (s *ReadSnapshot) GetBool(key string, def ...bool) bool { return s.cfg.GetBool(key, def...) }
+
+// GetDuration 获取时间间隔配置
+func (s *ReadSnapshot) GetDuration(key string) time.Duration { return s.cfg.GetDuration(key) }
+
+// GetTime 获取时间配置
+func (s *ReadSnapshot) GetTime(key string) time.Time { return s.cfg.GetTime(key) }
+
+// GetStringSlice 获取字符串切片配置
+func (s *ReadSnapshot) GetStringSlice(key string, opts ...SliceOption) []string {
+	return s.cfg.GetStringSlice(key, opts...)
+}
+
+// GetIntSlice 获取整数切片配置
+func (s *ReadSnapshot) GetIntSlice(key string) []int { return s.cfg.GetIntSlice(key) }
+
+// GetFloatSlice 获取浮点数切片配置
+func (s *ReadSnapshot) GetFloatSlice(key string) []float64 { return s.cfg.GetFloatSlice(key) }
+
+// GetBoolSlice 获取布尔值切片配置
+func (s *ReadSnapshot) GetBoolSlice(key string) []bool { return s.cfg.GetBoolSlice(key) }
+
+// GetTimeSlice 获取时间切片配置
+func (s *ReadSnapshot) GetTimeSlice(key string) []time.Time { return s.cfg.GetTimeSlice(key) }
+
+// GetStringMap 获取字符串映射配置
+func (s *ReadSnapshot) GetStringMap(key string) map[string]any { return s.cfg.GetStringMap(key) }
+
+// GetStringMapString 获取字符串-字符串映射配置
+func (s *ReadSnapshot) GetStringMapString(key string) map[string]string {
+	return s.cfg.GetStringMapString(key)
+}
+
+// IsSet 检查配置键是否存在
+func (s *ReadSnapshot) IsSet(key string) bool { return s.cfg.IsSet(key) }
+
+// Keys 获取所有配置键
+func (s *ReadSnapshot) Keys() []string { return s.cfg.Keys() }
+
+// AllSettings 获取全部配置的嵌套副本
+func (s *ReadSnapshot) AllSettings() map[string]any { return s.cfg.AllSettings() }
+
+// Unmarshal 将快照解析到结构体，语义同 Config.Unmarshal
+func (s *ReadSnapshot) Unmarshal(obj any, key ...string) error { return s.cfg.Unmarshal(obj, key...) }
+
+// ReadSnapshotAs 泛型读取快照中的配置值，语义同 GetAs
+func ReadSnapshotAs[T any](s *ReadSnapshot, key string, defaultValue ...T) T {
+	return GetAs(s.cfg, key, defaultValue...)
+}
@@ -0,0 +1,136 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestAcquireSnapshotIsolation(t *testing.T) {
+	cfg, err := New(WithContent("db:\n  host: a\n  size: 1\n"), WithMode(YAML), WithLenientNumbers(true))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	snap := cfg.Acquire()
+	if err := cfg.SetMultiple(map[string]any{"db.host": "b", "db.size": "2,000"}); err != nil {
+		t.Fatalf("set multiple: %v", err)
+	}
+
+	if snap.GetString("db.host") != "a" || snap.GetInt("db.size") != 1 {
+		t.Fatalf("snapshot should keep the acquired version: %v", snap.AllSettings())
+	}
+	if cfg.GetString("db.host") != "b" || cfg.GetInt("db.size") != 2000 {
+		t.Fatalf("config should see the new version")
+	}
+	if got := cfg.Acquire().GetInt("db.size"); got != 2000 {
+		t.Fatalf("new snapshot should honour parse options, got %d", got)
+	}
+
+	var db struct {
+		Host string `config:"host"`
+		Size int    `config:"size"`
+	}
+	if err := snap.Unmarshal(&db, "db"); err != nil || db.Host != "a" || db.Size != 1 {
+		t.Fatalf("unmarshal snapshot: %+v, %v", db, err)
+	}
+	if ReadSnapshotAs[string](snap, "missing", "fallback") != "fallback" || !snap.IsSet("db.size") {
+		t.Fatalf("unexpected snapshot lookups")
+	}
+}
+
+func TestAcquireConsistentUnderWrites(t *testing.T) {
+	cfg, err := New(WithContent("pair:\n  a: 0\n  b: 0\n"), WithMode(YAML))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for i := 1; i <= 200; i++ {
+			_ = cfg.SetMultiple(map[string]any{"pair.a": i, "pair.b": i})
+		}
+	})
+	wg.Go(func() {
+		for range 500 {
+			snap := cfg.Acquire()
+			if a, b := snap.GetInt("pair.a"), snap.GetInt("pair.b"); a != b {
+				t.Errorf("snapshot mixed versions: a=%d b=%d", a, b)
+				return
+			}
+		}
+	})
+	wg.Wait()
+}
+
+func TestAcquireDotenvMode(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "app.env"), []byte("DB_HOST=h1\nDB_PORT=5432\n"), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	cfg, err := New(WithPath(tmpDir), WithName("app"), WithMode("env"), WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	snap := cfg.Acquire()
+	for _, key := range []string{"DB_HOST", "db.host"} {
+		if got, want := snap.GetString(key), cfg.GetString(key); got != want || got != "h1" {
+			t.Fatalf("snapshot %s = %q, config = %q", key, got, want)
+		}
+	}
+	if got := snap.GetInt("DB_PORT"); got != 5432 {
+		t.Fatalf("snapshot DB_PORT = %d", got)
+	}
+}
+
+func TestAcquireCopiesPublishedState(t *testing.T) {
+	cfg, err := New(WithContent("db:\n  host: a\n"), WithMode(YAML), WithOverrides("db.port=1"))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	cfg.GetString("db.host") // 建立规范键索引
+
+	// 快照必须复制 publishedState 的每个字段，新增字段遗漏复制时此处失败
+	src := reflect.ValueOf(&cfg.publishedState).Elem()
+	dst := reflect.ValueOf(&cfg.Acquire().cfg.publishedState).Elem()
+	for i := range src.NumField() {
+		load := func(v reflect.Value) any {
+			field := v.Field(i)
+			exported := reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr()))
+			return exported.MethodByName("Load").Call(nil)[0].Interface()
+		}
+		if !reflect.DeepEqual(load(src), load(dst)) {
+			t.Fatalf("snapshot did not copy %s", src.Type().Field(i).Name)
+		}
+	}
+}
+
+func TestAcquireRecordsReadsOnParent(t *testing.T) {
+	cfg, err := New(WithContent("x: \"5\"\ny: 2\n"), WithMode(YAML), WithAccessTracking(true))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	snap := cfg.Acquire()
+	if got := snap.GetInt("x"); got != 5 {
+		t.Fatalf("snapshot x = %d", got)
+	}
+	if unread := cfg.UnreadKeys(); !reflect.DeepEqual(unread, []string{"y"}) {
+		t.Fatalf("reads through a snapshot should count for the config, unread = %v", unread)
+	}
+	report := cfg.CoercionReport()
+	if len(report) != 1 || report[0].Key != "x" || report[0].Count != 1 {
+		t.Fatalf("snapshot coercions should be reported by the config, got %+v", report)
+	}
+}
@@ -0,0 +1,167 @@
+package sysconf
+
+import (
+	"errors"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// errDecoderOptions ViperFacade 不支持 viper 的解码选项
+var errDecoderOptions = errors.New("viper decoder options are not supported by sysconf")
+
+// KoanfProvider 实现 koanf.Provider 接口（结构化匹配，无需依赖 koanf），
+// 使第三方库可以通过 k.Load(cfg.KoanfProvider(), nil) 读取 sysconf 配置
+type KoanfProvider struct {
+	c *Config
+}
+
+// KoanfProvider 返回读取当前配置的 koanf.Provider，每次 Load 读取最新的生效配置
+func (c *Config) KoanfProvider() KoanfProvider {
+	return KoanfProvider{c: c}
+}
+
+// ReadBytes 实现 koanf.Provider；配置以嵌套 map 提供，不支持读取原始内容
+func (p KoanfProvider) ReadBytes() ([]byte, error) {
+	return nil, errors.New("sysconf koanf provider does not support ReadBytes")
+}
+
+// Read 实现 koanf.Provider，返回嵌套配置的副本
+func (p KoanfProvider) Read() (map[string]any, error) {
+	if p.c == nil {
+		return nil, errors.New("sysconf koanf provider has no config")
+	}
+	return p.c.AllSettings(), nil
+}
+
+// ViperReader viper 读取方法的子集，*viper.Viper 与 ViperFacade 均实现该接口。
+// 第三方库只需读取配置时可接受 ViperReader，调用方即可传入 viper 实例或 sysconf 配置。
+type ViperReader interface {
+	Get(key string) any
+	GetString(key string) string
+	GetBool(key string) bool
+	GetInt(key string) int
+	GetInt64(key string) int64
+	GetFloat64(key string) float64
+	GetDuration(key string) time.Duration
+	GetTime(key string) time.Time
+	GetStringSlice(key string) []string
+	GetIntSlice(key string) []int
+	GetStringMap(key string) map[string]any
+	GetStringMapString(key string) map[string]string
+	IsSet(key string) bool
+	AllKeys() []string
+	AllSettings() map[string]any
+	UnmarshalKey(key string, rawVal any, opts ...viper.DecoderConfigOption) error
+	Unmarshal(rawVal any, opts ...viper.DecoderConfigOption) error
+}
+
+var (
+	_ ViperReader = (*viper.Viper)(nil)
+	_ ViperReader = ViperFacade{}
+)
+
+// ViperFacade 以 viper 的方法签名读取 sysconf 配置，用于要求 viper 风格 getter 的第三方库。
+// 读取走 sysconf 的完整查找链（环境变量、覆盖值、缓存等），不依赖底层 viper 实例，原生引擎下同样可用。
+type ViperFacade struct {
+	c *Config
+}
+
+// ViperFacade 返回以 viper 方法签名读取当前配置的门面
+func (c *Config) ViperFacade() ViperFacade {
+	return ViperFacade{c: c}
+}
+
+// Get 同 viper.Get
+func (f ViperFacade) Get(key string) any { return f.c.Get(key) }
+
+// GetString 同 viper.GetString
+func (f ViperFacade) GetString(key string) string { return f.c.GetString(key) }
+
+// GetBool 同 viper.GetBool
+func (f ViperFacade) GetBool(key string) bool { return f.c.GetBool(key) }
+
+// GetInt 同 viper.GetInt
+func (f ViperFacade) GetInt(key string) int { return f.c.GetInt(key) }
+
+// GetInt64 同 viper.GetInt64
+func (f ViperFacade) GetInt64(key string) int64 { return GetAs[int64](f.c, key) }
+
+// GetFloat64 同 viper.GetFloat64
+func (f ViperFacade) GetFloat64(key string) float64 { return f.c.GetFloat(key) }
+
+// GetDuration 同 viper.GetDuration
+func (f ViperFacade) GetDuration(key string) time.Duration { return f.c.GetDuration(key) }
+
+// GetTime 同 viper.GetTime
+func (f ViperFacade) GetTime(key string) time.Time { return f.c.GetTime(key) }
+
+// GetStringSlice 同 viper.GetStringSlice
+func (f ViperFacade) GetStringSlice(key string) []string { return f.c.GetStringSlice(key) }
+
+// GetIntSlice 同 viper.GetIntSlice
+func (f ViperFacade) GetIntSlice(key string) []int { return f.c.GetIntSlice(key) }
+
+// GetStringMap 同 viper.GetStringMap
+func (f ViperFacade) GetStringMap(key string) map[string]any { return f.c.GetStringMap(key) }
+
+// GetStringMapString 同 viper.GetStringMapString
+func (f ViperFacade) GetStringMapString(key string) map[string]string {
+	return f.c.GetStringMapString(key)
+}
+
+// IsSet 同 viper.IsSet
+func (f ViperFacade) IsSet(key string) bool { return f.c.IsSet(key) }
+
+// AllKeys 同 viper.AllKeys，返回已排序的全部叶子键
+func (f ViperFacade) AllKeys() []string { return slices.Sorted(slices.Values(f.c.Keys())) }
+
+// AllSettings 同 viper.AllSettings
+func (f ViperFacade) AllSettings() map[string]any { return f.c.AllSettings() }
+
+// UnmarshalKey 同 viper.UnmarshalKey，按 sysconf 的规则解码（default 标签、验证器等）；不支持 viper 解码选项
+func (f ViperFacade) UnmarshalKey(key string, rawVal any, opts ...viper.DecoderConfigOption) error {
+	if len(opts) > 0 {
+		return errDecoderOptions
+	}
+	return f.c.Unmarshal(rawVal, key)
+}
+
+// Unmarshal 同 viper.Unmarshal，按 sysconf 的规则解码；不支持 viper 解码选项
+func (f ViperFacade) Unmarshal(rawVal any, opts ...viper.DecoderConfigOption) error {
+	if len(opts) > 0 {
+		return errDecoderOptions
+	}
+	return f.c.Unmarshal(rawVal)
+}
+
+// LookupEnv 返回 os.LookupEnv 形式的查找函数，供只接受环境变量查找的库读取 sysconf 配置。
+// 变量名与 ExportEnv 一致（大写的 <前缀>_<键>，"." 与 "-" 替换为 "_"）。
+// 变量名到配置键的索引在配置数据变化时重建，每次调用只解析对应键的当前生效值。
+//
+//	lib.Configure(lib.WithLookup(cfg.LookupEnv(sysconf.ExportOptions{Prefix: "APP"})))
+func (c *Config) LookupEnv(opts ExportOptions) func(name string) (string, bool) {
+	var index atomic.Pointer[envNameIndex]
+	return func(name string) (string, bool) {
+		if c.closed.Load() {
+			return "", false
+		}
+		data := c.loadData()
+		idx := index.Load()
+		if idx == nil || !sameMap(idx.data, data) {
+			idx = c.buildEnvNameIndex(data, opts)
+			index.Store(idx)
+		}
+		key, ok := idx.keys[name]
+		if !ok {
+			return "", false
+		}
+		value := data[key]
+		if effective, found := c.lookupRaw(key); found {
+			value = effective
+		}
+		return exportString(value), true
+	}
+}
@@ -0,0 +1,115 @@
+package sysconf
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
+)
+
+const adapterContent = `
+server:
+  host: localhost
+  port: 8080
+  timeout: 5s
+tags: [a, b]
+`
+
+func newAdapterConfig(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := New(WithContent(adapterContent))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	return cfg
+}
+
+func TestKoanfProvider(t *testing.T) {
+	cfg := newAdapterConfig(t)
+	provider := cfg.KoanfProvider()
+
+	settings, err := provider.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	server, ok := settings["server"].(map[string]any)
+	if !ok || server["host"] != "localhost" {
+		t.Fatalf("unexpected settings: %v", settings)
+	}
+	if _, err := provider.ReadBytes(); err == nil {
+		t.Fatal("ReadBytes should not be supported")
+	}
+}
+
+func TestViperFacade(t *testing.T) {
+	cfg := newAdapterConfig(t)
+	read := func(r ViperReader) (string, int64, time.Duration, []string) {
+		return r.GetString("server.host"), r.GetInt64("server.port"), r.GetDuration("server.timeout"), r.GetStringSlice("tags")
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.MergeConfigMap(cfg.AllSettings()); err != nil {
+		t.Fatal(err)
+	}
+	wantHost, wantPort, wantTimeout, wantTags := read(v)
+	host, port, timeout, tags := read(cfg.ViperFacade())
+	if host != wantHost || port != wantPort || timeout != wantTimeout || !slices.Equal(tags, wantTags) {
+		t.Fatalf("facade = %v %v %v %v, viper = %v %v %v %v", host, port, timeout, tags, wantHost, wantPort, wantTimeout, wantTags)
+	}
+
+	facade := cfg.ViperFacade()
+	if keys := facade.AllKeys(); !slices.Equal(keys, []string{"server.host", "server.port", "server.timeout", "tags"}) {
+		t.Fatalf("AllKeys = %v", keys)
+	}
+	var server struct {
+		Host string
+		Port int
+	}
+	if err := facade.UnmarshalKey("server", &server); err != nil || server.Port != 8080 {
+		t.Fatalf("UnmarshalKey = %+v, %v", server, err)
+	}
+	err := facade.Unmarshal(&server, func(*mapstructure.DecoderConfig) {})
+	if !errors.Is(err, errDecoderOptions) {
+		t.Fatalf("expected decoder options error, got %v", err)
+	}
+}
+
+func TestLookupEnv(t *testing.T) {
+	cfg := newAdapterConfig(t)
+	lookup := cfg.LookupEnv(ExportOptions{Prefix: "app"})
+
+	if value, ok := lookup("APP_SERVER_PORT"); !ok || value != "8080" {
+		t.Fatalf("APP_SERVER_PORT = %q, %v", value, ok)
+	}
+	if _, ok := lookup("APP_MISSING"); ok {
+		t.Fatal("missing variable should not be found")
+	}
+	if err := cfg.Set("server.port", 9090); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if value, _ := lookup("APP_SERVER_PORT"); value != "9090" {
+		t.Fatalf("lookup should reflect current config, got %q", value)
+	}
+}
+
+func TestLookupEnvMatchesExportEnv(t *testing.T) {
+	cfg := newAdapterConfig(t)
+	for _, opts := range []ExportOptions{
+		{Prefix: "app"},
+		{Sections: []string{"server"}, StripSection: true},
+		{KeyMapper: func(key string) string { return "X_" + key }},
+	} {
+		lookup := cfg.LookupEnv(opts)
+		for name, want := range cfg.ExportEnv(opts) {
+			if got, ok := lookup(name); !ok || got != want {
+				t.Fatalf("%+v: lookup(%s) = %q, %v, want %q", opts, name, got, ok, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package sysconf
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Snapshot 配置在某一时刻的只读快照，供应用器比较新旧配置
+type Snapshot struct {
+	data map[string]any
+}
+
+// Snapshot 返回当前配置的只读快照
+func (c *Config) Snapshot() Snapshot {
+	return Snapshot{data: c.loadData()}
+}
+
+// Lookup 返回键对应的值（返回副本）；父键返回由子键重建的嵌套 map
+func (s Snapshot) Lookup(key string) (any, bool) {
+	if value, ok := s.data[key]; ok {
+		return deepCloneValue(value), true
+	}
+	prefix := key + "."
+	nested := make(map[string]any)
+	for k, v := range s.data {
+		if rest, ok := strings.CutPrefix(k, prefix); ok {
+			setNestedMapValue(nested, rest, deepCloneValue(v))
+		}
+	}
+	if len(nested) == 0 {
+		return nil, false
+	}
+	return nested, true
+}
+
+// Get 返回键对应的值，不存在时返回 nil
+func (s Snapshot) Get(key string) any {
+	value, _ := s.Lookup(key)
+	return value
+}
+
+// Settings 返回快照的完整嵌套配置（副本）
+func (s Snapshot) Settings() map[string]any {
+	return nestFlatData(s.data)
+}
+
+// Changed 判断 prefix 下（prefix 为空时为全部配置）是否有键与 other 不同
+func (s Snapshot) Changed(other Snapshot, prefix string) bool {
+	segments := strings.Split(prefix, ".")
+	pick := func(data map[string]any) map[string]any {
+		result := make(map[string]any)
+		for key, value := range data {
+			if prefix == "" || matchKeyPattern(segments, strings.Split(key, ".")) {
+				result[key] = value
+			}
+		}
+		return result
+	}
+	return len(diffValueKeys(pick(s.data), pick(other.data))) > 0
+}
+
+// ApplierFunc 将新配置应用到运行中的组件，old 为应用前的配置
+type ApplierFunc func(old, new Snapshot) error
+
+// applier 已注册的应用器
+type applier struct {
+	name   string
+	fn     ApplierFunc
+	after  []string    // 必须先于本应用器执行的应用器
+	dryRun ApplierFunc // Shadow 评估候选配置时调用的演练函数，nil 表示不参与演练
+}
+
+// RegisterApplier 注册配置应用器，配置重载后按依赖顺序执行：after 中列出的应用器先于本应用器执行。
+// 任一应用器失败时，已执行的应用器按相反顺序以 fn(new, old) 回滚，配置恢复为重载前的数据，
+// 并且不会触发 Watch 回调，使热重载成为事务性操作。
+//
+//	cfg.RegisterApplier("db", applyDB)
+//	cfg.RegisterApplier("http", applyHTTP, "db") // db 先于 http
+func (c *Config) RegisterApplier(name string, fn ApplierFunc, after ...string) error {
+	if name == "" || fn == nil {
+		return fmt.Errorf("applier name and function are required")
+	}
+	c.applyMu.Lock()
+	defer c.applyMu.Unlock()
+	if slices.ContainsFunc(c.appliers, func(a *applier) bool { return a.name == name }) {
+		return fmt.Errorf("applier %q already registered", name)
+	}
+	c.appliers = append(c.appliers, &applier{name: name, fn: fn, after: slices.Clone(after)})
+	return nil
+}
+
+// orderAppliers 按依赖关系对应用器排序，同层保持注册顺序
+func orderAppliers(list []*applier) ([]*applier, error) {
+	known := make(map[string]bool, len(list))
+	for _, a := range list {
+		known[a.name] = true
+	}
+	for _, a := range list {
+		for _, dep := range a.after {
+			if !known[dep] {
+				return nil, fmt.Errorf("applier %q depends on unknown applier %q", a.name, dep)
+			}
+		}
+	}
+
+	ordered := make([]*applier, 0, len(list))
+	placed := make(map[string]bool, len(list))
+	for len(ordered) < len(list) {
+		progressed := false
+		for _, a := range list {
+			if placed[a.name] || !allPlaced(a.after, placed) {
+				continue
+			}
+			ordered = append(ordered, a)
+			placed[a.name] = true
+			progressed = true
+		}
+		if !progressed {
+			var pending []string
+			for _, a := range list {
+				if !placed[a.name] {
+					pending = append(pending, a.name)
+				}
+			}
+			return nil, fmt.Errorf("applier dependency cycle among %v", pending)
+		}
+	}
+	return ordered, nil
+}
+
+// allPlaced 判断依赖是否均已排入
+func allPlaced(deps []string, placed map[string]bool) bool {
+	for _, dep := range deps {
+		if !placed[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+// applyReload 重载成功后执行应用管道；失败时回滚已执行的应用器并恢复重载前的配置
+func (c *Config) applyReload(oldData, oldCache map[string]any, oldOrigins *map[string]Source, oldRemote bool) error {
+	c.applyMu.Lock()
+	defer c.applyMu.Unlock()
+	if len(c.appliers) == 0 {
+		return nil
+	}
+
+	old, next := Snapshot{data: oldData}, c.Snapshot()
+	ordered, err := orderAppliers(c.appliers)
+	if err == nil {
+		for i, a := range ordered {
+			if applyErr := a.fn(old, next); applyErr != nil {
+				err = fmt.Errorf("applier %q: %w", a.name, applyErr)
+				c.rollbackAppliers(ordered[:i], old, next)
+				break
+			}
+		}
+	}
+	if err != nil {
+		c.restoreSnapshot(&snapshot{data: oldData, readCache: oldCache, origins: oldOrigins})
+		c.remoteLoaded.Store(oldRemote)
+		c.invalidateLookupCache()
+		c.invalidateCache()
+		return err
+	}
+	c.logger.Debugf("Applied config to %d appliers", len(ordered))
+	return nil
+}
+
+// rollbackAppliers 按相反顺序将已执行的应用器恢复到旧配置
+func (c *Config) rollbackAppliers(applied []*applier, old, next Snapshot) {
+	for _, a := range slices.Backward(applied) {
+		if err := a.fn(next, old); err != nil {
+			c.logger.Errorf("Failed to roll back applier %s: %v", a.name, err)
+		}
+	}
+}
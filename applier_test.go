@@ -0,0 +1,98 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplierPipeline(t *testing.T) {
+	cfg, configFile := newWatchTestConfig(t)
+	replaceFile := func(data string) {
+		time.Sleep(50 * time.Millisecond) // 越过前沿防抖窗口
+		tmp := configFile + ".tmp"
+		require.NoError(t, os.WriteFile(tmp, []byte(data), 0o644))
+		require.NoError(t, os.Rename(tmp, configFile))
+	}
+
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	record := func(name string, fail func(next Snapshot) bool) ApplierFunc {
+		return func(old, next Snapshot) error {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, fmt.Sprintf("%s:%v->%v", name, old.Get("key"), next.Get("key")))
+			if fail != nil && fail(next) {
+				return errors.New("boom")
+			}
+			return nil
+		}
+	}
+	require.NoError(t, cfg.RegisterApplier("http", record("http", func(next Snapshot) bool { return next.Get("key") == "bad" }), "db"))
+	require.NoError(t, cfg.RegisterApplier("db", record("db", nil)))
+	require.Error(t, cfg.RegisterApplier("db", record("db", nil)))
+
+	watched := make(chan struct{}, 4)
+	cfg.Watch(func() { watched <- struct{}{} })
+
+	// 依赖顺序：db 先于 http
+	replaceFile("key: good\n")
+	waitSignal(t, watched, "expected watch callback after successful apply")
+	mu.Lock()
+	assert.Equal(t, []string{"db:initial->good", "http:initial->good"}, calls)
+	calls = nil
+	mu.Unlock()
+
+	// http 失败：db 以相反方向回滚，配置恢复且不触发 Watch 回调
+	replaceFile("key: bad\n")
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(calls) == 3
+	}, 3*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, []string{"db:good->bad", "http:good->bad", "db:bad->good"}, calls)
+	mu.Unlock()
+	assert.Equal(t, "good", cfg.GetString("key"))
+	select {
+	case <-watched:
+		t.Fatal("watch callbacks should not fire after rollback")
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.False(t, cfg.Health().Healthy)
+}
+
+func TestOrderAppliersAndSnapshot(t *testing.T) {
+	noop := func(Snapshot, Snapshot) error { return nil }
+	ordered, err := orderAppliers([]*applier{
+		{name: "c", fn: noop, after: []string{"b"}},
+		{name: "a", fn: noop},
+		{name: "b", fn: noop, after: []string{"a"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "a", ordered[0].name)
+	require.Equal(t, "b", ordered[1].name)
+	require.Equal(t, "c", ordered[2].name)
+
+	_, err = orderAppliers([]*applier{{name: "a", after: []string{"b"}}, {name: "b", after: []string{"a"}}})
+	require.ErrorContains(t, err, "cycle")
+	_, err = orderAppliers([]*applier{{name: "a", after: []string{"missing"}}})
+	require.ErrorContains(t, err, "unknown")
+
+	old := Snapshot{data: map[string]any{"http.port": 80, "db.host": "a"}}
+	next := Snapshot{data: map[string]any{"http.port": 8080, "db.host": "a"}}
+	assert.True(t, old.Changed(next, "http"))
+	assert.False(t, old.Changed(next, "db"))
+	assert.Equal(t, map[string]any{"port": 8080}, next.Get("http"))
+	assert.Equal(t, map[string]any{"http": map[string]any{"port": 80}, "db": map[string]any{"host": "a"}}, old.Settings())
+	_, ok := old.Lookup("missing")
+	assert.False(t, ok)
+}
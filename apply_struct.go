@@ -0,0 +1,97 @@
+package sysconf
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ApplyStruct 将结构体中已设置的字段写回配置，只更新这些字段对应的键，配置段中的其他键保持不变。
+// 适合绑定到结构体的管理表单“只修改一个字段”的场景；Marshal 则会用整个结构体覆盖配置段。
+//
+// 字段是否写入的规则：
+//   - 非指针字段仅在非零值时写入；
+//   - 指针字段在非 nil 时写入，即使指向零值，可用于显式写入 0、false 或空字符串；
+//   - 嵌套结构体（及非 nil 的结构体指针）按相同规则逐字段处理。
+//
+// 字段键名解析与 Unmarshal 一致（config 等标签，缺省为下划线风格）；与当前值相同的键被跳过，
+// 其余键通过一次 SetMultiple 写入，因此验证失败时全部回滚。
+//
+//	var patch ServerConfig
+//	patch.Port = 9090
+//	err := cfg.ApplyStruct(&patch, "server") // 只更新 server.port
+func (c *Config) ApplyStruct(obj any, key ...string) error {
+	val := reflect.ValueOf(obj)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return errors.New("apply struct: source cannot be nil")
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return errors.New("apply struct: source must be a struct or pointer to struct")
+	}
+
+	changes := make(map[string]any)
+	collectStructFields(val, strings.Join(key, "."), changes)
+
+	current := c.loadData()
+	for k, v := range changes {
+		if old, ok := current[k]; ok && reflect.DeepEqual(old, sanitizeValue(v)) {
+			delete(changes, k)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return c.SetMultiple(changes)
+}
+
+// collectStructFields 收集结构体中已设置字段的配置键与值
+func collectStructFields(val reflect.Value, prefix string, out map[string]any) {
+	t := val.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, inline, skip := docFieldKey(sf)
+		if skip {
+			continue
+		}
+		field := val.Field(i)
+		fieldKey := joinKey(prefix, name)
+		if inline {
+			fieldKey = prefix
+		}
+
+		explicit := false
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				continue
+			}
+			field, explicit = field.Elem(), true
+		}
+		if field.Kind() == reflect.Struct && field.Type() != timeType {
+			collectStructFields(field, fieldKey, out)
+			continue
+		}
+		if !explicit && field.IsZero() {
+			continue
+		}
+		out[fieldKey] = structFieldValue(field)
+	}
+}
+
+// structFieldValue 将字段值转换为写入配置文件的形式，时长与时间以字符串保存以便往返解析
+func structFieldValue(field reflect.Value) any {
+	switch v := field.Interface().(type) {
+	case time.Duration:
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,79 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+type applyServer struct {
+	Host    string         `config:"host"`
+	Port    int            `config:"port"`
+	Debug   *bool          `config:"debug"`
+	Timeout time.Duration  `config:"timeout"`
+	TLS     applyServerTLS `config:"tls"`
+}
+
+type applyServerTLS struct {
+	Cert string `config:"cert"`
+}
+
+func TestApplyStruct(t *testing.T) {
+	dir := t.TempDir()
+	content := `server:
+  host: example.com
+  port: 80
+  debug: true
+  timeout: 5s
+  tls:
+    cert: old.crt
+    key: old.key
+other: keep
+`
+	cfg, err := New(WithPath(dir), WithName("app"), WithMode(YAML), WithContent(content), WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	debug := false
+	patch := applyServer{Port: 9090, Debug: &debug, Timeout: 30 * time.Second, TLS: applyServerTLS{Cert: "new.crt"}}
+	if err := cfg.ApplyStruct(&patch, "server"); err != nil {
+		t.Fatalf("apply struct: %v", err)
+	}
+
+	if got := cfg.GetInt("server.port"); got != 9090 {
+		t.Fatalf("server.port = %d", got)
+	}
+	if cfg.GetBool("server.debug", true) {
+		t.Fatalf("explicit false pointer should be written")
+	}
+	if got := cfg.GetDuration("server.timeout"); got != 30*time.Second {
+		t.Fatalf("server.timeout = %v", got)
+	}
+	if got := cfg.GetString("server.host"); got != "example.com" {
+		t.Fatalf("zero field should not overwrite host, got %q", got)
+	}
+	if cfg.GetString("server.tls.key") != "old.key" || cfg.GetString("server.tls.cert") != "new.crt" || cfg.GetString("other") != "keep" {
+		t.Fatalf("unrelated keys changed: %v", cfg.AllSettings())
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "app.yaml"))
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+	if !strings.Contains(string(data), "old.key") || !strings.Contains(string(data), "30s") {
+		t.Fatalf("file not updated minimally:\n%s", data)
+	}
+
+	if err := cfg.ApplyStruct(nil); err == nil {
+		t.Fatalf("nil source should fail")
+	}
+	if err := cfg.ApplyStruct(42); err == nil {
+		t.Fatalf("non-struct source should fail")
+	}
+}
@@ -0,0 +1,90 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ErrChangeRejected 配置变更被审批器拒绝
+var ErrChangeRejected = errors.New("config change rejected")
+
+// Change 待提交的单个配置变更
+type Change struct {
+	Key      string // 配置键
+	OldValue any    // 变更前的值（Existed 为 false 时为 nil）
+	NewValue any    // 规范化后的新值
+	Existed  bool   // 变更前键是否存在
+	Batch    int    // 同一批次（SetMultiple）中的变更数量，Set 为 1
+}
+
+// ChangeApprover 在 Set/SetMultiple 提交前审批配置变更，可对接外部策略引擎（如 OPA）或人工审批系统。
+// 返回非 nil 错误即拒绝变更，整个批次不会生效。
+type ChangeApprover interface {
+	Approve(change Change) error
+}
+
+// ChangeApproverFunc 函数形式的审批器
+type ChangeApproverFunc func(change Change) error
+
+// Approve 实现 ChangeApprover 接口
+func (f ChangeApproverFunc) Approve(change Change) error {
+	return f(change)
+}
+
+// WithChangeApprover 添加配置变更审批器
+func WithChangeApprover(approvers ...ChangeApprover) Option {
+	return func(c *Config) {
+		c.approvers = append(c.approvers, approvers...)
+	}
+}
+
+// AddChangeApprover 添加配置变更审批器。审批在规范化之后、验证与存储之前执行，
+// 调用时不持有配置写锁，因此审批器可以执行网络请求等耗时操作，但不应在审批器中写入同一配置。
+func (c *Config) AddChangeApprover(approver ChangeApprover) {
+	if approver == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.approvers = append(c.approvers, approver)
+}
+
+// prepareChanges 对写入值执行规范化并提交审批，返回规范化后的值；未注册规范化与审批时原样返回
+func (c *Config) prepareChanges(values map[string]any) (map[string]any, error) {
+	c.mu.RLock()
+	if len(c.normalizers) == 0 && len(c.approvers) == 0 {
+		c.mu.RUnlock()
+		return values, nil
+	}
+	prepared := make(map[string]any, len(values))
+	for key, value := range values {
+		normalized, err := c.normalizeValueUnsafe(key, value)
+		if err != nil {
+			c.mu.RUnlock()
+			return nil, err
+		}
+		prepared[key] = normalized
+	}
+	approvers := slices.Clone(c.approvers)
+	c.mu.RUnlock()
+
+	if len(approvers) == 0 {
+		return prepared, nil
+	}
+	data := c.loadData()
+	for _, key := range slices.Sorted(maps.Keys(prepared)) {
+		old, existed := data[key]
+		if !existed {
+			old, existed = c.getNestedValueFromData(data, key)
+		}
+		change := Change{Key: key, OldValue: old, NewValue: prepared[key], Existed: existed, Batch: len(prepared)}
+		for _, approver := range approvers {
+			if err := approver.Approve(change); err != nil {
+				return nil, fmt.Errorf("%w: %s: %w", ErrChangeRejected, key, err)
+			}
+		}
+	}
+	return prepared, nil
+}
@@ -0,0 +1,70 @@
+package sysconf
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestChangeApprover(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		changes []Change
+	)
+	policy := ChangeApproverFunc(func(change Change) error {
+		mu.Lock()
+		changes = append(changes, change)
+		mu.Unlock()
+		if change.Key == "server.port" && change.NewValue == 22 {
+			return errors.New("port 22 is reserved")
+		}
+		return nil
+	})
+
+	cfg, err := New(WithContent("server:\n  host: localhost\n  port: 80\n"), WithChangeApprover(policy))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	cfg.AddNormalizer("server.host", NormalizeLower)
+
+	if err := cfg.Set("server.host", "API.local"); err != nil {
+		t.Fatalf("approved set failed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].OldValue != "localhost" || changes[0].NewValue != "api.local" || !changes[0].Existed || changes[0].Batch != 1 {
+		t.Fatalf("approver should see normalized change, got %+v", changes)
+	}
+
+	if err := cfg.Set("server.port", 22); !errors.Is(err, ErrChangeRejected) {
+		t.Fatalf("expected ErrChangeRejected, got %v", err)
+	}
+	if got := cfg.GetInt("server.port"); got != 80 {
+		t.Fatalf("rejected change should not apply, got %d", got)
+	}
+
+	changes = nil
+	err = cfg.SetMultiple(map[string]any{"server.port": 22, "server.debug": true})
+	if !errors.Is(err, ErrChangeRejected) {
+		t.Fatalf("expected batch rejection, got %v", err)
+	}
+	if cfg.IsSet("server.debug") {
+		t.Fatalf("rejected batch should not apply any key")
+	}
+	if len(changes) != 2 || changes[0].Key != "server.debug" || changes[0].Existed || changes[0].Batch != 2 {
+		t.Fatalf("unexpected batch changes: %+v", changes)
+	}
+
+	var second int
+	cfg.AddChangeApprover(ChangeApproverFunc(func(Change) error {
+		second++
+		return nil
+	}))
+	if err := cfg.Set("server.port", 8080); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if second != 1 {
+		t.Fatalf("expected added approver to run once, got %d", second)
+	}
+}
@@ -0,0 +1,197 @@
+package sysconf
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// backupSuffix 加密配置上一版本备份文件的后缀
+const backupSuffix = ".prev"
+
+// BackupPath 返回配置文件对应的上一版本备份路径（启用加密时由写入流程维护）
+func BackupPath(configFile string) string {
+	return configFile + backupSuffix
+}
+
+// keepsBackup 是否在写入时保留上一版本：加密文件一旦写坏无法人工修复，因此仅在启用加密时维护 .prev
+func (c *Config) keepsBackup() bool {
+	return c.cryptoOptions.Enabled && c.crypto != nil
+}
+
+// renameFile 替换目标文件使用的 rename，测试中替换以模拟无法 rename 的挂载方式
+var renameFile = os.Rename
+
+// writeFileAtomic 先写入同目录临时文件并 fsync，再以 rename 原子替换目标文件，进程在任意时刻终止都不会留下写了一半的配置；
+// 启用加密时，替换前将当前已知完好的文件保留为 .prev。目标为符号链接时写入链接指向的文件。
+// 临时文件沿用目标文件的权限与属主；目标文件无法被替换（单文件 bind mount、跨设备或目录不可写）时
+// 退化为原地截断写入并 fsync，此时不再具备原子性。
+func (c *Config) writeFileAtomic(configFile string, write func(io.Writer) error) (err error) {
+	target := configFile
+	if resolved, err := filepath.EvalSymlinks(configFile); err == nil {
+		target = resolved
+	}
+	perm := os.FileMode(0o644)
+	info, statErr := os.Stat(target)
+	if statErr == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".tmp-*")
+	if err != nil {
+		if statErr == nil && isReplaceDenied(err) {
+			c.logger.Warnf("Cannot create temporary file next to %s (%v), writing in place", target, err)
+			if c.keepsBackup() {
+				c.backupCurrentFile(target)
+			}
+			return writeFileInPlace(target, write)
+		}
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	w := bufio.NewWriter(tmp)
+	if err = write(w); err != nil {
+		return err
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if statErr == nil {
+		copyFileOwner(tmp, info)
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	if c.keepsBackup() {
+		c.backupCurrentFile(target)
+	}
+	if err = renameFile(tmp.Name(), target); err != nil {
+		if statErr != nil || !isReplaceDenied(err) {
+			return err
+		}
+		c.logger.Warnf("Cannot replace %s (%v), writing in place", target, err)
+		err = copyFileInPlace(target, tmp.Name())
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	syncDir(filepath.Dir(target))
+	return nil
+}
+
+// copyFileInPlace 将已写好的临时文件内容原地写入目标文件
+func copyFileInPlace(target, source string) error {
+	src, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	return writeFileInPlace(target, func(w io.Writer) error {
+		_, err := io.Copy(w, src)
+		return err
+	})
+}
+
+// writeFileInPlace 截断并原地写入已存在的目标文件后 fsync，保留文件的 inode、权限与属主
+func writeFileInPlace(target string, write func(io.Writer) error) error {
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	if err = write(w); err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// backupCurrentFile 将当前文件保留为 .prev。仅当文件内容与最近一次成功读写时一致才备份，
+// 避免以已损坏的主文件覆盖完好的备份；备份失败只记录日志，不影响本次写入。
+func (c *Config) backupCurrentFile(target string) {
+	raw, err := os.ReadFile(target)
+	if err != nil || len(raw) == 0 {
+		return
+	}
+	info := c.fileInfo.Load()
+	sum := sha256.Sum256(raw)
+	if info == nil || info.Checksum != "sha256:"+hex.EncodeToString(sum[:]) {
+		c.logger.Warnf("Config file %s changed since last successful read, keeping existing backup", target)
+		return
+	}
+
+	backup := BackupPath(target)
+	_ = os.Remove(backup)
+	if err := os.Link(target, backup); err == nil {
+		return
+	}
+	if err := os.WriteFile(backup, raw, 0o600); err != nil {
+		c.logger.Warnf("Failed to back up config file %s: %v", target, err)
+	}
+}
+
+// syncDir 尽力同步目录项，使 rename 在断电后同样持久（部分平台不支持对目录 fsync，忽略错误）
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
+
+// canRecoverFromBackup 判断主配置文件的加载错误能否尝试从 .prev 恢复：
+// 仅在启动加载且启用加密时恢复；密钥或算法不匹配属于配置错误，备份同样无法解密，直接返回错误
+func (c *Config) canRecoverFromBackup(err error) bool {
+	return c.initialLoad && c.keepsBackup() && !errors.Is(err, ErrEncryptionMismatch)
+}
+
+// recoverFromBackup 主配置文件无法解密或解析时改为加载 .prev，成功后记录恢复原因，
+// 由 New 在释放锁后发出 RecoveredFromBackup 健康事件。主文件保持原样以便排查，下一次写入会替换它。
+func (c *Config) recoverFromBackup(configFile string, cause error, locked bool) error {
+	backup := BackupPath(configFile)
+	if resolved, err := filepath.EvalSymlinks(configFile); err == nil {
+		backup = BackupPath(resolved)
+	}
+	data, err := os.ReadFile(backup)
+	if err != nil {
+		return cause
+	}
+	if err := c.loadConfigContent(data, locked); err != nil {
+		c.logger.Errorf("Config backup %s is also unreadable: %v", backup, err)
+		return cause
+	}
+	c.logger.Errorf("Config file %s is unreadable, loaded backup %s: %v", configFile, backup, cause)
+	c.backupRecovery = fmt.Errorf("recovered from %s: %w", backup, cause)
+	return nil
+}
+
+// reportBackupRecovery 发出启动时从备份恢复的健康事件（调用者不得持有 mu）
+func (c *Config) reportBackupRecovery() {
+	if c.backupRecovery == nil {
+		return
+	}
+	c.emitHealthEvent(HealthEventRecoveredFromBackup, "config file unreadable, loaded previous version from backup", c.backupRecovery)
+}
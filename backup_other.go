@@ -0,0 +1,17 @@
+//go:build !unix
+
+package sysconf
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// isReplaceDenied 判断错误是否表示目标文件无法通过 rename 替换（被占用或无权限）
+func isReplaceDenied(err error) bool {
+	return errors.Is(err, fs.ErrPermission)
+}
+
+// copyFileOwner 当前平台不使用 uid/gid 属主
+func copyFileOwner(*os.File, os.FileInfo) {}
@@ -0,0 +1,111 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestEncryptedWriteKeepsBackup(t *testing.T) {
+	tmpDir := t.TempDir()
+	open := func() *Config {
+		t.Helper()
+		cfg, err := New(
+			WithPath(tmpDir),
+			WithName("app"),
+			WithMode("yaml"),
+			WithContent("app:\n  version: 1\n"),
+			WithEncryption("backup-test-key"),
+			WithWriteDebounceDelay(0),
+		)
+		if err != nil {
+			t.Fatalf("create config failed: %v", err)
+		}
+		return cfg
+	}
+
+	cfg := open()
+	if err := cfg.Set("app.version", 2); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if err := cfg.Set("app.version", 3); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if err := cfg.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	configFile := filepath.Join(tmpDir, "app.yaml")
+	if _, err := os.Stat(BackupPath(configFile)); err != nil {
+		t.Fatalf("expected backup file: %v", err)
+	}
+	entries, _ := os.ReadDir(tmpDir)
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Fatalf("temporary file left behind: %s", entry.Name())
+		}
+	}
+
+	// 模拟写入中途崩溃留下的残缺密文
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatalf("read config failed: %v", err)
+	}
+	if err := os.WriteFile(configFile, data[:len(data)/2], 0o644); err != nil {
+		t.Fatalf("truncate config failed: %v", err)
+	}
+
+	recovered := open()
+	testutil.Cleanup(t, recovered.Close)
+	if got := recovered.GetInt("app.version"); got != 2 {
+		t.Fatalf("expected backup version 2, got %d", got)
+	}
+	health := recovered.Health()
+	if health.Healthy || health.LastError == nil {
+		t.Fatalf("recovery should be reported as unhealthy: %+v", health)
+	}
+	events := health.RecentEvents
+	if len(events) == 0 || events[len(events)-1].Type != HealthEventRecoveredFromBackup {
+		t.Fatalf("expected RecoveredFromBackup event, got %+v", events)
+	}
+
+	// 主文件损坏时写入不得以残缺内容覆盖完好的备份
+	backupBefore, _ := os.ReadFile(BackupPath(configFile))
+	if err := recovered.Set("app.version", 4); err != nil {
+		t.Fatalf("set after recovery failed: %v", err)
+	}
+	backupAfter, _ := os.ReadFile(BackupPath(configFile))
+	if string(backupBefore) != string(backupAfter) {
+		t.Fatalf("backup should not be replaced by a corrupted primary file")
+	}
+}
+
+func TestPlainWriteIsAtomicWithoutBackup(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg, err := New(
+		WithPath(tmpDir),
+		WithName("app"),
+		WithMode("yaml"),
+		WithContent("app:\n  name: demo\n"),
+		WithWriteDebounceDelay(0),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if err := cfg.Set("app.name", "renamed"); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	entries, _ := os.ReadDir(tmpDir)
+	if len(entries) != 1 || entries[0].Name() != "app.yaml" {
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		t.Fatalf("unexpected files after write: %v", names)
+	}
+}
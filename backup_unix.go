@@ -0,0 +1,23 @@
+//go:build unix
+
+package sysconf
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// isReplaceDenied 判断错误是否表示目标文件无法通过 rename 替换：单文件 bind mount（EBUSY）、
+// 跨设备（EXDEV）或目录不可写（EPERM/EACCES）
+func isReplaceDenied(err error) bool {
+	return errors.Is(err, syscall.EBUSY) || errors.Is(err, syscall.EXDEV) || errors.Is(err, fs.ErrPermission)
+}
+
+// copyFileOwner 尽力将原文件的属主与属组复制到替换文件，无权修改时保持当前用户
+func copyFileOwner(f *os.File, info os.FileInfo) {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		_ = f.Chown(int(st.Uid), int(st.Gid))
+	}
+}
@@ -0,0 +1,130 @@
+//go:build unix
+
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestWriteFallsBackToInPlaceWhenRenameFails(t *testing.T) {
+	for name, errno := range map[string]syscall.Errno{"EBUSY": syscall.EBUSY, "EXDEV": syscall.EXDEV, "EACCES": syscall.EACCES} {
+		t.Run(name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			cfg, err := New(
+				WithPath(tmpDir),
+				WithName("app"),
+				WithMode("yaml"),
+				WithContent("app:\n  name: demo\n"),
+				WithWriteDebounceDelay(0),
+			)
+			if err != nil {
+				t.Fatalf("create config failed: %v", err)
+			}
+			testutil.Cleanup(t, cfg.Close)
+
+			configFile := filepath.Join(tmpDir, "app.yaml")
+			if err := cfg.Set("app.name", "first"); err != nil {
+				t.Fatalf("set failed: %v", err)
+			}
+			before, err := os.Stat(configFile)
+			if err != nil {
+				t.Fatalf("stat failed: %v", err)
+			}
+
+			// 模拟单文件 bind mount 等无法 rename 覆盖目标文件的场景
+			renameFile = func(oldpath, newpath string) error {
+				return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: errno}
+			}
+			t.Cleanup(func() { renameFile = os.Rename })
+
+			if err := cfg.Set("app.name", "in-place"); err != nil {
+				t.Fatalf("set should fall back to in-place write: %v", err)
+			}
+			after, err := os.Stat(configFile)
+			if err != nil {
+				t.Fatalf("stat failed: %v", err)
+			}
+			if !os.SameFile(before, after) {
+				t.Fatal("expected the original file to be rewritten in place")
+			}
+			data, err := os.ReadFile(configFile)
+			if err != nil || !strings.Contains(string(data), "in-place") {
+				t.Fatalf("expected new content, got %q (%v)", data, err)
+			}
+			entries, _ := os.ReadDir(tmpDir)
+			if len(entries) != 1 {
+				t.Fatalf("temporary file left behind: %v", entries)
+			}
+		})
+	}
+}
+
+func TestWriteKeepsFileOwner(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("changing file owner requires root")
+	}
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "app.yaml")
+	if err := os.WriteFile(configFile, []byte("app:\n  name: demo\n"), 0o640); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	if err := os.Chown(configFile, 1234, 5678); err != nil {
+		t.Fatalf("chown failed: %v", err)
+	}
+	cfg, err := New(WithPath(tmpDir), WithName("app"), WithMode("yaml"), WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if err := cfg.Set("app.name", "renamed"); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	info, err := os.Stat(configFile)
+	if err != nil {
+		t.Fatalf("stat failed: %v", err)
+	}
+	st := info.Sys().(*syscall.Stat_t)
+	if st.Uid != 1234 || st.Gid != 5678 || info.Mode().Perm() != 0o640 {
+		t.Fatalf("expected owner 1234:5678 and mode 0640, got %d:%d %v", st.Uid, st.Gid, info.Mode().Perm())
+	}
+}
+
+func TestWriteInPlaceWhenDirectoryIsReadOnly(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root ignores directory permissions")
+	}
+	tmpDir := t.TempDir()
+	cfg, err := New(
+		WithPath(tmpDir),
+		WithName("app"),
+		WithMode("yaml"),
+		WithContent("app:\n  name: demo\n"),
+		WithWriteDebounceDelay(0),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if err := cfg.Set("app.name", "first"); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	if err := os.Chmod(tmpDir, 0o555); err != nil {
+		t.Fatalf("chmod failed: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chmod(tmpDir, 0o755) })
+	if err := cfg.Set("app.name", "read-only-dir"); err != nil {
+		t.Fatalf("set should write the file in place: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, "app.yaml"))
+	if err != nil || !strings.Contains(string(data), "read-only-dir") {
+		t.Fatalf("expected new content, got %q (%v)", data, err)
+	}
+}
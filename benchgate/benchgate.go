@@ -0,0 +1,237 @@
+// Package benchgate 提供 sysconf 核心 Get/Set 延迟基准测试套件与性能基线对比，
+// 供下游 CI 做性能回归门禁。独立为子包，使 sysconf 主包不依赖 testing。
+package benchgate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf"
+)
+
+// ErrPerformanceRegression CompareBaseline 发现操作耗时或内存分配超出基线阈值
+var ErrPerformanceRegression = errors.New("performance regression")
+
+// baselineNoiseNs 低于该差值（纳秒/次）的耗时变化视为测量噪声，避免纳秒级读取因抖动误报
+const baselineNoiseNs = 5
+
+// benchContent 基准测试使用的配置内容
+const benchContent = `database:
+  host: localhost
+  port: 5432
+  connection:
+    host: db.example.com
+    timeout: 30
+server:
+  port: 8080
+  debug: true
+  timeout: 30s
+  hosts: [host1, host2, host3]
+simple:
+  float: 3.14159
+metrics:
+  rates: [0.5, 0.9, 0.95, 0.99]
+`
+
+// benchCase 一项基准测试，run 中的 i 为迭代序号
+type benchCase struct {
+	name string
+	run  func(c *sysconf.Config, i int)
+}
+
+// benchRotatingKeys 轮换读取的键，用于衡量多键访问下的缓存表现
+var benchRotatingKeys = []string{"database.host", "database.port", "server.port", "server.debug", "simple.float"}
+
+// benchSuite Get/Set 延迟基准测试套件，供 BenchmarkSuite 与 BenchBaseline/CompareBaseline 共用
+var benchSuite = []benchCase{
+	{"Get/String", func(c *sysconf.Config, _ int) { _ = c.GetString("database.host") }},
+	{"Get/Int", func(c *sysconf.Config, _ int) { _ = c.GetInt("database.port") }},
+	{"Get/Bool", func(c *sysconf.Config, _ int) { _ = c.GetBool("server.debug") }},
+	{"Get/Float", func(c *sysconf.Config, _ int) { _ = c.GetFloat("simple.float") }},
+	{"Get/Nested", func(c *sysconf.Config, _ int) { _ = c.GetString("database.connection.host") }},
+	{"Get/Rotating", func(c *sysconf.Config, i int) { _ = c.GetString(benchRotatingKeys[i%len(benchRotatingKeys)]) }},
+	{"GetAs/Duration", func(c *sysconf.Config, _ int) { _ = sysconf.GetAs[time.Duration](c, "server.timeout") }},
+	{"GetSliceAs/Float64", func(c *sysconf.Config, _ int) { _ = sysconf.GetSliceAs[float64](c, "metrics.rates") }},
+	{"Set/Simple", func(c *sysconf.Config, i int) { _ = c.Set("bench.key", i) }},
+	{"Set/Nested", func(c *sysconf.Config, i int) { _ = c.Set(benchNestedKeys[i%len(benchNestedKeys)], i) }},
+}
+
+// benchNestedKeys 嵌套写入使用的键
+var benchNestedKeys = func() []string {
+	keys := make([]string, 100)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("section.subsection.key_%d", i)
+	}
+	return keys
+}()
+
+// runBenchCase 在独立的内存配置上执行一项基准测试
+func runBenchCase(b *testing.B, bc benchCase) {
+	cfg, err := sysconf.New(sysconf.WithContent(benchContent))
+	if err != nil {
+		b.Fatalf("create config failed: %v", err)
+	}
+	defer func() { _ = cfg.Close() }()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		bc.run(cfg, i)
+	}
+}
+
+// BenchResult 单项基准测试结果
+type BenchResult struct {
+	Name        string  `json:"name"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+}
+
+// Baseline 性能基线，记录生成时的运行环境与各项结果
+type Baseline struct {
+	GoVersion string        `json:"go_version"`
+	OS        string        `json:"os"`
+	Arch      string        `json:"arch"`
+	CPUs      int           `json:"cpus"`
+	CreatedAt time.Time     `json:"created_at"`
+	Results   []BenchResult `json:"results"`
+}
+
+// RunBenchmarks 执行内置的 Get/Set 基准测试套件，每项运行约 1 秒
+func RunBenchmarks() []BenchResult {
+	results := make([]BenchResult, 0, len(benchSuite))
+	for _, bc := range benchSuite {
+		r := testing.Benchmark(func(b *testing.B) { runBenchCase(b, bc) })
+		results = append(results, BenchResult{
+			Name:        bc.name,
+			NsPerOp:     float64(r.T.Nanoseconds()) / float64(max(r.N, 1)),
+			AllocsPerOp: r.AllocsPerOp(),
+			BytesPerOp:  r.AllocedBytesPerOp(),
+		})
+	}
+	return results
+}
+
+// BenchBaseline 执行基准测试套件并将结果写入 file（JSON），作为 CompareBaseline 的比较基准。
+// 基线应在与 CI 相同规格的机器上生成并提交到仓库。
+func BenchBaseline(file string) (*Baseline, error) {
+	baseline := &Baseline{
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		CPUs:      runtime.NumCPU(),
+		CreatedAt: time.Now(),
+		Results:   RunBenchmarks(),
+	}
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal baseline: %w", err)
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0o644); err != nil {
+		return nil, fmt.Errorf("write baseline: %w", err)
+	}
+	return baseline, nil
+}
+
+// BenchDelta 单项基准测试与基线的对比
+type BenchDelta struct {
+	Name      string
+	Baseline  BenchResult
+	Current   BenchResult
+	Change    float64 // 耗时变化比例，0.25 表示慢了 25%
+	Regressed bool    // 耗时超出阈值或内存分配次数增加
+}
+
+// BaselineComparison CompareBaseline 的对比结果
+type BaselineComparison struct {
+	Threshold float64
+	Deltas    []BenchDelta
+	Missing   []string // 基线中存在但当前套件中已没有的测试项
+}
+
+// Regressions 返回出现退化的测试项
+func (r *BaselineComparison) Regressions() []BenchDelta {
+	var regressed []BenchDelta
+	for _, d := range r.Deltas {
+		if d.Regressed {
+			regressed = append(regressed, d)
+		}
+	}
+	return regressed
+}
+
+// String 返回适合 CI 日志输出的对比表
+func (r *BaselineComparison) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-20s %12s %12s %8s %10s\n", "benchmark", "baseline", "current", "change", "allocs")
+	for _, d := range r.Deltas {
+		mark := ""
+		if d.Regressed {
+			mark = "  REGRESSED"
+		}
+		fmt.Fprintf(&b, "%-20s %10.1fns %10.1fns %+7.1f%% %4d → %-3d%s\n",
+			d.Name, d.Baseline.NsPerOp, d.Current.NsPerOp, d.Change*100, d.Baseline.AllocsPerOp, d.Current.AllocsPerOp, mark)
+	}
+	for _, name := range r.Missing {
+		fmt.Fprintf(&b, "%-20s missing from current suite\n", name)
+	}
+	return b.String()
+}
+
+// CompareBaseline 执行基准测试套件并与 BenchBaseline 写入的基线比较。
+// 某项耗时超出基线的 threshold 比例（如 0.2 表示慢 20%）或每次操作的内存分配次数增加时视为退化，
+// 返回的错误匹配 ErrPerformanceRegression，可直接用于 CI 中使构建失败：
+//
+//	if cmp, err := benchgate.CompareBaseline("testdata/sysconf_bench.json", 0.2); err != nil {
+//		t.Fatalf("%v\n%s", err, cmp)
+//	}
+func CompareBaseline(file string, threshold float64) (*BaselineComparison, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read baseline: %w", err)
+	}
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("parse baseline %s: %w", file, err)
+	}
+	comparison := compareBaseline(baseline.Results, RunBenchmarks(), threshold)
+	if regressed := comparison.Regressions(); len(regressed) > 0 {
+		names := make([]string, len(regressed))
+		for i, d := range regressed {
+			names[i] = d.Name
+		}
+		return comparison, fmt.Errorf("%w: %s", ErrPerformanceRegression, strings.Join(names, ", "))
+	}
+	return comparison, nil
+}
+
+// compareBaseline 按名称对比基线与当前结果，基线中没有的新测试项不参与比较
+func compareBaseline(baseline, current []BenchResult, threshold float64) *BaselineComparison {
+	comparison := &BaselineComparison{Threshold: threshold}
+	byName := make(map[string]BenchResult, len(current))
+	for _, r := range current {
+		byName[r.Name] = r
+	}
+	for _, base := range baseline {
+		cur, ok := byName[base.Name]
+		if !ok {
+			comparison.Missing = append(comparison.Missing, base.Name)
+			continue
+		}
+		d := BenchDelta{Name: base.Name, Baseline: base, Current: cur}
+		if base.NsPerOp > 0 {
+			d.Change = (cur.NsPerOp - base.NsPerOp) / base.NsPerOp
+		}
+		slower := d.Change > threshold && cur.NsPerOp-base.NsPerOp > baselineNoiseNs
+		d.Regressed = slower || cur.AllocsPerOp > base.AllocsPerOp
+		comparison.Deltas = append(comparison.Deltas, d)
+	}
+	return comparison
+}
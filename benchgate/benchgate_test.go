@@ -0,0 +1,73 @@
+package benchgate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// BenchmarkSuite 内置 Get/Set 延迟套件：go test -bench BenchmarkSuite -benchmem ./benchgate
+func BenchmarkSuite(b *testing.B) {
+	for _, bc := range benchSuite {
+		b.Run(bc.name, func(b *testing.B) { runBenchCase(b, bc) })
+	}
+}
+
+func TestCompareBaselineDetectsRegressions(t *testing.T) {
+	baseline := []BenchResult{
+		{Name: "Get/String", NsPerOp: 10},
+		{Name: "Get/Int", NsPerOp: 100},
+		{Name: "Set/Simple", NsPerOp: 1000, AllocsPerOp: 3},
+		{Name: "Removed", NsPerOp: 10},
+	}
+	current := []BenchResult{
+		{Name: "Get/String", NsPerOp: 14},                  // +40% 但低于噪声阈值
+		{Name: "Get/Int", NsPerOp: 150},                    // +50%
+		{Name: "Set/Simple", NsPerOp: 900, AllocsPerOp: 4}, // 更快但分配增加
+		{Name: "New", NsPerOp: 1},
+	}
+
+	cmp := compareBaseline(baseline, current, 0.2)
+	regressed := cmp.Regressions()
+	if len(regressed) != 2 || regressed[0].Name != "Get/Int" || regressed[1].Name != "Set/Simple" {
+		t.Fatalf("unexpected regressions: %+v", regressed)
+	}
+	if len(cmp.Missing) != 1 || cmp.Missing[0] != "Removed" {
+		t.Fatalf("expected removed benchmark to be reported, got %v", cmp.Missing)
+	}
+	if cmp.String() == "" {
+		t.Fatalf("expected printable comparison")
+	}
+}
+
+func TestBenchBaselineRoundTrip(t *testing.T) {
+	saved := benchSuite
+	t.Cleanup(func() { benchSuite = saved })
+	benchSuite = []benchCase{benchSuite[0]}
+
+	file := filepath.Join(t.TempDir(), "baseline.json")
+	baseline, err := BenchBaseline(file)
+	if err != nil {
+		t.Fatalf("create baseline failed: %v", err)
+	}
+	if len(baseline.Results) != 1 || baseline.Results[0].NsPerOp <= 0 {
+		t.Fatalf("unexpected baseline results: %+v", baseline.Results)
+	}
+
+	if _, err := CompareBaseline(file, 10); err != nil {
+		t.Fatalf("expected no regression against fresh baseline, got %v", err)
+	}
+
+	// 基线耗时远低于实际值时应报告退化
+	if err := os.WriteFile(file, []byte(`{"results":[{"name":"Get/String","ns_per_op":0.001}]}`), 0o644); err != nil {
+		t.Fatalf("write baseline failed: %v", err)
+	}
+	cmp, err := CompareBaseline(file, 0.2)
+	if !errors.Is(err, ErrPerformanceRegression) {
+		t.Fatalf("expected regression error, got %v", err)
+	}
+	if len(cmp.Regressions()) != 1 {
+		t.Fatalf("expected one regression, got %+v", cmp.Deltas)
+	}
+}
@@ -0,0 +1,166 @@
+package sysconf
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// FieldChange 结构体字段的一次变化
+type FieldChange struct {
+	Path string // 字段路径（Go 字段名，以 "." 分隔，如 "Database.Pool.Max"）
+	Old  any    // 变化前的值
+	New  any    // 变化后的值
+}
+
+// View 绑定到配置段的类型化视图：配置重载或 Set 提交后自动重新解码，并按字段报告变化
+type View[T any] struct {
+	cfg    *Config
+	key    []string
+	value  atomic.Pointer[T]
+	cancel context.CancelFunc
+
+	mu        sync.Mutex // 串行化重新解码与回调分发
+	listeners []func(path string, old, new any)
+}
+
+// Bind 将配置（或 key 指定的配置段）解码为 T 并保持同步。配置重载与 Set/SetMultiple 提交后视图重新解码，
+// 通过 OnFieldChange 注册的回调仅收到实际变化的字段，便于模块只重建受影响的组件。
+// 重新解码与回调在视图独立的 goroutine 中异步执行（同 Observe），回调中可以调用 Set；
+// Set 返回后 Get 可能短暂仍为旧值。重新解码失败时保留上一次的值并记录错误。不再使用时调用 Close 停止同步。
+func Bind[T any](c *Config, key ...string) (*View[T], error) {
+	v := &View[T]{cfg: c, key: key}
+	// 先订阅再解码初始值，避免遗漏两者之间提交的变化
+	signals, cancel := c.watchCommits()
+	initial := new(T)
+	if err := c.Unmarshal(initial, key...); err != nil {
+		cancel()
+		return nil, fmt.Errorf("bind config: %w", err)
+	}
+	v.value.Store(initial)
+	v.cancel = cancel
+	c.wg.Go(func() {
+		for range signals {
+			v.refresh()
+		}
+	})
+	return v, nil
+}
+
+// Get 返回当前解码后的值
+func (v *View[T]) Get() T {
+	return *v.value.Load()
+}
+
+// OnFieldChange 注册字段变化回调，每个变化的叶子字段调用一次（按字段声明顺序）
+func (v *View[T]) OnFieldChange(fn func(path string, old, new any)) {
+	if fn == nil {
+		return
+	}
+	v.mu.Lock()
+	v.listeners = append(v.listeners, fn)
+	v.mu.Unlock()
+}
+
+// Close 停止跟随配置变化
+func (v *View[T]) Close() {
+	if v.cancel != nil {
+		v.cancel()
+	}
+}
+
+// refresh 重新解码配置并分发字段变化
+func (v *View[T]) refresh() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	next := new(T)
+	if err := v.cfg.Unmarshal(next, v.key...); err != nil {
+		v.cfg.logger.Errorf("Failed to refresh bound config, keeping previous value: %v", err)
+		return
+	}
+	previous := v.value.Swap(next)
+	for _, change := range DiffStruct(*previous, *next) {
+		for _, fn := range v.listeners {
+			fn(change.Path, change.Old, change.New)
+		}
+	}
+}
+
+// DiffStruct 通过反射比较两个同类型结构体（或其指针），返回变化的叶子字段。
+// 嵌套结构体逐字段比较；切片、映射等其他类型整体比较。可用于比较两次 Unmarshal 的结果。
+func DiffStruct(old, new any) []FieldChange {
+	var changes []FieldChange
+	diffValue("", reflect.ValueOf(old), reflect.ValueOf(new), &changes)
+	return changes
+}
+
+// diffValue 递归比较两个值
+func diffValue(path string, a, b reflect.Value, changes *[]FieldChange) {
+	if a.IsValid() && b.IsValid() && a.Type() == b.Type() {
+		switch a.Kind() {
+		case reflect.Pointer:
+			if !a.IsNil() && !b.IsNil() && a.Elem().Kind() == reflect.Struct {
+				diffValue(path, a.Elem(), b.Elem(), changes)
+				return
+			}
+		case reflect.Struct:
+			if hasExportedFields(a.Type()) {
+				for i := range a.NumField() {
+					field := a.Type().Field(i)
+					if !field.IsExported() {
+						continue
+					}
+					// 嵌入的结构体字段提升到当前层级
+					name := path
+					if !field.Anonymous || indirectType(field.Type).Kind() != reflect.Struct {
+						name = joinFieldPath(path, field.Name)
+					}
+					diffValue(name, a.Field(i), b.Field(i), changes)
+				}
+				return
+			}
+		}
+	}
+
+	oldValue, newValue := interfaceOf(a), interfaceOf(b)
+	if !reflect.DeepEqual(oldValue, newValue) {
+		*changes = append(*changes, FieldChange{Path: path, Old: oldValue, New: newValue})
+	}
+}
+
+// joinFieldPath 拼接字段路径
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// indirectType 返回指针指向的类型
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// hasExportedFields 判断结构体是否包含导出字段（time.Time 等不含导出字段的类型整体比较）
+func hasExportedFields(t reflect.Type) bool {
+	for i := range t.NumField() {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// interfaceOf 返回反射值对应的接口值，无效值返回 nil
+func interfaceOf(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
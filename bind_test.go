@@ -0,0 +1,114 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bindPool struct {
+	Max  int `config:"max"`
+	Idle int `config:"idle"`
+}
+
+type bindDatabase struct {
+	Host string    `config:"host"`
+	Pool *bindPool `config:"pool"`
+	Tags []string  `config:"tags"`
+}
+
+func TestBindFieldChange(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "bind.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("database:\n  host: a\n  pool:\n    max: 5\n    idle: 1\n  tags: [x]\n"), 0o644))
+
+	cfg, err := New(WithPath(tmpDir), WithMode("yaml"), WithName("bind"), WithWatchDebounce(10*time.Millisecond))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	view, err := Bind[bindDatabase](cfg, "database")
+	require.NoError(t, err)
+	t.Cleanup(view.Close)
+	assert.Equal(t, "a", view.Get().Host)
+
+	changes := make(chan FieldChange, 8)
+	view.OnFieldChange(func(path string, old, new any) {
+		changes <- FieldChange{Path: path, Old: old, New: new}
+	})
+
+	time.Sleep(50 * time.Millisecond)
+	tmp := configFile + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte("database:\n  host: a\n  pool:\n    max: 10\n    idle: 1\n  tags: [x, y]\n"), 0o644))
+	require.NoError(t, os.Rename(tmp, configFile))
+
+	var got []FieldChange
+	for len(got) < 2 {
+		select {
+		case change := <-changes:
+			got = append(got, change)
+		case <-time.After(3 * time.Second):
+			t.Fatalf("expected field changes, got %+v", got)
+		}
+	}
+	assert.Equal(t, []FieldChange{
+		{Path: "Pool.Max", Old: 5, New: 10},
+		{Path: "Tags", Old: []string{"x"}, New: []string{"x", "y"}},
+	}, got)
+	assert.Equal(t, 10, view.Get().Pool.Max)
+}
+
+func TestDiffStruct(t *testing.T) {
+	type Embedded struct{ Level string }
+	type settings struct {
+		Embedded
+		Name    string
+		Started time.Time
+		Pool    *bindPool
+		hidden  int
+	}
+	now := time.Unix(100, 0)
+	a := settings{Embedded: Embedded{"info"}, Name: "x", Started: now, Pool: &bindPool{Max: 1}, hidden: 1}
+	b := settings{Embedded: Embedded{"debug"}, Name: "x", Started: now.Add(time.Second), Pool: nil, hidden: 2}
+
+	changes := DiffStruct(&a, &b)
+	require.Len(t, changes, 3)
+	assert.Equal(t, "Level", changes[0].Path)
+	assert.Equal(t, "Started", changes[1].Path)
+	assert.Equal(t, "Pool", changes[2].Path)
+	assert.Nil(t, changes[2].New.(*bindPool))
+	assert.Empty(t, DiffStruct(a, a))
+}
+
+func TestBindFollowsSet(t *testing.T) {
+	cfg, err := New(WithContent("server:\n  port: 1\n"), WithWriteDebounceDelay(0))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	type server struct {
+		Port int `config:"port"`
+	}
+	view, err := Bind[server](cfg, "server")
+	require.NoError(t, err)
+	t.Cleanup(view.Close)
+
+	changes := make(chan FieldChange, 4)
+	view.OnFieldChange(func(path string, old, new any) {
+		changes <- FieldChange{Path: path, Old: old, New: new}
+		// 回调异步执行，不占用 Watch 回调队列，可以同步调用 Set
+		_ = cfg.Set("server.seen", new)
+	})
+
+	require.NoError(t, cfg.Set("server.port", 2))
+	select {
+	case change := <-changes:
+		assert.Equal(t, FieldChange{Path: "Port", Old: 1, New: 2}, change)
+	case <-time.After(3 * time.Second):
+		t.Fatal("view did not refresh after Set")
+	}
+	assert.Equal(t, 2, view.Get().Port)
+	assert.Eventually(t, func() bool { return cfg.GetInt("server.seen") == 2 }, 3*time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,67 @@
+package sysconf
+
+import (
+	"reflect"
+	"strings"
+
+	mapstructure "github.com/go-viper/mapstructure/v2"
+)
+
+// WithExtendedBools 启用扩展的布尔值写法：不区分大小写地接受 yes/no、on/off、enabled/disabled。
+// 运维编写的 YAML 中未加引号的 `debug: yes`、`enabled: on` 在 YAML 1.2 下被解析为字符串，
+// 启用后 GetBool、GetAs[bool] 与 Unmarshal 的布尔字段均可正确识别。
+func WithExtendedBools(enabled bool) Option {
+	return func(c *Config) {
+		c.extendedBools = enabled
+	}
+}
+
+// parseExtendedBool 解析扩展布尔值写法
+func parseExtendedBool(s string) (value bool, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "yes", "y", "on", "enabled", "enable":
+		return true, true
+	case "no", "n", "off", "disabled", "disable":
+		return false, true
+	}
+	return false, false
+}
+
+// extendedBoolValue 启用 WithExtendedBools 时将扩展写法的字符串转换为布尔目标类型
+func extendedBoolValue[T any](c *Config, val any) (T, bool) {
+	var zero T
+	s, ok := val.(string)
+	if !ok || !c.extendedBools {
+		return zero, false
+	}
+	target := reflect.TypeFor[T]()
+	if target.Kind() != reflect.Bool {
+		return zero, false
+	}
+	b, ok := parseExtendedBool(s)
+	if !ok {
+		return zero, false
+	}
+	return reflect.ValueOf(b).Convert(target).Interface().(T), true
+}
+
+// convertFor 按配置选项转换值：先尝试扩展布尔写法，再使用通用转换
+func convertFor[T any](c *Config, val any) (T, bool) {
+	if converted, ok := extendedBoolValue[T](c, val); ok {
+		return converted, true
+	}
+	return convertValue[T](val)
+}
+
+// boolDecodeHook Unmarshal 使用的布尔解码钩子，启用 WithExtendedBools 时识别扩展写法
+func (c *Config) boolDecodeHook() mapstructure.DecodeHookFunc {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if !c.extendedBools || from.Kind() != reflect.String || to.Kind() != reflect.Bool {
+			return data, nil
+		}
+		if b, ok := parseExtendedBool(data.(string)); ok {
+			return b, nil
+		}
+		return data, nil
+	}
+}
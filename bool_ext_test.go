@@ -0,0 +1,55 @@
+package sysconf
+
+import (
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestExtendedBools(t *testing.T) {
+	content := `debug: "Yes"
+cache: "enabled"
+metrics: "OFF"
+tracing: "Disabled"
+flags: ["on", "no", "maybe"]
+`
+	strict, err := New(WithContent(content), WithMode(YAML))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, strict.Close)
+	if strict.GetBool("cache") || GetAs[bool](strict, "debug") {
+		t.Fatalf("extended spellings should require WithExtendedBools")
+	}
+
+	cfg, err := New(WithContent(content), WithMode(YAML), WithExtendedBools(true))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if !cfg.GetBool("debug") || !cfg.GetBool("cache") || cfg.GetBool("metrics", true) || cfg.GetBool("tracing", true) {
+		t.Fatalf("GetBool did not accept extended spellings")
+	}
+	if !GetAs[bool](cfg, "cache") {
+		t.Fatalf("GetAs[bool] did not accept enabled")
+	}
+	if v, err := GetAsWithError[bool](cfg, "tracing"); err != nil || v {
+		t.Fatalf("GetAsWithError = %v, %v", v, err)
+	}
+	if got := GetSliceAs[bool](cfg, "flags"); len(got) != 2 || !got[0] || got[1] {
+		t.Fatalf("GetSliceAs = %v", got)
+	}
+
+	var out struct {
+		Debug   bool `config:"debug"`
+		Cache   bool `config:"cache"`
+		Metrics bool `config:"metrics" default:"true"`
+	}
+	if err := cfg.Unmarshal(&out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !out.Debug || !out.Cache || out.Metrics {
+		t.Fatalf("unexpected result: %+v", out)
+	}
+}
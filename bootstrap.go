@@ -0,0 +1,91 @@
+package sysconf
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// 首次启动时其他进程抢先创建配置文件后，读取其内容的重试参数
+const (
+	bootstrapReadRetries = 5
+	bootstrapRetryDelay  = 20 * time.Millisecond
+)
+
+// createFileExclusive 仅在目标文件不存在时创建它，created 为 false 表示文件已被其他进程创建。
+// 内容先完整写入同目录临时文件并 fsync，再以 link 发布（与 O_EXCL 一样在目标已存在时失败），
+// 其他进程因此不会读到写了一半的文件；文件系统不支持硬链接时退回 O_CREATE|O_EXCL 直接写入。
+func createFileExclusive(path string, data []byte, perm os.FileMode) (created bool, err error) {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Chmod(perm)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return false, err
+	}
+
+	linkErr := os.Link(tmp.Name(), path)
+	if linkErr == nil {
+		syncDir(dir)
+		return true, nil
+	}
+	if errors.Is(linkErr, fs.ErrExist) {
+		return false, nil
+	}
+	return writeFileExclusive(path, data, perm)
+}
+
+// writeFileExclusive 以 O_CREATE|O_EXCL 创建并写入文件，目标已存在时返回 created 为 false
+func writeFileExclusive(path string, data []byte, perm os.FileMode) (created bool, err error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if errors.Is(err, fs.ErrExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return false, err
+	}
+	return true, nil
+}
+
+// readConcurrentlyCreated 读取其他进程抢先创建的配置文件。对方可能仍在写入（不支持硬链接的文件系统），
+// 读取失败时短暂等待后重试；密钥或算法不匹配属于配置错误，不再重试。
+func (c *Config) readConcurrentlyCreated(locked bool) error {
+	var err error
+	for attempt := range bootstrapReadRetries {
+		if attempt > 0 {
+			time.Sleep(bootstrapRetryDelay * time.Duration(attempt))
+		}
+		if err = c.readCreatedConfig(locked); err == nil || errors.Is(err, ErrEncryptionMismatch) {
+			return err
+		}
+		c.logger.Debugf("Concurrently created config file not readable yet (attempt %d): %v", attempt+1, err)
+	}
+	c.logger.Errorf("Failed to read concurrently created config: %v", err)
+	return err
+}
@@ -0,0 +1,79 @@
+package sysconf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestCreateFileExclusive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.yaml")
+
+	created, err := createFileExclusive(path, []byte("a: 1\n"), 0o644)
+	if err != nil || !created {
+		t.Fatalf("expected file to be created, created=%v err=%v", created, err)
+	}
+	created, err = createFileExclusive(path, []byte("a: 2\n"), 0o644)
+	if err != nil || created {
+		t.Fatalf("expected existing file to be kept, created=%v err=%v", created, err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "a: 1\n" {
+		t.Fatalf("existing file overwritten: %q", data)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Fatalf("temporary files left behind: %v", entries)
+	}
+
+	created, err = writeFileExclusive(path, []byte("a: 3\n"), 0o644)
+	if err != nil || created {
+		t.Fatalf("expected O_EXCL fallback to keep existing file, created=%v err=%v", created, err)
+	}
+}
+
+func TestConcurrentBootstrapCreatesOnce(t *testing.T) {
+	for _, encrypted := range []bool{false, true} {
+		t.Run(fmt.Sprintf("encrypted=%v", encrypted), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			const instances = 8
+
+			var wg sync.WaitGroup
+			values := make([]int, instances)
+			errs := make([]error, instances)
+			for i := range instances {
+				wg.Go(func() {
+					opts := []Option{
+						WithPath(tmpDir),
+						WithName("app"),
+						WithMode("yaml"),
+						WithContent(fmt.Sprintf("instance: %d\n", i)),
+					}
+					if encrypted {
+						opts = append(opts, WithEncryption("bootstrap-key"))
+					}
+					cfg, err := New(opts...)
+					if err != nil {
+						errs[i] = err
+						return
+					}
+					values[i] = cfg.GetInt("instance")
+					errs[i] = cfg.Close()
+				})
+			}
+			wg.Wait()
+
+			for i, err := range errs {
+				if err != nil {
+					t.Fatalf("instance %d failed: %v", i, err)
+				}
+			}
+			for i, v := range values {
+				if v != values[0] {
+					t.Fatalf("instance %d loaded %d, instance 0 loaded %d", i, v, values[0])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package sysconf
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// ConfigBuilder 链式构造 Config，是 WithX 选项列表之外的另一种写法，方法名与参数类型可在 IDE 中直接发现。
+//
+//	cfg, err := sysconf.Builder().
+//	    Path("./").
+//	    Name("app").
+//	    Mode(sysconf.YAML).
+//	    EnvPrefix("APP").
+//	    Validator(v).
+//	    Build()
+//
+// 各方法按调用顺序追加对应的 WithX 选项，后调用的覆盖先调用的；Builder 不是并发安全的。
+type ConfigBuilder struct {
+	opts []Option
+}
+
+// Builder 创建配置构造器
+func Builder() *ConfigBuilder {
+	return &ConfigBuilder{}
+}
+
+// Path 设置配置文件目录或完整文件路径，同 WithPath
+func (b *ConfigBuilder) Path(path string) *ConfigBuilder {
+	return b.With(WithPath(path))
+}
+
+// File 以单个文件路径设置目录、文件名与格式，同 WithFile
+func (b *ConfigBuilder) File(path string) *ConfigBuilder {
+	return b.With(WithFile(path))
+}
+
+// Name 设置配置文件名（不含扩展名），同 WithName
+func (b *ConfigBuilder) Name(name string) *ConfigBuilder {
+	return b.With(WithName(name))
+}
+
+// Mode 设置配置文件格式，同 WithMode
+func (b *ConfigBuilder) Mode(mode Mode) *ConfigBuilder {
+	return b.With(WithMode(mode))
+}
+
+// Content 设置默认配置内容，同 WithContent
+func (b *ConfigBuilder) Content(content string) *ConfigBuilder {
+	return b.With(WithContent(content))
+}
+
+// Engine 选择存储引擎，同 WithEngine
+func (b *ConfigBuilder) Engine(engine Engine) *ConfigBuilder {
+	return b.With(WithEngine(engine))
+}
+
+// EnvPrefix 启用环境变量覆盖并设置前缀（默认开启智能大小写匹配），同 WithEnv
+func (b *ConfigBuilder) EnvPrefix(prefix string) *ConfigBuilder {
+	return b.With(WithEnv(prefix))
+}
+
+// Env 设置完整的环境变量选项，同 WithEnvOptions
+func (b *ConfigBuilder) Env(opts EnvOptions) *ConfigBuilder {
+	return b.With(WithEnvOptions(opts))
+}
+
+// PFlags 绑定命令行标志，同 WithPFlags
+func (b *ConfigBuilder) PFlags(flags ...*pflag.FlagSet) *ConfigBuilder {
+	return b.With(WithPFlags(flags...))
+}
+
+// Validator 添加验证器，同 WithValidator
+func (b *ConfigBuilder) Validator(validator ConfigValidator) *ConfigBuilder {
+	return b.With(WithValidator(validator))
+}
+
+// ValidateFunc 添加函数式验证器，同 WithValidateFunc
+func (b *ConfigBuilder) ValidateFunc(fn func(config map[string]any) error) *ConfigBuilder {
+	return b.With(WithValidateFunc(fn))
+}
+
+// Logger 设置日志记录器，同 WithLogger
+func (b *ConfigBuilder) Logger(logger Logger) *ConfigBuilder {
+	return b.With(WithLogger(logger))
+}
+
+// Encryption 启用配置加密，同 WithEncryption
+func (b *ConfigBuilder) Encryption(key string) *ConfigBuilder {
+	return b.With(WithEncryption(key))
+}
+
+// WriteDelay 设置防抖写入延迟，同 WithWriteDebounceDelay
+func (b *ConfigBuilder) WriteDelay(delay time.Duration) *ConfigBuilder {
+	return b.With(WithWriteDebounceDelay(delay))
+}
+
+// WatchDebounce 设置文件监听防抖时间，同 WithWatchDebounce
+func (b *ConfigBuilder) WatchDebounce(delay time.Duration) *ConfigBuilder {
+	return b.With(WithWatchDebounce(delay))
+}
+
+// With 追加任意选项，用于 Builder 未单独提供方法的配置
+func (b *ConfigBuilder) With(opts ...Option) *ConfigBuilder {
+	for _, opt := range opts {
+		if opt != nil {
+			b.opts = append(b.opts, opt)
+		}
+	}
+	return b
+}
+
+// Options 返回已累积的选项副本，便于与 New 或 WithBundle 组合
+func (b *ConfigBuilder) Options() []Option {
+	return append([]Option(nil), b.opts...)
+}
+
+// Build 使用累积的选项创建 Config，同 New
+func (b *ConfigBuilder) Build() (*Config, error) {
+	return New(b.opts...)
+}
+
+// MustBuild 同 Build，创建失败时 panic
+func (b *ConfigBuilder) MustBuild() *Config {
+	cfg, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return cfg
+}
@@ -0,0 +1,63 @@
+package sysconf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestBuilder(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("BLD_SERVER_PORT", "9090")
+
+	cfg, err := Builder().
+		Path(tmpDir).
+		Name("app").
+		Mode(JSON).
+		Content(`{"server": {"host": "localhost", "port": 80}}`).
+		EnvPrefix("BLD").
+		WriteDelay(0).
+		ValidateFunc(func(config map[string]any) error {
+			if server, ok := config["server"].(map[string]any); ok && server["host"] == "" {
+				return errors.New("host required")
+			}
+			return nil
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("build config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "app.json")); err != nil {
+		t.Fatalf("expected app.json to be created: %v", err)
+	}
+	if got := cfg.GetInt("server.port"); got != 9090 {
+		t.Fatalf("env prefix not applied, got %d", got)
+	}
+	if got := cfg.GetString("server.host"); got != "localhost" {
+		t.Fatalf("unexpected host %q", got)
+	}
+}
+
+func TestBuilderOptionsAndMustBuild(t *testing.T) {
+	b := Builder().Content("a: 1\n").With(nil, WithAccessTracking(true))
+	if len(b.Options()) != 2 {
+		t.Fatalf("nil options should be skipped, got %d", len(b.Options()))
+	}
+	cfg := b.MustBuild()
+	testutil.Cleanup(t, cfg.Close)
+	if !cfg.trackAccess || cfg.GetInt("a") != 1 {
+		t.Fatalf("builder options not applied")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("MustBuild should panic on invalid mode")
+		}
+	}()
+	Builder().Mode(Mode("yamll")).Content("a: 1\n").MustBuild()
+}
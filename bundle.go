@@ -0,0 +1,116 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrBundleConflict 两个选项包声明了相同的独占能力或使用了相同的名称
+var ErrBundleConflict = errors.New("bundle conflict")
+
+// redactedValue 敏感配置值在 Redacted 输出中的占位符
+const redactedValue = "******"
+
+// Bundle 选项包：生态库（如 k8sbundle.Options()、awsbundle.Options()）可将数据源、验证器、
+// 脱敏规则等选项打包发布，由使用方通过 WithBundle 一次性接入。
+//
+//	cfg, err := sysconf.New(
+//	    sysconf.WithBundle(k8sbundle.Bundle(), awsbundle.Bundle()),
+//	)
+type Bundle struct {
+	Name     string   // 包名，同一配置中不可重复
+	Provides []string // 本包占用的独占能力（如 "remote-source"、"logger"、"crypto"），两个包声明同一能力视为冲突
+	Options  []Option // 按顺序应用的选项
+	Redact   []string // 需要脱敏的键模式，语法同 WithRedactKeys
+}
+
+// appliedBundle 已应用选项包的冲突检测信息
+type appliedBundle struct {
+	name     string
+	provides []string
+}
+
+// WithBundle 按顺序应用一个或多个选项包。包内选项与直接传给 New 的选项等价，按出现位置生效。
+// 两个包名称相同或声明了相同的 Provides 能力时，New 返回 ErrBundleConflict。
+func WithBundle(bundles ...Bundle) Option {
+	return func(c *Config) {
+		for _, b := range bundles {
+			c.bundles = append(c.bundles, appliedBundle{name: b.Name, provides: slices.Clone(b.Provides)})
+			for _, opt := range b.Options {
+				if opt != nil {
+					opt(c)
+				}
+			}
+			if len(b.Redact) > 0 {
+				WithRedactKeys(b.Redact...)(c)
+			}
+		}
+	}
+}
+
+// Bundles 返回已应用的选项包名称（按应用顺序）
+func (c *Config) Bundles() []string {
+	names := make([]string, 0, len(c.bundles))
+	for _, b := range c.bundles {
+		names = append(names, b.name)
+	}
+	return names
+}
+
+// checkBundleConflicts 检查选项包之间的名称与能力冲突
+func (c *Config) checkBundleConflicts() error {
+	names := make(map[string]struct{}, len(c.bundles))
+	owners := make(map[string]string)
+	for _, b := range c.bundles {
+		if b.name == "" {
+			return fmt.Errorf("%w: bundle name is empty", ErrBundleConflict)
+		}
+		if _, dup := names[b.name]; dup {
+			return fmt.Errorf("%w: bundle %q applied more than once", ErrBundleConflict, b.name)
+		}
+		names[b.name] = struct{}{}
+		for _, capability := range b.provides {
+			if owner, taken := owners[capability]; taken {
+				return fmt.Errorf("%w: bundles %q and %q both provide %q", ErrBundleConflict, owner, b.name, capability)
+			}
+			owners[capability] = b.name
+		}
+	}
+	return nil
+}
+
+// WithRedactKeys 追加需要脱敏的配置键模式（按 "." 分段，支持 * 与 **，语法同 WatchKeysGlob），
+// 匹配的键与键名包含 password/secret/token 等片段的键一起在 Redacted 中被替换为占位符。
+func WithRedactKeys(patterns ...string) Option {
+	return func(c *Config) {
+		for _, pattern := range patterns {
+			if pattern != "" {
+				c.redactKeys = append(c.redactKeys, strings.Split(pattern, "."))
+			}
+		}
+	}
+}
+
+// IsSensitiveKey 判断配置键是否为敏感键（匹配 WithRedactKeys 模式或键名包含常见凭据片段）
+func (c *Config) IsSensitiveKey(key string) bool {
+	if isSecretKey(key) {
+		return true
+	}
+	parts := strings.Split(key, ".")
+	return slices.ContainsFunc(c.redactKeys, func(pattern []string) bool {
+		return matchKeyPattern(pattern, parts)
+	})
+}
+
+// Redacted 返回全部配置的嵌套副本，敏感键的值被替换为 "******"，适合打印到日志或调试页面
+func (c *Config) Redacted() map[string]any {
+	flat := deepCloneMap(c.loadData())
+	for key := range flat {
+		if c.IsSensitiveKey(key) {
+			flat[key] = redactedValue
+		}
+	}
+	return c.reconstructNestedStructure(flat)
+}
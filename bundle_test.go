@@ -0,0 +1,74 @@
+package sysconf
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestWithBundle(t *testing.T) {
+	k8s := Bundle{
+		Name:     "k8s",
+		Provides: []string{"remote-source"},
+		Options:  []Option{WithEnv("K8S")},
+		Redact:   []string{"cluster.*.ca"},
+	}
+	audit := Bundle{
+		Name:    "audit",
+		Options: []Option{WithProtectedKeys("audit.**")},
+	}
+
+	cfg, err := New(
+		WithContent("cluster:\n  prod:\n    ca: cert-data\n    host: k8s.local\ndb:\n  password: p@ss\naudit:\n  sink: s3\n"),
+		WithBundle(k8s, audit),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.Bundles(); !slices.Equal(got, []string{"k8s", "audit"}) {
+		t.Fatalf("unexpected bundles: %v", got)
+	}
+	if !cfg.envOptions.Enabled || cfg.envOptions.Prefix != "K8S" {
+		t.Fatalf("bundle options should be applied, got %+v", cfg.envOptions)
+	}
+	if err := cfg.Set("audit.sink", "file"); !errors.Is(err, ErrProtectedKey) {
+		t.Fatalf("expected protected key from bundle, got %v", err)
+	}
+
+	redacted := cfg.Redacted()
+	cluster := redacted["cluster"].(map[string]any)["prod"].(map[string]any)
+	if cluster["ca"] != redactedValue || cluster["host"] != "k8s.local" {
+		t.Fatalf("bundle redact pattern not applied: %v", cluster)
+	}
+	if redacted["db"].(map[string]any)["password"] != redactedValue {
+		t.Fatalf("password should be redacted by default: %v", redacted["db"])
+	}
+	if cfg.GetString("cluster.prod.ca") != "cert-data" {
+		t.Fatalf("redaction must not modify stored values")
+	}
+}
+
+func TestWithBundleConflicts(t *testing.T) {
+	vault := Bundle{Name: "vault", Provides: []string{"remote-source", "crypto"}}
+	aws := Bundle{Name: "aws", Provides: []string{"remote-source"}}
+
+	if _, err := New(WithBundle(vault, aws)); !errors.Is(err, ErrBundleConflict) {
+		t.Fatalf("expected capability conflict, got %v", err)
+	}
+	if _, err := New(WithBundle(aws), WithBundle(aws)); !errors.Is(err, ErrBundleConflict) {
+		t.Fatalf("expected duplicate bundle conflict, got %v", err)
+	}
+	if _, err := New(WithBundle(Bundle{})); !errors.Is(err, ErrBundleConflict) {
+		t.Fatalf("expected error for unnamed bundle, got %v", err)
+	}
+
+	cfg, err := New(WithBundle(vault, Bundle{Name: "k8s", Provides: []string{"logger"}}))
+	if err != nil {
+		t.Fatalf("non-overlapping bundles should combine: %v", err)
+	}
+	_ = cfg.Close()
+}
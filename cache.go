@@ -20,6 +20,7 @@ func (c *Config) enableReadCache() {
 // disableReadCache 禁用读取缓存
 func (c *Config) disableReadCache() {
 	c.cacheEnabled.Store(false)
+	c.warm.Store(nil)
 	emptyCache := make(map[string]any)
 	c.readCache.Store(emptyCache)
 }
@@ -48,7 +49,7 @@ func (c *Config) updateReadCache() {
 		return
 	}
 
-	// 通过 snapshotAllSettings 获取安全快照（内部已按 cacheBuildMu -> mu -> writeMu 顺序加锁）
+	// 通过 snapshotAllSettings 获取安全快照（基于原子数据快照，无需加锁）
 	safeSettings := c.snapshotAllSettings()
 
 	// 然后更新缓存
@@ -56,8 +57,9 @@ func (c *Config) updateReadCache() {
 	defer c.cacheMu.Unlock()
 
 	// 创建缓存的深拷贝，同时构建嵌套键缓存
-	newCache := make(map[string]any)
-	flatCache := make(map[string]any)
+	newCache := make(map[string]any, len(safeSettings))
+	flatCache := getScratchMap()
+	defer putScratchMap(flatCache)
 
 	for key, value := range safeSettings {
 		newCache[key] = value
@@ -72,8 +74,10 @@ func (c *Config) updateReadCache() {
 	c.readCache.Store(newCache)
 	atomic.AddInt64(&c.cacheVersion, 1)
 
-	c.logger.Debugf("Read cache updated, version: %d, keys: %d, flat keys: %d",
-		atomic.LoadInt64(&c.cacheVersion), len(safeSettings), len(flatCache))
+	if c.debugEnabled() {
+		c.logger.Debugf("Read cache updated, version: %d, keys: %d, flat keys: %d",
+			atomic.LoadInt64(&c.cacheVersion), len(safeSettings), len(flatCache))
+	}
 }
 
 // flattenMapToCache 递归扁平化map结构，生成完整的键路径
@@ -102,6 +106,10 @@ func (c *Config) flattenMapToCache(prefix string, value any, cache map[string]an
 
 // getCachedValue 从缓存获取值，如果缓存未命中则从viper获取
 func (c *Config) getCachedValue(key string) (any, bool) {
+	// 覆盖值与文件引用不进入读缓存，存在时走完整查找链
+	if c.hasOverrides() || c.fileRefMax > 0 {
+		return nil, false
+	}
 	// 简化：只从缓存读取，避免复杂的锁逻辑
 	if cache := c.loadReadCache(); cache != nil {
 		// 首先尝试直接匹配
@@ -134,8 +142,9 @@ func (c *Config) getNestedValue(cache map[string]any, key string) any {
 	return nil
 }
 
-// invalidateCache 使缓存失效（在配置更新时调用）
+// invalidateCache 使缓存失效（在配置更新时调用，调用者不得持有 mu），预热键立即重新解析
 func (c *Config) invalidateCache() {
+	c.warmKeyCache()
 	if c.cacheEnabled.Load() {
 		// 存储空的map而不是nil，避免atomic.Value的nil限制
 		emptyCache := make(map[string]any)
@@ -0,0 +1,75 @@
+// Command sysconf 是 sysconf 配置库的命令行工具。
+//
+// 根据 JSON Schema 生成强类型配置访问器：
+//
+//	sysconf gen accessors -schema schema.json -pkg config [-type Accessors] [-o accessors_gen.go]
+//
+// 可配合 go:generate 使用：
+//
+//	//go:generate go run github.com/darkit/sysconf/cmd/sysconf gen accessors -schema schema.json -pkg config -o accessors_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/darkit/sysconf"
+)
+
+const usage = `Usage:
+  sysconf gen accessors -schema <schema.json> -pkg <package> [-type <TypeName>] [-o <file>]
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "sysconf:", err)
+		os.Exit(1)
+	}
+}
+
+// run 解析子命令并执行，便于测试
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) < 2 || args[0] != "gen" || args[1] != "accessors" {
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("unknown command")
+	}
+	return genAccessors(args[2:], stdout, stderr)
+}
+
+// genAccessors 实现 "gen accessors" 子命令
+func genAccessors(args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("gen accessors", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	schemaPath := fs.String("schema", "", "JSON Schema file describing the config keys")
+	pkg := fs.String("pkg", "", "package name of the generated code")
+	typeName := fs.String("type", "Accessors", "name of the root accessor type")
+	output := fs.String("o", "", "output file (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *schemaPath == "" || *pkg == "" {
+		fs.Usage()
+		return fmt.Errorf("-schema and -pkg are required")
+	}
+
+	schema, err := os.ReadFile(*schemaPath)
+	if err != nil {
+		return err
+	}
+	code, err := sysconf.GenerateAccessors(schema, sysconf.AccessorOptions{
+		Package:  *pkg,
+		TypeName: *typeName,
+		Source:   filepath.Base(*schemaPath),
+	})
+	if err != nil {
+		return fmt.Errorf("generate accessors from %s: %w", *schemaPath, err)
+	}
+	if *output == "" {
+		_, err = stdout.Write(code)
+		return err
+	}
+	return os.WriteFile(*output, code, 0o644)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenAccessors(t *testing.T) {
+	dir := t.TempDir()
+	schema := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(schema, []byte(`{"type": "object", "properties": {"app": {"type": "object", "properties": {"name": {"type": "string"}}}}}`), 0o644); err != nil {
+		t.Fatalf("write schema failed: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"gen", "accessors", "-schema", schema, "-pkg", "config"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run failed: %v (%s)", err, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "func (a AppAccessors) Name() string") {
+		t.Fatalf("unexpected output:\n%s", stdout.String())
+	}
+
+	output := filepath.Join(dir, "accessors_gen.go")
+	if err := run([]string{"gen", "accessors", "-schema", schema, "-pkg", "config", "-o", output}, &stdout, &stderr); err != nil {
+		t.Fatalf("run with -o failed: %v", err)
+	}
+	if _, err := os.Stat(output); err != nil {
+		t.Fatalf("output file not written: %v", err)
+	}
+
+	if err := run([]string{"gen", "accessors", "-pkg", "config"}, &stdout, &stderr); err == nil {
+		t.Fatalf("expected error without -schema")
+	}
+	if err := run([]string{"lint"}, &stdout, &stderr); err == nil {
+		t.Fatalf("expected error for unknown command")
+	}
+}
@@ -0,0 +1,109 @@
+package sysconf
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// Coercion 一个配置键在读取时发生的类型转换，由 CoercionReport 返回
+type Coercion struct {
+	Key           string // 配置键
+	StoredType    string // 配置中保存的值类型，如 "string"
+	RequestedType string // 读取时请求的类型，如 "int"
+	Count         int64  // 发生转换的读取次数
+}
+
+// coercionID 按键与请求类型区分的转换记录标识
+type coercionID struct {
+	key       string
+	requested reflect.Type
+}
+
+// coercionEntry 转换记录，计数可并发累加
+type coercionEntry struct {
+	stored string
+	count  atomic.Int64
+}
+
+// CoercionReport 返回运行期间读取时保存类型与请求类型不一致的配置键（按键与请求类型排序），
+// 例如以 GetInt 读取字符串 "8080"。据此修正配置文件中的值类型后，读取可走类型断言的快速路径。
+// 仅记录跨类别的转换：数值之间（如 JSON 的 float64 读取为 int）以及字符串读取为时间间隔、时间与枚举不计入。
+func (c *Config) CoercionReport() []Coercion {
+	var report []Coercion
+	c.coercions.Range(func(k, v any) bool {
+		id, entry := k.(coercionID), v.(*coercionEntry)
+		report = append(report, Coercion{
+			Key:           id.key,
+			StoredType:    entry.stored,
+			RequestedType: id.requested.String(),
+			Count:         entry.count.Load(),
+		})
+		return true
+	})
+	slices.SortFunc(report, func(a, b Coercion) int {
+		return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.RequestedType, b.RequestedType))
+	})
+	return report
+}
+
+// noteCoercion 记录以 requested 类型读取 val 时发生的转换，调用方只在快速路径未命中时调用
+func (c *Config) noteCoercion(key string, val any, requested reflect.Type) {
+	stored, want := valueClass(val), typeClass(requested)
+	if stored == want || stored == "string" && (want == "duration" || want == "time" || want == "enum") {
+		return
+	}
+	id := coercionID{key: c.resolveKey(c.loadData(), key), requested: requested}
+	coercions := &c.statsOwner().coercions
+	entry, ok := coercions.Load(id)
+	if !ok {
+		entry, _ = coercions.LoadOrStore(id, &coercionEntry{stored: fmt.Sprintf("%T", val)})
+	}
+	entry.(*coercionEntry).count.Add(1)
+}
+
+// valueClass 返回配置值所属的类别
+func valueClass(val any) string {
+	switch val.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case time.Duration:
+		return "duration"
+	case time.Time:
+		return "time"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return "number"
+	}
+	return reflect.TypeOf(val).Kind().String()
+}
+
+// typeClass 返回请求类型所属的类别，与 valueClass 对应
+func typeClass(typ reflect.Type) string {
+	switch typ {
+	case reflect.TypeFor[time.Duration]():
+		return "duration"
+	case reflect.TypeFor[time.Time]():
+		return "time"
+	}
+	if _, ok := lookupEnumType(typ); ok {
+		return "enum"
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return typ.Kind().String()
+}
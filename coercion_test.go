@@ -0,0 +1,55 @@
+package sysconf
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestCoercionReport(t *testing.T) {
+	content := `server:
+  port: "8080"
+  debug: "true"
+  timeout: 30s
+  retries: 3
+  ratio: 0.5
+  workers: 4
+app:
+  name: demo
+  version: 2
+`
+	cfg, err := New(WithContent(content))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.CoercionReport(); len(got) != 0 {
+		t.Fatalf("expected empty report before reads, got %v", got)
+	}
+
+	for range 3 {
+		_ = cfg.GetInt("server.port")
+	}
+	_ = cfg.GetBool("server.debug")
+	_ = cfg.GetString("app.version")
+	_ = GetAs[int](cfg, "SERVER.PORT")
+	// 以下读取类型一致或属于允许的表示方式，不应计入
+	_ = cfg.GetDuration("server.timeout")
+	_ = cfg.GetInt("server.retries")
+	_ = cfg.GetFloat("server.ratio")
+	_ = cfg.GetFloat("server.workers")
+	_ = GetAs[time.Duration](cfg, "server.timeout")
+	_ = cfg.GetString("app.name")
+
+	want := []Coercion{
+		{Key: "app.version", StoredType: "int", RequestedType: "string", Count: 1},
+		{Key: "server.debug", StoredType: "string", RequestedType: "bool", Count: 1},
+		{Key: "server.port", StoredType: "string", RequestedType: "int", Count: 4},
+	}
+	if got := cfg.CoercionReport(); !slices.Equal(got, want) {
+		t.Fatalf("unexpected report:\n got  %+v\n want %+v", got, want)
+	}
+}
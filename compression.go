@@ -0,0 +1,148 @@
+package sysconf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compressor 配置文件压缩算法。内置 gzip 与 zstd，其他算法可包装第三方实现后通过 RegisterCompressor 注册。
+type Compressor interface {
+	// Name 算法名称，对应 WithCompression 的参数
+	Name() string
+	// Magic 压缩数据的魔数前缀，用于加载时自动识别
+	Magic() []byte
+	// Compress 压缩数据
+	Compress(data []byte) ([]byte, error)
+	// Decompress 解压数据
+	Decompress(data []byte) ([]byte, error)
+}
+
+var compressors sync.Map // map[string]Compressor
+
+func init() {
+	RegisterCompressor(gzipCompressor{})
+	RegisterCompressor(zstdCompressor{})
+}
+
+// RegisterCompressor 注册压缩算法，同名算法会被覆盖
+func RegisterCompressor(compressor Compressor) {
+	compressors.Store(compressor.Name(), compressor)
+}
+
+// WithCompression 写入配置文件时先压缩再加密（如启用），读取时在解密后自动识别并解压。
+// name 为内置的 "gzip"、"zstd" 或已通过 RegisterCompressor 注册的算法名；未注册的算法在 New 时返回错误。
+// 加载时按魔数自动识别压缩格式，因此启用压缩前写入的未压缩文件仍可直接读取。
+// 适用于路由表等由程序生成的大型配置，可显著减少磁盘与网络存储的读写量。
+func WithCompression(name string) Option {
+	return func(c *Config) {
+		c.compression = name
+	}
+}
+
+// initCompression 解析 WithCompression 指定的压缩算法
+func (c *Config) initCompression() error {
+	if c.compression == "" {
+		c.compressor = nil
+		return nil
+	}
+	compressor, ok := compressors.Load(c.compression)
+	if !ok {
+		return fmt.Errorf("compression %q is not registered", c.compression)
+	}
+	c.compressor = compressor.(Compressor)
+	return nil
+}
+
+// compressContent 启用压缩时压缩写入内容
+func (c *Config) compressContent(data []byte) ([]byte, error) {
+	if c.compressor == nil {
+		return data, nil
+	}
+	compressed, err := c.compressor.Compress(data)
+	if err != nil {
+		return nil, fmt.Errorf("compress config: %w", err)
+	}
+	c.logger.Debugf("Config compressed with %s: %d -> %d bytes", c.compressor.Name(), len(data), len(compressed))
+	return compressed, nil
+}
+
+// decompressContent 按魔数识别并解压内容；未压缩的内容原样返回
+func decompressContent(data []byte) ([]byte, error) {
+	var (
+		plain   []byte
+		matched bool
+		err     error
+	)
+	compressors.Range(func(_, value any) bool {
+		compressor := value.(Compressor)
+		if magic := compressor.Magic(); len(magic) > 0 && bytes.HasPrefix(data, magic) {
+			matched = true
+			if plain, err = compressor.Decompress(data); err != nil {
+				err = fmt.Errorf("decompress config (%s): %w", compressor.Name(), err)
+			}
+			return false
+		}
+		return true
+	})
+	if matched {
+		return plain, err
+	}
+	return data, nil
+}
+
+// gzipCompressor 内置 gzip 压缩
+type gzipCompressor struct{}
+
+func (gzipCompressor) Name() string { return "gzip" }
+
+func (gzipCompressor) Magic() []byte { return []byte{0x1f, 0x8b} }
+
+func (gzipCompressor) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCompressor) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+	return io.ReadAll(r)
+}
+
+// zstdCodec 惰性创建的 zstd 编解码器，EncodeAll/DecodeAll 可并发调用
+var zstdCodec = sync.OnceValues(func() (*zstd.Encoder, *zstd.Decoder) {
+	encoder, _ := zstd.NewWriter(nil)
+	decoder, _ := zstd.NewReader(nil)
+	return encoder, decoder
+})
+
+// zstdCompressor 内置 zstd 压缩，压缩率与速度通常优于 gzip
+type zstdCompressor struct{}
+
+func (zstdCompressor) Name() string { return "zstd" }
+
+func (zstdCompressor) Magic() []byte { return []byte{0x28, 0xb5, 0x2f, 0xfd} }
+
+func (zstdCompressor) Compress(data []byte) ([]byte, error) {
+	encoder, _ := zstdCodec()
+	return encoder.EncodeAll(data, nil), nil
+}
+
+func (zstdCompressor) Decompress(data []byte) ([]byte, error) {
+	_, decoder := zstdCodec()
+	return decoder.DecodeAll(data, nil)
+}
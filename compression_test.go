@@ -0,0 +1,112 @@
+package sysconf
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+// reverseCompressor 测试用压缩算法：魔数 + 反转字节
+type reverseCompressor struct{}
+
+func (reverseCompressor) Name() string  { return "reverse" }
+func (reverseCompressor) Magic() []byte { return []byte("REV!") }
+func (reverseCompressor) Compress(data []byte) ([]byte, error) {
+	out := append([]byte("REV!"), data...)
+	reverseBytes(out[4:])
+	return out, nil
+}
+
+func (reverseCompressor) Decompress(data []byte) ([]byte, error) {
+	out := bytes.Clone(data[4:])
+	reverseBytes(out)
+	return out, nil
+}
+
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
+func TestWithCompressionBuiltin(t *testing.T) {
+	magics := map[string][]byte{"gzip": {0x1f, 0x8b}, "zstd": {0x28, 0xb5, 0x2f, 0xfd}}
+	for _, name := range []string{"gzip", "zstd"} {
+		for _, engine := range []Engine{CompatibilityEngine, NativeEngine} {
+			tmpDir := t.TempDir()
+			routes := strings.Repeat("- /api/v1/resource\n", 500)
+			opts := []Option{
+				WithEngine(engine), WithPath(tmpDir), WithMode("yaml"), WithName("routes"),
+				WithContent("routes:\n" + routes), WithCompression(name), WithWriteDebounceDelay(0),
+			}
+			cfg, err := New(opts...)
+			if err != nil {
+				t.Fatalf("%s/engine %v: create config failed: %v", name, engine, err)
+			}
+			if err := cfg.Set("name", "edge"); err != nil {
+				t.Fatalf("%s/engine %v: set failed: %v", name, engine, err)
+			}
+			_ = cfg.Close()
+
+			raw, err := os.ReadFile(filepath.Join(tmpDir, "routes.yaml"))
+			if err != nil {
+				t.Fatalf("%s/engine %v: read file failed: %v", name, engine, err)
+			}
+			if !bytes.HasPrefix(raw, magics[name]) || len(raw) > len(routes)/5 {
+				t.Fatalf("%s/engine %v: expected small compressed file, got %d bytes", name, engine, len(raw))
+			}
+
+			reopened, err := New(opts...)
+			if err != nil {
+				t.Fatalf("%s/engine %v: reopen failed: %v", name, engine, err)
+			}
+			if got := reopened.GetString("name"); got != "edge" || len(reopened.GetStringSlice("routes")) != 500 {
+				t.Fatalf("%s/engine %v: unexpected values after reopen: %q", name, engine, got)
+			}
+			_ = reopened.Close()
+		}
+	}
+}
+
+func TestWithCompressionEncryptedAndDetection(t *testing.T) {
+	RegisterCompressor(reverseCompressor{})
+	tmpDir := t.TempDir()
+
+	// 未压缩的旧文件在启用压缩后仍可读取，并在写回时压缩
+	if err := os.WriteFile(filepath.Join(tmpDir, "app.yaml"), []byte("name: plain\n"), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	cfg, err := New(WithPath(tmpDir), WithMode("yaml"), WithName("app"),
+		WithCompression("reverse"), WithEncryption("key"), WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if got := cfg.GetString("name"); got != "plain" {
+		t.Fatalf("expected plain value, got %q", got)
+	}
+	if err := cfg.Set("name", "packed"); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	// 不指定 WithCompression 时按魔数自动识别
+	reopened, err := New(WithPath(tmpDir), WithMode("yaml"), WithName("app"), WithEncryption("key"))
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	testutil.Cleanup(t, reopened.Close)
+	if got := reopened.GetString("name"); got != "packed" {
+		t.Fatalf("expected packed value, got %q", got)
+	}
+
+	if _, err := New(WithCompression("lz4")); err == nil {
+		t.Fatalf("expected error for unregistered compression")
+	}
+	if _, err := decompressContent([]byte{0x28, 0xb5, 0x2f, 0xfd, 0x00}); err == nil || !strings.Contains(err.Error(), "zstd") {
+		t.Fatalf("expected zstd decompression error, got %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"net/http"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -16,7 +17,7 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/fsnotify/fsnotify"
+	"github.com/pelletier/go-toml/v2"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
@@ -34,6 +35,8 @@ var (
 	ErrInvalidKey       = errors.New("invalid configuration key")
 	ErrInitGlobalConfig = errors.New("failed to initialize global config")
 	ErrAlreadyClosed    = errors.New("config already closed")
+	// ErrFormatNotWritable 配置文件格式不支持写回（如 HCL），Set/SetMultiple 在修改前返回
+	ErrFormatNotWritable = errors.New("config format is not writable")
 )
 
 const (
@@ -41,6 +44,7 @@ const (
 	defaultCacheWarmupDelay  = 10 * time.Millisecond
 	defaultCacheRebuildDelay = 50 * time.Millisecond
 	defaultWatchDebounce     = 200 * time.Millisecond
+	defaultWarmRefresh       = time.Second
 )
 
 // EnvOptions 环境变量配置选项
@@ -73,10 +77,57 @@ func (f ConfigValidateFunc) GetName() string {
 	return "函数式验证器"
 }
 
+// readOptions 读取与解析选项，只在 New 期间设置、之后不再修改。
+// 影响读取结果的新选项应放在这里，Acquire 整体复制到快照，避免快照与配置的读取行为不一致。
+type readOptions struct {
+	timeLayouts    []string    // GetTime 与 Unmarshal 优先尝试的时间格式
+	lenientNumbers bool        // 宽松解析数值字符串（千位分隔符、进制前缀）
+	extendedBools  bool        // 布尔值接受 yes/no/on/off/enabled/disabled
+	strictTypes    bool        // 严格类型模式，禁止隐式类型转换（WithStrictTypes）
+	slicePolicy    SlicePolicy // 切片中无法转换的元素的处理策略（WithSlicePolicy）
+	pathKeys       [][]string  // 路径类配置键模式（WithPathKeys）
+	pathsRelative  bool        // 相对路径按配置文件目录解析
+	dashEquivalent bool        // 键中 "-" 与 "_" 视为等价（WithDashUnderscoreEquivalence）
+	keyDelim       string      // 调用方键的层级分隔符，空表示 "."（WithKeyDelimiter）
+	fileRefMax     int64       // 文件引用大小上限，0 表示未启用（WithFileReferences）
+	fileRefBase    string      // 相对文件引用的基准目录
+	sourceOrder    []Source    // 补全后的完整优先级（从高到低）
+	trackAccess    bool        // 是否记录键读取（WithAccessTracking）
+}
+
+// publishedState 随每次数据发布更新、读取路径依赖的状态。
+// 影响读取结果的新状态应放在这里并在 copyFrom 中复制，Acquire 据此固定快照的版本。
+type publishedState struct {
+	data         atomic.Value                      // 存储map[string]any
+	dotenv       atomic.Pointer[map[string]string] // dotenv 文件中的变量，优先级低于进程环境变量
+	dotenvKeys   atomic.Bool                       // 当前数据来自 dotenv 格式，变量名形式的键按层级映射
+	keyIndex     atomic.Pointer[keyAliasIndex]     // 当前数据快照的规范键索引
+	keyOrigins   atomic.Pointer[map[string]Source] // 当前数据中不来自主配置的键及其来源（标志、环境变量、附加数据源）
+	flagOrigins  atomic.Pointer[map[string]Source] // 最近一次加载时由命令行标志或环境变量提供的键
+	overrides    atomic.Pointer[map[string]any]    // 最高优先级的覆盖值（扁平键 → 值）
+	remoteLoaded atomic.Bool                       // 主配置当前是否来自远程配置源
+}
+
+// copyFrom 复制 src 当前发布的全部状态
+func (p *publishedState) copyFrom(src *publishedState) {
+	if data := src.data.Load(); data != nil {
+		p.data.Store(data)
+	}
+	p.dotenv.Store(src.dotenv.Load())
+	p.dotenvKeys.Store(src.dotenvKeys.Load())
+	p.keyIndex.Store(src.keyIndex.Load())
+	p.keyOrigins.Store(src.keyOrigins.Load())
+	p.flagOrigins.Store(src.flagOrigins.Load())
+	p.overrides.Store(src.overrides.Load())
+	p.remoteLoaded.Store(src.remoteLoaded.Load())
+}
+
 // Config 统一配置实现
 type Config struct {
-	// 核心数据存储 - 使用atomic.Value实现无锁读取
-	data atomic.Value // 存储map[string]any
+	// 核心数据存储 - 使用atomic.Value实现无锁读取，及随数据发布的读取侧状态
+	publishedState
+	// 读取与解析选项
+	readOptions
 
 	// 并发控制
 	mu sync.RWMutex // 保护元数据和写操作
@@ -87,43 +138,127 @@ type Config struct {
 	closed   atomic.Bool    // 防止重复关闭
 
 	// 基本配置
-	logger Logger // 日志记录器
-	path   string // 配置文件路径
-	mode   string // 配置文件类型
-	name   string // 配置文件名称
+	logger   Logger   // 日志记录器
+	logLevel LogLevel // WithLogLevel 指定的最低日志级别
+	path     string   // 配置文件路径
+	mode     string   // 配置文件类型
+	name     string   // 配置文件名称
 	// configFileName 保存需要按精确文件名读取的隐藏配置文件，例如 .env。
 	configFileName string
 	content        string // 默认配置文件内容
+	contentVersion int    // 默认内容版本（WithContentVersion）
+	noContentCache bool   // 不使用进程级已解析内容缓存（WithContentCache）
 
 	// 功能组件
-	envOptions    EnvOptions        // 环境变量配置选项
-	envEnabled    atomic.Bool       // 环境变量热路径开关
-	envKeyCache   sync.Map          // 环境变量键派生缓存
-	cryptoOptions CryptoOptions     // 加密配置选项
-	crypto        ConfigCrypto      // 加密实现实例
-	validators    []ConfigValidator // 配置验证器列表
-	pflags        []*pflag.FlagSet  // 命令行标志绑定
-	pflagOptions  PFlagOptions      // 命令行标志绑定选项
+	envOptions      EnvOptions                  // 环境变量配置选项
+	envEnabled      atomic.Bool                 // 环境变量热路径开关
+	envSections     []envSection                // 按配置子树映射的环境变量前缀（WithEnvSectionPrefix）
+	envAllow        [][]string                  // 允许由环境变量覆盖的键模式（WithEnvKeyAllowPatterns）
+	dotenvFiles     []dotenvFile                // 并入环境变量覆盖层的 dotenv 文件（WithDotenvFile）
+	envKeyCache     sync.Map                    // 环境变量键派生缓存
+	lookupTTL       time.Duration               // 环境变量/回退查询结果缓存时长
+	lookupCache     sync.Map                    // 环境变量/回退查询结果缓存
+	readKeys        sync.Map                    // 已读取过的键
+	statsParent     *Config                     // Acquire 创建的快照所属的配置，读取记录与类型转换记到该配置上
+	coercions       sync.Map                    // 读取时发生的类型转换（coercionID → *coercionEntry）
+	cryptoOptions   CryptoOptions               // 加密配置选项
+	crypto          ConfigCrypto                // 加密实现实例
+	validators      []ConfigValidator           // 配置验证器列表
+	normalizers     map[string][]NormalizerFunc // 写入前的值规范化函数（配置键 → 函数列表）
+	protectedKeys   [][]string                  // 写保护键模式（按 "." 分段）
+	frozen          atomic.Pointer[frozenState] // FreezeSubtree 冻结的配置子树及冻结时的值
+	setRateLimiter  *setRateLimiter             // WithSetRateLimit 写入频率限制
+	approvers       []ChangeApprover            // 配置变更审批器
+	applyMu         sync.Mutex                  // 保护应用器列表并串行化应用管道
+	appliers        []*applier                  // 重载后按依赖顺序执行的配置应用器
+	instanceID      string                      // 实例标识（分阶段发布分桶）
+	fileLockTimeout time.Duration               // 写入配置文件时的文件锁等待时间（WithFileLock）
+	streamThreshold int64                       // 流式加密阈值（WithEncryptionStreamThreshold）
+	compression     string                      // 压缩算法名称（WithCompression）
+	compressor      Compressor                  // 已解析的压缩算法
+	bundles         []appliedBundle             // 已应用的选项包（WithBundle）
+	redactKeys      [][]string                  // 需要脱敏的键模式（WithRedactKeys）
+	notifyEnabled   bool                        // 是否启用跨进程变更通知（WithLocalNotify）
+	notifyAddr      string                      // 变更通知套接字地址
+	notifier        *localNotifier              // 跨进程变更通知通道
+	pflags          []*pflag.FlagSet            // 命令行标志绑定
+	pflagOptions    PFlagOptions                // 命令行标志绑定选项
+
+	// 解析选项
+	rejectDuplicateKeys bool                                // 严格解码：出现重复键时加载失败
+	yamlAliasLimit      int                                 // YAML 别名展开节点上限（0 使用默认值，<0 关闭）
+	jsoncStripComments  bool                                // jsonc 模式写回时丢弃注释
+	jsoncComments       atomic.Pointer[map[string][]string] // jsonc 注释（键路径 → 注释行）
+	iniSliceStyle       INISliceStyle                       // ini 模式写入列表值的方式
+	iniStripComments    bool                                // ini 模式写回时丢弃注释
+	iniLayout           atomic.Pointer[iniLayout]           // ini 节顺序、键顺序与注释
+	faultInjector       FaultInjector                       // 测试用故障注入器
+	fileRefCache        sync.Map                            // 引用文件内容缓存（路径 → *fileRefEntry）
+
+	// 配置段
+	section     string                         // WithSection 指定的顶级段名称
+	sectionRest atomic.Pointer[map[string]any] // 配置文件中除配置段以外的内容
+
+	// 附加数据源
+	sources        []*sourceLayer                 // 挂载到配置键上的附加数据源
+	recorder       *recorder                      // WithRecorder 变更记录
+	initialLoad    bool                           // 是否处于 New 的初始加载阶段（受 mu 保护）
+	backupRecovery error                          // 初始加载时从 .prev 恢复的原因，nil 表示未恢复
+	initCtx        context.Context                // NewWithContext 的上下文，仅在初始化期间非空
+	sourceValues   atomic.Pointer[map[string]any] // 数据源覆盖层（扁平键 → 值）
+	viperDefaults  atomic.Pointer[map[string]any] // FromViper 导入的 SetDefault 默认值，仅填充数据中缺失的键（扁平键 → 值）
+	urlSource      *urlSource                     // 远程 HTTP(S) 配置源
+	httpClient     *http.Client                   // 远程配置源使用的 HTTP 客户端
+	objectSource   *objectSource                  // 对象存储配置源
+	sqlSource      *sqlSource                     // 数据库键值表配置源
+
+	// 来源优先级
+	sourcePriority []Source            // WithSourcePriority 指定的顺序
+	overrideArgs   []string            // WithOverrides 指定的 key=value 覆盖值
+	viperOverrides map[string]struct{} // 通过 viper.Set 写入的键，viper 合并时优先于标志与环境变量（受 mu 保护）
 
 	// 文件监控和写入控制
 	lastUpdate      time.Time   // 配置最后更新时间
 	writeTimer      *time.Timer // 延迟写入定时器
 	pendingWrites   bool        // 是否有待写入的更改
+	nextFlushAt     time.Time   // 延迟写入定时器的触发时间，零值表示未安排
+	flushing        atomic.Bool // 是否正在将配置写入磁盘
 	writeDelay      time.Duration
 	watchDebounce   time.Duration
 	watchStarted    bool
+	watcherStarts   int // 文件监听启动次数，用于统计重启
 	watchCallbacks  map[uint64]func()
+	syncWatches     map[uint64]struct{} // 由 WatchSync 注册、在 Set 返回前执行的回调
+	callbackQueue   callbackQueue       // 按变更提交顺序串行执行回调
+	commits         commitSignals       // 数据发布后异步通知 Observe 等订阅者
 	nextWatchHandle uint64
+	watchCancels    map[uint64]context.CancelFunc // 每次 Watch 调用对应的取消函数
+	nextWatchSub    uint64
+	watchStop       chan struct{}            // 停止内部文件监听
+	extraPaths      map[string]struct{}      // WatchExtraPath 登记的附加监听路径
+	extraWatch      *extraWatch              // 运行中的附加路径监听器
+	health          healthState              // 配置健康状态与事件监听
+	fileInfo        atomic.Pointer[FileInfo] // 最近一次读写的配置文件元数据
+	replicas        replicaState             // 多写者写入的键版本与冲突监听
+	resolver        ConflictResolver         // WithConflictResolver 设置的冲突解决函数
 
 	// viper兼容层（用于文件操作和环境变量）
+	engine      Engine // 存储引擎类型
 	viper       *viper.Viper
 	viperLoaded bool
+	viperSync   time.Duration // FromViper 导入后轮询原 viper 实例的间隔，0 表示只导入一次（WithViperSync）
+	viperSyncMu sync.Locker   // 轮询时保护原 viper 实例的锁
 
 	// 高性能缓存 - 简化版本，无复杂版本控制
 	cacheEnabled atomic.Bool // 是否启用缓存（原子操作保证并发安全）
 	// 缓存调度参数
 	cacheWarmupDelay  time.Duration
 	cacheRebuildDelay time.Duration
+	// 预热键（WithCacheWarmKeys）
+	warmKeys    []string
+	warmRefresh time.Duration             // 后台刷新间隔，0 表示不刷新
+	warm        atomic.Pointer[warmCache] // 预热键在当前数据快照上的解析结果
+	warmMu      sync.Mutex                // 串行化预热结果重建
 
 	// 兼容字段（保持与现有代码的兼容性）
 	readCache    atomic.Value // 只读缓存，存储map[string]any
@@ -138,6 +273,7 @@ type Config struct {
 type snapshot struct {
 	data      map[string]any
 	readCache map[string]any
+	origins   *map[string]Source
 	timestamp time.Time
 }
 
@@ -146,13 +282,28 @@ type Option func(*Config)
 
 // New 创建新的统一配置实例
 func New(opts ...Option) (*Config, error) {
+	return NewWithContext(context.Background(), opts...)
+}
+
+// NewWithContext 同 New，但初始化遵循 ctx 的取消与截止时间：各 I/O 步骤（路径解析、读取或创建配置文件、
+// 远程与附加数据源加载）开始前检查 ctx，远程请求随 ctx 取消；阻塞在无法中断的文件系统调用上时同样按时返回，
+// 后台未完成的初始化结束后自动关闭。取消或超时时返回 ErrTypeInitialization 类型的 *ConfigError，
+// 其原因为 ctx.Err()，可用 errors.Is(err, context.DeadlineExceeded) 判断。
+func NewWithContext(ctx context.Context, opts ...Option) (*Config, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, initCanceledError(err)
+	}
+
 	workPathOnce.Do(func() {
 		workPathValue = WorkPath()
 	})
 
 	// 创建统一配置实例
 	c := &Config{
-		viper:             viper.New(),
+		viper:             newViper(),
 		viperLoaded:       true,
 		path:              workPathValue,
 		mode:              "yaml",
@@ -161,6 +312,7 @@ func New(opts ...Option) (*Config, error) {
 		watchDebounce:     defaultWatchDebounce,
 		cacheWarmupDelay:  defaultCacheWarmupDelay,
 		cacheRebuildDelay: defaultCacheRebuildDelay,
+		warmRefresh:       defaultWarmRefresh,
 		stopChan:          make(chan struct{}),
 		watchCallbacks:    make(map[uint64]func()),
 	}
@@ -171,19 +323,41 @@ func New(opts ...Option) (*Config, error) {
 	// 初始化原子数据存储
 	c.data.Store(make(map[string]any))
 
+	// 先应用 SYSCONF_* 调优环境变量，再应用显式选项，使显式选项优先
+	invalidTuning := c.applyEnvTuning()
+
 	// 应用自定义选项
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.applyLogLevel()
+	for _, msg := range invalidTuning {
+		c.logger.Warnf("Ignoring invalid tuning environment variable %s", msg)
+	}
+
+	if err := c.checkBundleConflicts(); err != nil {
+		return nil, err
+	}
 
 	if c.pflags == nil && len(c.pflagOptions.FlagSets) > 0 {
 		c.pflags = c.pflagOptions.FlagSets
 	}
+	if err := c.loadDotenvFiles(); err != nil {
+		return nil, err
+	}
+	if err := c.initOverrides(); err != nil {
+		return nil, err
+	}
 
 	// 初始化配置
-	if err := c.initialize(); err != nil {
-		return nil, fmt.Errorf("initialize config: %w", err)
+	if err := c.initializeWithContext(ctx); err != nil {
+		return nil, err
 	}
+	c.fileRefBase = c.configDir()
+	c.reportBackupRecovery()
+	c.upgradeDefaultContent()
+	c.startLocalNotify()
+	c.startWarmKeys()
 
 	return c, nil
 }
@@ -230,6 +404,11 @@ func (c *Config) Watch(callbacks ...func()) {
 
 // WatchWithContext 监听配置变化并返回取消函数，用于显式停止监听。
 func (c *Config) WatchWithContext(ctx context.Context, callbacks ...func()) context.CancelFunc {
+	return c.watchWithContext(ctx, false, callbacks...)
+}
+
+// watchWithContext 注册回调并启动文件监听，syncOnSet 为 true 时回调同时在 Set 提交后同步执行
+func (c *Config) watchWithContext(ctx context.Context, syncOnSet bool, callbacks ...func()) context.CancelFunc {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -245,22 +424,31 @@ func (c *Config) WatchWithContext(ctx context.Context, callbacks ...func()) cont
 		c.stopChan = make(chan struct{})
 	}
 	handles := c.registerWatchCallbacksLocked(callbacks...)
+	if syncOnSet {
+		c.markSyncWatchesLocked(handles)
+	}
 	if err := c.startWatchLocked(); err != nil {
 		for _, handle := range handles {
 			delete(c.watchCallbacks, handle)
+			delete(c.syncWatches, handle)
 		}
 		c.mu.Unlock()
 		cancel()
 		c.logger.Errorf("Failed to start config watch: %v", err)
 		return func() {}
 	}
+	subID := c.registerWatchSubscriptionLocked(cancel)
+	stopChan := c.stopChan
 	c.mu.Unlock()
 
 	c.wg.Go(func() {
 		select {
 		case <-watchCtx.Done():
-		case <-c.stopChan:
+		case <-stopChan:
 		}
+		c.mu.Lock()
+		delete(c.watchCancels, subID)
+		c.mu.Unlock()
 		c.unregisterWatchCallbacks(handles...)
 	})
 
@@ -274,19 +462,31 @@ func (c *Config) reloadConfigLocked() error {
 	if c.name == "" {
 		return nil
 	}
+	c.remoteLoaded.Store(false)
 
-	if c.cryptoOptions.Enabled {
-		// 加密配置不依赖 viper 的内部自动重载，改为显式读取解密内容。
+	if c.readsFileDirectly() {
+		// 加密或压缩配置、原生引擎与 jsonc 不依赖 viper 的内部自动重载，改为显式读取文件内容。
 		return c.readConfigFileUnsafe()
 	}
-	// 非加密配置由 viper.WatchConfig 内部完成 ReadInConfig。
+	if err := c.checkContentFile(); err != nil {
+		return err
+	}
+	if err := c.viper.ReadInConfig(); err != nil {
+		return err
+	}
+	c.recordFileInfo(c.viper.ConfigFileUsed(), nil)
 	return nil
 }
 
 // Viper 返回底层的 viper 实例
+// 原生引擎（NativeEngine）下不存在 viper 实例，返回 nil。
 func (c *Config) Viper() *viper.Viper {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if c.isNative() {
+		c.logger.Warnf("Viper() is not available with the native engine")
+		return nil
+	}
 	c.ensureViperLoadedLocked()
 	return c.viper
 }
@@ -335,10 +535,20 @@ func (c *Config) createDefaultConfigInternal(locked bool) error {
 	if c.content == "" {
 		return nil
 	}
+	if err := c.checkContent([]byte(c.content)); err != nil {
+		return err
+	}
 
 	// 支持纯内存配置：如果没有设置name，则不创建物理文件
 	if c.name == "" {
 		c.logger.Infof("Loading configuration in memory-only mode (no file name specified)")
+		if c.isNative() {
+			if !locked {
+				c.mu.Lock()
+				defer c.mu.Unlock()
+			}
+			return c.loadNativeBytesUnsafe([]byte(c.content))
+		}
 		if locked {
 			return c.loadContentToMemoryUnsafe()
 		}
@@ -361,18 +571,30 @@ func (c *Config) createDefaultConfigInternal(locked bool) error {
 
 	c.logger.Infof("Creating default config file: %s", configFile)
 
+	// 初始加载时调用者已确认文件不存在，属于首次启动，需与同时启动的其他实例竞争创建；
+	// 此时不能再以文件是否存在判断，否则在两次检查之间被其他实例创建的文件会被覆盖
+	bootstrap := locked && c.initialLoad
+
 	// 创建备份（如果文件已存在）
-	if err := c.createBackupIfExists(configFile); err != nil {
-		c.logger.Warnf("Failed to create backup: %v", err)
+	if !bootstrap {
+		if err := c.createBackupIfExists(configFile); err != nil {
+			c.logger.Warnf("Failed to create backup: %v", err)
+		}
 	}
 
-	// 准备要写入的数据
-	data := []byte(c.content)
+	// 准备要写入的数据（启用配置段时包装到段下，启用压缩时先压缩）
+	data, err := c.sectionDefaultContent()
+	if err != nil {
+		return err
+	}
+	if data, err = c.compressContent(data); err != nil {
+		return err
+	}
 
 	// 如果启用了加密，先加密数据
 	if c.cryptoOptions.Enabled && c.crypto != nil {
 		c.logger.Debugf("Encrypting default config content")
-		encryptedData, err := c.crypto.Encrypt(data)
+		encryptedData, err := c.encryptContent(data)
 		if err != nil {
 			c.logger.Errorf("Failed to encrypt default config: %v", err)
 			return fmt.Errorf("encrypt default config: %w", err)
@@ -381,39 +603,56 @@ func (c *Config) createDefaultConfigInternal(locked bool) error {
 		c.logger.Infof("Default config content encrypted successfully")
 	}
 
-	if err := os.WriteFile(configFile, data, 0o644); err != nil {
+	if bootstrap {
+		created, err := createFileExclusive(configFile, data, 0o644)
+		if err != nil {
+			c.logger.Errorf("Failed to write default config: %v", err)
+			return fmt.Errorf("write default config: %w", err)
+		}
+		if !created {
+			// 其他实例已抢先创建，加载其内容而不是覆盖
+			c.logger.Infof("Config file %s was created concurrently, loading it instead of defaults", configFile)
+			return c.readConcurrentlyCreated(locked)
+		}
+	} else if err := os.WriteFile(configFile, data, 0o644); err != nil {
 		c.logger.Errorf("Failed to write default config: %v", err)
 		return fmt.Errorf("write default config: %w", err)
 	}
 
-	// 读取刚创建的配置文件
-	if c.cryptoOptions.Enabled {
+	if err := c.readCreatedConfig(locked); err != nil {
+		c.logger.Errorf("Failed to read new config: %v", err)
+		return err
+	}
+	c.logger.Infof("Default config file created successfully")
+	return nil
+}
+
+// readCreatedConfig 读取刚创建的配置文件
+func (c *Config) readCreatedConfig(locked bool) error {
+	if c.readsFileDirectly() {
 		if err := c.readConfigFileInternal(locked); err != nil {
-			c.logger.Errorf("Failed to read new encrypted config: %v", err)
 			return fmt.Errorf("read new encrypted config: %w", err)
 		}
-	} else {
-		err := c.readDefaultConfigFromDisk(locked)
-		if err != nil {
-			c.logger.Errorf("Failed to read new config: %v", err)
-			return fmt.Errorf("read new config: %w", err)
-		}
+		return nil
+	}
+	if err := c.readDefaultConfigFromDisk(locked); err != nil {
+		return fmt.Errorf("read new config: %w", err)
 	}
-
-	c.logger.Infof("Default config file created successfully")
 	return nil
 }
 
 func (c *Config) readDefaultConfigFromDisk(locked bool) error {
+	var err error
 	if locked {
-		return c.viper.ReadInConfig()
+		err = c.viper.ReadInConfig()
+	} else {
+		c.mu.Lock()
+		err = c.viper.ReadInConfig()
+		c.mu.Unlock()
+	}
+	if err == nil {
+		c.recordFileInfo(c.viper.ConfigFileUsed(), nil)
 	}
-
-	c.cacheBuildMu.Lock()
-	c.writeMu.Lock()
-	err := c.viper.ReadInConfig()
-	c.writeMu.Unlock()
-	c.cacheBuildMu.Unlock()
 	return err
 }
 
@@ -421,23 +660,24 @@ func (c *Config) readDefaultConfigFromDisk(locked bool) error {
 func (c *Config) loadContentToMemory() error {
 	c.logger.Debugf("Loading config content to memory")
 
-	// 使用bytes.NewReader创建一个读取器
-	reader := strings.NewReader(c.content)
+	content, err := c.prepareContent([]byte(c.content))
+	if err != nil {
+		return fmt.Errorf("read config from memory: %w", err)
+	}
+	reader := strings.NewReader(string(content))
 
-	// viper 操作需要锁保护（锁顺序：cacheBuildMu -> writeMu）
-	c.cacheBuildMu.Lock()
-	c.writeMu.Lock()
+	// viper 仅在持有 mu 时访问（见 lockorder.go）
+	c.mu.Lock()
 
 	// 设置配置类型，确保viper知道如何解析内容
 	if c.mode != "" {
-		c.viper.SetConfigType(c.mode)
+		c.viper.SetConfigType(c.viperConfigType())
 	}
 
 	// 从内存中读取配置
-	err := c.viper.ReadConfig(reader)
+	err = c.viper.ReadConfig(reader)
 
-	c.writeMu.Unlock()
-	c.cacheBuildMu.Unlock()
+	c.mu.Unlock()
 
 	if err != nil {
 		c.logger.Errorf("Failed to read config from memory: %v", err)
@@ -456,10 +696,14 @@ func (c *Config) loadContentToMemoryUnsafe() error {
 		return c.loadContentDirectUnsafe()
 	}
 
-	reader := strings.NewReader(c.content)
+	content, err := c.prepareContent([]byte(c.content))
+	if err != nil {
+		return fmt.Errorf("read config from memory: %w", err)
+	}
+	reader := strings.NewReader(string(content))
 
 	if c.mode != "" {
-		c.viper.SetConfigType(c.mode)
+		c.viper.SetConfigType(c.viperConfigType())
 	}
 
 	if err := c.viper.ReadConfig(reader); err != nil {
@@ -482,6 +726,10 @@ func (c *Config) checkDirectoryPermissions(dir string) error {
 		return fmt.Errorf("path is not a directory: %s", dir)
 	}
 
+	if !dirPermissionProbe {
+		return nil
+	}
+
 	// 创建临时文件测试写入权限
 	tempFile := filepath.Join(dir, ".write_test_"+fmt.Sprintf("%d", time.Now().UnixNano()))
 	if err := os.WriteFile(tempFile, []byte("test"), 0o644); err != nil {
@@ -522,16 +770,22 @@ func (c *Config) createBackupIfExists(configFile string) error {
 func (c *Config) initialize() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.initialLoad = true
+	defer func() { c.initialLoad = false }()
 
 	if c.stopChan == nil {
 		c.stopChan = make(chan struct{})
 	}
 
 	c.pendingWrites = false
+	c.nextFlushAt = time.Time{}
 	c.envKeyCache = sync.Map{}
+	c.lookupCache.Clear()
 	c.watchCallbacks = make(map[uint64]func())
+	c.syncWatches = nil
 	c.nextWatchHandle = 0
-	c.watchStarted = false
+	c.watchCancels = make(map[uint64]context.CancelFunc)
+	c.stopFileWatcherLocked()
 	if c.writeTimer != nil {
 		c.writeTimer.Stop()
 	}
@@ -541,15 +795,79 @@ func (c *Config) initialize() error {
 		c.logger = &NopLogger{}
 	}
 
-	c.viper = viper.New()
+	if c.isNative() {
+		return c.initializeNativeUnsafe()
+	}
+
+	c.viper = newViper()
 	c.viperLoaded = true
 
+	if err := c.initSourcePriority(); err != nil {
+		return c.wrapError(err, "设置配置来源优先级")
+	}
 	if err := c.initializeEnv(); err != nil {
 		return c.wrapError(err, "初始化环境变量")
 	}
 
-	// 绑定命令行参数
+	c.bindPFlagsLocked()
+	if err := c.checkInitContext(); err != nil {
+		return err
+	}
+	if err := c.configureViperFileLocked(); err != nil {
+		return err
+	}
+
+	// 初始化加密配置
+	if err := c.initializeCrypto(); err != nil {
+		return c.wrapError(err, "初始化加密配置")
+	}
+	if err := c.initCompression(); err != nil {
+		return c.wrapError(err, "初始化压缩配置")
+	}
+	if err := c.initSection(); err != nil {
+		return c.wrapError(err, "初始化配置段")
+	}
+
+	if err := c.checkInitContext(); err != nil {
+		return err
+	}
+	if err := c.loadOrCreateConfig(); err != nil {
+		return err // loadOrCreateConfig 已经使用了 wrapError
+	}
+
+	if c.viperLoaded {
+		// 同步viper数据到原子存储（已在锁内，直接调用内部方法）
+		c.syncFromViperUnsafe()
+	}
+
+	if err := c.checkInitContext(); err != nil {
+		return err
+	}
+	if err := c.initURLSourceLocked(); err != nil {
+		return err
+	}
+	if err := c.initObjectSourceLocked(); err != nil {
+		return err
+	}
+	if err := c.initSQLSourceLocked(); err != nil {
+		return err
+	}
+	if err := c.loadSourcesLocked(); err != nil {
+		return err
+	}
+
+	// 启用读取缓存以优化并发访问性能（保持兼容性）
+	c.enableReadCache()
+
+	return nil
+}
+
+// bindPFlagsLocked 将命令行标志绑定到 viper；自定义优先级时改为在同步数据时按优先级合并（调用者需持有 mu）
+func (c *Config) bindPFlagsLocked() {
 	for _, flagSet := range c.pflags {
+		if c.customPriority() {
+			break
+		}
 		// 获取所有注册的flags
 		flagSet.VisitAll(func(f *pflag.Flag) {
 			if c.pflagOptions.OnlyChanged && !f.Changed {
@@ -570,7 +888,10 @@ func (c *Config) initialize() error {
 			}
 		})
 	}
+}
 
+// configureViperFileLocked 按路径、名称与格式设置 viper 读取的配置文件（调用者需持有 mu）
+func (c *Config) configureViperFileLocked() error {
 	if c.path != "" {
 		if err := c.validatePath(); err != nil {
 			return c.wrapError(err, "验证配置文件路径")
@@ -585,7 +906,7 @@ func (c *Config) initialize() error {
 	}
 
 	if c.mode != "" {
-		c.viper.SetConfigType(c.mode)
+		c.viper.SetConfigType(c.viperConfigType())
 	}
 
 	if c.configFileName != "" {
@@ -593,24 +914,78 @@ func (c *Config) initialize() error {
 	} else if c.name != "" {
 		c.viper.SetConfigName(c.name)
 	}
+	return nil
+}
+
+// configureNativeFileLocked 原生引擎下校验配置文件路径与格式（调用者需持有 mu）
+func (c *Config) configureNativeFileLocked() error {
+	if c.path != "" {
+		if err := c.validatePath(); err != nil {
+			return c.wrapError(err, "验证配置文件路径")
+		}
+	}
+
+	if c.mode == "" {
+		c.mode = "yaml"
+	}
+	if err := c.validateNativeMode(); err != nil {
+		return c.wrapError(err, "验证配置文件模式")
+	}
+	return nil
+}
+
+// initializeNativeUnsafe 原生引擎初始化流程，不创建 viper 实例（调用者需持有 mu）
+func (c *Config) initializeNativeUnsafe() error {
+	c.viper = nil
+	c.viperLoaded = false
+
+	if err := c.initSourcePriority(); err != nil {
+		return c.wrapError(err, "设置配置来源优先级")
+	}
+	if err := c.initializeEnv(); err != nil {
+		return c.wrapError(err, "初始化环境变量")
+	}
+
+	if err := c.checkInitContext(); err != nil {
+		return err
+	}
+	if err := c.configureNativeFileLocked(); err != nil {
+		return err
+	}
 
-	// 初始化加密配置
 	if err := c.initializeCrypto(); err != nil {
 		return c.wrapError(err, "初始化加密配置")
 	}
-
-	if err := c.loadOrCreateConfig(); err != nil {
-		return err // loadOrCreateConfig 已经使用了 wrapError
+	if err := c.initCompression(); err != nil {
+		return c.wrapError(err, "初始化压缩配置")
+	}
+	if err := c.initSection(); err != nil {
+		return c.wrapError(err, "初始化配置段")
 	}
 
-	if c.viperLoaded {
-		// 同步viper数据到原子存储（已在锁内，直接调用内部方法）
-		c.syncFromViperUnsafe()
+	if err := c.checkInitContext(); err != nil {
+		return err
+	}
+	if err := c.loadNativeConfigUnsafe(); err != nil {
+		return err
+	}
+	if err := c.checkInitContext(); err != nil {
+		return err
+	}
+	if err := c.initURLSourceLocked(); err != nil {
+		return err
+	}
+	if err := c.initObjectSourceLocked(); err != nil {
+		return err
+	}
+	if err := c.initSQLSourceLocked(); err != nil {
+		return err
+	}
+	if err := c.loadSourcesLocked(); err != nil {
+		return err
 	}
 
-	// 启用读取缓存以优化并发访问性能（保持兼容性）
 	c.enableReadCache()
-
 	return nil
 }
 
@@ -631,6 +1006,7 @@ func (c *Config) Close() error {
 		c.writeTimer.Stop()
 		c.writeTimer = nil
 	}
+	c.nextFlushAt = time.Time{}
 	needsFlush = c.pendingWrites
 	if needsFlush && c.name != "" {
 		settingsToSave = c.snapshotAllSettings()
@@ -649,6 +1025,7 @@ func (c *Config) Close() error {
 	if c.stopChan != nil {
 		close(c.stopChan)
 	}
+	c.commits.close()
 
 	// 在关闭前同步落盘，避免 debounce 写入在 Close 时丢失。
 	if needsFlush && c.name != "" {
@@ -667,6 +1044,11 @@ func (c *Config) Close() error {
 
 	select {
 	case <-done:
+		if c.recorder != nil {
+			if err := c.recorder.close(); err != nil && flushErr == nil {
+				flushErr = fmt.Errorf("close config recording: %w", err)
+			}
+		}
 		return flushErr
 	case <-time.After(5 * time.Second):
 		if flushErr != nil {
@@ -698,6 +1080,7 @@ func (c *Config) unregisterWatchCallbacks(handles ...uint64) {
 	c.mu.Lock()
 	for _, handle := range handles {
 		delete(c.watchCallbacks, handle)
+		delete(c.syncWatches, handle)
 	}
 	c.mu.Unlock()
 }
@@ -707,62 +1090,119 @@ func (c *Config) startWatchLocked() error {
 		return nil
 	}
 
-	c.viper.OnConfigChange(func(e fsnotify.Event) {
-		c.handleConfigChange(e)
-	})
-	c.viper.WatchConfig()
+	hasFile := c.configFilePath() != ""
+	if !hasFile && len(c.sourceWatchPaths()) == 0 && len(c.extraPaths) == 0 {
+		c.logger.Debugf("Memory-only config: watch callbacks registered without file watcher")
+		return nil
+	}
+
+	c.watchStop = make(chan struct{})
+	if hasFile {
+		if err := c.startFileWatcherLocked(); err != nil {
+			c.stopFileWatcherLocked()
+			return err
+		}
+	}
+	if err := c.startSourceWatcherLocked(); err != nil {
+		c.stopFileWatcherLocked()
+		return err
+	}
+	if err := c.startExtraWatcherLocked(); err != nil {
+		c.stopFileWatcherLocked()
+		return err
+	}
 	c.watchStarted = true
 	c.logger.Infof("Config file watching started")
 	return nil
 }
 
-func (c *Config) handleConfigChange(e fsnotify.Event) {
-	if e.Op&fsnotify.Write == 0 {
-		return
-	}
-
+// reloadChangedFile 重新加载发生变化的配置文件并触发回调；force 为 true 时跳过防抖（用于跨进程变更通知）
+func (c *Config) reloadChangedFile(name string, force bool) {
 	select {
 	case <-c.stopChan:
 		return
 	default:
 	}
 
+	if err := c.injectFault(FaultReload, name); err != nil {
+		recordReloadOperation(0, err)
+		c.logger.Errorf("Failed to reload config after change: %v", err)
+		c.emitHealthEvent(HealthEventReloadFailed, "reload failed, keeping last known good config", err)
+		return
+	}
+
+	// 文件缺失期间跳过防抖，确保文件重新出现时的事件不会被丢弃
+	missing := c.isFileMissing()
+
 	c.mu.Lock()
 	now := time.Now()
-	if now.Sub(c.lastUpdate) < c.watchDebounce {
+	if !missing && !force && now.Sub(c.lastUpdate) < c.watchDebounce {
 		c.mu.Unlock()
 		return
 	}
+	if missing {
+		// 文件刚被重建、内容尚未写入时等待后续写入事件，避免以空配置覆盖最后一次成功加载的配置
+		if info, err := os.Stat(c.configFilePath()); err == nil && info.Size() == 0 {
+			c.mu.Unlock()
+			c.logger.Debugf("Recreated config file is still empty, waiting for content: %s", name)
+			return
+		}
+	}
 	c.lastUpdate = now
 
+	oldData, oldCache, oldOrigins, oldRemote := c.loadData(), c.loadReadCache(), c.keyOrigins.Load(), c.remoteLoaded.Load()
 	if err := c.reloadConfigLocked(); err != nil {
-		c.logger.Errorf("Failed to reload config after change: %v", err)
 		c.mu.Unlock()
+		if isConfigFileMissingError(err) {
+			c.markConfigFileMissing(err)
+			return
+		}
+		recordReloadOperation(time.Since(now), err)
+		c.logger.Errorf("Failed to reload config after change: %v", err)
+		c.emitHealthEvent(HealthEventReloadFailed, "reload failed, keeping last known good config", err)
 		return
 	}
 	c.syncFromViperUnsafe()
+	c.invalidateLookupCache()
 
-	callbacks := make([]func(), 0, len(c.watchCallbacks))
-	for _, cb := range c.watchCallbacks {
-		callbacks = append(callbacks, cb)
-	}
+	ticket, callbacks := c.watchCallbacksLocked()
 	c.mu.Unlock()
 
 	c.invalidateCache()
-	c.logger.Infof("Config file change detected: %s", e.Name)
+	if err := c.applyReload(oldData, oldCache, oldOrigins, oldRemote); err != nil {
+		c.runWatchCallbacks(ticket, nil)
+		recordReloadOperation(time.Since(now), err)
+		c.logger.Errorf("Config apply failed after change, rolled back: %v", err)
+		c.emitHealthEvent(HealthEventReloadFailed, "config apply failed, rolled back to previous config", err)
+		return
+	}
+	recordReloadOperation(time.Since(now), nil)
+	if missing {
+		c.logger.Infof("Config file reappeared, resumed reloading: %s", name)
+		c.emitHealthEvent(HealthEventFileRestored, "config file reappeared and was reloaded", nil)
+	} else {
+		c.logEvent(InfoLevel, "Config file change detected", F("file", name))
+		c.emitHealthEvent(HealthEventReloaded, "config reloaded", nil)
+	}
 
-	for _, cb := range callbacks {
-		cb()
+	c.runWatchCallbacks(ticket, callbacks)
+	if c.debugEnabled() {
+		c.logger.Debugf("Executed %d config change callbacks", len(callbacks))
 	}
-	c.logger.Debugf("Executed %d config change callbacks", len(callbacks))
 }
 
 func (c *Config) initializeEnv() error {
+	// 仅设置了分区前缀时只按分区前缀查询，不启用全局环境变量绑定
+	c.envEnabled.Store(c.envOptions.Enabled || len(c.envSections) > 0)
 	if !c.envOptions.Enabled {
-		c.envEnabled.Store(false)
 		return nil
 	}
-	c.envEnabled.Store(true)
+
+	// 原生引擎直接通过 lookupEnvValue 查询环境变量，无需绑定 viper；
+	// 自定义来源优先级或限定可覆盖的键时同样在读取时解析，避免环境变量被合并进主配置数据
+	if c.viper == nil || c.customPriority() || len(c.envAllow) > 0 {
+		return nil
+	}
 
 	// 设置环境变量前缀（自动转大写）
 	if c.envOptions.Prefix != "" {
@@ -795,6 +1235,9 @@ func (c *Config) setupSmartCaseEnv() {
 
 // 绑定智能大小写环境变量
 func (c *Config) bindSmartCaseEnvVars() {
+	if !envSweepSupported {
+		return
+	}
 	startTime := time.Now()
 	envVars := os.Environ()
 	totalEnvs := len(envVars)
@@ -930,8 +1373,8 @@ func (c *Config) loadOrCreateConfig() error {
 		return nil
 	}
 
-	// 如果启用了加密，使用自定义的读取方法
-	if c.cryptoOptions.Enabled {
+	// 如果启用了加密、压缩或使用 jsonc，使用自定义的读取方法
+	if c.readsFileDirectly() {
 		err := c.readConfigFileUnsafe()
 		if err != nil {
 			if os.IsNotExist(err) {
@@ -950,6 +1393,9 @@ func (c *Config) loadOrCreateConfig() error {
 	}
 
 	// 没有启用加密时，使用viper的标准读取方法（此时已在 initialize 锁内，无需额外锁）
+	if err := c.checkContentFile(); err != nil {
+		return c.wrapError(err, "读取配置文件")
+	}
 	err := c.viper.ReadInConfig()
 	if err != nil {
 		var configFileNotFoundError viper.ConfigFileNotFoundError
@@ -965,6 +1411,7 @@ func (c *Config) loadOrCreateConfig() error {
 		return c.wrapError(err, "读取配置文件")
 	}
 
+	c.recordFileInfo(c.viper.ConfigFileUsed(), nil)
 	c.logger.Infof("Successfully loaded config file: %s", c.viper.ConfigFileUsed())
 	return nil
 }
@@ -976,8 +1423,8 @@ func (c *Config) validateMode() error {
 		return nil
 	}
 
-	// 检查是否是支持的文件类型
-	if slices.Contains(viper.SupportedExts, c.mode) {
+	// 检查是否是支持的文件类型（jsonc 在去除注释后按 json 解析）
+	if slices.Contains(viper.SupportedExts, c.mode) || c.isJSONC() {
 		return nil
 	}
 
@@ -1093,7 +1540,10 @@ func (c *Config) reinitialize() error {
 	}
 
 	// 同步环境变量和viper数据到原子存储
-	c.syncFromViperUnsafe()
+	if !c.isNative() {
+		c.syncFromViperUnsafe()
+	}
+	c.invalidateLookupCache()
 
 	return nil
 }
@@ -1107,8 +1557,13 @@ func (c *Config) restoreSnapshot(snap *snapshot) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.keyOrigins.Store(snap.origins)
+	if c.recorder != nil {
+		c.recordChange(RecordRollback, c.loadData(), snap.data)
+	}
 	c.data.Store(deepCloneMap(snap.data))
 	c.readCache.Store(deepCloneMap(snap.readCache))
+	c.commits.notify()
 }
 
 // deepCloneMap 对 map[string]any 进行深拷贝，避免并发场景下共享可变引用。
@@ -1142,21 +1597,49 @@ func (c *Config) loadData() map[string]any {
 
 // storeData 原子性存储配置数据（创建副本以确保线程安全）
 func (c *Config) storeData(newData map[string]any) {
-	dataCopy := maps.Clone(newData)
-	if dataCopy == nil {
-		dataCopy = make(map[string]any)
+	c.storeDataOp(RecordReload, newData)
+}
+
+// storeDataOp 同 storeData，op 为 WithRecorder 记录的操作类型；written 为本次写入的键（RecordSet），
+// 这些键及其子键此后视为来自主配置
+func (c *Config) storeDataOp(op RecordOp, newData map[string]any, written ...string) {
+	// 按实际键数分配而不是 maps.Clone，避免复用池中的临时 map 把多余容量带进发布的数据
+	dataCopy := make(map[string]any, len(newData))
+	maps.Copy(dataCopy, newData)
+	normalizeLoadedValues(dataCopy)
+	c.dotenvKeys.Store(isDotenvMode(c.mode))
+	origins := c.originsFor(op, dataCopy, written)
+	c.applySources(dataCopy, origins)
+	c.fillViperDefaults(dataCopy, origins)
+	if changed := c.restoreFrozen(dataCopy); len(changed) > 0 {
+		c.logger.Warnf("Rejected %s changes to frozen keys, keeping startup values: %s", op, strings.Join(changed, ", "))
+	}
+	c.keyOrigins.Store(&origins)
+	if c.recorder != nil {
+		c.recordChange(op, c.loadData(), dataCopy)
 	}
 	c.data.Store(dataCopy)
+	c.commits.notify()
 }
 
 // syncFromViperUnsafe 从viper同步数据到原子存储（不加锁，用于已在锁内的场景）
 func (c *Config) syncFromViperUnsafe() {
+	if c.viper == nil {
+		return
+	}
+
 	// 从viper获取所有数据并进行扁平化处理
 	viperData := c.viper.AllSettings()
-	flatData := make(map[string]any, len(viperData)*12)
+	flatData := getScratchMap()
+	defer putScratchMap(flatData)
 
 	// 将嵌套数据扁平化，例如 app.name, database.host 等
 	c.flattenViperData("", viperData, flatData)
+	if c.customPriority() {
+		c.applyNativeFlags(flatData)
+	} else {
+		c.recordViperOrigins(flatData)
+	}
 
 	// 原子性存储
 	c.storeData(flatData)
@@ -1164,28 +1647,117 @@ func (c *Config) syncFromViperUnsafe() {
 
 // flattenViperData 递归扁平化viper数据
 func (c *Config) flattenViperData(prefix string, data map[string]any, result map[string]any) {
+	flattenSettings(prefix, data, result)
+}
+
+// flattenSettings 递归扁平化嵌套配置，结果键为点分路径
+func flattenSettings(prefix string, data map[string]any, result map[string]any) {
 	for key, value := range data {
 		fullKey := key
 		if prefix != "" {
 			fullKey = prefix + "." + key
 		}
 
+		// YAML v2 风格解码器产生的 map[any]any 先转换为 map[string]any，确保同样被展开
+		if legacy, ok := value.(map[any]any); ok {
+			value = sanitizeValue(legacy)
+		}
+
 		// 如果是map，递归处理
 		if nestedMap, ok := value.(map[string]any); ok {
-			c.flattenViperData(fullKey, nestedMap, result)
+			flattenSettings(fullKey, nestedMap, result)
 		} else {
 			result[fullKey] = sanitizeValue(value)
 		}
 	}
 }
 
-// getRaw 无锁读取原始配置值
+// normalizeLoadedValues 将加载数据中残留的 map[any]any（YAML v2 风格解码器、外部工具生成的文件）
+// 转换为 map[string]any，避免 GetStringMap 与 Unmarshal 失败；不含此类值时不产生复制
+func normalizeLoadedValues(data map[string]any) {
+	for key, value := range data {
+		if hasLegacyMap(value) {
+			data[key] = sanitizeValue(value)
+		}
+	}
+}
+
+// hasLegacyMap 判断值中是否包含 map[any]any
+func hasLegacyMap(value any) bool {
+	switch v := value.(type) {
+	case map[any]any, []map[any]any:
+		return true
+	case map[string]any:
+		for _, item := range v {
+			if hasLegacyMap(item) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if hasLegacyMap(item) {
+				return true
+			}
+		}
+	case []map[string]any:
+		for _, item := range v {
+			if hasLegacyMap(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getRaw 无锁读取原始配置值，并在启用访问跟踪时记录读取
 func (c *Config) getRaw(key string) (any, bool) {
-	if value, exists := c.lookupEnvValue(key); exists {
-		return value, true
+	data := c.loadData()
+	key = c.resolveKey(data, key)
+	c.markRead(key)
+	if value, found, ok := c.warmLookup(data, key); ok {
+		return value, found
 	}
+	return c.lookupResolved(data, key)
+}
 
+// lookupRaw 无锁读取原始配置值，不记录读取
+func (c *Config) lookupRaw(key string) (any, bool) {
 	data := c.loadData()
+	return c.lookupResolved(data, c.resolveKey(data, key))
+}
+
+// lookupResolved 在数据快照中读取已解析为实际键的配置值，覆盖值优先，并解析文件引用
+func (c *Config) lookupResolved(data map[string]any, key string) (any, bool) {
+	value, found := c.lookupSources(data, key)
+	if c.hasOverrides() {
+		value, found = c.applyOverrides(key, value, found)
+	}
+	if found && c.fileRefMax > 0 {
+		value = c.resolveFileRefs(value)
+	}
+	return value, found
+}
+
+// lookupMode 单次查找的来源与缓存控制（GetOption 设置）
+type lookupMode uint8
+
+const (
+	lookupNoCache lookupMode = 1 << iota // 跳过环境变量与回退查询的 TTL 缓存
+	lookupNoEnv                          // 只读取数据快照，跳过环境变量与 viper 回退
+)
+
+// lookupSources 按环境变量、数据快照、viper 的顺序读取配置值
+func (c *Config) lookupSources(data map[string]any, key string) (any, bool) {
+	return c.lookupSourcesMode(data, key, 0)
+}
+
+// lookupSourcesMode 同 lookupSources，mode 控制跳过的来源与缓存
+func (c *Config) lookupSourcesMode(data map[string]any, key string, mode lookupMode) (any, bool) {
+	if mode&lookupNoEnv == 0 {
+		if value, exists := c.lookupEnvValue(key, mode); exists && c.envWins(data, key) {
+			return value, true
+		}
+	}
 
 	// 首先尝试直接匹配
 	if value, exists := data[key]; exists {
@@ -1203,10 +1775,22 @@ func (c *Config) getRaw(key string) (any, bool) {
 	}
 
 	// 回退到 viper 与环境变量查询，确保环境值立即可见
-	return c.fetchFromViperOrEnv(key)
+	if mode&lookupNoEnv != 0 {
+		return nil, false
+	}
+	if mode&lookupNoCache != 0 {
+		return c.fetchFromViperOrEnv(key)
+	}
+	if entry, ok := c.loadLookupEntry("fallback|" + key); ok {
+		return entry.value, entry.found
+	}
+	value, found := c.fetchFromViperOrEnv(key)
+	c.storeLookupEntry("fallback|"+key, value, found)
+	return value, found
 }
 
-func (c *Config) lookupEnvValue(key string) (any, bool) {
+// lookupEnvValue 查找配置键对应的环境变量，mode 含 lookupNoCache 时跳过查询缓存
+func (c *Config) lookupEnvValue(key string, mode lookupMode) (any, bool) {
 	if !c.envEnabled.Load() {
 		return nil, false
 	}
@@ -1215,13 +1799,41 @@ func (c *Config) lookupEnvValue(key string) (any, bool) {
 	envOptions := c.envOptions
 	c.mu.RUnlock()
 
-	if !envOptions.Enabled {
+	if mode&lookupNoCache != 0 {
+		return c.findEnvValue(envOptions, key)
+	}
+	return c.lookupEnvWithOptions(envOptions, key)
+}
+
+// lookupEnvWithOptions 按给定选项查找配置键对应的环境变量，不获取 mu
+func (c *Config) lookupEnvWithOptions(envOptions EnvOptions, key string) (any, bool) {
+	if !envOptions.Enabled && len(c.envSections) == 0 {
 		return nil, false
 	}
 
-	envKeys := c.deriveEnvKeys(envOptions, key)
+	if entry, ok := c.loadLookupEntry("env|" + key); ok {
+		return entry.value, entry.found
+	}
+	val, found := c.findEnvValue(envOptions, key)
+	c.storeLookupEntry("env|"+key, val, found)
+	return val, found
+}
+
+// findEnvValue 按分区前缀与全局前缀依次查找环境变量，不使用查询缓存
+func (c *Config) findEnvValue(envOptions EnvOptions, key string) (any, bool) {
+	if !envOptions.Enabled && len(c.envSections) == 0 {
+		return nil, false
+	}
+	if !c.envKeyAllowed(key) {
+		return nil, false
+	}
+	// 分区前缀更具体，优先于全局前缀
+	envKeys := c.sectionEnvKeys(envOptions, key)
+	if envOptions.Enabled {
+		envKeys = append(envKeys, c.deriveEnvKeys(envOptions, key)...)
+	}
 	for _, envKey := range envKeys {
-		if val, ok := os.LookupEnv(envKey); ok {
+		if val, ok := c.lookupEnvVar(envKey); ok {
 			return val, true
 		}
 	}
@@ -1235,13 +1847,13 @@ func (c *Config) fetchFromViperOrEnv(key string) (any, bool) {
 
 	if c.viper != nil && c.viperLoaded {
 		if c.viper.IsSet(key) || c.viper.InConfig(key) {
-			return c.viper.Get(key), true
+			return sanitizeValue(c.viper.Get(key)), true
 		}
 	}
 
 	if c.viper != nil && c.viperLoaded {
 		if val := c.viper.Get(key); hasConcreteValue(val) {
-			return val, true
+			return sanitizeValue(val), true
 		}
 	}
 
@@ -1255,18 +1867,16 @@ func (c *Config) canLoadContentDirectly() bool {
 	if c.cryptoOptions.Enabled {
 		return false
 	}
-	return c.mode == "yaml" || c.mode == "yml" || c.mode == "json"
+	return c.mode == "yaml" || c.mode == "yml" || c.mode == "json" || c.mode == "jsonc"
 }
 
 func (c *Config) loadContentDirectUnsafe() error {
-	nested, err := parseContentMap([]byte(c.content), c.mode)
+	flatData, err := c.flatContent([]byte(c.content))
 	if err != nil {
 		c.logger.Errorf("Failed to parse config content directly: %v", err)
 		return fmt.Errorf("read config from memory: %w", err)
 	}
 
-	flatData := make(map[string]any, len(nested)*12)
-	c.flattenViperData("", nested, flatData)
 	c.storeData(flatData)
 	c.viperLoaded = false
 	c.logger.Infof("Configuration loaded successfully in direct memory-only mode")
@@ -1284,6 +1894,21 @@ func parseContentMap(data []byte, mode string) (map[string]any, error) {
 		if err := json.Unmarshal(data, &result); err != nil {
 			return nil, err
 		}
+	case "jsonc":
+		return parseJSONC(data)
+	case "properties", "props", "prop":
+		return parseProperties(data)
+	case "ini":
+		nested, _, err := parseINI(data)
+		return nested, err
+	case "dotenv", "env":
+		return parseDotenv(data)
+	case "hcl", "tfvars":
+		return parseHCL(data)
+	case "toml":
+		if err := toml.Unmarshal(data, &result); err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unsupported direct content mode: %s", mode)
 	}
@@ -1296,7 +1921,7 @@ func (c *Config) ensureViperLoadedLocked() {
 	}
 
 	if c.mode != "" {
-		c.viper.SetConfigType(c.mode)
+		c.viper.SetConfigType(c.viperConfigType())
 	}
 	for key, value := range c.loadData() {
 		c.viper.Set(key, deepCloneValue(value))
@@ -1329,6 +1954,13 @@ func (c *Config) deriveEnvKeys(opts EnvOptions, key string) []string {
 	if opts.SmartCase {
 		baseVariants[titleCaseEnv(sanitized)] = struct{}{}
 	}
+	// 多数 shell 不支持变量名中的 "-"，含 "-" 的键同时匹配以 "_" 替代的形式（如 rate-limit → RATE_LIMIT）
+	if underscored := strings.ReplaceAll(sanitized, "-", "_"); underscored != sanitized {
+		baseVariants[strings.ToUpper(underscored)] = struct{}{}
+		if opts.SmartCase {
+			baseVariants[titleCaseEnv(underscored)] = struct{}{}
+		}
+	}
 
 	prefixVariants := map[string]struct{}{"": {}}
 	if opts.Prefix != "" {
@@ -1457,8 +2089,26 @@ func (c *Config) reconstructNestedStructure(flatData map[string]any) map[string]
 	return result
 }
 
+// nestFlatData 将扁平化数据转换为独立的嵌套结构，父键先于子键写入，结果不与原数据共享引用
+func nestFlatData(flatData map[string]any) map[string]any {
+	result := make(map[string]any)
+	for _, key := range slices.Sorted(maps.Keys(flatData)) {
+		value := flatData[key]
+		if m, ok := value.(map[string]any); ok {
+			value = deepCloneMap(m)
+		}
+		setNestedMapValue(result, key, value)
+	}
+	return result
+}
+
 // setNestedValue 在嵌套map中设置值
 func (c *Config) setNestedValue(m map[string]any, key string, value any) {
+	setNestedMapValue(m, key, value)
+}
+
+// setNestedMapValue 按点分键在嵌套 map 中设置值，路径上的非 map 值会被替换
+func setNestedMapValue(m map[string]any, key string, value any) {
 	if !strings.Contains(key, ".") {
 		m[key] = value
 		return
@@ -1519,9 +2169,10 @@ func sanitizeValue(value any) any {
 	case map[any]any:
 		copied := make(map[string]any, len(v))
 		for rawKey, val := range v {
+			// 非字符串键（如 YAML 中的 8080: web）按文本形式保留
 			strKey, ok := rawKey.(string)
 			if !ok {
-				continue
+				strKey = fmt.Sprint(rawKey)
 			}
 			copied[strKey] = sanitizeValue(val)
 		}
@@ -1590,8 +2241,7 @@ func (c *Config) AllSettings() map[string]any {
 	return c.snapshotAllSettings()
 }
 
-// snapshotAllSettings 在统一锁顺序下获取 viper 配置快照，避免并发读写竞态。
-// 锁顺序：cacheBuildMu -> mu.RLock -> writeMu
+// snapshotAllSettings 基于原子数据快照构建嵌套配置副本，无需加锁，可在任意锁内调用
 func (c *Config) snapshotAllSettings() map[string]any {
 	if c == nil {
 		return nil
@@ -1,14 +1,11 @@
 package sysconf
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
-
-	"gopkg.in/yaml.v3"
 )
 
 // readConfigFile 读取配置文件（支持解密）- 线程安全版本
@@ -18,6 +15,11 @@ func (c *Config) readConfigFile() error {
 	return c.readConfigFileInternal(false)
 }
 
+// readsFileDirectly 是否绕过 viper 自行读取配置文件（加密、压缩、原生引擎、jsonc 与配置段需要先处理原始内容）
+func (c *Config) readsFileDirectly() bool {
+	return c.cryptoOptions.Enabled || c.compressor != nil || c.isNative() || c.isJSONC() || c.mode == "ini" || c.section != ""
+}
+
 // readConfigFileUnsafe 读取配置文件 - 调用者已持锁版本（供 initialize 等内部方法使用）
 func (c *Config) readConfigFileUnsafe() error {
 	return c.readConfigFileInternal(true)
@@ -38,40 +40,75 @@ func (c *Config) readConfigFileInternal(locked bool) error {
 	if err != nil {
 		return fmt.Errorf("read config file: %w", err)
 	}
+	// 启动时先核对信封中的密钥标识，密钥不匹配时直接失败，而不是在解析阶段报出难以理解的格式错误
+	if c.initialLoad && c.keepsBackup() {
+		if err := c.checkEncryptionKey(configFile, data); err != nil {
+			return err
+		}
+	}
+	if err := c.loadConfigContent(data, locked); err != nil {
+		if !c.canRecoverFromBackup(err) {
+			return err
+		}
+		return c.recoverFromBackup(configFile, err, locked)
+	}
+	c.recordFileInfo(configFile, data)
 
+	return nil
+}
+
+// loadConfigContent 解密、解压并解析磁盘上的原始配置内容
+func (c *Config) loadConfigContent(data []byte, locked bool) error {
 	if c.cryptoOptions.Enabled && c.crypto != nil {
-		if c.crypto.IsEncrypted(data) {
-			c.logger.Debugf("Decrypting config file")
-			decryptedData, err := c.crypto.Decrypt(data)
-			if err != nil {
-				return fmt.Errorf("decrypt config file: %w", err)
-			}
+		decryptedData, encrypted, err := c.decryptContent(data)
+		if err != nil {
+			return c.encryptionKeyError(c.configFilePath(), data, fmt.Errorf("decrypt config file: %w", err))
+		}
+		if encrypted {
 			data = decryptedData
 			c.logger.Infof("Config file decrypted successfully")
 		} else {
 			c.logger.Debugf("Config file is not encrypted")
 		}
 	}
+	var err error
+	if data, err = decompressContent(data); err != nil {
+		return err
+	}
+	if data, err = c.extractSection(data); err != nil {
+		return fmt.Errorf("extract config section: %w", err)
+	}
 
 	if err := c.readConfigBytes(data, locked); err != nil {
 		return fmt.Errorf("parse config content: %w", err)
 	}
-
 	return nil
 }
 
 func (c *Config) readConfigBytes(data []byte, locked bool) error {
+	if err := c.checkContent(data); err != nil {
+		return err
+	}
+	data, err := c.prepareContent(data)
+	if err != nil {
+		return err
+	}
+	if c.isNative() {
+		if !locked {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+		}
+		return c.loadNativeBytesUnsafe(data)
+	}
+
 	reader := strings.NewReader(string(data))
 	if locked {
 		return c.viper.ReadConfig(reader)
 	}
 
-	c.cacheBuildMu.Lock()
-	c.writeMu.Lock()
-	err := c.viper.ReadConfig(reader)
-	c.writeMu.Unlock()
-	c.cacheBuildMu.Unlock()
-	return err
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.viper.ReadConfig(reader)
 }
 
 // writeConfigFile 写入配置文件（支持加密）
@@ -93,41 +130,84 @@ func (c *Config) writeConfigFile() error {
 		return fmt.Errorf("marshal config: %w", err)
 	}
 
-	// 如果启用了加密，加密数据
-	if c.cryptoOptions.Enabled && c.crypto != nil {
-		c.logger.Debugf("Encrypting config file")
-		encryptedData, err := c.crypto.Encrypt(data)
+	if err := c.injectFault(FaultWrite, configFile); err != nil {
+		return fmt.Errorf("write config file: %w", err)
+	}
+
+	// 按需加密后写入文件
+	raw, err := c.persistConfigData(configFile, data)
+	if err != nil {
+		return err
+	}
+
+	c.recordFileInfo(configFile, raw)
+	c.logEvent(InfoLevel, "Config file written", F("file", configFile))
+	return nil
+}
+
+// persistConfigData 按需压缩、加密并写入配置文件，返回写入磁盘的原始内容。
+// 明文不小于流式加密阈值且加密器支持 StreamCrypto 时，分块加密直接写入文件并返回 nil，避免在内存中保留整份密文。
+func (c *Config) persistConfigData(configFile string, data []byte) ([]byte, error) {
+	data, err := c.compressContent(data)
+	if err != nil {
+		return nil, err
+	}
+	if !c.cryptoOptions.Enabled || c.crypto == nil {
+		return data, c.writeConfigBytes(configFile, data)
+	}
+
+	c.logger.Debugf("Encrypting config file")
+	if stream, ok := c.streamCrypto(len(data)); ok {
+		err := c.writeConfigStream(configFile, func(w io.Writer) error {
+			return c.encryptContentStream(stream, w, data)
+		})
 		if err != nil {
-			return fmt.Errorf("encrypt config: %w", err)
+			return nil, err
 		}
-		data = encryptedData
-		c.logger.Infof("Config file encrypted successfully")
+		c.logger.Infof("Config file encrypted successfully (streamed, %d bytes)", len(data))
+		return nil, nil
 	}
 
-	// 写入文件
-	if err := os.WriteFile(configFile, data, 0o644); err != nil {
-		return fmt.Errorf("write config file: %w", err)
+	encryptedData, err := c.encryptContent(data)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt config: %w", err)
 	}
+	c.logger.Infof("Config file encrypted successfully")
+	return encryptedData, c.writeConfigBytes(configFile, encryptedData)
+}
+
+// writeConfigBytes 写入配置文件内容
+func (c *Config) writeConfigBytes(configFile string, data []byte) error {
+	return c.writeConfigStream(configFile, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// writeConfigStream 通过 write 原子写入配置文件；启用 WithFileLock 时在写入期间持有文件锁，
+// 启用 WithLocalNotify 时写入成功后通知同一主机上的其他进程
+func (c *Config) writeConfigStream(configFile string, write func(io.Writer) error) error {
+	lock, err := c.lockConfigFile(configFile)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := lock.release(); err != nil {
+			c.logger.Warnf("Failed to release config file lock: %v", err)
+		}
+	}()
 
-	c.logger.Infof("Config file written: %s", configFile)
+	if err := c.writeFileAtomic(configFile, write); err != nil {
+		return fmt.Errorf("write config file: %w", err)
+	}
+	c.notifyPeers()
 	return nil
 }
 
 // marshalConfig 将viper配置序列化为指定格式的字节数组
 func (c *Config) marshalConfig() ([]byte, error) {
 	allSettings := c.snapshotAllSettings()
-
-	switch c.mode {
-	case "yaml", "yml":
-		return yaml.Marshal(allSettings)
-	case "json":
-		return json.MarshalIndent(allSettings, "", "  ")
-	case "ini":
-		// 对于INI格式，我们需要特殊处理
-		return c.marshalToINI(allSettings)
-	default:
-		return nil, fmt.Errorf("unsupported config format: %s", c.mode)
-	}
+	return c.marshalConfigWithData(allSettings)
 }
 
 // writeConfigFileWithData 使用传入的配置数据写入文件（支持加密）
@@ -154,62 +234,34 @@ func (c *Config) writeConfigFileWithData(settingsData map[string]any) error {
 		return fmt.Errorf("marshal config: %w", err)
 	}
 
-	// 如果启用了加密，加密数据
-	if c.cryptoOptions.Enabled && c.crypto != nil {
-		c.logger.Debugf("Encrypting config file")
-		encryptedData, err := c.crypto.Encrypt(data)
-		if err != nil {
-			return fmt.Errorf("encrypt config: %w", err)
-		}
-		data = encryptedData
-		c.logger.Infof("Config file encrypted successfully")
+	if err := c.injectFault(FaultWrite, configFile); err != nil {
+		return fmt.Errorf("write config file: %w", err)
 	}
 
-	// 写入文件
-	if err := os.WriteFile(configFile, data, 0o644); err != nil {
-		return fmt.Errorf("write config file: %w", err)
+	// 按需加密后写入文件
+	raw, err := c.persistConfigData(configFile, data)
+	if err != nil {
+		return err
 	}
 
-	c.logger.Infof("Config file written: %s", configFile)
+	c.recordFileInfo(configFile, raw)
+	c.logEvent(InfoLevel, "Config file written", F("file", configFile))
 	return nil
 }
 
 // marshalConfigWithData 使用传入的配置数据序列化为指定格式的字节数组
 // 不调用 snapshotAllSettings()，由调用者提供数据以避免锁竞争
 func (c *Config) marshalConfigWithData(settings map[string]any) ([]byte, error) {
-	switch c.mode {
-	case "yaml", "yml":
-		return yaml.Marshal(settings)
-	case "json":
-		return json.MarshalIndent(settings, "", "  ")
-	case "ini":
+	settings = c.withoutSourceKeys(settings)
+	settings = c.wrapSection(settings)
+	if c.mode == "ini" {
 		// 对于INI格式，我们需要特殊处理
 		return c.marshalToINI(settings)
-	default:
-		return nil, fmt.Errorf("unsupported config format: %s", c.mode)
-	}
-}
-
-// marshalToINI 将配置转换为INI格式
-func (c *Config) marshalToINI(settings map[string]any) ([]byte, error) {
-	var buf bytes.Buffer
-
-	// 写入顶级键值对
-	for key, value := range settings {
-		switch v := value.(type) {
-		case map[string]any:
-			// 写入section
-			fmt.Fprintf(&buf, "\n[%s]\n", key)
-			for k, val := range v {
-				fmt.Fprintf(&buf, "%s = %v\n", k, val)
-			}
-		default:
-			// 写入顶级键值对
-			fmt.Fprintf(&buf, "%s = %v\n", key, v)
-		}
 	}
-
-	return buf.Bytes(), nil
+	if c.isJSONC() {
+		return c.marshalJSONCSettings(settings)
+	}
+	return marshalSettings(settings, c.mode)
 }
 
 // GetEncryptionKey 获取当前使用的加密密钥（如果适用）
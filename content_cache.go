@@ -0,0 +1,92 @@
+package sysconf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// 进程级已解析内容缓存的容量限制
+const (
+	contentCacheEntries = 64        // 最多缓存的内容份数，超出时淘汰最早缓存的条目
+	contentCacheMaxSize = 256 << 10 // 超过该大小的内容不缓存
+)
+
+// parsedContentCache 按 (内容校验和, 格式) 缓存扁平化后的解析结果，供多个实例使用相同默认内容时跳过重复解析
+var parsedContentCache = &contentCache{entries: make(map[string]map[string]any)}
+
+// contentCache 有界的已解析内容缓存，缓存值只读，取出时深拷贝
+type contentCache struct {
+	mu      sync.Mutex
+	entries map[string]map[string]any
+	order   []string // 按缓存时间排列的键，用于淘汰
+}
+
+// WithContentCache 设置是否使用进程级已解析内容缓存（默认启用）。同一进程内多个实例
+// （如测试或插件）使用相同的 WithContent 内容时，按内容校验和与格式复用解析结果，跳过重复解析；
+// 缓存结果在取出时深拷贝，实例之间互不影响。解析存在副作用或需要排查解析问题时可关闭。
+func WithContentCache(enabled bool) Option {
+	return func(c *Config) {
+		c.noContentCache = !enabled
+	}
+}
+
+// flatContent 解析当前格式的配置内容并扁平化，启用缓存时复用相同内容的结果
+func (c *Config) flatContent(data []byte) (map[string]any, error) {
+	if c.noContentCache || len(data) > contentCacheMaxSize {
+		return parseFlatContent(data, c.mode)
+	}
+	key := contentCacheKey(c.mode, data)
+	if flat, ok := parsedContentCache.get(key); ok {
+		return flat, nil
+	}
+	flat, err := parseFlatContent(data, c.mode)
+	if err != nil {
+		return nil, err
+	}
+	parsedContentCache.put(key, deepCloneMap(flat))
+	return flat, nil
+}
+
+// contentCacheKey 由格式与内容校验和组成的缓存键
+func contentCacheKey(mode string, data []byte) string {
+	sum := sha256.Sum256(data)
+	return mode + ":" + hex.EncodeToString(sum[:])
+}
+
+// parseFlatContent 解析配置内容并扁平化为点分键
+func parseFlatContent(data []byte, mode string) (map[string]any, error) {
+	nested, err := parseContentMap(data, mode)
+	if err != nil {
+		return nil, err
+	}
+	flat := make(map[string]any, len(nested)*12)
+	flattenSettings("", nested, flat)
+	return flat, nil
+}
+
+// get 返回缓存结果的深拷贝
+func (cc *contentCache) get(key string) (map[string]any, bool) {
+	cc.mu.Lock()
+	nested, ok := cc.entries[key]
+	cc.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	return deepCloneMap(nested), true
+}
+
+// put 缓存解析结果，超出容量时淘汰最早的条目
+func (cc *contentCache) put(key string, nested map[string]any) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if _, ok := cc.entries[key]; ok {
+		return
+	}
+	if len(cc.order) >= contentCacheEntries {
+		delete(cc.entries, cc.order[0])
+		cc.order = cc.order[1:]
+	}
+	cc.entries[key] = nested
+	cc.order = append(cc.order, key)
+}
@@ -0,0 +1,87 @@
+package sysconf
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func contentCacheLen() int {
+	parsedContentCache.mu.Lock()
+	defer parsedContentCache.mu.Unlock()
+	return len(parsedContentCache.entries)
+}
+
+func contentCached(mode, content string) bool {
+	parsedContentCache.mu.Lock()
+	defer parsedContentCache.mu.Unlock()
+	_, ok := parsedContentCache.entries[contentCacheKey(mode, []byte(content))]
+	return ok
+}
+
+func TestContentCacheSharedAcrossInstances(t *testing.T) {
+	content := "cache_test:\n  name: shared\n  tags: [a, b]\n"
+
+	first, err := New(WithContent(content))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, first.Close)
+	if !contentCached("yaml", content) {
+		t.Fatal("expected parsed content to be cached")
+	}
+
+	second, err := New(WithContent(content))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, second.Close)
+
+	if err := first.Set("cache_test.name", "changed"); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if got := second.GetString("cache_test.name"); got != "shared" {
+		t.Fatalf("instances must not share parsed data, got %q", got)
+	}
+	if got := second.GetStringSlice("cache_test.tags"); len(got) != 2 {
+		t.Fatalf("expected cached slice, got %v", got)
+	}
+
+	third, err := New(WithContent(content))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, third.Close)
+	if got := third.GetString("cache_test.name"); got != "shared" {
+		t.Fatalf("cached content was mutated, got %q", got)
+	}
+}
+
+func TestContentCacheDisabled(t *testing.T) {
+	content := "cache_test:\n  name: uncached\n"
+	cfg, err := New(WithContent(content), WithContentCache(false))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if contentCached("yaml", content) {
+		t.Fatal("content must not be cached when disabled")
+	}
+	if got := cfg.GetString("cache_test.name"); got != "uncached" {
+		t.Fatalf("unexpected value %q", got)
+	}
+}
+
+func TestContentCacheBounded(t *testing.T) {
+	for i := range contentCacheEntries + 10 {
+		c := &Config{mode: "yaml"}
+		if _, err := c.flatContent(fmt.Appendf(nil, "bounded_%d: %d\n", i, i)); err != nil {
+			t.Fatalf("parse failed: %v", err)
+		}
+	}
+	if n := contentCacheLen(); n > contentCacheEntries {
+		t.Fatalf("cache exceeded bound: %d entries", n)
+	}
+}
@@ -0,0 +1,94 @@
+package sysconf
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// ContentVersionKey 配置文件中记录默认内容版本的键（见 WithContentVersion）
+const ContentVersionKey = "_content_version"
+
+// WithContentVersion 为 WithContent 提供的默认内容标注版本号。启动时若配置文件记录的版本低于 n，
+// 将新默认内容中文件缺少的键补入文件（已存在的键一律保留用户的值，包括值为空或类型不同的键及其子键），
+// 并把 n 写入文件的 ContentVersionKey，使应用升级后已有配置文件也能获得新增的默认项。
+// 文件记录的版本不低于 n 时不做任何修改；纯内存配置不适用。补全失败只记录错误日志，不影响启动。
+func WithContentVersion(n int) Option {
+	return func(c *Config) {
+		if n > 0 {
+			c.contentVersion = n
+		}
+	}
+}
+
+// upgradeDefaultContent 按 WithContentVersion 将新默认内容中缺少的键合并进配置文件（调用者不得持有 mu）
+func (c *Config) upgradeDefaultContent() {
+	if c.contentVersion <= 0 || c.name == "" || c.content == "" {
+		return
+	}
+
+	data := c.loadData()
+	fileVersion := 0
+	if raw, ok := data[ContentVersionKey]; ok {
+		v, err := cast.ToIntE(raw)
+		if err != nil {
+			c.logger.Warnf("Ignoring invalid %s value %v: %v", ContentVersionKey, raw, err)
+		}
+		fileVersion = v
+	}
+	if fileVersion >= c.contentVersion {
+		return
+	}
+
+	defaults, err := c.flatDefaultContent()
+	if err != nil {
+		c.logger.Errorf("Failed to parse default content for upgrade: %v", err)
+		return
+	}
+	changes := map[string]any{ContentVersionKey: c.contentVersion}
+	for _, key := range slices.Sorted(maps.Keys(defaults)) {
+		if key != ContentVersionKey && !hasKeyOrAncestor(data, key) {
+			changes[key] = defaults[key]
+		}
+	}
+	if err := c.SetMultiple(changes); err != nil {
+		c.logger.Errorf("Failed to upgrade config content from version %d to %d: %v", fileVersion, c.contentVersion, err)
+		return
+	}
+	c.logger.Infof("Upgraded config content from version %d to %d, added %d default keys",
+		fileVersion, c.contentVersion, len(changes)-1)
+}
+
+// flatDefaultContent 解析默认内容为扁平键值
+func (c *Config) flatDefaultContent() (map[string]any, error) {
+	content, err := c.prepareContent([]byte(c.content))
+	if err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	nested, err := c.parseConfigBytes(content)
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	flat := make(map[string]any)
+	flattenSettings("", nested, flat)
+	return flat, nil
+}
+
+// hasKeyOrAncestor 判断键本身或其任一上级键已存在于扁平数据中；
+// 上级键存在说明用户将该层级设置为标量或列表，补入子键会覆盖用户的值
+func hasKeyOrAncestor(data map[string]any, key string) bool {
+	for {
+		if _, ok := data[key]; ok {
+			return true
+		}
+		i := strings.LastIndex(key, ".")
+		if i < 0 {
+			return false
+		}
+		key = key[:i]
+	}
+}
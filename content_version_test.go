@@ -0,0 +1,108 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestContentVersionUpgrade(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "app.yaml")
+	open := func(content string, version int) *Config {
+		t.Helper()
+		cfg, err := New(
+			WithPath(tmpDir),
+			WithName("app"),
+			WithMode("yaml"),
+			WithContent(content),
+			WithContentVersion(version),
+			WithWriteDebounceDelay(0),
+		)
+		if err != nil {
+			t.Fatalf("create config failed: %v", err)
+		}
+		return cfg
+	}
+
+	v1 := "server:\n  port: 8080\n  host: localhost\n"
+	cfg := open(v1, 1)
+	if got := cfg.GetInt(ContentVersionKey); got != 1 {
+		t.Fatalf("expected new file to be stamped with version 1, got %d", got)
+	}
+	if err := cfg.Set("server.port", 9090); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if err := cfg.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	v2 := "server:\n  port: 8080\n  host: localhost\n  timeout: 30s\nlog:\n  level: info\n"
+	cfg = open(v2, 2)
+	if got := cfg.GetInt("server.port"); got != 9090 {
+		t.Fatalf("user value must survive upgrade, got %d", got)
+	}
+	if got := cfg.GetString("server.timeout"); got != "30s" {
+		t.Fatalf("expected new default key, got %q", got)
+	}
+	if got := cfg.GetString("log.level"); got != "info" {
+		t.Fatalf("expected new default section, got %q", got)
+	}
+	if err := cfg.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatalf("read config failed: %v", err)
+	}
+	for _, want := range []string{"_content_version: 2", "timeout: 30s", "port: 9090"} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("expected %q in upgraded file:\n%s", want, data)
+		}
+	}
+
+	// 已是更新版本的文件不会被旧版本的默认内容回退或补全
+	cfg = open("server:\n  port: 8080\nlegacy: true\n", 1)
+	testutil.Cleanup(t, cfg.Close)
+	if cfg.IsSet("legacy") {
+		t.Fatal("older default content must not be merged into a newer file")
+	}
+	if got := cfg.GetInt(ContentVersionKey); got != 2 {
+		t.Fatalf("expected version to stay 2, got %d", got)
+	}
+}
+
+func TestContentVersionKeepsUserShapes(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "app.yaml")
+	if err := os.WriteFile(configFile, []byte("cache: disabled\nserver:\n  host: example.com\n"), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+
+	cfg, err := New(
+		WithPath(tmpDir),
+		WithName("app"),
+		WithMode("yaml"),
+		WithContent("cache:\n  size: 100\nserver:\n  host: localhost\n  port: 8080\n"),
+		WithContentVersion(1),
+		WithWriteDebounceDelay(0),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("cache"); got != "disabled" {
+		t.Fatalf("scalar user value must not be replaced by default map, got %q", got)
+	}
+	if got := cfg.GetString("server.host"); got != "example.com" {
+		t.Fatalf("user value must survive upgrade, got %q", got)
+	}
+	if got := cfg.GetInt("server.port"); got != 8080 {
+		t.Fatalf("expected missing default key, got %d", got)
+	}
+}
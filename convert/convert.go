@@ -0,0 +1,63 @@
+// Package convert 提供 sysconf 内部使用的命名与类型转换工具，
+// 便于编写自定义编解码器、验证器或自行调用 mapstructure 解码时与 sysconf.Unmarshal 保持一致的行为。
+package convert
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/darkit/sysconf/internal/utils"
+	mapstructure "github.com/go-viper/mapstructure/v2"
+)
+
+// CamelToSnake 驼峰命名转下划线命名，例如 AccessID -> access_id、HTTPStatusCode -> http_status_code
+func CamelToSnake(s string) string {
+	return utils.CamelToSnake(s)
+}
+
+// SnakeToCamel 下划线命名转驼峰命名，例如 access_id -> accessId
+func SnakeToCamel(s string) string {
+	return utils.SnakeToCamel(s)
+}
+
+// ParseSlice 将字符串解析为 t 类型的切片，支持 JSON 数组（如 `[1,2]`）与逗号分隔（如 "a, b"）两种写法
+func ParseSlice(s string, t reflect.Type) (reflect.Value, error) {
+	return utils.ParseSlice(s, t)
+}
+
+// ParseLenientInt 宽松解析整数：允许首尾空白、千位分隔符（"1,000"、"1_000_000"、"1'000"）、
+// 进制前缀（"0xFF"、"0o17"、"0b101"）与小数部分为零的写法，规则同 sysconf.WithLenientNumbers
+func ParseLenientInt(s string) (int64, error) {
+	return utils.ParseLenientInt(s)
+}
+
+// ParseLenientFloat 宽松解析浮点数，规则同 ParseLenientInt，另支持小数与科学计数法
+func ParseLenientFloat(s string) (float64, error) {
+	return utils.ParseLenientFloat(s)
+}
+
+// IsZero 判断反射值是否为零值，结构体在全部字段为零值时视为零值
+func IsZero(v reflect.Value) bool {
+	return utils.IsZero(v)
+}
+
+// StringToSliceHookFunc 将字符串解码为切片的 mapstructure 钩子：优先按 JSON 数组解析，否则按逗号分隔并忽略空项
+func StringToSliceHookFunc() mapstructure.DecodeHookFunc {
+	return utils.StringToSliceHookFunc()
+}
+
+// StringToMapHookFunc 将 JSON 对象字符串解码为 map 的 mapstructure 钩子
+func StringToMapHookFunc() mapstructure.DecodeHookFunc {
+	return utils.StringToMapHookFunc()
+}
+
+// DecodeHook 返回 sysconf.Unmarshal 使用的通用解码钩子组合：
+// time.Duration、RFC3339 时间、字符串到切片与字符串到 map（不含按配置注册的枚举钩子）
+func DecodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToTimeHookFunc(time.RFC3339),
+		StringToSliceHookFunc(),
+		StringToMapHookFunc(),
+	)
+}
@@ -0,0 +1,56 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	mapstructure "github.com/go-viper/mapstructure/v2"
+)
+
+func TestNaming(t *testing.T) {
+	cases := map[string]string{"AccessID": "access_id", "HTTPStatusCode": "http_status_code", "服务Name": "服务_name"}
+	for in, want := range cases {
+		if got := CamelToSnake(in); got != want {
+			t.Errorf("CamelToSnake(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := SnakeToCamel("_access_id"); got != "accessId" {
+		t.Errorf("SnakeToCamel = %q", got)
+	}
+	if got := SnakeToCamel("access_id"); got != "accessId" {
+		t.Errorf("SnakeToCamel = %q", got)
+	}
+}
+
+func TestParseSlice(t *testing.T) {
+	v, err := ParseSlice("1, 2,3", reflect.TypeOf([]int{}))
+	if err != nil {
+		t.Fatalf("ParseSlice: %v", err)
+	}
+	if got := v.Interface().([]int); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("ParseSlice = %v", got)
+	}
+	if _, err := ParseSlice("a", reflect.TypeOf("")); err == nil {
+		t.Fatalf("non-slice target should fail")
+	}
+}
+
+func TestDecodeHook(t *testing.T) {
+	var out struct {
+		Timeout time.Duration
+		Hosts   []string
+		Labels  map[string]any
+	}
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{DecodeHook: DecodeHook(), Result: &out})
+	if err != nil {
+		t.Fatalf("new decoder: %v", err)
+	}
+	in := map[string]any{"timeout": "2s", "hosts": "a, b,", "labels": `{"env":"prod"}`}
+	if err := dec.Decode(in); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if out.Timeout != 2*time.Second || !reflect.DeepEqual(out.Hosts, []string{"a", "b"}) || out.Labels["env"] != "prod" {
+		t.Fatalf("unexpected result: %+v", out)
+	}
+}
@@ -213,9 +213,9 @@ func TestErrorHandlingHelpers(t *testing.T) {
 	}
 
 	r, w, _ := os.Pipe()
-	stdout := os.Stdout
-	defer func() { os.Stdout = stdout }()
-	os.Stdout = w
+	stderr := os.Stderr
+	defer func() { os.Stderr = stderr }()
+	os.Stderr = w
 	PrintErrorHelp(decryptErr)
 	_ = w.Close()
 	buf, _ := io.ReadAll(r)
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 
 	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/chacha20poly1305"
@@ -50,6 +51,9 @@ type CryptoOptions struct {
 type DefaultCrypto struct {
 	key    []byte // 256位密钥
 	prefix string // 加密数据前缀标识
+
+	keyIDOnce sync.Once // 密钥标识只派生一次
+	keyID     string
 }
 
 // Argon2id 参数常量
@@ -0,0 +1,151 @@
+package sysconf
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/darkit/sysconf/internal/utils"
+)
+
+// decodePlanCache 按结构体类型缓存的解码计划，使用 sync.Map 实现无锁读取
+var decodePlanCache sync.Map // map[reflect.Type]*decodePlan
+
+// fieldPlanKind 字段在默认值与必填校验中的处理方式
+type fieldPlanKind uint8
+
+const (
+	fieldScalar    fieldPlanKind = iota // 普通字段（含标量指针）
+	fieldStruct                         // 嵌套结构体，递归处理
+	fieldStructPtr                      // 结构体指针，非 nil 时递归处理
+)
+
+// fieldPlan 单个导出字段的预解析信息
+type fieldPlan struct {
+	index    int
+	name     string
+	kind     fieldPlanKind
+	def      string // default 标签
+	required bool   // required:"true" 或 validate 含 required
+	nested   *decodePlan
+}
+
+// decodePlan 结构体类型的解码计划：Unmarshal 据此填充默认值、执行必填校验，
+// 并跳过类型中不存在的 layout 标签与结构体指针的预处理，避免每次调用重新反射字段标签
+type decodePlan struct {
+	fields      []fieldPlan
+	hasLayouts  bool // 本类型或嵌套类型含 layout 标签
+	hasSections bool // 本类型或嵌套类型含结构体指针字段
+	hasPaths    bool // 本类型或嵌套类型含 path:"true" 标签
+}
+
+// decodePlanFor 获取结构体类型的解码计划（带缓存）
+func decodePlanFor(t reflect.Type) *decodePlan {
+	if cached, ok := decodePlanCache.Load(t); ok {
+		return cached.(*decodePlan)
+	}
+	plan := buildDecodePlan(t, make(map[reflect.Type]*decodePlan))
+	actual, _ := decodePlanCache.LoadOrStore(t, plan)
+	return actual.(*decodePlan)
+}
+
+// buildDecodePlan 构建解码计划，building 记录构建中的类型以支持自引用结构体
+func buildDecodePlan(t reflect.Type, building map[reflect.Type]*decodePlan) *decodePlan {
+	if plan, ok := building[t]; ok {
+		return plan
+	}
+	plan := &decodePlan{}
+	building[t] = plan
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if sf.Tag.Get("layout") != "" {
+			plan.hasLayouts = true
+		}
+		if sf.Tag.Get("path") == "true" {
+			plan.hasPaths = true
+		}
+		requiredTag := sf.Tag.Get("required")
+		fp := fieldPlan{
+			index:    i,
+			name:     sf.Name,
+			def:      sf.Tag.Get("default"),
+			required: requiredTag == "true" || requiredTag == "required" || strings.Contains(sf.Tag.Get("validate"), "required"),
+		}
+		switch {
+		case sf.Type.Kind() == reflect.Struct:
+			fp.kind = fieldStruct
+			fp.nested = buildDecodePlan(sf.Type, building)
+		case sf.Type.Kind() == reflect.Pointer && sf.Type.Elem().Kind() == reflect.Struct:
+			fp.kind = fieldStructPtr
+			fp.nested = buildDecodePlan(sf.Type.Elem(), building)
+			plan.hasSections = true
+		}
+		if fp.nested != nil {
+			plan.hasLayouts = plan.hasLayouts || fp.nested.hasLayouts
+			plan.hasSections = plan.hasSections || fp.nested.hasSections
+			plan.hasPaths = plan.hasPaths || fp.nested.hasPaths
+		}
+		plan.fields = append(plan.fields, fp)
+	}
+	return plan
+}
+
+// applyDefaults 按计划填充零值字段的 default 标签，行为同 utils.SetDefaultValues
+func (p *decodePlan) applyDefaults(val reflect.Value, lenientNumbers bool) error {
+	setField := utils.SetFieldValue
+	if lenientNumbers {
+		setField = utils.SetFieldValueLenient
+	}
+	for i := range p.fields {
+		fp := &p.fields[i]
+		field := val.Field(fp.index)
+		switch fp.kind {
+		case fieldStruct:
+			if err := fp.nested.applyDefaults(field, lenientNumbers); err != nil {
+				return err
+			}
+		case fieldStructPtr:
+			if !field.IsNil() {
+				if err := fp.nested.applyDefaults(field.Elem(), lenientNumbers); err != nil {
+					return err
+				}
+			}
+		default:
+			if fp.def != "" && utils.IsZero(field) {
+				if err := setField(field, fp.def); err != nil {
+					return fmt.Errorf("set field %s: %w", fp.name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateRequired 按计划校验必填字段，行为与错误信息同 utils.ValidateStruct
+func (p *decodePlan) validateRequired(val reflect.Value) error {
+	for i := range p.fields {
+		fp := &p.fields[i]
+		field := val.Field(fp.index)
+		if fp.required && utils.IsZero(field) {
+			return fmt.Errorf("field %s is required", fp.name)
+		}
+		switch fp.kind {
+		case fieldStruct:
+			if err := fp.nested.validateRequired(field); err != nil {
+				return fmt.Errorf("nested field %s: %w", fp.name, err)
+			}
+		case fieldStructPtr:
+			if !field.IsNil() {
+				if err := fp.nested.validateRequired(field.Elem()); err != nil {
+					return fmt.Errorf("pointer field %s: %w", fp.name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package sysconf
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/utils"
+)
+
+type planNode struct {
+	Name string    `config:"name" default:"node"`
+	Next *planNode `config:"next"`
+}
+
+type planSettings struct {
+	Server struct {
+		Host string `config:"host" default:"localhost"`
+		Port int    `config:"port" validate:"required"`
+	} `config:"server"`
+	Tree planNode `config:"tree"`
+}
+
+func TestDecodePlanCached(t *testing.T) {
+	typ := reflect.TypeFor[planSettings]()
+	plan := decodePlanFor(typ)
+	if decodePlanFor(typ) != plan {
+		t.Fatalf("decode plan should be cached per type")
+	}
+	if plan.hasLayouts || !plan.hasSections {
+		t.Fatalf("unexpected plan flags: layouts=%v sections=%v", plan.hasLayouts, plan.hasSections)
+	}
+	// 自引用类型复用构建中的计划而不是无限递归
+	node := decodePlanFor(reflect.TypeFor[planNode]())
+	if node.fields[1].nested != node {
+		t.Fatalf("self-referential plan should point to itself")
+	}
+}
+
+func TestDecodePlanMatchesUtils(t *testing.T) {
+	var viaPlan, viaUtils planSettings
+	viaPlan.Tree.Next = &planNode{}
+	viaUtils.Tree.Next = &planNode{}
+	if err := decodePlanFor(reflect.TypeFor[planSettings]()).applyDefaults(reflect.ValueOf(&viaPlan).Elem(), false); err != nil {
+		t.Fatalf("plan defaults: %v", err)
+	}
+	if err := utils.SetDefaultValues(&viaUtils); err != nil {
+		t.Fatalf("utils defaults: %v", err)
+	}
+	if viaPlan.Server.Host != viaUtils.Server.Host || viaPlan.Tree.Next.Name != viaUtils.Tree.Next.Name {
+		t.Fatalf("defaults differ: %+v vs %+v", viaPlan, viaUtils)
+	}
+
+	planErr := decodePlanFor(reflect.TypeFor[planSettings]()).validateRequired(reflect.ValueOf(&viaPlan).Elem())
+	utilsErr := utils.ValidateStruct(&viaUtils)
+	if planErr == nil || utilsErr == nil || planErr.Error() != utilsErr.Error() {
+		t.Fatalf("required errors differ: %v vs %v", planErr, utilsErr)
+	}
+}
+
+func BenchmarkUnmarshalStruct(b *testing.B) {
+	cfg, err := New(WithContent("server:\n  host: example.com\n  port: 8080\ntree:\n  name: root\n"), WithMode(YAML))
+	if err != nil {
+		b.Fatalf("new config: %v", err)
+	}
+	b.Cleanup(func() { _ = cfg.Close() })
+
+	b.ReportAllocs()
+	for b.Loop() {
+		var out planSettings
+		if err := cfg.Unmarshal(&out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package sysconf
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/darkit/sysconf/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// docKeyTags 推导配置键名时依次查找的结构体标签，与 Unmarshal 的标签约定一致
+var docKeyTags = []string{"config", "sysconf", "yaml", "json", "toml"}
+
+// FieldDoc 单个配置键的文档描述
+type FieldDoc struct {
+	Key         string     // 完整配置键（点分路径）
+	Name        string     // Go 结构体字段名
+	Type        string     // 字段类型
+	Default     string     // default 标签中的默认值
+	Validation  string     // validate 标签中的校验规则
+	Required    bool       // 是否必填（required:"true" 或 validate 包含 required）
+	Description string     // desc 标签中的说明
+	Secret      bool       // 是否为敏感字段（secret:"true" 或字段名包含 password/secret/token 等）
+	Fields      []FieldDoc // 嵌套结构体的子字段，叶子字段为空
+
+	goType reflect.Type // 字段的 Go 类型，用于生成示例值
+}
+
+// StructDoc 由结构体生成的配置文档模型
+type StructDoc struct {
+	Name   string     // 结构体类型名
+	Fields []FieldDoc // 顶层字段
+}
+
+// DocumentStruct 根据结构体定义生成配置文档模型。
+// 键名规则与 Unmarshal 保持一致：优先使用 config/sysconf/yaml/json/toml 标签，
+// 否则将字段名转换为蛇形命名；",inline"/",squash" 字段会展开到父级。
+// 字段说明取自 desc 标签，默认值取自 default 标签，校验规则取自 validate 标签。
+func DocumentStruct(obj any) (*StructDoc, error) {
+	if obj == nil {
+		return nil, fmt.Errorf("document target cannot be nil")
+	}
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("document target must be a struct or pointer to struct, got %s", t.Kind())
+	}
+
+	return &StructDoc{
+		Name:   t.Name(),
+		Fields: documentFields(t, "", map[reflect.Type]bool{t: true}),
+	}, nil
+}
+
+// documentFields 递归收集结构体字段文档，visiting 用于防止自引用类型无限递归
+func documentFields(t reflect.Type, prefix string, visiting map[reflect.Type]bool) []FieldDoc {
+	fields := make([]FieldDoc, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+
+		name, inline, skip := docFieldKey(sf)
+		if skip {
+			continue
+		}
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		nested := ft.Kind() == reflect.Struct && ft != reflect.TypeFor[time.Time]()
+
+		if inline && nested {
+			if !visiting[ft] {
+				visiting[ft] = true
+				fields = append(fields, documentFields(ft, prefix, visiting)...)
+				delete(visiting, ft)
+			}
+			continue
+		}
+
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		doc := FieldDoc{
+			Key:         key,
+			Name:        sf.Name,
+			Type:        sf.Type.String(),
+			Default:     sf.Tag.Get("default"),
+			Validation:  sf.Tag.Get("validate"),
+			Required:    docFieldRequired(sf),
+			Description: sf.Tag.Get("desc"),
+			Secret:      docFieldSecret(sf, name),
+			goType:      sf.Type,
+		}
+		if nested && !visiting[ft] {
+			visiting[ft] = true
+			doc.Fields = documentFields(ft, key, visiting)
+			delete(visiting, ft)
+		}
+		fields = append(fields, doc)
+	}
+	return fields
+}
+
+// docFieldKey 解析字段对应的配置键名
+func docFieldKey(sf reflect.StructField) (name string, inline bool, skip bool) {
+	for _, tagName := range docKeyTags {
+		tag, ok := sf.Tag.Lookup(tagName)
+		if !ok {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		if parts[0] == "-" {
+			return "", false, true
+		}
+		for _, opt := range parts[1:] {
+			if opt == "inline" || opt == "squash" {
+				inline = true
+			}
+		}
+		if parts[0] != "" {
+			return parts[0], inline, false
+		}
+		if inline {
+			return "", true, false
+		}
+	}
+	return camelToSnake(sf.Name), false, false
+}
+
+// docFieldRequired 判断字段是否必填，规则与 Unmarshal 的必填校验一致
+func docFieldRequired(sf reflect.StructField) bool {
+	if tag := sf.Tag.Get("required"); tag == "true" || tag == "required" {
+		return true
+	}
+	return strings.Contains(sf.Tag.Get("validate"), "required")
+}
+
+// docSecretHints 用于识别敏感字段的键名片段
+var docSecretHints = []string{"password", "passwd", "secret", "token", "api_key", "apikey", "private_key", "credential"}
+
+// docFieldSecret 判断字段是否为敏感字段，secret 标签优先于键名推断
+func docFieldSecret(sf reflect.StructField, key string) bool {
+	if tag, ok := sf.Tag.Lookup("secret"); ok {
+		return tag == "true"
+	}
+	return isSecretKey(key)
+}
+
+// isSecretKey 根据键名最后一段判断是否为敏感字段
+func isSecretKey(key string) bool {
+	lower := strings.ToLower(lastKeySegment(key))
+	for _, hint := range docSecretHints {
+		if strings.Contains(lower, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// Leaves 返回所有叶子字段（不含嵌套结构体本身），按声明顺序排列
+func (d *StructDoc) Leaves() []FieldDoc {
+	var leaves []FieldDoc
+	var walk func(fields []FieldDoc)
+	walk = func(fields []FieldDoc) {
+		for _, f := range fields {
+			if len(f.Fields) > 0 {
+				walk(f.Fields)
+				continue
+			}
+			leaves = append(leaves, f)
+		}
+	}
+	walk(d.Fields)
+	return leaves
+}
+
+// Markdown 将文档渲染为 Markdown 表格
+func (d *StructDoc) Markdown() string {
+	var b strings.Builder
+	if d.Name != "" {
+		fmt.Fprintf(&b, "## %s\n\n", d.Name)
+	}
+	b.WriteString("| Key | Type | Default | Required | Validation | Description |\n")
+	b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+	for _, f := range d.Leaves() {
+		required := ""
+		if f.Required {
+			required = "yes"
+		}
+		fmt.Fprintf(&b, "| `%s` | `%s` | %s | %s | %s | %s |\n",
+			f.Key, f.Type, markdownCode(f.Default), required, markdownCode(f.Validation), markdownEscape(f.Description))
+	}
+	return b.String()
+}
+
+// SampleYAML 将文档渲染为带注释的示例 YAML，字段值取默认值，敏感字段留空
+func (d *StructDoc) SampleYAML() ([]byte, error) {
+	return d.renderYAML(true)
+}
+
+// renderYAML 渲染示例 YAML，withComments 控制是否输出字段注释
+func (d *StructDoc) renderYAML(withComments bool) ([]byte, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	if err := appendDocNodes(root, d.Fields, withComments); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(root)
+}
+
+// appendDocNodes 将字段文档追加为 YAML 映射节点
+func appendDocNodes(mapping *yaml.Node, fields []FieldDoc, withComments bool) error {
+	for _, f := range fields {
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: lastKeySegment(f.Key)}
+		if withComments {
+			keyNode.HeadComment = docComment(f)
+		}
+
+		var valueNode *yaml.Node
+		if len(f.Fields) > 0 {
+			valueNode = &yaml.Node{Kind: yaml.MappingNode}
+			if err := appendDocNodes(valueNode, f.Fields, withComments); err != nil {
+				return err
+			}
+		} else {
+			node, err := sampleValueNode(f)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", f.Key, err)
+			}
+			valueNode = node
+		}
+		mapping.Content = append(mapping.Content, keyNode, valueNode)
+	}
+	return nil
+}
+
+// docComment 生成字段注释：说明、类型与校验规则
+func docComment(f FieldDoc) string {
+	var lines []string
+	if f.Description != "" {
+		lines = append(lines, f.Description)
+	}
+	if len(f.Fields) > 0 {
+		return strings.Join(lines, "\n")
+	}
+	meta := "type: " + f.Type
+	if f.Required {
+		meta += ", required"
+	}
+	if f.Validation != "" {
+		meta += ", validate: " + f.Validation
+	}
+	if f.Secret {
+		meta += ", secret"
+	}
+	lines = append(lines, meta)
+	return strings.Join(lines, "\n")
+}
+
+// sampleValueNode 根据字段类型与默认值生成 YAML 值节点
+func sampleValueNode(f FieldDoc) (*yaml.Node, error) {
+	node := &yaml.Node{}
+	typ := f.goType
+	if typ == nil {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: f.Default}, nil
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if f.Secret {
+		// 敏感字段不输出默认值，避免示例文件携带凭据
+		f.Default = ""
+	}
+
+	switch typ {
+	case reflect.TypeFor[time.Duration]():
+		value := f.Default
+		if value == "" {
+			value = "0s"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	case reflect.TypeFor[time.Time]():
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: f.Default}, nil
+	}
+
+	value := reflect.New(typ).Elem()
+	if f.Default != "" {
+		if err := utils.SetFieldValue(value, f.Default); err != nil {
+			return nil, err
+		}
+	}
+	switch value.Kind() {
+	case reflect.Slice:
+		if value.IsNil() {
+			value = reflect.MakeSlice(typ, 0, 0)
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			value = reflect.MakeMap(typ)
+		}
+	}
+	if err := node.Encode(value.Interface()); err != nil {
+		return nil, err
+	}
+	if node.Kind == yaml.SequenceNode || node.Kind == yaml.MappingNode {
+		node.Style = yaml.FlowStyle
+	}
+	return node, nil
+}
+
+// lastKeySegment 返回点分键的最后一段
+func lastKeySegment(key string) string {
+	if idx := strings.LastIndex(key, "."); idx >= 0 {
+		return key[idx+1:]
+	}
+	return key
+}
+
+// markdownCode 将非空值包装为行内代码
+func markdownCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + strings.ReplaceAll(s, "|", `\|`) + "`"
+}
+
+// markdownEscape 转义 Markdown 表格中的特殊字符
+func markdownEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,97 @@
+package sysconf
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type docDatabase struct {
+	Host    string        `config:"host" default:"localhost" validate:"required,hostname" desc:"数据库主机"`
+	Port    int           `config:"port" default:"5432" validate:"min=1,max=65535" desc:"数据库端口"`
+	Timeout time.Duration `config:"timeout" default:"30s" desc:"连接超时"`
+}
+
+type DocCommon struct {
+	Debug bool `config:"debug" default:"true" desc:"调试模式"`
+}
+
+type docAppConfig struct {
+	DocCommon  `config:",inline"`
+	Name       string       `config:"name" required:"true" desc:"应用名称"`
+	Tags       []string     `config:"tags" default:"a,b"`
+	Database   docDatabase  `config:"database" desc:"数据库配置"`
+	Cache      *docDatabase `config:"cache"`
+	Internal   string       `config:"-"`
+	MaxConns   int
+	unexported string
+}
+
+func TestDocumentStructModel(t *testing.T) {
+	doc, err := DocumentStruct(&docAppConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "docAppConfig", doc.Name)
+
+	keys := make([]string, 0)
+	byKey := make(map[string]FieldDoc)
+	for _, f := range doc.Leaves() {
+		keys = append(keys, f.Key)
+		byKey[f.Key] = f
+	}
+	require.Equal(t, []string{
+		"debug", "name", "tags",
+		"database.host", "database.port", "database.timeout",
+		"cache.host", "cache.port", "cache.timeout",
+		"max_conns",
+	}, keys)
+
+	host := byKey["database.host"]
+	require.True(t, host.Required)
+	require.Equal(t, "localhost", host.Default)
+	require.Equal(t, "required,hostname", host.Validation)
+	require.Equal(t, "数据库主机", host.Description)
+	require.True(t, byKey["name"].Required)
+	require.Equal(t, "time.Duration", byKey["database.timeout"].Type)
+}
+
+func TestDocumentStructRejectsNonStruct(t *testing.T) {
+	_, err := DocumentStruct(42)
+	require.Error(t, err)
+	_, err = DocumentStruct(nil)
+	require.Error(t, err)
+}
+
+func TestStructDocRenderers(t *testing.T) {
+	doc, err := DocumentStruct(docAppConfig{})
+	require.NoError(t, err)
+
+	md := doc.Markdown()
+	require.Contains(t, md, "| Key | Type | Default | Required | Validation | Description |")
+	require.Contains(t, md, "| `database.port` | `int` | `5432` |  | `min=1,max=65535` | 数据库端口 |")
+	require.Contains(t, md, "| `name` | `string` |  | yes |  | 应用名称 |")
+
+	sample, err := doc.SampleYAML()
+	require.NoError(t, err)
+	require.True(t, strings.Contains(string(sample), "# 数据库端口"), string(sample))
+
+	var parsed map[string]any
+	require.NoError(t, yaml.Unmarshal(sample, &parsed))
+	require.Equal(t, true, parsed["debug"])
+	require.Equal(t, []any{"a", "b"}, parsed["tags"])
+	database := parsed["database"].(map[string]any)
+	require.Equal(t, "localhost", database["host"])
+	require.Equal(t, 5432, database["port"])
+	require.Equal(t, "30s", database["timeout"])
+
+	// 示例 YAML 应能被 sysconf 直接加载并解析回结构体
+	cfg, err := New(WithContent(string(sample)))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+	require.NoError(t, cfg.Set("name", "demo"))
+	var decoded docAppConfig
+	require.NoError(t, cfg.Unmarshal(&decoded))
+	require.Equal(t, 30*time.Second, decoded.Database.Timeout)
+}
@@ -0,0 +1,72 @@
+package sysconf
+
+import (
+	"fmt"
+	"maps"
+	"os"
+
+	"github.com/subosito/gotenv"
+)
+
+// dotenvFile WithDotenvFile 注册的 dotenv 文件
+type dotenvFile struct {
+	path     string
+	optional bool
+}
+
+// WithDotenvFile 在创建配置时读取 dotenv 文件（KEY=VALUE，支持注释、引号、export 前缀与 ${VAR} 展开），
+// 将其中的变量并入环境变量覆盖层，而不是写入配置树：变量名按 WithEnv / WithEnvSectionPrefix 的规则匹配配置键，
+// 进程环境变量中已存在的同名变量优先于文件，使本地开发与容器中注入环境变量的行为一致。
+// 可多次调用，后注册的文件覆盖先注册文件中的同名变量。optional 为 true 时文件不存在不视为错误；
+// 文件无法解析时 New 返回错误。
+func WithDotenvFile(path string, optional bool) Option {
+	return func(c *Config) {
+		if path == "" {
+			return
+		}
+		c.dotenvFiles = append(c.dotenvFiles, dotenvFile{path: path, optional: optional})
+	}
+}
+
+// loadDotenvFiles 读取全部 dotenv 文件并合并为环境变量覆盖层
+func (c *Config) loadDotenvFiles() error {
+	if len(c.dotenvFiles) == 0 {
+		return nil
+	}
+	values := make(map[string]string)
+	for _, file := range c.dotenvFiles {
+		f, err := os.Open(file.path)
+		if os.IsNotExist(err) && file.optional {
+			c.logger.Debugf("Optional dotenv file %s not found, skipping", file.path)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("open dotenv file: %w", err)
+		}
+		env, err := gotenv.StrictParse(f)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("parse dotenv file %s: %w", file.path, err)
+		}
+		maps.Copy(values, env)
+		c.logger.Debugf("Loaded %d variables from dotenv file %s", len(env), file.path)
+	}
+	if !c.envOptions.Enabled && len(c.envSections) == 0 && len(values) > 0 {
+		c.logger.Warnf("Dotenv variables loaded but environment overrides are disabled; use WithEnv or WithEnvSectionPrefix")
+	}
+	c.dotenv.Store(&values)
+	return nil
+}
+
+// lookupEnvVar 查询环境变量，进程环境优先于 dotenv 文件
+func (c *Config) lookupEnvVar(name string) (string, bool) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true
+	}
+	values := c.dotenv.Load()
+	if values == nil {
+		return "", false
+	}
+	value, ok := (*values)[name]
+	return value, ok
+}
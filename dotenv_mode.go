@@ -0,0 +1,194 @@
+package sysconf
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/subosito/gotenv"
+)
+
+// dotenvModes dotenv 格式的模式名（与 viper.SupportedExts 一致）
+var dotenvModes = []string{"dotenv", "env"}
+
+// isDotenvMode 判断格式是否为 dotenv
+func isDotenvMode(mode string) bool {
+	return slices.Contains(dotenvModes, mode)
+}
+
+// dotenvCodec 实现 viper.Codec，按层级映射读写 dotenv 格式：
+// DATABASE_HOST 对应 database.host，双下划线表示键名中的下划线（DATABASE_MAX__CONNS → database.max_conns）
+type dotenvCodec struct{}
+
+// Encode 实现 viper.Encoder
+func (dotenvCodec) Encode(v map[string]any) ([]byte, error) {
+	return marshalDotenv(v)
+}
+
+// Decode 实现 viper.Decoder
+func (dotenvCodec) Decode(b []byte, v map[string]any) error {
+	nested, err := parseDotenv(b)
+	if err != nil {
+		return err
+	}
+	for key, value := range nested {
+		v[key] = value
+	}
+	return nil
+}
+
+// dotenvKeyPath 将变量名映射为点分配置键：单下划线分隔层级，双下划线还原为键名中的下划线
+func dotenvKeyPath(name string) string {
+	parts := strings.Split(strings.ToLower(name), "__")
+	for i, part := range parts {
+		parts[i] = strings.ReplaceAll(part, "_", ".")
+	}
+	return strings.Trim(strings.Join(parts, "_"), ".")
+}
+
+// dotenvVarName 将点分配置键映射为变量名，是 dotenvKeyPath 的逆映射
+func dotenvVarName(key string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.ReplaceAll(key, "_", "__"), ".", "_"))
+}
+
+// parseDotenv 解析 dotenv 内容（支持注释、引号、export 前缀与 ${VAR} 展开），变量按层级映射为嵌套配置，值一律按字符串保存。
+// 同一键既是值又是上级节点时（如 APP 与 APP_ENV）无法映射为嵌套结构，按名称排序后出现的变量保留为顶层叶子键（app_env）
+func parseDotenv(data []byte) (map[string]any, error) {
+	env, err := gotenv.StrictParse(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make(map[string]any)
+	owners := make(map[string]string, len(names)) // 配置键 → 变量名，用于报告冲突
+	for _, name := range names {
+		key := dotenvKeyPath(name)
+		if key == "" {
+			continue
+		}
+		if other := dotenvConflict(owners, key); other != "" {
+			flat := strings.ToLower(name)
+			if other := dotenvConflict(owners, flat); other != "" {
+				return nil, fmt.Errorf("dotenv variable %s conflicts with %s; use a double underscore (e.g. %s) to keep an underscore in the key",
+					name, other, strings.Replace(name, "_", "__", 1))
+			}
+			key = flat
+		}
+		owners[key] = name
+		setNestedMapValue(result, key, env[name])
+	}
+	return result, nil
+}
+
+// dotenvConflict 返回与 key 冲突（同一键、上级节点或下级节点已被占用）的变量名，无冲突时返回空字符串
+func dotenvConflict(owners map[string]string, key string) string {
+	for prefix := key; ; {
+		if other, ok := owners[prefix]; ok {
+			return other
+		}
+		idx := strings.LastIndexByte(prefix, '.')
+		if idx < 0 {
+			break
+		}
+		prefix = prefix[:idx]
+	}
+	for other, otherName := range owners {
+		if strings.HasPrefix(other, key+".") {
+			return otherName
+		}
+	}
+	return ""
+}
+
+// dotenvLeafName 返回顶层叶子键的变量名：键被上级叶子节点遮蔽而由 parseDotenv 保留为叶子时（如 app 与 app_env），
+// 沿用单下划线的原变量名（APP_ENV），否则按 dotenvVarName 映射
+func dotenvLeafName(flat map[string]any, key string) string {
+	name := dotenvVarName(key)
+	natural := strings.ToUpper(key)
+	if natural == name || strings.Contains(key, ".") || !isDotenvName(natural) {
+		return name
+	}
+	parts := strings.Split(dotenvKeyPath(natural), ".")
+	for i := 1; i < len(parts); i++ {
+		if _, ok := flat[strings.Join(parts[:i], ".")]; ok {
+			return natural
+		}
+	}
+	return name
+}
+
+// marshalDotenv 将配置序列化为 dotenv 格式：变量名由点分键映射（见 dotenvVarName），按名称排序，
+// 列表以逗号连接；需要时值写为单引号（不展开变量）或转义的双引号字符串，写入前校验可被原样读回
+func marshalDotenv(settings map[string]any) ([]byte, error) {
+	flat := make(map[string]any, len(settings))
+	flattenSettings("", settings, flat)
+
+	lines := make(map[string]string, len(flat))
+	names := make([]string, 0, len(flat))
+	for key, value := range flat {
+		name := dotenvLeafName(flat, key)
+		if !isDotenvName(name) {
+			return nil, fmt.Errorf("key %s cannot be written as a dotenv variable name", key)
+		}
+		if _, dup := lines[name]; dup {
+			return nil, fmt.Errorf("key %s maps to dotenv variable %s more than once", key, name)
+		}
+		text, err := propertyString(value)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", key, err)
+		}
+		line := name + "=" + dotenvValueText(text)
+		if env, err := gotenv.StrictParse(strings.NewReader(line)); err != nil || env[name] != text {
+			return nil, fmt.Errorf("key %s: value cannot be represented in dotenv format", key)
+		}
+		lines[name] = line
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	for _, name := range names {
+		buf.WriteString(lines[name])
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// isDotenvName 判断变量名是否只包含字母、数字与下划线
+func isDotenvName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		ch := name[i]
+		if !(ch == '_' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// dotenvValueText 返回值的写入形式：简单值原样写出，不含单引号与换行的值用单引号，其余用转义的双引号
+func dotenvValueText(s string) string {
+	plain := s != ""
+	for i := 0; i < len(s) && plain; i++ {
+		ch := s[i]
+		plain = ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || strings.IndexByte("_-./:@,+%", ch) >= 0
+	}
+	switch {
+	case plain:
+		return s
+	case !strings.ContainsAny(s, "'\n\r"):
+		return "'" + s + "'"
+	default:
+		r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "$", `\$`)
+		return `"` + r.Replace(s) + `"`
+	}
+}
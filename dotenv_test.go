@@ -0,0 +1,192 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestDotenvFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	dotenv := filepath.Join(tmpDir, ".env")
+	content := "# local overrides\nAPP_DATABASE_HOST=db.local\nexport APP_DATABASE_PORT=6543\nAPP_SERVER_NAME=\"dev server\"\n"
+	if err := os.WriteFile(dotenv, []byte(content), 0o600); err != nil {
+		t.Fatalf("write dotenv failed: %v", err)
+	}
+	t.Setenv("APP_DATABASE_PORT", "7000")
+
+	cfg, err := New(
+		WithMode("yaml"),
+		WithContent("database:\n  host: localhost\n  port: 5432\nserver:\n  name: prod\n"),
+		WithEnv("APP"),
+		WithDotenvFile(dotenv, false),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("database.host"); got != "db.local" {
+		t.Fatalf("expected dotenv override, got %q", got)
+	}
+	if got := cfg.GetInt("database.port"); got != 7000 {
+		t.Fatalf("expected process env to win over dotenv, got %d", got)
+	}
+	if got := cfg.GetString("server.name"); got != "dev server" {
+		t.Fatalf("expected quoted dotenv value, got %q", got)
+	}
+	if origin, _ := cfg.Origin("database.host"); origin != SourceEnv {
+		t.Fatalf("expected env origin, got %q", origin)
+	}
+	if _, ok := os.LookupEnv("APP_DATABASE_HOST"); ok {
+		t.Fatal("dotenv must not modify the process environment")
+	}
+}
+
+func TestDotenvFileMissing(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), ".env")
+
+	cfg, err := New(WithContent("a: 1\n"), WithEnv("APP"), WithDotenvFile(missing, true))
+	if err != nil {
+		t.Fatalf("optional dotenv should not fail: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if _, err := New(WithContent("a: 1\n"), WithEnv("APP"), WithDotenvFile(missing, false)); err == nil {
+		t.Fatal("expected error for missing required dotenv file")
+	}
+}
+
+func TestDotenvFileInvalid(t *testing.T) {
+	dotenv := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(dotenv, []byte("NOT A VALID LINE\n"), 0o600); err != nil {
+		t.Fatalf("write dotenv failed: %v", err)
+	}
+	_, err := New(WithContent("a: 1\n"), WithEnv("APP"), WithDotenvFile(dotenv, true))
+	if err == nil || !strings.Contains(err.Error(), "parse dotenv file") {
+		t.Fatalf("expected parse error, got %v", err)
+	}
+}
+
+func TestDotenvModeHierarchicalKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "app.env")
+	content := "DATABASE_HOST=localhost\nDATABASE_MAX__CONNS=10\nAPP__NAME='demo app'\n"
+	if err := os.WriteFile(configFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+
+	cfg, err := New(WithPath(tmpDir), WithName("app"), WithMode("env"), WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("database.host"); got != "localhost" {
+		t.Fatalf("expected database.host from DATABASE_HOST, got %q", got)
+	}
+	if got := cfg.GetString("DATABASE_HOST"); got != "localhost" {
+		t.Fatalf("expected variable name lookup, got %q", got)
+	}
+	if got := cfg.GetInt("database.max_conns"); got != 10 {
+		t.Fatalf("expected double underscore to keep literal underscore, got %d", got)
+	}
+	if got := cfg.GetString("app_name"); got != "demo app" {
+		t.Fatalf("expected top-level key with underscore, got %q", got)
+	}
+	if got := cfg.GetStringMap("database"); len(got) != 2 {
+		t.Fatalf("expected nested database section, got %v", got)
+	}
+
+	if err := cfg.Set("database.port", 5432); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if err := cfg.Set("CACHE_TTL", "1m"); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if err := cfg.Set("database.password", `p@ss "word" $HOME`); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if got := cfg.GetString("cache.ttl"); got != "1m" {
+		t.Fatalf("expected variable name write to map to cache.ttl, got %q", got)
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatalf("read config failed: %v", err)
+	}
+	for _, line := range []string{"DATABASE_HOST=localhost", "DATABASE_MAX__CONNS=10", "DATABASE_PORT=5432", "CACHE_TTL=1m", "APP__NAME='demo app'"} {
+		if !strings.Contains(string(data), line+"\n") {
+			t.Fatalf("expected %q in written file, got:\n%s", line, data)
+		}
+	}
+
+	reopened, err := New(WithPath(tmpDir), WithName("app"), WithMode("env"))
+	if err != nil {
+		t.Fatalf("reopen config failed: %v", err)
+	}
+	testutil.Cleanup(t, reopened.Close)
+	if got := reopened.GetString("database.password"); got != `p@ss "word" $HOME` {
+		t.Fatalf("expected escaped value to round-trip, got %q", got)
+	}
+	if got := reopened.GetInt("database.max_conns"); got != 10 {
+		t.Fatalf("expected literal underscore to round-trip, got %d", got)
+	}
+}
+
+func TestDotenvModeKeyConflict(t *testing.T) {
+	nested, err := parseDotenv([]byte("DATABASE=main\nDATABASE_HOST=localhost\nDATABASE_PORT=5432\n"))
+	if err != nil {
+		t.Fatalf("conflicting variables should not fail the load: %v", err)
+	}
+	if nested["database"] != "main" || nested["database_host"] != "localhost" || nested["database_port"] != "5432" {
+		t.Fatalf("expected shadowed variables kept as flat leaves, got %v", nested)
+	}
+	data, err := marshalDotenv(nested)
+	if err != nil || string(data) != "DATABASE=main\nDATABASE_HOST=localhost\nDATABASE_PORT=5432\n" {
+		t.Fatalf("expected original variable names on write, got %q (%v)", data, err)
+	}
+
+	_, err = parseDotenv([]byte("A=1\nA_B=2\na_b=3\n"))
+	if err == nil || !strings.Contains(err.Error(), "double underscore") {
+		t.Fatalf("expected conflict error suggesting double underscore, got %v", err)
+	}
+	if _, err := marshalDotenv(map[string]any{"bad-key": "x"}); err == nil {
+		t.Fatal("expected error for key outside the dotenv name charset")
+	}
+}
+
+func TestDotenvModeSharedPrefix(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "app.env")
+	if err := os.WriteFile(configFile, []byte("APP=demo\nAPP_ENV=dev\n"), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+
+	cfg, err := New(WithPath(tmpDir), WithName("app"), WithMode("env"), WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("common dotenv file should load: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("APP"); got != "demo" {
+		t.Fatalf("APP = %q", got)
+	}
+	if got := cfg.GetString("APP_ENV"); got != "dev" {
+		t.Fatalf("APP_ENV = %q", got)
+	}
+	if got := cfg.GetString("app_env"); got != "dev" {
+		t.Fatalf("app_env = %q", got)
+	}
+
+	if err := cfg.Set("APP_ENV", "prod"); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	data, err := os.ReadFile(configFile)
+	if err != nil || string(data) != "APP=demo\nAPP_ENV=prod\n" {
+		t.Fatalf("expected variable names preserved on write, got %q (%v)", data, err)
+	}
+}
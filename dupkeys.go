@@ -0,0 +1,170 @@
+package sysconf
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DuplicateKeyError 配置内容中存在重复键
+type DuplicateKeyError struct {
+	Key        string // 重复的完整配置键（点分路径）
+	FirstLine  int    // 第一次定义所在行
+	SecondLine int    // 重复定义所在行
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("duplicate key %q at line %d (first defined at line %d)", e.Key, e.SecondLine, e.FirstLine)
+}
+
+// WithRejectDuplicateKeys 启用严格解码：YAML/JSON 内容中出现重复键时加载失败，
+// 错误中包含键名与两处定义的行号。默认关闭（保持后定义覆盖先定义的行为）。
+func WithRejectDuplicateKeys(reject bool) Option {
+	return func(c *Config) {
+		c.rejectDuplicateKeys = reject
+	}
+}
+
+// checkDuplicateKeys 在启用严格解码时检查配置内容中的重复键
+func (c *Config) checkDuplicateKeys(data []byte) error {
+	if !c.rejectDuplicateKeys {
+		return nil
+	}
+	dup, err := findDuplicateKey(data, c.mode)
+	if err != nil || dup == nil {
+		// 语法错误交由后续解析器报告
+		return nil
+	}
+	return &ConfigError{
+		Type:    ErrTypeInvalidFormat,
+		Message: dup.Error(),
+		Key:     dup.Key,
+		File:    c.configFilePath(),
+		Cause:   dup,
+	}
+}
+
+// findDuplicateKey 查找内容中的第一个重复键，仅支持 yaml/json/jsonc，其他格式返回 nil
+func findDuplicateKey(data []byte, mode string) (*DuplicateKeyError, error) {
+	switch mode {
+	case "yaml", "yml":
+		var root yaml.Node
+		if err := yaml.Unmarshal(data, &root); err != nil {
+			return nil, err
+		}
+		if len(root.Content) == 0 {
+			return nil, nil
+		}
+		return findYAMLDuplicate(root.Content[0], ""), nil
+	case "json":
+		return findJSONDuplicate(data)
+	case "jsonc":
+		clean, err := stripJSONC(data)
+		if err != nil {
+			return nil, err
+		}
+		return findJSONDuplicate(clean)
+	default:
+		return nil, nil
+	}
+}
+
+// findYAMLDuplicate 递归查找 YAML 映射中的重复键
+func findYAMLDuplicate(node *yaml.Node, prefix string) *DuplicateKeyError {
+	switch node.Kind {
+	case yaml.MappingNode:
+		seen := make(map[string]int, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			key := joinKey(prefix, keyNode.Value)
+			if keyNode.Value != "<<" {
+				if first, ok := seen[keyNode.Value]; ok {
+					return &DuplicateKeyError{Key: key, FirstLine: first, SecondLine: keyNode.Line}
+				}
+				seen[keyNode.Value] = keyNode.Line
+			}
+			if dup := findYAMLDuplicate(valueNode, key); dup != nil {
+				return dup
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if dup := findYAMLDuplicate(item, prefix); dup != nil {
+				return dup
+			}
+		}
+	}
+	return nil
+}
+
+// findJSONDuplicate 以流式方式查找 JSON 对象中的重复键
+func findJSONDuplicate(data []byte) (*DuplicateKeyError, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dup, err := walkJSONValue(dec, data, "")
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	return dup, err
+}
+
+// walkJSONValue 递归遍历一个 JSON 值
+func walkJSONValue(dec *json.Decoder, data []byte, prefix string) (*DuplicateKeyError, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil, nil
+	}
+
+	switch delim {
+	case '{':
+		seen := make(map[string]int)
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			name, _ := keyTok.(string)
+			line := lineAtOffset(data, dec.InputOffset())
+			key := joinKey(prefix, name)
+			if first, ok := seen[name]; ok {
+				return &DuplicateKeyError{Key: key, FirstLine: first, SecondLine: line}, nil
+			}
+			seen[name] = line
+			if dup, err := walkJSONValue(dec, data, key); dup != nil || err != nil {
+				return dup, err
+			}
+		}
+	case '[':
+		for dec.More() {
+			if dup, err := walkJSONValue(dec, data, prefix); dup != nil || err != nil {
+				return dup, err
+			}
+		}
+	}
+	// 消费结束分隔符
+	_, err = dec.Token()
+	return nil, err
+}
+
+// lineAtOffset 计算字节偏移所在的行号（从 1 开始）
+func lineAtOffset(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// joinKey 拼接点分配置键
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,58 @@
+package sysconf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRejectDuplicateKeysJSON(t *testing.T) {
+	content := "{\n  \"server\": {\n    \"port\": 80,\n    \"port\": 8080\n  }\n}\n"
+
+	cfg, err := New(WithMode("json"), WithContent(content))
+	require.NoError(t, err, "duplicates are tolerated by default")
+	t.Cleanup(func() { _ = cfg.Close() })
+	require.Equal(t, 8080, cfg.GetInt("server.port"))
+
+	_, err = New(WithMode("json"), WithContent(content), WithRejectDuplicateKeys(true))
+	require.Error(t, err)
+	var dup *DuplicateKeyError
+	require.True(t, errors.As(err, &dup), "error should expose DuplicateKeyError: %v", err)
+	require.Equal(t, "server.port", dup.Key)
+	require.Equal(t, 3, dup.FirstLine)
+	require.Equal(t, 4, dup.SecondLine)
+	require.Contains(t, err.Error(), "line 4")
+}
+
+func TestRejectDuplicateKeysFromFile(t *testing.T) {
+	for _, engine := range []Engine{CompatibilityEngine, NativeEngine} {
+		t.Run(engine.String(), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			content := "app:\n  name: a\nlist:\n  - x: 1\n    x: 2\n"
+			require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "dup.yaml"), []byte(content), 0o644))
+
+			_, err := New(WithEngine(engine), WithPath(tmpDir), WithName("dup"), WithMode("yaml"), WithRejectDuplicateKeys(true))
+			require.Error(t, err)
+			var dup *DuplicateKeyError
+			require.True(t, errors.As(err, &dup), "error should expose DuplicateKeyError: %v", err)
+			require.Equal(t, "list.x", dup.Key)
+			require.Equal(t, 4, dup.FirstLine)
+			require.Equal(t, 5, dup.SecondLine)
+		})
+	}
+}
+
+func TestFindDuplicateKeyIgnoresMergeKeys(t *testing.T) {
+	content := "base: &base\n  a: 1\nchild:\n  <<: *base\n  b: 2\nother:\n  <<: *base\n"
+	dup, err := findDuplicateKey([]byte(content), "yaml")
+	require.NoError(t, err)
+	require.Nil(t, dup)
+
+	dup, err = findDuplicateKey([]byte(`{"a": [{"b": 1, "c": {"d": 1, "d": 2}}]}`), "json")
+	require.NoError(t, err)
+	require.NotNil(t, dup)
+	require.Equal(t, "a.c.d", dup.Key)
+}
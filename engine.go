@@ -0,0 +1,222 @@
+package sysconf
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/cast"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// Engine 配置存储引擎类型
+type Engine int
+
+const (
+	// CompatibilityEngine viper 兼容引擎（默认），文件读写、环境变量与命令行绑定均经由 viper 完成
+	CompatibilityEngine Engine = iota
+	// NativeEngine 原生引擎，完全绕过 viper，使用内置的 yaml/json/toml 解析器与写入器
+	NativeEngine
+)
+
+// nativeSupportedModes 原生引擎支持的配置格式
+var nativeSupportedModes = []string{"yaml", "yml", "json", "jsonc", "toml", "ini", "properties", "props", "prop", "dotenv", "env"}
+
+// String 返回引擎名称
+func (e Engine) String() string {
+	switch e {
+	case NativeEngine:
+		return "native"
+	default:
+		return "compatibility"
+	}
+}
+
+// WithEngine 设置配置存储引擎。
+// NativeEngine 下 Viper() 返回 nil，仅支持 yaml/json/jsonc/toml/ini/properties 格式。
+func WithEngine(engine Engine) Option {
+	return func(c *Config) {
+		c.engine = engine
+	}
+}
+
+// Engine 返回当前使用的存储引擎
+func (c *Config) Engine() Engine {
+	return c.engine
+}
+
+// isNative 是否使用原生引擎
+func (c *Config) isNative() bool {
+	return c.engine == NativeEngine
+}
+
+// viperSet 在兼容引擎下同步写入 viper
+func (c *Config) viperSet(key string, value any) {
+	if c.viper == nil {
+		return
+	}
+	c.viper.Set(key, value)
+	if c.viperOverrides == nil {
+		c.viperOverrides = make(map[string]struct{})
+	}
+	c.viperOverrides[key] = struct{}{}
+}
+
+// validateNativeMode 校验原生引擎支持的配置格式
+func (c *Config) validateNativeMode() error {
+	if slices.Contains(nativeSupportedModes, c.mode) {
+		return nil
+	}
+	return fmt.Errorf("unsupported config mode for native engine: %s (supported: %s)",
+		c.mode, strings.Join(nativeSupportedModes, ", "))
+}
+
+// loadNativeBytesUnsafe 使用原生解析器加载配置内容（调用者需持有 mu）
+func (c *Config) loadNativeBytesUnsafe(data []byte) error {
+	flatData, err := c.flatContent(data)
+	if err != nil {
+		return err
+	}
+
+	c.applyNativeFlags(flatData)
+	c.storeData(flatData)
+	return nil
+}
+
+// loadNativeConfigUnsafe 原生引擎下读取或创建配置文件（调用者需持有 mu）
+func (c *Config) loadNativeConfigUnsafe() error {
+	if c.name == "" {
+		if c.content == "" {
+			c.applyNativeFlagsToStore()
+			return nil
+		}
+		c.logger.Infof("Memory-only mode: skipping file operations")
+		if err := c.loadNativeBytesUnsafe([]byte(c.content)); err != nil {
+			return c.wrapError(fmt.Errorf("read config from memory: %w", err), "创建内存配置")
+		}
+		return nil
+	}
+
+	err := c.readConfigFileUnsafe()
+	if err == nil {
+		c.logger.Infof("Successfully loaded config file: %s", c.configFilePath())
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		c.logger.Errorf("Failed to read config file: %v", err)
+		return c.wrapError(err, "读取配置文件")
+	}
+
+	c.logger.Infof("Config file not found, creating default config")
+	if c.content == "" {
+		c.applyNativeFlagsToStore()
+		return nil
+	}
+	if err := c.createDefaultConfigUnsafe(); err != nil {
+		return c.wrapError(err, "创建默认配置")
+	}
+	return nil
+}
+
+// applyNativeFlagsToStore 将命令行标志应用到当前存储
+func (c *Config) applyNativeFlagsToStore() {
+	if len(c.pflags) == 0 {
+		return
+	}
+	flatData := deepCloneMap(c.loadData())
+	c.applyNativeFlags(flatData)
+	c.storeData(flatData)
+}
+
+// applyNativeFlags 按来源优先级合并命令行标志：默认优先级下
+// 已修改的标志覆盖配置值，未修改的标志仅在配置缺失时提供默认值。
+func (c *Config) applyNativeFlags(flatData map[string]any) {
+	if len(c.pflags) == 0 {
+		return
+	}
+	base := c.baseSource()
+	origins := make(map[string]Source)
+	for _, flagSet := range c.pflags {
+		flagSet.VisitAll(func(f *pflag.Flag) {
+			if c.pflagOptions.OnlyChanged && !f.Changed {
+				return
+			}
+			if c.pflagOptions.Validate != nil {
+				if err := c.pflagOptions.Validate(f); err != nil {
+					c.logger.Errorf("Invalid flag %s: %v", f.Name, err)
+					return
+				}
+			}
+			key := c.flagKey(f)
+			source := SourceDefaults
+			if f.Changed {
+				source = SourceFlags
+			}
+			if _, exists := flatData[key]; exists && !c.outranks(source, base) {
+				return
+			}
+			value := nativeFlagValue(f)
+			flatData[key] = value
+			origins[key] = source
+		})
+	}
+	c.flagOrigins.Store(&origins)
+}
+
+// nativeFlagValue 根据标志类型转换为对应的 Go 值
+func nativeFlagValue(f *pflag.Flag) any {
+	raw := f.Value.String()
+	switch f.Value.Type() {
+	case "bool":
+		return cast.ToBool(raw)
+	case "int", "int8", "int16", "int32", "int64":
+		return cast.ToInt(raw)
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		return cast.ToUint(raw)
+	case "float32", "float64":
+		return cast.ToFloat64(raw)
+	case "duration":
+		return cast.ToDuration(raw)
+	case "stringSlice", "stringArray":
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			return sv.GetSlice()
+		}
+		return strings.Split(strings.Trim(raw, "[]"), ",")
+	case "intSlice":
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			return cast.ToIntSlice(sv.GetSlice())
+		}
+		return raw
+	default:
+		return raw
+	}
+}
+
+// marshalSettings 将配置序列化为指定格式
+func marshalSettings(settings map[string]any, mode string) ([]byte, error) {
+	switch mode {
+	case "yaml", "yml":
+		return yaml.Marshal(settings)
+	case "json", "jsonc":
+		return json.MarshalIndent(settings, "", "  ")
+	case "properties", "props", "prop":
+		return marshalProperties(settings)
+	case "ini":
+		return marshalINI(settings, nil, INIRepeatedKeys)
+	case "dotenv", "env":
+		return marshalDotenv(settings)
+	case "toml":
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(settings); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", mode)
+	}
+}
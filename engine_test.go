@@ -0,0 +1,120 @@
+package sysconf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNativeEngineMemoryFormats(t *testing.T) {
+	cases := map[string]string{
+		"yaml": "app:\n  name: native\n  port: 8080\n",
+		"json": `{"app": {"name": "native", "port": 8080}}`,
+		"toml": "[app]\nname = \"native\"\nport = 8080\n",
+	}
+
+	for mode, content := range cases {
+		t.Run(mode, func(t *testing.T) {
+			cfg, err := New(WithEngine(NativeEngine), WithMode(mode), WithContent(content))
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = cfg.Close() })
+
+			require.Equal(t, NativeEngine, cfg.Engine())
+			require.Nil(t, cfg.Viper())
+			require.Equal(t, "native", cfg.GetString("app.name"))
+			require.Equal(t, 8080, cfg.GetInt("app.port"))
+		})
+	}
+}
+
+func TestNativeEngineRejectsUnsupportedMode(t *testing.T) {
+	_, err := New(WithEngine(NativeEngine), WithMode("hcl"), WithContent("a = 1"))
+	require.Error(t, err)
+	require.True(t, IsConfigError(err))
+}
+
+func TestNativeEngineFilePersistence(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg, err := New(
+		WithEngine(NativeEngine),
+		WithPath(tmpDir),
+		WithName("native"),
+		WithMode("toml"),
+		WithContent("[server]\nport = 8080\n"),
+		WithWriteDebounceDelay(0),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, cfg.Set("server.host", "example.com"))
+	require.NoError(t, cfg.Close())
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "native.toml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "example.com")
+
+	reopened, err := New(WithEngine(NativeEngine), WithPath(tmpDir), WithName("native"), WithMode("toml"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = reopened.Close() })
+	require.Equal(t, "example.com", reopened.GetString("server.host"))
+	require.Equal(t, 8080, reopened.GetInt("server.port"))
+}
+
+func TestNativeEngineEnvAndFlags(t *testing.T) {
+	t.Setenv("NATIVE_APP_NAME", "from-env")
+
+	flags := pflag.NewFlagSet("native", pflag.ContinueOnError)
+	flags.Int("port", 80, "port")
+	flags.Bool("debug", false, "debug")
+	flags.String("region", "eu", "region")
+	require.NoError(t, flags.Parse([]string{"--port=9090"}))
+
+	cfg, err := New(
+		WithEngine(NativeEngine),
+		WithEnv("NATIVE"),
+		WithBindPFlags(flags),
+		WithContent("app:\n  name: file\nport: 8080\ndebug: true\n"),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	require.Equal(t, "from-env", cfg.GetString("app.name"))
+	require.Equal(t, 9090, cfg.GetInt("port"), "changed flag overrides config")
+	require.True(t, cfg.GetBool("debug"), "unchanged flag must not override config")
+	require.Equal(t, "eu", cfg.GetString("region"), "unchanged flag provides default")
+}
+
+func TestNativeEngineWatchReload(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "watch.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("key: initial\n"), 0o644))
+
+	cfg, err := New(
+		WithEngine(NativeEngine),
+		WithPath(tmpDir),
+		WithName("watch"),
+		WithMode("yaml"),
+		WithWatchDebounce(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	changed := make(chan struct{}, 4)
+	stop := cfg.WatchWithContext(context.Background(), func() { changed <- struct{}{} })
+	t.Cleanup(stop)
+
+	require.NoError(t, os.WriteFile(configFile, []byte("key: updated\n"), 0o644))
+	select {
+	case <-changed:
+	case <-time.After(3 * time.Second):
+		t.Fatal("native watcher did not deliver change event")
+	}
+	require.Eventually(t, func() bool {
+		return strings.EqualFold(cfg.GetString("key"), "updated")
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,113 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidEnumValue 配置值不是已注册枚举中的名称
+var ErrInvalidEnumValue = errors.New("invalid enum value")
+
+// enumInfo 已注册枚举类型的名称映射
+type enumInfo struct {
+	typ    reflect.Type
+	values map[string]any // 小写名称 → 枚举值
+	names  []string       // 已排序的原始名称，用于错误提示
+}
+
+var (
+	enumTypes sync.Map // map[reflect.Type]*enumInfo
+	enumKeys  sync.Map // map[string]*enumInfo，配置键 → 枚举
+)
+
+// RegisterEnum 为自定义枚举类型（如基于 iota 的 LogLevel）注册名称映射，
+// 使 GetAs/Unmarshal 可将配置中的字符串（大小写不敏感）直接解码为 T。
+// key 非空时同时对该配置键的 Set 进行校验；未知名称返回列出可选值的 "must be one of" 错误。
+//
+//	sysconf.RegisterEnum("log.level", map[string]LogLevel{"debug": Debug, "info": Info})
+func RegisterEnum[T comparable](key string, names map[string]T) {
+	info := &enumInfo{
+		typ:    reflect.TypeFor[T](),
+		values: make(map[string]any, len(names)),
+		names:  slices.Sorted(maps.Keys(names)),
+	}
+	for name, value := range names {
+		info.values[strings.ToLower(name)] = value
+	}
+	enumTypes.Store(info.typ, info)
+	if key != "" {
+		enumKeys.Store(key, info)
+	}
+}
+
+// lookupEnumType 返回类型对应的已注册枚举
+func lookupEnumType(typ reflect.Type) (*enumInfo, bool) {
+	if info, ok := enumTypes.Load(typ); ok {
+		return info.(*enumInfo), true
+	}
+	return nil, false
+}
+
+// lookupEnumKey 返回配置键对应的已注册枚举
+func lookupEnumKey(key string) (*enumInfo, bool) {
+	if info, ok := enumKeys.Load(key); ok {
+		return info.(*enumInfo), true
+	}
+	return nil, false
+}
+
+// parse 将名称或数值转换为枚举值，key 仅用于错误信息
+func (e *enumInfo) parse(key string, val any) (any, error) {
+	if s, ok := val.(string); ok {
+		if value, found := e.values[strings.ToLower(strings.TrimSpace(s))]; found {
+			return value, nil
+		}
+		return nil, e.invalid(key, val)
+	}
+
+	// 已是枚举类型或可转换的数值时，要求其为已注册的值
+	rv := reflect.ValueOf(val)
+	if rv.IsValid() && rv.Type().ConvertibleTo(e.typ) && rv.Kind() != reflect.String {
+		converted := rv.Convert(e.typ).Interface()
+		for _, value := range e.values {
+			if value == converted {
+				return converted, nil
+			}
+		}
+	}
+	return nil, e.invalid(key, val)
+}
+
+// invalid 构造列出可选名称的错误
+func (e *enumInfo) invalid(key string, val any) error {
+	if key == "" {
+		key = "value"
+	}
+	return fmt.Errorf("%s must be one of [%s], got %v: %w", key, strings.Join(e.names, ", "), val, ErrInvalidEnumValue)
+}
+
+// enumDecodeHookFunc Unmarshal 使用的解码钩子，将名称解码为已注册的枚举类型
+func enumDecodeHookFunc() func(reflect.Type, reflect.Type, any) (any, error) {
+	return func(_ reflect.Type, to reflect.Type, data any) (any, error) {
+		info, ok := lookupEnumType(to)
+		if !ok {
+			return data, nil
+		}
+		return info.parse("", data)
+	}
+}
+
+// validateEnumKey 校验写入已注册枚举键的值
+func validateEnumKey(key string, value any) error {
+	info, ok := lookupEnumKey(key)
+	if !ok {
+		return nil
+	}
+	_, err := info.parse(key, value)
+	return err
+}
@@ -0,0 +1,68 @@
+package sysconf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+type testLogLevel int
+
+const (
+	testLevelDebug testLogLevel = iota
+	testLevelInfo
+	testLevelWarn
+)
+
+func TestRegisterEnum(t *testing.T) {
+	RegisterEnum("log.level", map[string]testLogLevel{
+		"debug": testLevelDebug,
+		"info":  testLevelInfo,
+		"warn":  testLevelWarn,
+	})
+
+	cfg, err := New(WithContent("log:\n  level: WARN\n  fallback: 1\n  bogus: verbose\n"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := GetAs[testLogLevel](cfg, "log.level"); got != testLevelWarn {
+		t.Fatalf("expected warn, got %v", got)
+	}
+	if got := GetAs[testLogLevel](cfg, "log.fallback"); got != testLevelInfo {
+		t.Fatalf("registered numeric value should decode, got %v", got)
+	}
+	_, err = GetAsWithError[testLogLevel](cfg, "log.bogus")
+	if !errors.Is(err, ErrInvalidEnumValue) || !strings.Contains(err.Error(), "must be one of [debug, info, warn]") {
+		t.Fatalf("expected must-be-one-of error, got %v", err)
+	}
+
+	var target struct {
+		Level testLogLevel `config:"level"`
+	}
+	if err := cfg.Unmarshal(&target, "log"); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if target.Level != testLevelWarn {
+		t.Fatalf("expected warn after unmarshal, got %v", target.Level)
+	}
+	var bad struct {
+		Bogus testLogLevel `config:"bogus"`
+	}
+	if err := cfg.Unmarshal(&bad, "log"); err == nil || !strings.Contains(err.Error(), "must be one of") {
+		t.Fatalf("expected unmarshal enum error, got %v", err)
+	}
+
+	if err := cfg.Set("log.level", "verbose"); !errors.Is(err, ErrInvalidEnumValue) {
+		t.Fatalf("expected enum validation error on set, got %v", err)
+	}
+	if err := cfg.Set("log.level", "debug"); err != nil {
+		t.Fatalf("set valid enum failed: %v", err)
+	}
+	if got := GetAs[testLogLevel](cfg, "log.level"); got != testLevelDebug {
+		t.Fatalf("expected debug, got %v", got)
+	}
+}
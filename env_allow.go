@@ -0,0 +1,32 @@
+package sysconf
+
+import "strings"
+
+// WithEnvKeyAllowPatterns 限定可由环境变量覆盖的配置键，例如 WithEnvKeyAllowPatterns("database.*", "log.level")。
+// 模式语法与 WatchKeysGlob 相同（匹配某个键同时覆盖其子键）。设置后只有匹配的键会查找环境变量，
+// 其他键即使存在同名环境变量（如未设置前缀时的 HOST → host）也只从配置数据读取；
+// 兼容引擎不再将环境变量整体合并进配置数据，改为在读取时按模式查找。可多次调用叠加模式。
+// 单次读取不需要环境变量时也可使用 GetStringE 等 E 系列方法的 FromSource(FileOnly) 选项。
+func WithEnvKeyAllowPatterns(patterns ...string) Option {
+	return func(c *Config) {
+		for _, pattern := range patterns {
+			if pattern = strings.Trim(strings.TrimSpace(pattern), "."); pattern != "" {
+				c.envAllow = append(c.envAllow, strings.Split(pattern, "."))
+			}
+		}
+	}
+}
+
+// envKeyAllowed 判断配置键是否允许从环境变量读取，未设置允许模式时全部允许
+func (c *Config) envKeyAllowed(key string) bool {
+	if len(c.envAllow) == 0 {
+		return true
+	}
+	segments := strings.Split(key, ".")
+	for _, pattern := range c.envAllow {
+		if matchKeyPattern(pattern, segments) {
+			return true
+		}
+	}
+	return false
+}
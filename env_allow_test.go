@@ -0,0 +1,52 @@
+package sysconf
+
+import (
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestEnvKeyAllowPatterns(t *testing.T) {
+	t.Setenv("HOST", "leaked.example.com")
+	t.Setenv("DATABASE_HOST", "db.internal")
+	t.Setenv("LOG_LEVEL", "debug")
+
+	for _, engine := range []Engine{CompatibilityEngine, NativeEngine} {
+		t.Run(engine.String(), func(t *testing.T) {
+			cfg, err := New(
+				WithEngine(engine),
+				WithMode("yaml"),
+				WithContent("host: localhost\ndatabase:\n  host: localhost\nlog:\n  level: info\n"),
+				WithEnv(""),
+				WithEnvKeyAllowPatterns("database.*"),
+			)
+			if err != nil {
+				t.Fatalf("create config failed: %v", err)
+			}
+			testutil.Cleanup(t, cfg.Close)
+
+			if got := cfg.GetString("host"); got != "localhost" {
+				t.Fatalf("HOST must not leak into host, got %q", got)
+			}
+			if got := cfg.GetString("log.level"); got != "info" {
+				t.Fatalf("LOG_LEVEL must not override log.level, got %q", got)
+			}
+			if got := cfg.GetString("database.host"); got != "db.internal" {
+				t.Fatalf("expected allowed env override, got %q", got)
+			}
+			if got, _ := cfg.GetStringE("database.host", FromSource(FileOnly)); got != "localhost" {
+				t.Fatalf("expected file value with FileOnly, got %q", got)
+			}
+
+			var db struct {
+				Host string `config:"host"`
+			}
+			if err := cfg.Unmarshal(&db, "database"); err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+			if db.Host != "db.internal" {
+				t.Fatalf("expected allowed override in unmarshal, got %q", db.Host)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package sysconf
+
+import (
+	"slices"
+	"strings"
+)
+
+// envSection 一个配置子树与其环境变量前缀的映射
+type envSection struct {
+	prefix string // 环境变量前缀，如 "DB"
+	key    string // 配置子树，如 "database"
+}
+
+// WithEnvSectionPrefix 将环境变量前缀映射到配置子树，例如
+//
+//	sysconf.WithEnvSectionPrefix("DB", "database") // DB_HOST → database.host
+//	sysconf.WithEnvSectionPrefix("HTTP", "server") // HTTP_PORT → server.port
+//
+// 可多次调用叠加映射，嵌套子树时更深的映射优先；分区前缀优先于 WithEnv 设置的全局前缀。
+// 大小写匹配规则同 WithEnv 的 SmartCase。仅设置分区前缀而未启用 WithEnv 时，
+// 只有分区前缀下的环境变量参与覆盖。
+func WithEnvSectionPrefix(prefix, key string) Option {
+	return func(c *Config) {
+		prefix = strings.Trim(strings.TrimSpace(prefix), "_")
+		key = strings.Trim(strings.TrimSpace(key), ".")
+		if prefix == "" || key == "" {
+			return
+		}
+		c.envSections = slices.DeleteFunc(c.envSections, func(s envSection) bool { return s.key == key })
+		c.envSections = append(c.envSections, envSection{prefix: prefix, key: key})
+		slices.SortStableFunc(c.envSections, func(a, b envSection) int {
+			return len(b.key) - len(a.key)
+		})
+	}
+}
+
+// sectionEnvKeys 返回配置键在所属分区前缀下可能对应的环境变量名，按分区由深到浅排列
+func (c *Config) sectionEnvKeys(opts EnvOptions, key string) []string {
+	var result []string
+	for _, section := range c.envSections {
+		rest, ok := strings.CutPrefix(key, section.key+".")
+		if !ok {
+			continue
+		}
+		prefixes := []string{strings.ToUpper(section.prefix)}
+		if opts.SmartCase {
+			lower := strings.ToLower(section.prefix)
+			prefixes = append(prefixes, lower, titleCaseEnv(lower))
+		}
+		bases := c.deriveEnvKeys(EnvOptions{SmartCase: opts.SmartCase}, rest)
+		for _, prefix := range slices.Compact(prefixes) {
+			for _, base := range bases {
+				result = append(result, prefix+"_"+base)
+			}
+		}
+	}
+	return result
+}
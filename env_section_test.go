@@ -0,0 +1,82 @@
+package sysconf
+
+import (
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestEnvSectionPrefix(t *testing.T) {
+	t.Setenv("DB_HOST", "db.internal")
+	t.Setenv("DB_POOL_SIZE", "20")
+	t.Setenv("HTTP_PORT", "9090")
+	t.Setenv("DATABASE_USER", "ignored")
+
+	cfg, err := New(
+		WithMode("yaml"),
+		WithContent("database:\n  host: localhost\n  user: app\n  pool:\n    size: 5\nserver:\n  port: 8080\n"),
+		WithEnvSectionPrefix("DB", "database"),
+		WithEnvSectionPrefix("HTTP", "server"),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("database.host"); got != "db.internal" {
+		t.Fatalf("expected DB_HOST override, got %q", got)
+	}
+	if got := cfg.GetInt("database.pool.size"); got != 20 {
+		t.Fatalf("expected DB_POOL_SIZE override, got %d", got)
+	}
+	if got := cfg.GetInt("server.port"); got != 9090 {
+		t.Fatalf("expected HTTP_PORT override, got %d", got)
+	}
+	// 未启用 WithEnv 时不按全局规则匹配
+	if got := cfg.GetString("database.user"); got != "app" {
+		t.Fatalf("expected file value without global env, got %q", got)
+	}
+
+	var db struct {
+		Host string `config:"host"`
+		Pool struct {
+			Size int `config:"size"`
+		} `config:"pool"`
+	}
+	if err := cfg.Unmarshal(&db, "database"); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if db.Host != "db.internal" || db.Pool.Size != 20 {
+		t.Fatalf("unexpected unmarshal result: %+v", db)
+	}
+}
+
+func TestEnvSectionPrefixWithGlobalPrefix(t *testing.T) {
+	t.Setenv("DB_HOST", "from-section")
+	t.Setenv("APP_DATABASE_HOST", "from-global")
+	t.Setenv("APP_DATABASE_PORT", "6543")
+	t.Setenv("DB_REPLICA_HOST", "replica-section")
+	t.Setenv("RO_HOST", "replica-nested")
+
+	cfg, err := New(
+		WithMode("yaml"),
+		WithContent("database:\n  host: localhost\n  port: 5432\n  replica:\n    host: r1\n"),
+		WithEnv("APP"),
+		WithEnvSectionPrefix("DB", "database"),
+		WithEnvSectionPrefix("RO", "database.replica"),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("database.host"); got != "from-section" {
+		t.Fatalf("expected section prefix to win over global prefix, got %q", got)
+	}
+	if got := cfg.GetInt("database.port"); got != 6543 {
+		t.Fatalf("expected global prefix fallback, got %d", got)
+	}
+	if got := cfg.GetString("database.replica.host"); got != "replica-nested" {
+		t.Fatalf("expected deeper section to win, got %q", got)
+	}
+}
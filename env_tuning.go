@@ -0,0 +1,84 @@
+package sysconf
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// 库自身调优参数对应的环境变量。New 在应用显式选项之前读取这些变量，因此显式选项始终优先；
+// 平台团队可据此在不修改代码的情况下统一调整整个集群的行为。
+const (
+	EnvTuningWriteDelay        = "SYSCONF_WRITE_DELAY"         // 写入防抖延迟，同 WithWriteDebounceDelay（如 "500ms"）
+	EnvTuningWatchDebounce     = "SYSCONF_WATCH_DEBOUNCE"      // 文件监听防抖时间，同 WithWatchDebounce
+	EnvTuningCacheWarmupDelay  = "SYSCONF_CACHE_WARMUP_DELAY"  // 读取缓存预热延迟，同 WithCacheTiming 的 warmup
+	EnvTuningCacheRebuildDelay = "SYSCONF_CACHE_REBUILD_DELAY" // 读取缓存重建延迟，同 WithCacheTiming 的 rebuild
+	EnvTuningLookupTTL         = "SYSCONF_LOOKUP_TTL"          // 环境变量查询缓存时长，同 WithLookupTTL
+	EnvTuningYAMLAliasLimit    = "SYSCONF_YAML_ALIAS_LIMIT"    // YAML 别名展开节点上限，同 WithYAMLAliasLimit
+	EnvTuningFileLockTimeout   = "SYSCONF_FILE_LOCK_TIMEOUT"   // 设置后启用写入文件锁并使用该超时，同 WithFileLock
+	EnvTuningStreamThreshold   = "SYSCONF_STREAM_THRESHOLD"    // 流式加密阈值（字节），同 WithEncryptionStreamThreshold
+)
+
+// envTuningKnob 一个调优环境变量及其解析方式
+type envTuningKnob struct {
+	name  string
+	apply func(c *Config, value string) error
+}
+
+// envTuningKnobs 支持的调优环境变量
+var envTuningKnobs = []envTuningKnob{
+	{EnvTuningWriteDelay, durationKnob(func(c *Config, d time.Duration) { WithWriteDebounceDelay(d)(c) })},
+	{EnvTuningWatchDebounce, durationKnob(func(c *Config, d time.Duration) { WithWatchDebounce(d)(c) })},
+	{EnvTuningCacheWarmupDelay, durationKnob(func(c *Config, d time.Duration) {
+		WithCacheTiming(d, c.cacheRebuildDelay)(c)
+	})},
+	{EnvTuningCacheRebuildDelay, durationKnob(func(c *Config, d time.Duration) {
+		WithCacheTiming(c.cacheWarmupDelay, d)(c)
+	})},
+	{EnvTuningLookupTTL, durationKnob(func(c *Config, d time.Duration) { WithLookupTTL(d)(c) })},
+	{EnvTuningYAMLAliasLimit, func(c *Config, value string) error {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		WithYAMLAliasLimit(limit)(c)
+		return nil
+	}},
+	{EnvTuningFileLockTimeout, durationKnob(func(c *Config, d time.Duration) { WithFileLock(d)(c) })},
+	{EnvTuningStreamThreshold, func(c *Config, value string) error {
+		size, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		WithEncryptionStreamThreshold(size)(c)
+		return nil
+	}},
+}
+
+// durationKnob 构造解析 time.Duration 的调优参数
+func durationKnob(set func(c *Config, d time.Duration)) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		set(c, d)
+		return nil
+	}
+}
+
+// applyEnvTuning 读取 SYSCONF_* 调优环境变量，返回无法解析而被忽略的变量说明
+func (c *Config) applyEnvTuning() []string {
+	var invalid []string
+	for _, knob := range envTuningKnobs {
+		value, ok := os.LookupEnv(knob.name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := knob.apply(c, value); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s=%q: %v", knob.name, value, err))
+		}
+	}
+	return invalid
+}
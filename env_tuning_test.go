@@ -0,0 +1,53 @@
+package sysconf
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+// warnRecorder 记录 Warnf 输出的测试日志器
+type warnRecorder struct {
+	NopLogger
+	mu    sync.Mutex
+	warns []string
+}
+
+func (l *warnRecorder) Warnf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warns = append(l.warns, fmt.Sprintf(format, args...))
+}
+
+func TestEnvTuningKnobs(t *testing.T) {
+	t.Setenv(EnvTuningWriteDelay, "2s")
+	t.Setenv(EnvTuningCacheRebuildDelay, "75ms")
+	t.Setenv(EnvTuningLookupTTL, "1m")
+	t.Setenv(EnvTuningFileLockTimeout, "3s")
+	t.Setenv(EnvTuningYAMLAliasLimit, "lots")
+
+	logger := &warnRecorder{}
+	cfg, err := New(WithContent("a: 1\n"), WithLogger(logger), WithCacheTiming(0, 0))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if cfg.writeDelay != 2*time.Second || cfg.lookupTTL != time.Minute || cfg.fileLockTimeout != 3*time.Second {
+		t.Fatalf("env knobs not applied: write=%v ttl=%v lock=%v", cfg.writeDelay, cfg.lookupTTL, cfg.fileLockTimeout)
+	}
+	// 显式选项覆盖环境变量
+	if cfg.cacheRebuildDelay != 0 {
+		t.Fatalf("explicit option should override env knob, got %v", cfg.cacheRebuildDelay)
+	}
+	if cfg.yamlAliasLimit != 0 {
+		t.Fatalf("invalid knob should be ignored, got %d", cfg.yamlAliasLimit)
+	}
+	if len(logger.warns) != 1 || !strings.Contains(logger.warns[0], EnvTuningYAMLAliasLimit) {
+		t.Fatalf("expected warning for invalid knob, got %v", logger.warns)
+	}
+}
@@ -0,0 +1,226 @@
+package sysconf
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// envelopeMagic 加密信封头部的魔数，位于加密文件第一行开头
+const envelopeMagic = "SYSCONF-ENC"
+
+// envelopeVersion 当前写入的信封格式版本
+const envelopeVersion = 1
+
+// ErrEncryptionMismatch 加密文件的算法或密钥标识与当前配置的加密器不一致
+var ErrEncryptionMismatch = errors.New("encryption mismatch")
+
+// EnvelopeHeader 加密配置文件的明文头部，无需密钥即可读取，便于工具识别文件与后续算法迁移。
+// 文件第一行形如：
+//
+//	SYSCONF-ENC v=1 alg=chacha20-poly1305 kid=3f2a9c01d4e5b6a7 created=2026-01-02T15:04:05Z format=yaml
+//
+// 其后为加密器输出的密文。
+type EnvelopeHeader struct {
+	Version   int       // 信封格式版本
+	Algorithm string    // 加密算法名称
+	KeyID     string    // 密钥标识（经慢速派生的密钥指纹，不可逆推密钥）；加密器未提供时为空
+	CreatedAt time.Time // 加密时间
+	Format    string    // 明文配置格式（yaml、json 等）
+	Stream    bool      // 密文是否为流式分块格式（二进制，需加密器实现 StreamCrypto）
+}
+
+// KeyIDProvider 可由自定义加密器实现，为信封头部提供密钥标识
+type KeyIDProvider interface {
+	KeyID() string
+}
+
+// AlgorithmProvider 可由自定义加密器实现，为信封头部提供算法名称
+type AlgorithmProvider interface {
+	Algorithm() string
+}
+
+// keyIDSalt 派生密钥标识使用的固定上下文，与加密时的随机盐值区分
+var keyIDSalt = []byte("sysconf-key-id/v1")
+
+// KeyID 返回密钥标识：以固定上下文对密钥执行与加密相同参数的 Argon2id 派生，取前 8 字节（十六进制）。
+// 密码派生的密钥本身只是密码的快速摘要，若直接哈希，持有密文的人可借助头部中的标识离线猜测密码；
+// 经 Argon2id 派生后每次猜测的代价与解密相同。结果在首次调用后缓存。
+func (d *DefaultCrypto) KeyID() string {
+	d.keyIDOnce.Do(func() {
+		sum := argon2.IDKey(d.key, keyIDSalt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+		d.keyID = hex.EncodeToString(sum[:8])
+	})
+	return d.keyID
+}
+
+// Algorithm 返回算法名称
+func (d *DefaultCrypto) Algorithm() string {
+	return "chacha20-poly1305"
+}
+
+// cryptoKeyID 返回加密器的密钥标识，未实现 KeyIDProvider 时为空
+func cryptoKeyID(crypto ConfigCrypto) string {
+	if p, ok := crypto.(KeyIDProvider); ok {
+		return p.KeyID()
+	}
+	return ""
+}
+
+// ReadEnvelopeHeader 读取加密配置文件的信封头部；data 不是带信封的 sysconf 加密文件时返回 false。
+// 早期版本写入的无头部密文同样返回 false。
+func ReadEnvelopeHeader(data []byte) (EnvelopeHeader, bool) {
+	header, _, err := openEnvelope(data)
+	if err != nil {
+		return EnvelopeHeader{}, false
+	}
+	return header, true
+}
+
+// hasEnvelope 判断数据是否以信封魔数开头
+func hasEnvelope(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(envelopeMagic+" "))
+}
+
+// sealEnvelope 在密文前加上信封头部
+func sealEnvelope(header EnvelopeHeader, payload []byte) []byte {
+	line := envelopeHeaderLine(header)
+	buf := make([]byte, 0, len(line)+len(payload))
+	return append(append(buf, line...), payload...)
+}
+
+// envelopeHeaderLine 生成以换行结尾的信封头部行
+func envelopeHeaderLine(header EnvelopeHeader) []byte {
+	fields := []string{
+		envelopeMagic,
+		"v=" + strconv.Itoa(header.Version),
+		"alg=" + header.Algorithm,
+	}
+	if header.KeyID != "" {
+		fields = append(fields, "kid="+header.KeyID)
+	}
+	fields = append(fields, "created="+header.CreatedAt.UTC().Format(time.RFC3339))
+	if header.Format != "" {
+		fields = append(fields, "format="+header.Format)
+	}
+	if header.Stream {
+		fields = append(fields, "stream=1")
+	}
+	return []byte(strings.Join(fields, " ") + "\n")
+}
+
+// openEnvelope 解析信封头部并返回其后的密文；未知字段被忽略以兼容后续扩展
+func openEnvelope(data []byte) (EnvelopeHeader, []byte, error) {
+	var header EnvelopeHeader
+	if !hasEnvelope(data) {
+		return header, nil, errors.New("missing encryption envelope")
+	}
+	line, payload, _ := bytes.Cut(data, []byte("\n"))
+	for _, field := range strings.Fields(string(bytes.TrimSpace(line)))[1:] {
+		name, value, _ := strings.Cut(field, "=")
+		switch name {
+		case "v":
+			v, err := strconv.Atoi(value)
+			if err != nil {
+				return header, nil, fmt.Errorf("invalid envelope version %q", value)
+			}
+			header.Version = v
+		case "alg":
+			header.Algorithm = value
+		case "kid":
+			header.KeyID = value
+		case "created":
+			if t, err := time.Parse(time.RFC3339, value); err == nil {
+				header.CreatedAt = t
+			}
+		case "format":
+			header.Format = value
+		case "stream":
+			header.Stream = value == "1"
+		}
+	}
+	if header.Version < 1 || header.Version > envelopeVersion {
+		return header, nil, fmt.Errorf("unsupported envelope version %d", header.Version)
+	}
+	if header.Stream {
+		// 流式密文为二进制，不能去除首尾空白
+		return header, payload, nil
+	}
+	return header, bytes.TrimSpace(payload), nil
+}
+
+// encryptContent 加密配置内容并加上信封头部
+func (c *Config) encryptContent(data []byte) ([]byte, error) {
+	payload, err := c.crypto.Encrypt(data)
+	if err != nil {
+		return nil, err
+	}
+	return sealEnvelope(EnvelopeHeader{
+		Version:   envelopeVersion,
+		Algorithm: cryptoTypeName(c.crypto),
+		KeyID:     cryptoKeyID(c.crypto),
+		CreatedAt: time.Now(),
+		Format:    c.mode,
+	}, payload), nil
+}
+
+// decryptContent 使用当前加密器解密配置内容，详见 decryptWith
+func (c *Config) decryptContent(data []byte) (plain []byte, encrypted bool, err error) {
+	if err := c.injectFault(FaultDecrypt, c.configFilePath()); err != nil {
+		return nil, true, err
+	}
+	return decryptWith(c.crypto, data)
+}
+
+// checkEnvelopeKey 核对信封头部记录的算法与密钥标识是否与加密器一致，不一致时返回 ErrEncryptionMismatch
+func checkEnvelopeKey(crypto ConfigCrypto, header EnvelopeHeader) error {
+	if alg := cryptoTypeName(crypto); header.Algorithm != "" && header.Algorithm != alg {
+		return fmt.Errorf("%w: encrypted with algorithm %s, configured algorithm %s",
+			ErrEncryptionMismatch, header.Algorithm, alg)
+	}
+	if kid := cryptoKeyID(crypto); header.KeyID != "" && kid != "" && header.KeyID != kid {
+		return fmt.Errorf("%w: encrypted with key id %s, provided key id %s",
+			ErrEncryptionMismatch, header.KeyID, kid)
+	}
+	return nil
+}
+
+// decryptWith 解密配置内容；encrypted 为 false 表示内容为明文。
+// 带信封的内容会先核对算法与密钥标识，不一致时返回描述双方标识的 ErrEncryptionMismatch。
+func decryptWith(crypto ConfigCrypto, data []byte) (plain []byte, encrypted bool, err error) {
+	if hasEnvelope(data) {
+		header, payload, err := openEnvelope(data)
+		if err != nil {
+			return nil, true, err
+		}
+		if err := checkEnvelopeKey(crypto, header); err != nil {
+			return nil, true, err
+		}
+		if header.Stream {
+			stream, ok := crypto.(StreamCrypto)
+			if !ok {
+				return nil, true, fmt.Errorf("%w: stream-encrypted file requires a StreamCrypto implementation", ErrEncryptionMismatch)
+			}
+			var buf bytes.Buffer
+			buf.Grow(len(payload))
+			if err := stream.DecryptStream(&buf, bytes.NewReader(payload)); err != nil {
+				return nil, true, err
+			}
+			return buf.Bytes(), true, nil
+		}
+		plain, err := crypto.Decrypt(payload)
+		return plain, true, err
+	}
+	// 兼容早期版本写入的无头部密文
+	if !crypto.IsEncrypted(data) {
+		return data, false, nil
+	}
+	plain, err = crypto.Decrypt(data)
+	return plain, true, err
+}
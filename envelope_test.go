@@ -0,0 +1,106 @@
+package sysconf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestEncryptionEnvelopeHeader(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg, err := New(WithPath(tmpDir), WithMode("yaml"), WithName("secure"),
+		WithContent("db:\n  password: s3cret\n"), WithEncryption("key-one"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	raw, err := os.ReadFile(filepath.Join(tmpDir, "secure.yaml"))
+	if err != nil {
+		t.Fatalf("read config failed: %v", err)
+	}
+	header, ok := ReadEnvelopeHeader(raw)
+	if !ok {
+		t.Fatalf("encrypted file should carry an envelope header: %q", raw[:min(len(raw), 80)])
+	}
+	want, _ := NewDefaultCrypto("key-one")
+	if header.Version != 1 || header.Algorithm != "chacha20-poly1305" || header.KeyID != want.KeyID() ||
+		header.Format != "yaml" || header.CreatedAt.IsZero() {
+		t.Fatalf("unexpected header: %+v", header)
+	}
+	if info, ok := cfg.FileInfo(); !ok || !info.Encrypted || info.KeyID != header.KeyID {
+		t.Fatalf("file info should report envelope key id, got %+v", info)
+	}
+	if got := cfg.GetString("db.password"); got != "s3cret" {
+		t.Fatalf("expected decrypted value, got %q", got)
+	}
+
+	_, err = New(WithPath(tmpDir), WithMode("yaml"), WithName("secure"), WithEncryption("key-two"))
+	if !errors.Is(err, ErrEncryptionMismatch) {
+		t.Fatalf("expected ErrEncryptionMismatch, got %v", err)
+	}
+	other, _ := NewDefaultCrypto("key-two")
+	if msg := err.Error(); !strings.Contains(msg, "encrypted with key id "+header.KeyID) ||
+		!strings.Contains(msg, "provided key id "+other.KeyID()) {
+		t.Fatalf("mismatch error should name both key ids: %v", err)
+	}
+}
+
+func TestEncryptionEnvelopeLegacyPayload(t *testing.T) {
+	tmpDir := t.TempDir()
+	crypto, _ := NewDefaultCrypto("legacy")
+	payload, err := crypto.Encrypt([]byte("name: old\n"))
+	if err != nil {
+		t.Fatalf("encrypt failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "old.yaml"), payload, 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	if _, ok := ReadEnvelopeHeader(payload); ok {
+		t.Fatalf("legacy payload should not report an envelope")
+	}
+
+	cfg, err := New(WithPath(tmpDir), WithMode("yaml"), WithName("old"), WithEncryption("legacy"))
+	if err != nil {
+		t.Fatalf("legacy encrypted file should still load: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if got := cfg.GetString("name"); got != "old" {
+		t.Fatalf("expected legacy value, got %q", got)
+	}
+
+	if _, _, err := openEnvelope([]byte("SYSCONF-ENC v=9 alg=x\npayload")); err == nil {
+		t.Fatalf("expected error for unsupported envelope version")
+	}
+}
+
+func TestDefaultCryptoKeyIDUsesSlowDerivation(t *testing.T) {
+	a, err := NewDefaultCrypto("correct horse")
+	if err != nil {
+		t.Fatalf("create crypto failed: %v", err)
+	}
+	b, _ := NewDefaultCrypto("correct horse")
+	other, _ := NewDefaultCrypto("battery staple")
+	if a.KeyID() != b.KeyID() || a.KeyID() == other.KeyID() {
+		t.Fatalf("key id should identify the key: %s %s %s", a.KeyID(), b.KeyID(), other.KeyID())
+	}
+
+	// 标识不能由密码的快速哈希直接算出，猜测密码须付出 Argon2id 的代价
+	password := sha256.Sum256([]byte("correct horse"))
+	fast := sha256.Sum256(append([]byte("sysconf-key-id:"), password[:]...))
+	if a.KeyID() == hex.EncodeToString(fast[:8]) {
+		t.Fatal("key id must not be a fast hash of the password")
+	}
+	slow := argon2.IDKey(password[:], keyIDSalt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+	if a.KeyID() != hex.EncodeToString(slow[:8]) {
+		t.Fatalf("key id should be derived with Argon2id, got %s", a.KeyID())
+	}
+}
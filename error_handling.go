@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 )
 
 // ConfigError 配置错误类型
@@ -14,14 +15,36 @@ type ConfigError struct {
 	Key     string `json:"key,omitempty"`
 	Value   string `json:"value,omitempty"`
 	File    string `json:"file,omitempty"`
+	Code    string `json:"code,omitempty"`     // 稳定错误码，为空时按 Type 推导（见 ErrorCode）
+	DocsURL string `json:"docs_url,omitempty"` // 修复文档地址，为空时按错误码生成（见 DocsLink）
 	Cause   error  `json:"-"`
 }
 
 func (e *ConfigError) Error() string {
 	if e.Cause != nil {
-		return fmt.Sprintf("%s: %s (caused by: %v)", e.Type, e.Message, e.Cause)
+		return fmt.Sprintf("[%s] %s: %s (caused by: %v)", e.ErrorCode(), e.Type, e.Message, e.Cause)
 	}
-	return fmt.Sprintf("%s: %s", e.Type, e.Message)
+	return fmt.Sprintf("[%s] %s: %s", e.ErrorCode(), e.Type, e.Message)
+}
+
+// ErrorCode 返回稳定错误码，如 SYSCONF-001，未设置 Code 时按错误类型推导
+func (e *ConfigError) ErrorCode() string {
+	if e.Code != "" {
+		return e.Code
+	}
+	return ErrorCodeFor(e.Type)
+}
+
+// DocsLink 返回修复文档地址：优先使用 DocsURL，其次为 SetErrorDocsBaseURL 设置的地址加错误码，
+// 否则指向 README 中对应的章节
+func (e *ConfigError) DocsLink() string {
+	if e.DocsURL != "" {
+		return e.DocsURL
+	}
+	if base := errorDocsBase.Load(); base != nil {
+		return *base + e.ErrorCode()
+	}
+	return errorDocs(e.Type)
 }
 
 func (e *ConfigError) Unwrap() error {
@@ -40,6 +63,40 @@ const (
 	ErrTypeInitialization = "Initialization"
 )
 
+// errorCodes 错误类型对应的稳定错误码，已发布的编号不再变更
+var errorCodes = map[string]string{
+	ErrTypeFileNotFound:   "SYSCONF-001",
+	ErrTypePermission:     "SYSCONF-002",
+	ErrTypeInvalidFormat:  "SYSCONF-003",
+	ErrTypeValidation:     "SYSCONF-004",
+	ErrTypeDecryption:     "SYSCONF-005",
+	ErrTypeConversion:     "SYSCONF-006",
+	ErrTypeEnvironment:    "SYSCONF-007",
+	ErrTypeInitialization: "SYSCONF-008",
+}
+
+// ErrorCodeFor 返回错误类型对应的稳定错误码，未知类型返回 SYSCONF-000
+func ErrorCodeFor(errorType string) string {
+	if code, ok := errorCodes[errorType]; ok {
+		return code
+	}
+	return "SYSCONF-000"
+}
+
+// errorDocsBase SetErrorDocsBaseURL 设置的文档基础地址
+var errorDocsBase atomic.Pointer[string]
+
+// SetErrorDocsBaseURL 设置错误文档的基础地址，之后 DocsLink 与 ErrorReport 返回该地址加错误码，
+// 如 "https://wiki.example.com/sysconf/" 生成 "https://wiki.example.com/sysconf/SYSCONF-004"；
+// 传入空字符串恢复为默认的 README 章节
+func SetErrorDocsBaseURL(base string) {
+	if base == "" {
+		errorDocsBase.Store(nil)
+		return
+	}
+	errorDocsBase.Store(&base)
+}
+
 // NewConfigError 创建新的配置错误
 func NewConfigError(errorType, message string) *ConfigError {
 	return &ConfigError{
@@ -154,7 +211,11 @@ func GetErrorSuggestion(err error) string {
 	if !errors.As(err, &configErr) {
 		return "请检查错误信息并重试"
 	}
+	return fmt.Sprintf("[%s] %s", configErr.ErrorCode(), errorSuggestion(configErr))
+}
 
+// errorSuggestion 按错误类型生成不含错误码的修复建议
+func errorSuggestion(configErr *ConfigError) string {
 	switch configErr.Type {
 	case ErrTypeFileNotFound:
 		return fmt.Sprintf("请确保配置文件 %s 存在，或使用 WithContent() 提供默认配置", configErr.File)
@@ -182,30 +243,116 @@ func GetErrorSuggestion(err error) string {
 	}
 }
 
-// PrintErrorHelp 打印错误帮助信息
-func PrintErrorHelp(err error) {
+// errorDocsURL 错误文档地址，按错误类型追加 README 中对应章节的锚点
+const errorDocsURL = "https://github.com/darkit/sysconf#"
+
+// errorDocsAnchors 错误类型对应的文档章节
+var errorDocsAnchors = map[string]string{
+	ErrTypeValidation:  "-智能验证系统",
+	ErrTypeDecryption:  "-高级加密功能",
+	ErrTypeEnvironment: "-环境变量与命令行集成",
+}
+
+// errorDocs 返回错误类型对应的文档地址，未知类型指向错误处理章节
+func errorDocs(errorType string) string {
+	if anchor, ok := errorDocsAnchors[errorType]; ok {
+		return errorDocsURL + anchor
+	}
+	return errorDocsURL + "3-错误处理"
+}
+
+// ErrorReport 返回错误的结构化描述，适合 JSON 日志与 API 错误响应。
+// 包含 error（完整错误文本）、type、code、message、suggestion 与 docs_url，
+// 以及非空的 key、value、file 和 cause；err 为 nil 时返回 nil。
+func ErrorReport(err error) map[string]any {
 	if err == nil {
+		return nil
+	}
+	report := map[string]any{
+		"error":      err.Error(),
+		"suggestion": GetErrorSuggestion(err),
+	}
+
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) {
+		report["type"] = ""
+		report["code"] = ErrorCodeFor("")
+		report["message"] = err.Error()
+		report["docs_url"] = (&ConfigError{}).DocsLink()
+		return report
+	}
+	report["type"] = configErr.Type
+	report["code"] = configErr.ErrorCode()
+	report["message"] = configErr.Message
+	report["docs_url"] = configErr.DocsLink()
+	if configErr.Key != "" {
+		report["key"] = configErr.Key
+	}
+	if configErr.Value != "" {
+		report["value"] = configErr.Value
+	}
+	if configErr.File != "" && configErr.File != "内存配置" {
+		report["file"] = configErr.File
+	}
+	if configErr.Cause != nil {
+		report["cause"] = configErr.Cause.Error()
+	}
+	return report
+}
+
+// PrintErrorHelp 输出错误帮助信息。传入 logger 时错误行以 Errorf、其余行以 Infof 记录，
+// 否则写入标准错误输出；设置 NO_COLOR 环境变量或使用 logger 时不带图标前缀
+func PrintErrorHelp(err error, logger ...Logger) {
+	if err == nil {
+		return
+	}
+
+	var out Logger
+	if len(logger) > 0 && logger[0] != nil {
+		out = logger[0]
+	}
+	plain := out != nil || os.Getenv("NO_COLOR") != ""
+	lines := errorHelpLines(err, plain)
+
+	if out != nil {
+		out.Errorf("%s", lines[0])
+		for _, line := range lines[1:] {
+			out.Infof("%s", line)
+		}
 		return
 	}
+	for _, line := range lines {
+		fmt.Fprintln(os.Stderr, line)
+	}
+}
 
-	fmt.Printf("❌ 配置错误: %v\n", err)
+// errorHelpLines 生成错误帮助文本，首行为错误本身，plain 为 true 时使用纯文本前缀
+func errorHelpLines(err error, plain bool) []string {
+	label := func(icon, text string) string {
+		if plain {
+			return text + ": "
+		}
+		return icon + " " + text + ": "
+	}
 
+	lines := []string{label("❌", "配置错误") + err.Error()}
 	if suggestion := GetErrorSuggestion(err); suggestion != "" {
-		fmt.Printf("💡 建议: %s\n", suggestion)
+		lines = append(lines, label("💡", "建议")+suggestion)
 	}
 
 	var configErr *ConfigError
 	if errors.As(err, &configErr) {
 		if configErr.File != "" && configErr.File != "内存配置" {
-			fmt.Printf("📁 文件: %s\n", configErr.File)
+			lines = append(lines, label("📁", "文件")+configErr.File)
 		}
 		if configErr.Key != "" {
-			fmt.Printf("🔑 配置键: %s\n", configErr.Key)
+			lines = append(lines, label("🔑", "配置键")+configErr.Key)
 		}
 		if configErr.Value != "" {
-			fmt.Printf("💾 配置值: %s\n", configErr.Value)
+			lines = append(lines, label("💾", "配置值")+configErr.Value)
 		}
 	}
+	return lines
 }
 
 // ErrorRecovery 错误恢复策略
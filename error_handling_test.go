@@ -1,11 +1,13 @@
 package sysconf
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -49,9 +51,9 @@ func TestRecoverFromErrorBranches(t *testing.T) {
 
 func TestPrintErrorHelpVariants(t *testing.T) {
 	r, w, _ := os.Pipe()
-	stdout := os.Stdout
-	defer func() { os.Stdout = stdout }()
-	os.Stdout = w
+	stderr := os.Stderr
+	defer func() { os.Stderr = stderr }()
+	os.Stderr = w
 
 	errs := []error{
 		NewConfigErrorWithDetails(ErrTypeValidation, "bad value", "k", "v", "f", nil),
@@ -96,3 +98,103 @@ func TestWrapErrorEnvAndConversion(t *testing.T) {
 		t.Fatalf("expected config file path")
 	}
 }
+
+func TestErrorReport(t *testing.T) {
+	if ErrorReport(nil) != nil {
+		t.Fatalf("nil error should produce nil report")
+	}
+
+	cause := errors.New("out of range")
+	err := fmt.Errorf("load: %w", NewConfigErrorWithDetails(ErrTypeValidation, "端口无效", "server.port", "70000", "app.yaml", cause))
+	report := ErrorReport(err)
+	for field, want := range map[string]any{
+		"type":     ErrTypeValidation,
+		"message":  "端口无效",
+		"key":      "server.port",
+		"value":    "70000",
+		"file":     "app.yaml",
+		"cause":    "out of range",
+		"error":    err.Error(),
+		"docs_url": errorDocs(ErrTypeValidation),
+	} {
+		if report[field] != want {
+			t.Fatalf("report[%q] = %v, want %v", field, report[field], want)
+		}
+	}
+	if report["suggestion"] == "" {
+		t.Fatalf("expected suggestion in report")
+	}
+	if _, err := json.Marshal(report); err != nil {
+		t.Fatalf("report should be JSON encodable: %v", err)
+	}
+
+	plain := ErrorReport(errors.New("boom"))
+	if plain["type"] != "" || plain["message"] != "boom" || plain["docs_url"] != errorDocs("") {
+		t.Fatalf("unexpected report for plain error: %v", plain)
+	}
+	if _, ok := plain["key"]; ok {
+		t.Fatalf("empty fields should be omitted: %v", plain)
+	}
+}
+
+func TestPrintErrorHelpLogger(t *testing.T) {
+	rec := &recordingLoggerV2{}
+	PrintErrorHelp(NewConfigErrorWithDetails(ErrTypeConversion, "conv", "k", "v", "", nil), &loggerV2Adapter{logger: rec})
+	if len(rec.logs) != 4 {
+		t.Fatalf("expected error, suggestion, key and value lines, got %+v", rec.logs)
+	}
+	if rec.logs[0].level != ErrorLevel || rec.logs[1].level != InfoLevel {
+		t.Fatalf("unexpected levels: %+v", rec.logs)
+	}
+	if !strings.HasPrefix(rec.logs[0].msg, "配置错误: ") || !strings.HasPrefix(rec.logs[2].msg, "配置键: k") {
+		t.Fatalf("logger output should use plain labels: %+v", rec.logs)
+	}
+}
+
+func TestPrintErrorHelpNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	r, w, _ := os.Pipe()
+	stderr := os.Stderr
+	defer func() { os.Stderr = stderr }()
+	os.Stderr = w
+	PrintErrorHelp(NewConfigError(ErrTypeDecryption, "fail"))
+	_ = w.Close()
+	out, _ := io.ReadAll(r)
+	if !strings.HasPrefix(string(out), "配置错误: ") || strings.Contains(string(out), "❌") {
+		t.Fatalf("NO_COLOR output should not contain icons: %q", out)
+	}
+}
+
+func TestConfigErrorCodes(t *testing.T) {
+	err := NewConfigErrorWithDetails(ErrTypeValidation, "bad port", "server.port", "0", "", nil)
+	if got := err.ErrorCode(); got != "SYSCONF-004" {
+		t.Fatalf("unexpected code %q", got)
+	}
+	if !strings.HasPrefix(err.Error(), "[SYSCONF-004] Validation: ") {
+		t.Fatalf("error text should start with code: %q", err.Error())
+	}
+	if !strings.HasPrefix(GetErrorSuggestion(err), "[SYSCONF-004] ") {
+		t.Fatalf("suggestion should include code: %q", GetErrorSuggestion(err))
+	}
+	if got := ErrorCodeFor("Unknown"); got != "SYSCONF-000" {
+		t.Fatalf("unknown type should map to SYSCONF-000, got %q", got)
+	}
+
+	custom := &ConfigError{Type: ErrTypeValidation, Code: "APP-042", Message: "x"}
+	if custom.ErrorCode() != "APP-042" || ErrorReport(custom)["code"] != "APP-042" {
+		t.Fatalf("explicit code should win: %v", ErrorReport(custom))
+	}
+
+	if got := err.DocsLink(); got != errorDocs(ErrTypeValidation) {
+		t.Fatalf("expected README docs link, got %q", got)
+	}
+	SetErrorDocsBaseURL("https://wiki.example.com/sysconf/")
+	t.Cleanup(func() { SetErrorDocsBaseURL("") })
+	if got := ErrorReport(err)["docs_url"]; got != "https://wiki.example.com/sysconf/SYSCONF-004" {
+		t.Fatalf("expected docs base URL with code, got %v", got)
+	}
+	custom.DocsURL = "https://wiki.example.com/app-042"
+	if got := custom.DocsLink(); got != custom.DocsURL {
+		t.Fatalf("explicit DocsURL should win, got %q", got)
+	}
+}
@@ -0,0 +1,190 @@
+package sysconf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrExecNotAllowed 命令数据源要执行的程序不在允许列表中
+var ErrExecNotAllowed = errors.New("exec source binary not allowed")
+
+const (
+	// maxExecSourceOutput 命令数据源标准输出的大小上限
+	maxExecSourceOutput = 16 << 20
+	// defaultExecSourceTimeout 命令数据源单次执行的默认超时
+	defaultExecSourceTimeout = 10 * time.Second
+	// maxExecStderr 错误信息中附带的标准错误输出长度上限
+	maxExecStderr = 512
+)
+
+// ExecParser 将命令的标准输出解析为配置值，返回 map[string]any 时按子键挂载
+type ExecParser func(output []byte) (any, error)
+
+// ExecFailurePolicy 命令执行或解析失败时的处理策略
+type ExecFailurePolicy int
+
+const (
+	// ExecFailClosed 启动时失败使 New 返回错误；刷新失败时保留最后一次成功的值（默认）
+	ExecFailClosed ExecFailurePolicy = iota
+	// ExecFailOpen 启动时失败记录日志并在没有该数据源的情况下继续；刷新失败时保留最后一次成功的值
+	ExecFailOpen
+	// ExecFailClear 同 ExecFailOpen，但刷新失败时清除该数据源的值，避免继续使用可能已失效的凭据
+	ExecFailClear
+)
+
+// ExecSourceOptions 命令数据源选项
+type ExecSourceOptions struct {
+	Key             string            // 挂载的配置键（必填）
+	Timeout         time.Duration     // 单次执行超时，默认 10 秒
+	AllowedBinaries []string          // 允许执行的程序（必填）：名称按 PATH 解析后比较，绝对路径按解析后的路径比较；为空时拒绝执行
+	FailurePolicy   ExecFailurePolicy // 失败处理策略，默认 ExecFailClosed
+	RefreshInterval time.Duration     // 定时重新执行的间隔，<=0 时仅在启动时执行一次
+	Env             []string          // 追加的环境变量（KEY=VALUE），命令继承当前进程环境
+	Dir             string            // 工作目录，为空时使用当前目录
+}
+
+// WithExecSource 执行外部命令（如 "aws ssm get-parameters-by-path"、内部密钥 CLI）并将其标准输出
+// 经 parser 解析后挂载到 opts.Key，可与 ExecOutputParser 配合解析 JSON/YAML 等格式。
+// 命令直接执行而不经过 shell；执行前按 PATH 解析程序路径并与 AllowedBinaries 比对，未设置允许列表或程序不在列表中时返回 ErrExecNotAllowed。
+// 与 WithTableSource 相同，命令输出覆盖主配置中的同名键且不会写回主配置文件；
+// 设置 RefreshInterval 后定期重新执行，值变化时触发 Watch 回调，失败按 FailurePolicy 处理并发出 ReloadFailed 健康事件。
+func WithExecSource(cmd string, args []string, parser ExecParser, opts ExecSourceOptions) Option {
+	return func(c *Config) {
+		if opts.Timeout <= 0 {
+			opts.Timeout = defaultExecSourceTimeout
+		}
+		args = slices.Clone(args)
+		layer := &sourceLayer{
+			name:         "exec source " + cmd,
+			key:          opts.Key,
+			interval:     opts.RefreshInterval,
+			optional:     opts.FailurePolicy != ExecFailClosed,
+			clearOnError: opts.FailurePolicy == ExecFailClear,
+		}
+		layer.load = func() (any, error) {
+			if opts.Key == "" {
+				return nil, errors.New("exec source requires a mount key")
+			}
+			if parser == nil {
+				return nil, errors.New("exec source requires a parser")
+			}
+			output, err := runExecSource(cmd, args, opts)
+			if err != nil {
+				return nil, err
+			}
+			value, err := parser(output)
+			if err != nil {
+				return nil, fmt.Errorf("parse output of %s: %w", cmd, err)
+			}
+			return value, nil
+		}
+		c.addSource(layer)
+	}
+}
+
+// ExecOutputParser 返回按指定配置格式解析命令输出的解析器
+func ExecOutputParser(mode Mode) ExecParser {
+	return func(output []byte) (any, error) {
+		format := string(mode)
+		if slices.Contains(nativeSupportedModes, format) {
+			return parseContentMap(output, format)
+		}
+		v := newViper()
+		v.SetConfigType(format)
+		if err := v.ReadConfig(bytes.NewReader(output)); err != nil {
+			return nil, err
+		}
+		return v.AllSettings(), nil
+	}
+}
+
+// runExecSource 校验允许列表后执行命令，返回标准输出
+func runExecSource(cmd string, args []string, opts ExecSourceOptions) ([]byte, error) {
+	path, err := resolveExecBinary(cmd, opts.AllowedBinaries)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+	command := exec.CommandContext(ctx, path, args...)
+	command.Dir = opts.Dir
+	if len(opts.Env) > 0 {
+		command.Env = append(os.Environ(), opts.Env...)
+	}
+	stdout := &limitedBuffer{limit: maxExecSourceOutput}
+	stderr := &limitedBuffer{limit: maxExecStderr}
+	command.Stdout = stdout
+	command.Stderr = stderr
+
+	if err := command.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("run %s: timed out after %v", cmd, opts.Timeout)
+		}
+		if msg := strings.TrimSpace(stderr.buf.String()); msg != "" {
+			return nil, fmt.Errorf("run %s: %w: %s", cmd, err, msg)
+		}
+		return nil, fmt.Errorf("run %s: %w", cmd, err)
+	}
+	if stdout.truncated {
+		return nil, fmt.Errorf("run %s: output exceeds %d bytes", cmd, maxExecSourceOutput)
+	}
+	return stdout.buf.Bytes(), nil
+}
+
+// resolveExecBinary 按 PATH 解析程序路径并检查允许列表，允许列表为空时拒绝执行
+func resolveExecBinary(cmd string, allowed []string) (string, error) {
+	if len(allowed) == 0 {
+		return "", fmt.Errorf("%w: %s (AllowedBinaries is empty, list the program explicitly)", ErrExecNotAllowed, cmd)
+	}
+	path, err := exec.LookPath(cmd)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range allowed {
+		resolved, err := exec.LookPath(entry)
+		if err != nil {
+			continue
+		}
+		if sameExecutable(resolved, path) {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrExecNotAllowed, path)
+}
+
+// sameExecutable 判断两个程序路径是否指向同一文件
+func sameExecutable(a, b string) bool {
+	if filepath.Clean(a) == filepath.Clean(b) {
+		return true
+	}
+	ia, errA := os.Stat(a)
+	ib, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(ia, ib)
+}
+
+// limitedBuffer 超过上限后丢弃写入内容并记录截断的缓冲区
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room < len(p) {
+		b.truncated = true
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
@@ -0,0 +1,180 @@
+package sysconf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func requireShell(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("exec source tests rely on sh")
+	}
+}
+
+func TestExecSourceLoad(t *testing.T) {
+	requireShell(t)
+	cfg, err := New(
+		WithContent("app:\n  name: demo\nsecrets:\n  db: stale\n"),
+		WithExecSource("sh", []string{"-c", `echo '{"db": "s3cr3t", "api": {"token": "abc"}}'`}, ExecOutputParser(JSON), ExecSourceOptions{
+			Key:             "secrets",
+			AllowedBinaries: []string{"sh"},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("secrets.db"); got != "s3cr3t" {
+		t.Fatalf("secrets.db = %q, want s3cr3t", got)
+	}
+	if got := cfg.GetString("secrets.api.token"); got != "abc" {
+		t.Fatalf("secrets.api.token = %q, want abc", got)
+	}
+	if got := cfg.GetString("app.name"); got != "demo" {
+		t.Fatalf("app.name = %q, want demo", got)
+	}
+}
+
+func TestExecSourceAllowlist(t *testing.T) {
+	requireShell(t)
+	_, err := New(
+		WithContent("app: demo\n"),
+		WithExecSource("sh", []string{"-c", "echo '{}'"}, ExecOutputParser(JSON), ExecSourceOptions{
+			Key:             "secrets",
+			AllowedBinaries: []string{"true"},
+		}),
+	)
+	if !errors.Is(err, ErrExecNotAllowed) {
+		t.Fatalf("expected ErrExecNotAllowed, got %v", err)
+	}
+
+	// 未设置允许列表时拒绝执行任何程序，不默认放行 cmd 本身
+	_, err = New(
+		WithContent("app: demo\n"),
+		WithExecSource("sh", []string{"-c", "echo '{}'"}, ExecOutputParser(JSON), ExecSourceOptions{Key: "secrets"}),
+	)
+	if !errors.Is(err, ErrExecNotAllowed) {
+		t.Fatalf("expected ErrExecNotAllowed for empty allowlist, got %v", err)
+	}
+}
+
+func TestExecSourceFailurePolicy(t *testing.T) {
+	requireShell(t)
+	failing := []string{"-c", "echo boom >&2; exit 3"}
+
+	_, err := New(
+		WithContent("app: demo\n"),
+		WithExecSource("sh", failing, ExecOutputParser(JSON), ExecSourceOptions{Key: "secrets", AllowedBinaries: []string{"sh"}}),
+	)
+	if err == nil {
+		t.Fatalf("expected fail-closed exec source to fail New")
+	}
+
+	cfg, err := New(
+		WithContent("app: demo\n"),
+		WithExecSource("sh", failing, ExecOutputParser(JSON), ExecSourceOptions{Key: "secrets", AllowedBinaries: []string{"sh"}, FailurePolicy: ExecFailOpen}),
+	)
+	if err != nil {
+		t.Fatalf("fail-open exec source should not fail New: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if cfg.IsSet("secrets") || cfg.GetString("app") != "demo" {
+		t.Fatalf("unexpected config after fail-open load: %v", cfg.AllSettings())
+	}
+
+	_, err = New(
+		WithContent("app: demo\n"),
+		WithExecSource("sh", []string{"-c", "sleep 5"}, ExecOutputParser(JSON), ExecSourceOptions{
+			Key:             "secrets",
+			AllowedBinaries: []string{"sh"},
+			Timeout:         50 * time.Millisecond,
+		}),
+	)
+	if err == nil {
+		t.Fatalf("expected timeout error")
+	}
+}
+
+func TestExecSourceRefresh(t *testing.T) {
+	requireShell(t)
+	tmpDir := t.TempDir()
+	valueFile := filepath.Join(tmpDir, "value.json")
+	if err := os.WriteFile(valueFile, []byte(`{"token": "v1"}`), 0o600); err != nil {
+		t.Fatalf("write value failed: %v", err)
+	}
+
+	cfg, err := New(
+		WithContent("app: demo\n"),
+		WithExecSource("sh", []string{"-c", "cat value.json"}, ExecOutputParser(JSON), ExecSourceOptions{
+			Key:             "secrets",
+			AllowedBinaries: []string{"sh"},
+			Dir:             tmpDir,
+			RefreshInterval: 20 * time.Millisecond,
+			FailurePolicy:   ExecFailClear,
+		}),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	changed := make(chan struct{}, 8)
+	cfg.Watch(func() { changed <- struct{}{} })
+
+	waitFor := func(want string) {
+		t.Helper()
+		deadline := time.After(2 * time.Second)
+		for cfg.GetString("secrets.token") != want {
+			select {
+			case <-changed:
+			case <-deadline:
+				t.Fatalf("secrets.token = %q, want %q", cfg.GetString("secrets.token"), want)
+			}
+		}
+	}
+
+	if err := os.WriteFile(valueFile, []byte(`{"token": "v2"}`), 0o600); err != nil {
+		t.Fatalf("write value failed: %v", err)
+	}
+	waitFor("v2")
+
+	// ExecFailClear 在刷新失败时清除已加载的值
+	if err := os.Remove(valueFile); err != nil {
+		t.Fatalf("remove value failed: %v", err)
+	}
+	waitFor("")
+}
+
+func TestExecSourcesLoadConcurrently(t *testing.T) {
+	requireShell(t)
+	opts := []Option{WithContent("app: demo\n")}
+	for _, key := range []string{"a", "b", "c", "d"} {
+		opts = append(opts, WithExecSource("sh", []string{"-c", `sleep 0.3; echo '{"ok": true}'`}, ExecOutputParser(JSON), ExecSourceOptions{
+			Key:             key,
+			AllowedBinaries: []string{"sh"},
+		}))
+	}
+
+	start := time.Now()
+	cfg, err := New(opts...)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected sources to load concurrently, took %v", elapsed)
+	}
+	for _, key := range []string{"a", "b", "c", "d"} {
+		if !cfg.GetBool(key + ".ok") {
+			t.Fatalf("%s.ok not loaded", key)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package sysconf
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExportOptions 导出为命令行参数或环境变量时的选项
+type ExportOptions struct {
+	Sections     []string                // 导出的配置段（键前缀），为空时导出全部配置
+	StripSection bool                    // 去掉所属配置段前缀，如 "server.port" 导出为 "port"
+	Prefix       string                  // 环境变量名前缀，为空时使用 WithEnv 设置的前缀
+	KeyMapper    func(key string) string // 自定义参数名或环境变量名，返回空字符串时跳过该键
+}
+
+// ExportArgs 将配置导出为已排序的 "--key=value" 参数列表，用于启动第三方进程。
+// 仅导出叶子值（包含环境变量等覆盖后的生效值），列表以逗号拼接。
+func (c *Config) ExportArgs(opts ExportOptions) []string {
+	values := c.exportValues(opts)
+	args := make([]string, 0, len(values))
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		name := key
+		if opts.KeyMapper != nil {
+			if name = opts.KeyMapper(key); name == "" {
+				continue
+			}
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", name, values[key]))
+	}
+	return args
+}
+
+// ExportEnv 将配置导出为环境变量映射，变量名为大写的 <前缀>_<键>，"." 与 "-" 替换为 "_"
+func (c *Config) ExportEnv(opts ExportOptions) map[string]string {
+	envName := c.envExportName(opts)
+	values := c.exportValues(opts)
+	env := make(map[string]string, len(values))
+	for key, value := range values {
+		if name := envName(key); name != "" {
+			env[name] = value
+		}
+	}
+	return env
+}
+
+// envExportName 返回将导出键映射为环境变量名的函数，返回空字符串时跳过该键
+func (c *Config) envExportName(opts ExportOptions) func(key string) string {
+	if opts.KeyMapper != nil {
+		return opts.KeyMapper
+	}
+	prefix := opts.Prefix
+	if prefix == "" {
+		c.mu.RLock()
+		prefix = c.envOptions.Prefix
+		c.mu.RUnlock()
+	}
+	if prefix != "" {
+		prefix = strings.ToUpper(prefix) + "_"
+	}
+	replacer := strings.NewReplacer(".", "_", "-", "_")
+	return func(key string) string {
+		return prefix + strings.ToUpper(replacer.Replace(key))
+	}
+}
+
+// envNameIndex ExportEnv 变量名到配置键的映射，data 为建立索引时的配置数据
+type envNameIndex struct {
+	data map[string]any
+	keys map[string]string
+}
+
+// buildEnvNameIndex 按 ExportEnv 的命名规则建立变量名到数据键的索引；多个键映射到同一变量名时取字典序最小者
+func (c *Config) buildEnvNameIndex(data map[string]any, opts ExportOptions) *envNameIndex {
+	envName := c.envExportName(opts)
+	keys := make(map[string]string)
+	for key, value := range data {
+		if _, nested := value.(map[string]any); nested {
+			continue
+		}
+		exported, ok := exportKey(key, opts)
+		if !ok {
+			continue
+		}
+		name := envName(exported)
+		if name == "" {
+			continue
+		}
+		if prev, ok := keys[name]; !ok || key < prev {
+			keys[name] = key
+		}
+	}
+	return &envNameIndex{data: data, keys: keys}
+}
+
+// ExportComposeEnvironment 将配置渲染为 docker-compose 风格的 environment: YAML 块（变量按名称排序，值均为字符串）
+func (c *Config) ExportComposeEnvironment(opts ExportOptions) ([]byte, error) {
+	block := map[string]map[string]string{"environment": c.ExportEnv(opts)}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(block); err != nil {
+		return nil, fmt.Errorf("render compose environment: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("render compose environment: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// exportValues 收集待导出的叶子键及其字符串值
+func (c *Config) exportValues(opts ExportOptions) map[string]string {
+	if c.closed.Load() {
+		return nil
+	}
+	result := make(map[string]string)
+	for key, value := range c.loadData() {
+		if _, nested := value.(map[string]any); nested {
+			continue
+		}
+		name, ok := exportKey(key, opts)
+		if !ok {
+			continue
+		}
+		if effective, found := c.lookupRaw(key); found {
+			value = effective
+		}
+		result[name] = exportString(value)
+	}
+	return result
+}
+
+// exportKey 判断键是否属于待导出的配置段，并返回导出时使用的键
+func exportKey(key string, opts ExportOptions) (string, bool) {
+	if len(opts.Sections) == 0 {
+		return key, true
+	}
+	for _, section := range opts.Sections {
+		if key == section {
+			return key, true
+		}
+		if rest, ok := strings.CutPrefix(key, section+"."); ok {
+			if opts.StripSection {
+				return rest, true
+			}
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// exportString 将配置值格式化为参数或环境变量值，列表以逗号拼接
+func exportString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ",")
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = exportString(item)
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
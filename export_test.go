@@ -0,0 +1,56 @@
+package sysconf
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestExportArgsAndComposeEnvironment(t *testing.T) {
+	t.Setenv("EXP_SERVER_PORT", "9090")
+
+	cfg, err := New(
+		WithContent("server:\n  host: localhost\n  port: 8080\n  tags: [a, b]\n  read-timeout: 5s\ndatabase:\n  dsn: postgres://db\n"),
+		WithEnv("EXP"),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	args := cfg.ExportArgs(ExportOptions{Sections: []string{"server"}, StripSection: true})
+	want := []string{"--host=localhost", "--port=9090", "--read-timeout=5s", "--tags=a,b"}
+	if !slices.Equal(args, want) {
+		t.Fatalf("unexpected args: %v", args)
+	}
+
+	all := cfg.ExportArgs(ExportOptions{})
+	if !slices.Contains(all, "--database.dsn=postgres://db") || !slices.Contains(all, "--server.port=9090") {
+		t.Fatalf("unexpected full args: %v", all)
+	}
+
+	env := cfg.ExportEnv(ExportOptions{Sections: []string{"server"}})
+	if env["EXP_SERVER_READ_TIMEOUT"] != "5s" || env["EXP_SERVER_PORT"] != "9090" || len(env) != 4 {
+		t.Fatalf("unexpected env: %v", env)
+	}
+
+	block, err := cfg.ExportComposeEnvironment(ExportOptions{Sections: []string{"database", "server.port"}, Prefix: "app"})
+	if err != nil {
+		t.Fatalf("export compose environment failed: %v", err)
+	}
+	wantBlock := "environment:\n  APP_DATABASE_DSN: postgres://db\n  APP_SERVER_PORT: \"9090\"\n"
+	if string(block) != wantBlock {
+		t.Fatalf("unexpected compose block:\n%s", block)
+	}
+
+	mapped := cfg.ExportArgs(ExportOptions{Sections: []string{"server"}, KeyMapper: func(key string) string {
+		if key == "server.tags" {
+			return ""
+		}
+		return "app-" + key
+	}})
+	if len(mapped) != 3 || mapped[0] != "--app-server.host=localhost" {
+		t.Fatalf("unexpected mapped args: %v", mapped)
+	}
+}
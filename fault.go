@@ -0,0 +1,53 @@
+package sysconf
+
+// FaultPoint 故障注入点
+type FaultPoint string
+
+const (
+	// FaultWrite 写入配置文件前，返回错误模拟写盘失败（触发写入回滚）
+	FaultWrite FaultPoint = "write"
+	// FaultDecrypt 解密配置内容前，返回错误模拟解密失败
+	FaultDecrypt FaultPoint = "decrypt"
+	// FaultReload 文件变更触发重载前，阻塞可模拟缓慢重载，返回错误模拟重载失败（保留最后一次成功加载的配置）
+	FaultReload FaultPoint = "reload"
+	// FaultWatcher 文件监听收到事件时，返回错误模拟监听器断开（监听器随后自动重建）
+	FaultWatcher FaultPoint = "watcher"
+)
+
+// FaultInjector 故障注入器，用于在测试中确定性地模拟故障，验证应用的健康事件处理与恢复路径。
+// Inject 在每个注入点被调用，target 为相关文件路径；返回非 nil 错误即在该点注入故障。
+// Inject 可能在持有内部锁时被调用，不得回调同一 Config 的方法。
+type FaultInjector interface {
+	Inject(point FaultPoint, target string) error
+}
+
+// FaultInjectorFunc 函数形式的故障注入器
+type FaultInjectorFunc func(point FaultPoint, target string) error
+
+// Inject 实现 FaultInjector
+func (f FaultInjectorFunc) Inject(point FaultPoint, target string) error {
+	return f(point, target)
+}
+
+// WithFaultInjector 设置故障注入器，仅用于测试；未设置时各注入点没有额外开销
+//
+//	cfg, _ := sysconf.New(opts..., sysconf.WithFaultInjector(sysconf.FaultInjectorFunc(
+//	    func(point sysconf.FaultPoint, _ string) error {
+//	        if point == sysconf.FaultWrite {
+//	            return errors.New("disk full")
+//	        }
+//	        return nil
+//	    })))
+func WithFaultInjector(injector FaultInjector) Option {
+	return func(c *Config) {
+		c.faultInjector = injector
+	}
+}
+
+// injectFault 在注入点调用故障注入器
+func (c *Config) injectFault(point FaultPoint, target string) error {
+	if c.faultInjector == nil {
+		return nil
+	}
+	return c.faultInjector.Inject(point, target)
+}
@@ -0,0 +1,129 @@
+package sysconf
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// toggleFault 在指定注入点按开关注入错误，delay 用于模拟缓慢操作
+type toggleFault struct {
+	point   FaultPoint
+	enabled atomic.Bool
+	delay   time.Duration
+	hits    atomic.Int32
+}
+
+func (f *toggleFault) Inject(point FaultPoint, _ string) error {
+	if point != f.point || !f.enabled.Load() {
+		return nil
+	}
+	f.hits.Add(1)
+	time.Sleep(f.delay)
+	return errors.New("injected " + string(point) + " fault")
+}
+
+func TestFaultInjectorWriteFailureRollsBack(t *testing.T) {
+	fault := &toggleFault{point: FaultWrite}
+	cfg, configFile := newWatchTestConfig(t, WithWriteDebounceDelay(0), WithFaultInjector(fault))
+
+	require.NoError(t, cfg.Set("key", "written"))
+
+	fault.enabled.Store(true)
+	err := cfg.Set("key", "lost")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "injected write fault")
+	require.Equal(t, "written", cfg.GetString("key"), "failed write must roll back")
+
+	content, err := os.ReadFile(configFile)
+	require.NoError(t, err)
+	require.Contains(t, string(content), "written")
+}
+
+func TestFaultInjectorDecryptFailureKeepsLastGoodConfig(t *testing.T) {
+	fault := &toggleFault{point: FaultDecrypt}
+	dir := t.TempDir()
+	cfg, err := New(
+		WithPath(dir), WithMode("yaml"), WithName("secret"),
+		WithContent("key: initial\n"),
+		WithEncryption("fault-injection-key"),
+		WithFaultInjector(fault),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	events := make(chan HealthEvent, 8)
+	t.Cleanup(cfg.OnHealthEvent(func(e HealthEvent) { events <- e }))
+
+	fault.enabled.Store(true)
+	cfg.reloadChangedFile(filepath.Join(dir, "secret.yaml"), true)
+	event := waitHealthEvent(t, events, HealthEventReloadFailed)
+	require.ErrorContains(t, event.Err, "injected decrypt fault")
+	require.Equal(t, "initial", cfg.GetString("key"))
+	require.Positive(t, fault.hits.Load())
+}
+
+func TestFaultInjectorSlowAndFailedReload(t *testing.T) {
+	fault := &toggleFault{point: FaultReload, delay: 50 * time.Millisecond}
+	cfg, configFile := newWatchTestConfig(t, WithFaultInjector(fault))
+
+	events := make(chan HealthEvent, 8)
+	t.Cleanup(cfg.OnHealthEvent(func(e HealthEvent) { events <- e }))
+
+	fault.enabled.Store(true)
+	require.NoError(t, os.WriteFile(configFile, []byte("key: ignored\n"), 0o644))
+	start := time.Now()
+	cfg.reloadChangedFile(configFile, true)
+	require.GreaterOrEqual(t, time.Since(start), fault.delay, "reload should be slowed by the injector")
+
+	waitHealthEvent(t, events, HealthEventReloadFailed)
+	require.Equal(t, "initial", cfg.GetString("key"))
+	require.False(t, cfg.Health().Healthy)
+
+	// 关闭注入后重载恢复
+	fault.enabled.Store(false)
+	cfg.reloadChangedFile(configFile, true)
+	waitHealthEvent(t, events, HealthEventReloaded)
+	require.Equal(t, "ignored", cfg.GetString("key"))
+	require.True(t, cfg.Health().Healthy)
+}
+
+func TestFaultInjectorWatcherDrop(t *testing.T) {
+	fault := &toggleFault{point: FaultWatcher}
+	cfg, configFile := newWatchTestConfig(t, WithFaultInjector(fault))
+
+	stop := cfg.WatchWithContext(context.Background(), func() {})
+	t.Cleanup(stop)
+
+	watcherErrs := make(chan error, 8)
+	t.Cleanup(cfg.OnWatcherError(func(err error) { watcherErrs <- err }))
+
+	fault.enabled.Store(true)
+	require.NoError(t, os.WriteFile(configFile, []byte("key: dropped\n"), 0o644))
+	select {
+	case err := <-watcherErrs:
+		require.ErrorContains(t, err, "injected watcher fault")
+	case <-time.After(3 * time.Second):
+		t.Fatal("expected watcher error hook to fire")
+	}
+
+	// 监听器重建后补载断开期间的变更，并继续接收后续变更
+	fault.enabled.Store(false)
+	require.Eventually(t, func() bool { return cfg.GetString("key") == "dropped" }, 3*time.Second, 10*time.Millisecond)
+	require.Positive(t, cfg.Health().WatcherRestarts)
+	time.Sleep(50 * time.Millisecond) // 越过前沿防抖窗口
+	tmp := configFile + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte("key: seen\n"), 0o644))
+	require.NoError(t, os.Rename(tmp, configFile))
+	require.Eventually(t, func() bool { return cfg.GetString("key") == "seen" }, 3*time.Second, 10*time.Millisecond)
+
+	health := cfg.Health()
+	require.False(t, health.WatcherDown)
+	require.GreaterOrEqual(t, health.WatcherErrors, int64(1))
+}
@@ -0,0 +1,184 @@
+package sysconf
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 文件引用的前缀与默认限制
+const (
+	fileRefURLPrefix     = "file://"
+	fileRefPrefix        = "@file:"
+	defaultFileRefMax    = 1 << 20     // 单个引用文件默认大小上限（1 MiB）
+	fileRefCheckInterval = time.Second // 同一引用文件两次变更检查的最小间隔
+)
+
+// fileRefEntry 引用文件的缓存内容
+type fileRefEntry struct {
+	mu      sync.Mutex
+	content string
+	size    int64
+	modTime time.Time
+	checked time.Time // 最近一次检查文件状态的时间，零值表示尚未读取
+}
+
+// WithFileReferences 启用文件引用：值为 "file:///etc/ssl/cert.pem" 或 "@file:certs/cert.pem" 的字符串
+// 在读取时（Get 系列方法与 Unmarshal）解析为文件内容，常用于证书、密钥与模板。
+// 相对路径相对配置文件所在目录（纯内存配置相对当前工作目录），支持 ~ 与环境变量展开。
+// 文件内容按路径缓存，并按修改时间与大小检查变更（同一文件至多每秒检查一次），修改后在下一次读取时生效。
+// maxSize 为单个引用文件的大小上限（<=0 使用默认的 1 MiB）；文件不存在、超出上限或读取失败时
+// 记录错误日志并返回原始引用字符串。配置文件中保存的始终是引用本身。
+func WithFileReferences(maxSize int64) Option {
+	return func(c *Config) {
+		if maxSize <= 0 {
+			maxSize = defaultFileRefMax
+		}
+		c.fileRefMax = maxSize
+	}
+}
+
+// fileRefPath 判断字符串是否为文件引用并返回引用的路径
+func fileRefPath(s string) (string, bool) {
+	var p string
+	switch {
+	case strings.HasPrefix(s, fileRefURLPrefix):
+		p = strings.TrimPrefix(s, fileRefURLPrefix)
+		// file:///C:/path 在 Windows 上去掉盘符前的斜杠
+		if runtime.GOOS == "windows" && len(p) > 2 && p[0] == '/' && p[2] == ':' {
+			p = p[1:]
+		}
+	case strings.HasPrefix(s, fileRefPrefix):
+		p = strings.TrimPrefix(s, fileRefPrefix)
+	}
+	return p, p != ""
+}
+
+// hasFileRefs 判断值（含嵌套 map 与切片）中是否包含文件引用
+func hasFileRefs(value any) bool {
+	switch v := value.(type) {
+	case string:
+		_, ok := fileRefPath(v)
+		return ok
+	case map[string]any:
+		for _, item := range v {
+			if hasFileRefs(item) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if hasFileRefs(item) {
+				return true
+			}
+		}
+	case []string:
+		for _, item := range v {
+			if hasFileRefs(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolveFileRefs 返回将文件引用替换为文件内容后的值；未启用或不包含引用时原样返回
+func (c *Config) resolveFileRefs(value any) any {
+	if c.fileRefMax <= 0 || !hasFileRefs(value) {
+		return value
+	}
+	switch v := value.(type) {
+	case string:
+		p, _ := fileRefPath(v)
+		content, err := c.readFileRef(p)
+		if err != nil {
+			c.logger.Errorf("Failed to resolve file reference %s: %v", v, err)
+			return v
+		}
+		return content
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[key] = c.resolveFileRefs(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = c.resolveFileRefs(item)
+		}
+		return out
+	case []string:
+		out := make([]string, len(v))
+		for i, item := range v {
+			out[i] = c.resolveFileRefs(item).(string)
+		}
+		return out
+	}
+	return value
+}
+
+// readFileRef 读取引用文件内容，文件未变化时使用缓存
+func (c *Config) readFileRef(p string) (string, error) {
+	p = c.fileRefAbsPath(p)
+	cached, _ := c.fileRefCache.LoadOrStore(p, &fileRefEntry{})
+	entry := cached.(*fileRefEntry)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	now := time.Now()
+	if !entry.checked.IsZero() && now.Sub(entry.checked) < fileRefCheckInterval {
+		return entry.content, nil
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return "", fmt.Errorf("stat referenced file: %w", err)
+	}
+	if !entry.checked.IsZero() && info.Size() == entry.size && info.ModTime().Equal(entry.modTime) {
+		entry.checked = now
+		return entry.content, nil
+	}
+	if info.Size() > c.fileRefMax {
+		return "", fmt.Errorf("referenced file %s is %d bytes, exceeds limit of %d bytes", p, info.Size(), c.fileRefMax)
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		return "", fmt.Errorf("open referenced file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	// 多读一个字节以发现 Stat 之后被追加写入的文件
+	data, err := io.ReadAll(io.LimitReader(f, c.fileRefMax+1))
+	if err != nil {
+		return "", fmt.Errorf("read referenced file: %w", err)
+	}
+	if int64(len(data)) > c.fileRefMax {
+		return "", fmt.Errorf("referenced file %s exceeds limit of %d bytes", p, c.fileRefMax)
+	}
+
+	if !entry.checked.IsZero() {
+		c.logger.Infof("Referenced file changed, reloaded: %s", p)
+	}
+	entry.content, entry.size, entry.modTime, entry.checked = string(data), info.Size(), info.ModTime(), now
+	return entry.content, nil
+}
+
+// fileRefAbsPath 展开 ~ 与环境变量，并将相对路径解析为相对配置文件目录的路径
+func (c *Config) fileRefAbsPath(p string) string {
+	if strings.Contains(p, "$") {
+		p = os.ExpandEnv(p)
+	}
+	if expanded, err := expandHome(p); err == nil {
+		p = expanded
+	}
+	p = filepath.FromSlash(p)
+	if !filepath.IsAbs(p) && c.fileRefBase != "" {
+		p = filepath.Join(c.fileRefBase, p)
+	}
+	return filepath.Clean(p)
+}
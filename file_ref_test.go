@@ -0,0 +1,116 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestFileReferences(t *testing.T) {
+	tmpDir := t.TempDir()
+	certFile := filepath.Join(tmpDir, "cert.pem")
+	if err := os.WriteFile(certFile, []byte("CERT-1"), 0o600); err != nil {
+		t.Fatalf("write cert failed: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "tpl"), 0o755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "tpl", "mail.txt"), []byte("hello {{.Name}}"), 0o600); err != nil {
+		t.Fatalf("write template failed: %v", err)
+	}
+
+	content := "tls:\n  cert: file://" + filepath.ToSlash(certFile) + "\n  key: plain\ntemplate: \"@file:tpl/mail.txt\"\n"
+	cfg, err := New(
+		WithPath(tmpDir),
+		WithName("app"),
+		WithMode("yaml"),
+		WithContent(content),
+		WithFileReferences(0),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("tls.cert"); got != "CERT-1" {
+		t.Fatalf("expected file contents, got %q", got)
+	}
+	if got := cfg.GetString("template"); got != "hello {{.Name}}" {
+		t.Fatalf("expected relative reference resolved against config dir, got %q", got)
+	}
+	if got := cfg.GetStringMap("tls"); got["cert"] != "CERT-1" || got["key"] != "plain" {
+		t.Fatalf("expected section references to be resolved, got %v", got)
+	}
+
+	var target struct {
+		TLS struct {
+			Cert string
+		} `config:"tls"`
+	}
+	if err := cfg.Unmarshal(&target); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if target.TLS.Cert != "CERT-1" {
+		t.Fatalf("unmarshal must resolve references, got %q", target.TLS.Cert)
+	}
+
+	// 文件变更在下一次检查时生效
+	later := time.Now().Add(2 * time.Second)
+	if err := os.WriteFile(certFile, []byte("CERT-2-rotated"), 0o600); err != nil {
+		t.Fatalf("rewrite cert failed: %v", err)
+	}
+	if err := os.Chtimes(certFile, later, later); err != nil {
+		t.Fatalf("chtimes failed: %v", err)
+	}
+	cached, _ := cfg.fileRefCache.Load(filepath.Clean(certFile))
+	entry := cached.(*fileRefEntry)
+	entry.mu.Lock()
+	entry.checked = time.Now().Add(-fileRefCheckInterval)
+	entry.mu.Unlock()
+	if got := cfg.GetString("tls.cert"); got != "CERT-2-rotated" {
+		t.Fatalf("expected rotated file contents, got %q", got)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "app.yaml"))
+	if err != nil {
+		t.Fatalf("read config failed: %v", err)
+	}
+	if !strings.Contains(string(data), "file://") {
+		t.Fatalf("config file must keep the reference, got:\n%s", data)
+	}
+}
+
+func TestFileReferencesLimits(t *testing.T) {
+	tmpDir := t.TempDir()
+	big := filepath.Join(tmpDir, "big.bin")
+	if err := os.WriteFile(big, []byte(strings.Repeat("x", 64)), 0o600); err != nil {
+		t.Fatalf("write file failed: %v", err)
+	}
+	ref := "@file:" + filepath.ToSlash(big)
+
+	cfg, err := New(WithContent("blob: \""+ref+"\"\nmissing: \"@file:/nonexistent/file\"\n"), WithFileReferences(16))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("blob"); got != ref {
+		t.Fatalf("oversized file must not be resolved, got %q", got)
+	}
+	if got := cfg.GetString("missing"); got != "@file:/nonexistent/file" {
+		t.Fatalf("missing file must keep the reference, got %q", got)
+	}
+
+	plain, err := New(WithContent("blob: \"" + ref + "\"\n"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, plain.Close)
+	if got := plain.GetString("blob"); got != ref {
+		t.Fatalf("references must stay literal unless enabled, got %q", got)
+	}
+}
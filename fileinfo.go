@@ -0,0 +1,81 @@
+package sysconf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+)
+
+// FileInfo 配置文件元数据，记录最近一次成功读取或写入时的文件状态
+type FileInfo struct {
+	Path       string    // 配置文件路径
+	Format     string    // 配置格式（yaml/json/toml 等）
+	Size       int64     // 文件大小（字节，磁盘上的原始内容）
+	ModTime    time.Time // 文件修改时间
+	Checksum   string    // 磁盘原始内容的 SHA-256 校验和（十六进制，带 "sha256:" 前缀）
+	Encrypted  bool      // 磁盘内容是否为加密格式
+	KeyID      string    // 加密信封记录的密钥标识
+	CryptoType string    // 加密实现类型，未启用加密时为空
+	LoadedAt   time.Time // 元数据记录时间
+}
+
+// FileInfo 返回当前配置文件的元数据。
+// 元数据在加载、重载与写入配置文件时记录；内存模式或尚未读写过文件时返回 false。
+func (c *Config) FileInfo() (FileInfo, bool) {
+	info := c.fileInfo.Load()
+	if info == nil {
+		return FileInfo{}, false
+	}
+	return *info, true
+}
+
+// recordFileInfo 记录配置文件元数据。raw 为磁盘上的原始内容，为 nil 时从磁盘读取。
+func (c *Config) recordFileInfo(path string, raw []byte) {
+	if path == "" {
+		return
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		c.logger.Debugf("Failed to stat config file for metadata: %v", err)
+		return
+	}
+	if raw == nil {
+		if raw, err = os.ReadFile(path); err != nil {
+			c.logger.Debugf("Failed to read config file for metadata: %v", err)
+			return
+		}
+	}
+
+	sum := sha256.Sum256(raw)
+	info := &FileInfo{
+		Path:     path,
+		Format:   c.mode,
+		Size:     int64(len(raw)),
+		ModTime:  stat.ModTime(),
+		Checksum: "sha256:" + hex.EncodeToString(sum[:]),
+		LoadedAt: time.Now(),
+	}
+	if c.cryptoOptions.Enabled && c.crypto != nil {
+		info.CryptoType = cryptoTypeName(c.crypto)
+		if header, ok := ReadEnvelopeHeader(raw); ok {
+			info.Encrypted = true
+			info.KeyID = header.KeyID
+		} else {
+			info.Encrypted = c.crypto.IsEncrypted(raw)
+		}
+	}
+	c.fileInfo.Store(info)
+}
+
+// cryptoTypeName 返回加密实现的可读名称
+func cryptoTypeName(crypto ConfigCrypto) string {
+	switch crypto := crypto.(type) {
+	case AlgorithmProvider:
+		return crypto.Algorithm()
+	default:
+		return fmt.Sprintf("%T", crypto)
+	}
+}
@@ -0,0 +1,88 @@
+package sysconf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestFileInfoRecordsLoadedFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := []byte("app:\n  name: meta\n")
+	configFile := filepath.Join(tmpDir, "meta.yaml")
+	if err := os.WriteFile(configFile, content, 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+
+	cfg, err := New(WithPath(tmpDir), WithName("meta"), WithMode("yaml"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	info, ok := cfg.FileInfo()
+	if !ok {
+		t.Fatalf("expected file info to be recorded")
+	}
+	sum := sha256.Sum256(content)
+	if info.Path != configFile || info.Format != "yaml" || info.Size != int64(len(content)) {
+		t.Fatalf("unexpected file info: %+v", info)
+	}
+	if info.Checksum != "sha256:"+hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected checksum: %s", info.Checksum)
+	}
+	if info.ModTime.IsZero() || info.Encrypted || info.CryptoType != "" {
+		t.Fatalf("unexpected metadata: %+v", info)
+	}
+}
+
+func TestFileInfoEncryptedAndWrites(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg, err := New(
+		WithPath(tmpDir),
+		WithName("secure"),
+		WithMode("yaml"),
+		WithContent("app:\n  name: secure\n"),
+		WithEncryption("file-info-test-key"),
+		WithWriteDebounceDelay(0),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	before, ok := cfg.FileInfo()
+	if !ok {
+		t.Fatalf("expected file info after default config creation")
+	}
+	if !before.Encrypted || before.CryptoType != "chacha20-poly1305" {
+		t.Fatalf("expected encrypted metadata, got %+v", before)
+	}
+
+	if err := cfg.Set("app.name", "changed"); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	after, _ := cfg.FileInfo()
+	if after.Checksum == before.Checksum {
+		t.Fatalf("checksum should change after write")
+	}
+	if stat, err := os.Stat(after.Path); err != nil || stat.Size() != after.Size {
+		t.Fatalf("recorded size %d does not match disk: %v", after.Size, err)
+	}
+}
+
+func TestFileInfoMemoryOnly(t *testing.T) {
+	cfg, err := New(WithContent("app:\n  name: memory\n"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if _, ok := cfg.FileInfo(); ok {
+		t.Fatalf("memory-only config should not report file info")
+	}
+}
@@ -0,0 +1,80 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ErrLockTimeout 在超时时间内未能获得配置文件写锁
+var ErrLockTimeout = errors.New("config file lock timeout")
+
+// defaultFileLockTimeout WithFileLock 未指定超时时的默认等待时间
+const defaultFileLockTimeout = 10 * time.Second
+
+// fileLockPollInterval 获取文件锁失败后的重试间隔
+const fileLockPollInterval = 10 * time.Millisecond
+
+// WithFileLock 在写入配置文件时持有建议性文件锁（Unix 使用 flock，Windows 使用 LockFileEx），
+// 使共享同一配置文件（NFS、共享卷）的多个进程串行写入，避免写入内容相互交错。
+// 锁加在配置文件旁的 <文件名>.lock 上；timeout 内未获得锁时写入失败并返回 ErrLockTimeout，
+// timeout<=0 时使用默认的 10 秒。不支持文件锁的平台上该选项不生效。
+func WithFileLock(timeout time.Duration) Option {
+	return func(c *Config) {
+		if timeout <= 0 {
+			timeout = defaultFileLockTimeout
+		}
+		c.fileLockTimeout = timeout
+	}
+}
+
+// fileLock 已持有的文件锁
+type fileLock struct {
+	file *os.File
+}
+
+// acquireFileLock 在 timeout 内获取 path 上的排他锁
+func acquireFileLock(path string, timeout time.Duration) (*fileLock, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open lock file: %w", err)
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("lock %s: %w", path, err)
+		}
+		if locked {
+			return &fileLock{file: f}, nil
+		}
+		if time.Now().After(deadline) {
+			_ = f.Close()
+			return nil, fmt.Errorf("%w: %s not acquired within %v", ErrLockTimeout, path, timeout)
+		}
+		time.Sleep(fileLockPollInterval)
+	}
+}
+
+// release 释放文件锁
+func (l *fileLock) release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	err := unlockFile(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
+
+// lockConfigFile 启用 WithFileLock 时获取配置文件写锁，未启用时返回 nil
+func (c *Config) lockConfigFile(configFile string) (*fileLock, error) {
+	if c.fileLockTimeout <= 0 {
+		return nil, nil
+	}
+	return acquireFileLock(configFile+".lock", c.fileLockTimeout)
+}
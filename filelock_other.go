@@ -0,0 +1,15 @@
+//go:build !unix && !windows
+
+package sysconf
+
+import "os"
+
+// tryLockFile 当前平台不支持文件锁，直接视为已获得
+func tryLockFile(*os.File) (bool, error) {
+	return true, nil
+}
+
+// unlockFile 当前平台不支持文件锁
+func unlockFile(*os.File) error {
+	return nil
+}
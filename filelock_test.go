@@ -0,0 +1,50 @@
+package sysconf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestWithFileLock(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "shared.yaml")
+	if err := os.WriteFile(configFile, []byte("key: initial\n"), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+
+	cfg, err := New(WithPath(tmpDir), WithMode("yaml"), WithName("shared"),
+		WithWriteDebounceDelay(0), WithFileLock(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	// 模拟另一个进程持有写锁
+	held, err := acquireFileLock(configFile+".lock", time.Second)
+	if err != nil {
+		t.Fatalf("acquire lock failed: %v", err)
+	}
+	err = cfg.Set("key", "blocked")
+	if !errors.Is(err, ErrLockTimeout) {
+		t.Fatalf("expected ErrLockTimeout, got %v", err)
+	}
+	if got := cfg.GetString("key"); got != "initial" {
+		t.Fatalf("failed write should roll back, got %q", got)
+	}
+
+	if err := held.release(); err != nil {
+		t.Fatalf("release lock failed: %v", err)
+	}
+	if err := cfg.Set("key", "written"); err != nil {
+		t.Fatalf("set after release failed: %v", err)
+	}
+	data, err := os.ReadFile(configFile)
+	if err != nil || string(data) != "key: written\n" {
+		t.Fatalf("unexpected file content %q: %v", data, err)
+	}
+}
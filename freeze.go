@@ -0,0 +1,119 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrFrozenKey 修改已冻结的配置子树
+var ErrFrozenKey = errors.New("frozen config key")
+
+// frozenState 冻结的子树模式及冻结时的扁平键值，整体替换以便无锁读取
+type frozenState struct {
+	patterns [][]string
+	values   map[string]any
+}
+
+// FreezeSubtree 冻结匹配模式的配置子树，例如 FreezeSubtree("database")。模式语法与 WatchKeysGlob 相同
+// （匹配某个键同时覆盖其子键）。冻结后子树保持调用时的值：Set/SetMultiple 修改其中的键返回 ErrFrozenKey
+// （SetProtected 同样受限），文件、远程配置与附加数据源的重载仍会应用其他键，但冻结键的变化被丢弃并记录警告。
+// 适用于连接池等启动后不能热更新的配置，功能开关等其他子树保持可热重载。冻结不可撤销，重复调用会追加模式；
+// 环境变量覆盖在读取时生效，不受冻结限制。
+func (c *Config) FreezeSubtree(patterns ...string) error {
+	if len(patterns) == 0 {
+		return fmt.Errorf("%w: no subtree pattern", ErrInvalidKey)
+	}
+	parsed := make([][]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		segments := strings.Split(strings.TrimSpace(pattern), ".")
+		for _, seg := range segments {
+			if _, err := path.Match(seg, ""); err != nil || seg == "" {
+				return fmt.Errorf("%w: invalid subtree pattern %q", ErrInvalidKey, pattern)
+			}
+		}
+		parsed = append(parsed, segments)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	next := &frozenState{values: make(map[string]any)}
+	if prev := c.frozen.Load(); prev != nil {
+		next.patterns = slices.Clone(prev.patterns)
+		maps.Copy(next.values, prev.values)
+	}
+	next.patterns = append(next.patterns, parsed...)
+	for key, value := range c.loadData() {
+		if _, ok := next.values[key]; !ok && next.matches(key) {
+			next.values[key] = deepCloneValue(value)
+		}
+	}
+	c.frozen.Store(next)
+	c.logger.Infof("Froze config subtrees: %s", strings.Join(patterns, ", "))
+	return nil
+}
+
+// matches 判断键是否位于任一冻结子树内
+func (f *frozenState) matches(key string) bool {
+	segments := strings.Split(key, ".")
+	for _, pattern := range f.patterns {
+		if matchKeyPattern(pattern, segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// changedKeys 返回 data 中与冻结值不一致（修改、删除或新增）的冻结键，已排序
+func (f *frozenState) changedKeys(data map[string]any) []string {
+	var changed []string
+	for key, value := range data {
+		if !f.matches(key) {
+			continue
+		}
+		if frozen, ok := f.values[key]; !ok || !reflect.DeepEqual(frozen, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range f.values {
+		if _, ok := data[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// checkFrozen 检查候选数据是否修改了冻结键
+func (c *Config) checkFrozen(data map[string]any) error {
+	frozen := c.frozen.Load()
+	if frozen == nil {
+		return nil
+	}
+	if changed := frozen.changedKeys(data); len(changed) > 0 {
+		return fmt.Errorf("%w: %s", ErrFrozenKey, strings.Join(changed, ", "))
+	}
+	return nil
+}
+
+// restoreFrozen 将 data 中的冻结键恢复为冻结时的值，返回被丢弃变化的键
+func (c *Config) restoreFrozen(data map[string]any) []string {
+	frozen := c.frozen.Load()
+	if frozen == nil {
+		return nil
+	}
+	changed := frozen.changedKeys(data)
+	for _, key := range changed {
+		if value, ok := frozen.values[key]; ok {
+			data[key] = deepCloneValue(value)
+		} else {
+			delete(data, key)
+		}
+	}
+	return changed
+}
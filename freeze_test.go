@@ -0,0 +1,84 @@
+package sysconf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestFreezeSubtreeRejectsSet(t *testing.T) {
+	cfg, err := New(WithContent("database:\n  host: db\n  pool:\n    max: 10\nfeatures:\n  beta: false\n"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if err := cfg.FreezeSubtree("database"); err != nil {
+		t.Fatalf("freeze failed: %v", err)
+	}
+	if err := cfg.Set("database.pool.max", 20); !errors.Is(err, ErrFrozenKey) {
+		t.Fatalf("expected ErrFrozenKey, got %v", err)
+	}
+	if err := cfg.Set("database.user", "admin"); !errors.Is(err, ErrFrozenKey) {
+		t.Fatalf("adding a key to a frozen subtree should be refused, got %v", err)
+	}
+	if err := cfg.SetProtected("database.host", "other", ConfirmDangerousChange); !errors.Is(err, ErrFrozenKey) {
+		t.Fatalf("SetProtected must not bypass freeze, got %v", err)
+	}
+	if err := cfg.SetMultiple(map[string]any{"features.beta": true, "database.host": "other"}); !errors.Is(err, ErrFrozenKey) {
+		t.Fatalf("expected batch write to be refused, got %v", err)
+	}
+	if got := cfg.GetString("database.host"); got != "db" {
+		t.Fatalf("frozen value changed: %q", got)
+	}
+	if err := cfg.Set("database.host", "db"); err != nil {
+		t.Fatalf("writing the frozen value unchanged should succeed: %v", err)
+	}
+	if err := cfg.Set("features.beta", true); err != nil {
+		t.Fatalf("unfrozen key should be writable: %v", err)
+	}
+	if err := cfg.FreezeSubtree("bad..pattern"); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected ErrInvalidKey for invalid pattern, got %v", err)
+	}
+}
+
+func TestFreezeSubtreeKeepsValuesOnReload(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "app.yaml")
+	if err := os.WriteFile(configFile, []byte("database:\n  host: db\nfeatures:\n  beta: false\n"), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	cfg, err := New(WithPath(tmpDir), WithName("app"), WithMode("yaml"), WithWatchDebounce(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if err := cfg.FreezeSubtree("database"); err != nil {
+		t.Fatalf("freeze failed: %v", err)
+	}
+
+	reloaded := make(chan struct{}, 4)
+	cfg.Watch(func() { reloaded <- struct{}{} })
+	if err := os.WriteFile(configFile, []byte("database:\n  host: other\n  port: 1\nfeatures:\n  beta: true\n"), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	select {
+	case <-reloaded:
+	case <-time.After(3 * time.Second):
+		t.Fatal("config was not reloaded")
+	}
+
+	if !cfg.GetBool("features.beta") {
+		t.Fatal("unfrozen subtree should be hot-reloaded")
+	}
+	if got := cfg.GetString("database.host"); got != "db" {
+		t.Fatalf("frozen value changed on reload: %q", got)
+	}
+	if cfg.IsSet("database.port") || cfg.GetInt("database.port") != 0 {
+		t.Fatal("new keys in a frozen subtree must be dropped on reload")
+	}
+}
@@ -51,15 +51,11 @@ func GetAs[T any](c *Config, key string, defaultValue ...T) T {
 		return zero
 	}
 
-	// 优先从缓存获取
-	if val, exists := c.getCachedValue(key); exists {
-		if converted, ok := convertValue[T](val); ok {
-			return converted
-		}
+	// 优先从缓存获取，否则使用完整的 getRaw 查找链（包含嵌套查找、环境变量回退）
+	val, exists := c.getCachedValue(key)
+	if !exists {
+		val, exists = c.getRaw(key)
 	}
-
-	// 使用完整的 getRaw 查找链（包含嵌套查找、环境变量回退）
-	val, exists := c.getRaw(key)
 	if !exists || val == nil {
 		if len(defaultValue) > 0 {
 			return defaultValue[0]
@@ -68,7 +64,16 @@ func GetAs[T any](c *Config, key string, defaultValue ...T) T {
 		return zero
 	}
 
-	if converted, ok := convertValue[T](val); ok {
+	converted, ok, err := coerceFor[T](c, key, val)
+	if err != nil {
+		c.logger.Warnf("%v, using default", err)
+		if len(defaultValue) > 0 {
+			return defaultValue[0]
+		}
+		var zero T
+		return zero
+	}
+	if ok {
 		return converted
 	}
 
@@ -83,6 +88,18 @@ func GetAs[T any](c *Config, key string, defaultValue ...T) T {
 	return zero
 }
 
+// coerceFor 按 T 转换配置值，类型不一致时记录转换；启用 WithStrictTypes 且转换不被允许时返回错误
+func coerceFor[T any](c *Config, key string, val any) (T, bool, error) {
+	if _, exact := val.(T); !exact {
+		if err := c.checkCoercion(key, val, reflect.TypeFor[T]()); err != nil {
+			var zero T
+			return zero, false, err
+		}
+	}
+	converted, ok := convertFor[T](c, val)
+	return converted, ok, nil
+}
+
 // GetAsWithError 返回转换后的值和错误，便于区分键不存在或转换失败的具体原因
 func GetAsWithError[T any](cfg *Config, key string) (T, error) {
 	var zero T
@@ -100,6 +117,14 @@ func GetAsWithError[T any](cfg *Config, key string) (T, error) {
 		return zero, fmt.Errorf("key %q not found", key)
 	}
 
+	if _, exact := raw.(T); !exact {
+		if err := cfg.checkCoercion(key, raw, reflect.TypeFor[T]()); err != nil {
+			return zero, err
+		}
+	}
+	if converted, ok := extendedBoolValue[T](cfg, raw); ok {
+		return converted, nil
+	}
 	converted, err := convertTo[T](raw)
 	if err != nil {
 		return zero, fmt.Errorf("failed to convert key %q to %T: %w", key, zero, err)
@@ -124,37 +149,46 @@ func GetSliceAs[T any](c *Config, key string) []T {
 	if !exists || val == nil {
 		return []T{}
 	}
-
-	// 尝试直接类型断言
-	if slice, ok := val.([]T); ok {
-		return append([]T(nil), slice...)
+	result, err := sliceAs[T](c, key, val, c.slicePolicy)
+	if err != nil {
+		c.logger.Errorf("%v", err)
+		return []T{}
 	}
+	return result
+}
 
-	// 处理interface{}切片
-	if interfaceSlice, ok := val.([]any); ok {
-		result := make([]T, 0, len(interfaceSlice))
-		for _, item := range interfaceSlice {
-			if converted, ok := convertValue[T](item); ok {
-				result = append(result, converted)
-			}
-		}
-		return result
+// GetSliceAsWithError 泛型获取切片配置值，键不存在、值不是切片或存在无法转换的元素时返回错误，
+// 后者匹配 ErrSliceElement（不受 WithSlicePolicy 影响）
+func GetSliceAsWithError[T any](c *Config, key string) ([]T, error) {
+	if c == nil {
+		return nil, fmt.Errorf("config cannot be nil")
 	}
-
-	// 处理其他切片类型的转换
-	rv := reflect.ValueOf(val)
-	if rv.Kind() == reflect.Slice {
-		result := make([]T, 0, rv.Len())
-		for i := 0; i < rv.Len(); i++ {
-			item := rv.Index(i).Interface()
-			if converted, ok := convertValue[T](item); ok {
-				result = append(result, converted)
-			}
-		}
-		return result
+	if key == "" {
+		return nil, fmt.Errorf("key cannot be empty")
+	}
+	val, exists := c.getRaw(key)
+	if !exists || val == nil {
+		return nil, fmt.Errorf("key %q not found", key)
 	}
+	if _, ok := sliceItems(val); !ok {
+		return nil, fmt.Errorf("key %q is not a list (%T)", key, val)
+	}
+	return sliceAs[T](c, key, val, SliceError)
+}
 
-	return []T{}
+// sliceAs 将切片值逐个转换为 T，无法转换的元素按 policy 处理；非切片值返回空切片
+func sliceAs[T any](c *Config, key string, val any, policy SlicePolicy) ([]T, error) {
+	// 尝试直接类型断言
+	if slice, ok := val.([]T); ok {
+		return append([]T(nil), slice...), nil
+	}
+	items, ok := sliceItems(val)
+	if !ok {
+		return []T{}, nil
+	}
+	return convertSlice(c, key, items, policy, func(item any) (T, bool) {
+		return convertFor[T](c, item)
+	})
 }
 
 // getTypeInfo 获取类型信息（带缓存），使用 sync.Map 实现无锁读取
@@ -307,6 +341,14 @@ func convertValue[T any](val any) (T, bool) {
 		return zero, false
 	}
 
+	// 已注册的枚举类型按名称解析
+	if enum, ok := lookupEnumType(reflect.TypeFor[T]()); ok {
+		if value, err := enum.parse("", val); err == nil {
+			return value.(T), true
+		}
+		return zero, false
+	}
+
 	// 获取缓存的类型信息（含预编译转换器）
 	info := getTypeInfo[T]()
 
@@ -328,6 +370,10 @@ func convertTo[T any](val any) (T, error) {
 		return converted, nil
 	}
 	var zero T
+	if enum, ok := lookupEnumType(reflect.TypeFor[T]()); ok {
+		_, err := enum.parse("", val)
+		return zero, err
+	}
 	return zero, fmt.Errorf("convert failed")
 }
 
@@ -356,7 +402,7 @@ func GetWithFallback[T any](c *Config, keys ...string) T {
 	for _, key := range keys {
 		if key != "" {
 			if val, exists := c.getRaw(key); exists && val != nil {
-				if converted, ok := convertValue[T](val); ok {
+				if converted, ok := convertFor[T](c, val); ok {
 					return converted
 				}
 			}
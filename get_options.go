@@ -0,0 +1,107 @@
+package sysconf
+
+// GetOption E 系列 getter（GetWithError、GetStringE、GetIntSliceE 等）的单次读取选项，如 WithDefault、NoCache、
+// FromSource，可任意组合：cfg.GetStringE("k", sysconf.WithDefault("x"), sysconf.FromSource(sysconf.FileOnly))。
+// 普通 getter 保持类型化的默认值参数，需要单次读取选项时使用对应的 E 系列方法
+type GetOption func(*getOptions)
+
+// SourceScope 单次读取的来源范围
+type SourceScope int
+
+const (
+	AllSources SourceScope = iota // 按来源优先级读取全部来源（默认）
+	FileOnly                      // 只读取配置数据（文件、内容、远程源与 Set 写入的值），忽略环境变量与覆盖值
+)
+
+// getOptions 单次读取的设置
+type getOptions struct {
+	def     any
+	hasDef  bool
+	noCache bool
+	scope   SourceScope
+}
+
+// WithDefault 设置键不存在时返回的默认值（此时 E 系列方法不再返回错误），值会转换为 getter 的返回类型
+// （全局单例 Default 已占用该名称）
+func WithDefault(value any) GetOption {
+	return func(o *getOptions) {
+		o.def, o.hasDef = value, true
+	}
+}
+
+// NoCache 跳过环境变量与回退查询的缓存（WithLookupTTL），直接读取最新的环境变量
+func NoCache() GetOption {
+	return func(o *getOptions) {
+		o.noCache = true
+	}
+}
+
+// FromSource 限制本次读取的来源范围，例如 FromSource(FileOnly) 忽略环境变量。
+// 兼容引擎在默认来源优先级下加载时会将已存在的环境变量合并进配置数据，此时 FileOnly 只能排除读取时的
+// 环境变量查找；需要严格区分来源时使用 NativeEngine 或 WithSourcePriority。
+func FromSource(scope SourceScope) GetOption {
+	return func(o *getOptions) {
+		o.scope = scope
+	}
+}
+
+// applyGetOptions 应用单次读取选项
+func applyGetOptions(opts []GetOption) getOptions {
+	if len(opts) == 0 {
+		return getOptions{}
+	}
+	var o getOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// defaultAs 返回转换为 T 的默认值，未设置或无法转换时返回零值
+func defaultAs[T any](o *getOptions) T {
+	if o.hasDef {
+		if value, ok := o.def.(T); ok {
+			return value
+		}
+		if value, ok := convertValue[T](o.def); ok {
+			return value
+		}
+	}
+	var zero T
+	return zero
+}
+
+// getRawWith 按读取选项读取原始配置值，空键视为不存在
+func (c *Config) getRawWith(key string, o *getOptions) (any, bool) {
+	switch {
+	case key == "":
+		return nil, false
+	case !o.noCache && o.scope == AllSources:
+		return c.getRaw(key)
+	}
+	data := c.loadData()
+	key = c.resolveKey(data, key)
+	c.markRead(key)
+	return c.lookupScoped(data, key, o)
+}
+
+// lookupScoped 按读取选项限定来源与缓存后读取已解析的配置键
+func (c *Config) lookupScoped(data map[string]any, key string, o *getOptions) (any, bool) {
+	var mode lookupMode
+	if o.noCache {
+		mode |= lookupNoCache
+	}
+	if o.scope == FileOnly {
+		mode |= lookupNoEnv
+	}
+	value, found := c.lookupSourcesMode(data, key, mode)
+	if o.scope != FileOnly && c.hasOverrides() {
+		value, found = c.applyOverrides(key, value, found)
+	}
+	if found && c.fileRefMax > 0 {
+		value = c.resolveFileRefs(value)
+	}
+	return value, found
+}
@@ -0,0 +1,115 @@
+package sysconf
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOptionDefaults(t *testing.T) {
+	cfg, err := New(WithContent("name: app\nports: [1, x]\n"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	s, err := cfg.GetStringE("missing", WithDefault("x"))
+	require.NoError(t, err, "设置默认值后缺失的键不返回错误")
+	assert.Equal(t, "x", s)
+	s, err = cfg.GetStringE("name", WithDefault("x"))
+	require.NoError(t, err)
+	assert.Equal(t, "app", s)
+
+	n, err := cfg.GetIntE("missing", WithDefault("42"))
+	require.NoError(t, err)
+	assert.Equal(t, 42, n, "默认值应转换为返回类型")
+	d, err := cfg.GetDurationE("missing", WithDefault(5*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, d)
+	ints, err := cfg.GetIntSliceE("missing", WithDefault([]int{1, 2}))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ints)
+	m, err := cfg.GetStringMapStringE("missing", WithDefault(map[string]string{"a": "b"}))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "b"}, m)
+	v, err := cfg.GetWithError("missing", WithDefault("fallback"))
+	require.NoError(t, err)
+	assert.Equal(t, "fallback", v)
+
+	// 默认值只用于缺失的键，无法转换的值仍然返回错误
+	_, err = cfg.GetIntSliceE("ports", WithDefault([]int{80}))
+	assert.True(t, errors.Is(err, ErrSliceElement), "expected ErrSliceElement, got %v", err)
+	_, err = cfg.GetStringE("", WithDefault("x"))
+	assert.Error(t, err, "空键返回错误")
+}
+
+func TestGetOptionFromSourceAndNoCache(t *testing.T) {
+	t.Setenv("OPT_HOST", "from-env")
+	cfg, err := New(WithContent("host: from-file\n"), WithEnv("OPT"), WithLookupTTL(time.Hour), WithEngine(NativeEngine))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	assert.Equal(t, "from-env", cfg.GetString("host"))
+	host, err := cfg.GetStringE("host", FromSource(FileOnly))
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", host)
+	assert.False(t, cfg.IsSet("port"))
+	t.Setenv("OPT_PORT", "8080")
+	_, err = cfg.GetIntE("port", FromSource(FileOnly))
+	assert.Error(t, err, "FileOnly 不应回退到环境变量")
+
+	// 查询缓存命中旧值时，NoCache 直接读取最新的环境变量
+	require.NoError(t, os.Setenv("OPT_HOST", "changed"))
+	assert.Equal(t, "from-env", cfg.GetString("host"))
+	host, err = cfg.GetStringE("host", NoCache())
+	require.NoError(t, err)
+	assert.Equal(t, "changed", host)
+}
+
+func TestGetOptionMissingKeyReturnsError(t *testing.T) {
+	cfg, err := New(WithContent("name: app\nlimits:\n  workers: 100-200\n"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	assert.NotPanics(t, func() { _ = cfg.GetString("missing") }, "普通 getter 不因缺失的键 panic")
+	checks := map[string]func() error{
+		"GetWithError":         func() error { _, err := cfg.GetWithError("missing"); return err },
+		"GetStringE":           func() error { _, err := cfg.GetStringE("missing"); return err },
+		"GetPathE":             func() error { _, err := cfg.GetPathE("missing"); return err },
+		"GetTimeE":             func() error { _, err := cfg.GetTimeE("missing"); return err },
+		"GetIntBetweenE":       func() error { _, err := cfg.GetIntBetweenE("missing"); return err },
+		"GetDurationJitteredE": func() error { _, err := cfg.GetDurationJitteredE("missing", 0.1); return err },
+		"GetStringSliceE":      func() error { _, err := cfg.GetStringSliceE("missing"); return err },
+		"GetBoolSliceE":        func() error { _, err := cfg.GetBoolSliceE("missing"); return err },
+		"GetFloatSliceE":       func() error { _, err := cfg.GetFloatSliceE("missing"); return err },
+		"GetTimeSliceE":        func() error { _, err := cfg.GetTimeSliceE("missing"); return err },
+		"GetStringMapE":        func() error { _, err := cfg.GetStringMapE("missing"); return err },
+	}
+	for name, check := range checks {
+		assert.Error(t, check(), "%s 应以错误报告缺失的键", name)
+	}
+
+	n, err := cfg.GetIntBetweenE("limits.workers")
+	require.NoError(t, err)
+	assert.True(t, n >= 100 && n <= 200, "got %d", n)
+}
+
+func TestGetOptionDurationJittered(t *testing.T) {
+	t.Setenv("JIT_RETRY_BASE", "1m")
+	cfg, err := New(WithContent("retry:\n  base: 1s\n"), WithEnv("JIT"), WithEngine(NativeEngine))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	d, err := cfg.GetDurationJitteredE("retry.missing", 0, WithDefault(2*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, d)
+	d, err = cfg.GetDurationJitteredE("retry.missing", 0.1, WithDefault("10s"))
+	require.NoError(t, err)
+	assert.True(t, d >= 9*time.Second && d <= 11*time.Second, "默认值同样叠加抖动: %v", d)
+	assert.Equal(t, time.Minute, cfg.GetDurationJittered("retry.base", 0))
+	d, err = cfg.GetDurationJitteredE("retry.base", 0, FromSource(FileOnly))
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, d)
+}
@@ -3,6 +3,9 @@ package sysconf
 import (
 	"fmt"
 	"maps"
+	"math"
+	"math/rand/v2"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -31,15 +34,14 @@ func (c *Config) Get(key string, def ...any) any {
 	}()
 
 	if key == "" {
-		if len(def) > 0 {
-			return def[0]
-		}
-		return nil
+		return firstDefault(def)
 	}
 
 	// 使用新的无锁原子读取
 	if val, exists := c.getRaw(key); exists {
-		c.logger.Debugf("Get config value: %s = %v", key, val)
+		if c.debugEnabled() {
+			c.logger.Debugf("Get config value: %s = %v", key, val)
+		}
 		return deepCloneValue(val)
 	}
 
@@ -48,7 +50,9 @@ func (c *Config) Get(key string, def ...any) any {
 		return def[0]
 	}
 
-	c.logger.Debugf("Config key not found: %s", key)
+	if c.debugEnabled() {
+		c.logger.Debugf("Config key not found: %s", key)
+	}
 	return nil
 }
 
@@ -66,10 +70,7 @@ func (c *Config) Get(key string, def ...any) any {
 //   - 布尔类型的配置值，如果键不存在且提供了默认值则返回默认值
 func (c *Config) GetBool(key string, def ...bool) bool {
 	if key == "" {
-		if len(def) > 0 {
-			return def[0]
-		}
-		return false
+		return firstDefault(def)
 	}
 
 	if val, exists := c.getRaw(key); exists {
@@ -77,6 +78,10 @@ func (c *Config) GetBool(key string, def ...bool) bool {
 		if b, ok := val.(bool); ok {
 			return b
 		}
+		if err := c.checkCoercion(key, val, reflect.TypeFor[bool]()); err != nil {
+			c.logger.Warnf("%v, using default", err)
+			return firstDefault(def)
+		}
 		// 支持数字类型
 		switch v := val.(type) {
 		case int:
@@ -94,6 +99,9 @@ func (c *Config) GetBool(key string, def ...bool) bool {
 			case "false", "no", "off", "0":
 				return false
 			}
+			if b, ok := parseExtendedBool(s); ok && c.extendedBools {
+				return b
+			}
 		}
 		// 回退到 cast 转换
 		if result, err := cast.ToBoolE(val); err == nil {
@@ -101,11 +109,7 @@ func (c *Config) GetBool(key string, def ...bool) bool {
 		}
 	}
 
-	if len(def) > 0 {
-		return def[0]
-	}
-
-	return false
+	return firstDefault(def)
 }
 
 // GetFloat 获取浮点数配置
@@ -118,10 +122,7 @@ func (c *Config) GetBool(key string, def ...bool) bool {
 //   - 浮点类型的配置值，如果键不存在且提供了默认值则返回默认值
 func (c *Config) GetFloat(key string, def ...float64) float64 {
 	if key == "" {
-		if len(def) > 0 {
-			return def[0]
-		}
-		return 0
+		return firstDefault(def)
 	}
 
 	if val, exists := c.getRaw(key); exists {
@@ -135,17 +136,20 @@ func (c *Config) GetFloat(key string, def ...float64) float64 {
 		if i, ok := val.(int); ok {
 			return float64(i)
 		}
+		if err := c.checkCoercion(key, val, reflect.TypeFor[float64]()); err != nil {
+			c.logger.Warnf("%v, using default", err)
+			return firstDefault(def)
+		}
 		// 回退到 cast 转换
 		if result, err := cast.ToFloat64E(val); err == nil {
 			return result
 		}
+		if result, ok := c.lenientFloat(key, val); ok {
+			return result
+		}
 	}
 
-	if len(def) > 0 {
-		return def[0]
-	}
-
-	return 0.0
+	return firstDefault(def)
 }
 
 // GetInt 获取整数配置
@@ -158,10 +162,7 @@ func (c *Config) GetFloat(key string, def ...float64) float64 {
 //   - 整数类型的配置值，如果键不存在且提供了默认值则返回默认值
 func (c *Config) GetInt(key string, def ...int) int {
 	if key == "" {
-		if len(def) > 0 {
-			return def[0]
-		}
-		return 0
+		return firstDefault(def)
 	}
 
 	if val, exists := c.getRaw(key); exists {
@@ -172,20 +173,23 @@ func (c *Config) GetInt(key string, def ...int) int {
 		if i, ok := val.(int64); ok {
 			return int(i)
 		}
-		if f, ok := val.(float64); ok {
+		if f, ok := val.(float64); ok && !c.strictTypes {
 			return int(f)
 		}
+		if err := c.checkCoercion(key, val, reflect.TypeFor[int]()); err != nil {
+			c.logger.Warnf("%v, using default", err)
+			return firstDefault(def)
+		}
 		// 回退到 cast 转换
 		if result, err := cast.ToIntE(val); err == nil {
 			return result
 		}
+		if result, ok := c.lenientInt(key, val); ok {
+			return result
+		}
 	}
 
-	if len(def) > 0 {
-		return def[0]
-	}
-
-	return 0
+	return firstDefault(def)
 }
 
 // GetString 获取字符串配置
@@ -198,28 +202,30 @@ func (c *Config) GetInt(key string, def ...int) int {
 //   - 字符串类型的配置值，如果键不存在且提供了默认值则返回默认值
 func (c *Config) GetString(key string, def ...string) string {
 	if key == "" {
-		if len(def) > 0 {
-			return def[0]
-		}
-		return ""
+		return firstDefault(def)
 	}
 
 	if val, exists := c.getRaw(key); exists {
+		if c.isPathKey(key) {
+			if result, err := cast.ToStringE(val); err == nil {
+				return c.normalizePath(result)
+			}
+		}
 		// 快速路径：直接类型断言
 		if s, ok := val.(string); ok {
 			return s
 		}
+		if err := c.checkCoercion(key, val, reflect.TypeFor[string]()); err != nil {
+			c.logger.Warnf("%v, using default", err)
+			return firstDefault(def)
+		}
 		// 回退到 cast 转换
 		if result, err := cast.ToStringE(val); err == nil {
 			return result
 		}
 	}
 
-	if len(def) > 0 {
-		return def[0]
-	}
-
-	return ""
+	return firstDefault(def)
 }
 
 // GetStringPath 使用路径片段读取字符串配置（例如: GetStringPath("database", "host")）。
@@ -263,26 +269,45 @@ func joinConfigPath(parts ...string) string {
 //
 // 参数:
 //   - key: 配置键名
+//   - opts: 可选的读取选项，如 WithDefaultSlice、WithDelimiter
 //
 // 返回值:
 //   - 字符串切片类型的配置值
-func (c *Config) GetStringSlice(key string) []string {
+func (c *Config) GetStringSlice(key string, opts ...SliceOption) []string {
+	var o sliceOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
 	if key == "" {
-		return []string{}
+		return o.defaultSlice()
 	}
 
 	// 使用新的原子存储系统
 	val, exists := c.getRaw(key)
 	if !exists {
-		return []string{}
+		return o.defaultSlice()
 	}
 
-	result, err := cast.ToStringSliceE(val)
-	if err != nil {
-		return []string{}
+	var result []string
+	if s, ok := val.(string); ok && o.delimiter != "" {
+		result = splitDelimited(s, o.delimiter)
+	} else if items, ok := val.([]any); ok {
+		var err error
+		if result, err = convertSlice(c, key, items, c.slicePolicy, castOK(cast.ToStringE)); err != nil {
+			c.logger.Errorf("%v", err)
+			return o.defaultSlice()
+		}
+	} else {
+		var err error
+		if result, err = cast.ToStringSliceE(val); err != nil {
+			return o.defaultSlice()
+		}
 	}
-	if result == nil {
-		return []string{}
+	if len(result) == 0 {
+		return o.defaultSlice()
+	}
+	if c.isPathKey(key) {
+		return c.normalizePathValue(result).([]string)
 	}
 	return append([]string(nil), result...)
 }
@@ -308,32 +333,31 @@ func (c *Config) GetBoolSlice(key string) []bool {
 		return []bool{}
 	}
 
-	switch v := val.(type) {
-	case []bool:
+	if v, ok := val.([]bool); ok {
 		return append([]bool(nil), v...)
-	case []any:
-		result := make([]bool, 0, len(v))
-		for _, item := range v {
-			if b, ok := item.(bool); ok {
-				result = append(result, b)
-			} else if s, ok := item.(string); ok {
-				if b, err := strconv.ParseBool(s); err == nil {
-					result = append(result, b)
-				}
-			}
-		}
-		return result
-	case []string:
-		result := make([]bool, 0, len(v))
-		for _, s := range v {
-			if b, err := strconv.ParseBool(s); err == nil {
-				result = append(result, b)
-			}
-		}
-		return result
-	default:
+	}
+	items, ok := sliceItems(val)
+	if !ok {
 		return []bool{}
 	}
+	result, err := convertSlice(c, key, items, c.slicePolicy, boolItem)
+	if err != nil {
+		c.logger.Errorf("%v", err)
+		return []bool{}
+	}
+	return result
+}
+
+// boolItem 转换布尔切片元素，支持布尔值与 strconv.ParseBool 可解析的字符串
+func boolItem(item any) (bool, bool) {
+	switch v := item.(type) {
+	case bool:
+		return v, true
+	case string:
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	}
+	return false, false
 }
 
 // GetIntSlice 获取整数切片配置
@@ -354,6 +378,14 @@ func (c *Config) GetIntSlice(key string) []int {
 		return []int{}
 	}
 
+	if items, ok := val.([]any); ok {
+		result, err := convertSlice(c, key, items, c.slicePolicy, castOK(cast.ToIntE))
+		if err != nil {
+			c.logger.Errorf("%v", err)
+			return []int{}
+		}
+		return result
+	}
 	result, err := cast.ToIntSliceE(val)
 	if err != nil {
 		return []int{}
@@ -381,9 +413,14 @@ func (c *Config) GetFloatSlice(key string) []float64 {
 	if !exists {
 		val = nil
 	}
-	c.logger.Debugf("GetFloatSlice[%s] - 原始值: %v (类型: %T)", key, val, val)
+	debug := c.debugEnabled()
+	if debug {
+		c.logger.Debugf("GetFloatSlice[%s] - 原始值: %v (类型: %T)", key, val, val)
+	}
 	if val == nil {
-		c.logger.Debugf("GetFloatSlice[%s] - 值为nil，返回空切片", key)
+		if debug {
+			c.logger.Debugf("GetFloatSlice[%s] - 值为nil，返回空切片", key)
+		}
 		return []float64{}
 	}
 
@@ -391,35 +428,22 @@ func (c *Config) GetFloatSlice(key string) []float64 {
 	switch v := val.(type) {
 	case []float64:
 		// 已经是float64切片，直接返回
-		c.logger.Debugf("GetFloatSlice[%s] - 直接返回[]float64: %v", key, v)
+		if debug {
+			c.logger.Debugf("GetFloatSlice[%s] - 直接返回[]float64: %v", key, v)
+		}
 		return append([]float64(nil), v...)
 
-	case []any:
-		// interface{}切片，逐个转换
-		result := make([]float64, 0, len(v))
-		for i, item := range v {
-			if f, err := cast.ToFloat64E(item); err == nil {
-				c.logger.Debugf("GetFloatSlice[%s] - 元素[%d] %v -> %f", key, i, item, f)
-				result = append(result, f)
-			} else {
-				c.logger.Debugf("GetFloatSlice[%s] - 元素[%d] %v 转换失败: %v", key, i, item, err)
-			}
+	case []any, []string:
+		// 逐个转换，无法转换的元素按 WithSlicePolicy 处理
+		items, _ := sliceItems(v)
+		result, err := convertSlice(c, key, items, c.slicePolicy, castOK(cast.ToFloat64E))
+		if err != nil {
+			c.logger.Errorf("%v", err)
+			return []float64{}
 		}
-		c.logger.Debugf("GetFloatSlice[%s] - []interface{}转换结果: %v (长度: %d)", key, result, len(result))
-		return result
-
-	case []string:
-		// 字符串切片，转换为float64
-		result := make([]float64, 0, len(v))
-		for i, s := range v {
-			if f, err := cast.ToFloat64E(s); err == nil {
-				c.logger.Debugf("GetFloatSlice[%s] - 字符串[%d] %s -> %f", key, i, s, f)
-				result = append(result, f)
-			} else {
-				c.logger.Debugf("GetFloatSlice[%s] - 字符串[%d] %s 转换失败: %v", key, i, s, err)
-			}
+		if debug {
+			c.logger.Debugf("GetFloatSlice[%s] - 逐个转换结果: %v (长度: %d)", key, result, len(result))
 		}
-		c.logger.Debugf("GetFloatSlice[%s] - []string转换结果: %v (长度: %d)", key, result, len(result))
 		return result
 
 	case []int:
@@ -427,10 +451,14 @@ func (c *Config) GetFloatSlice(key string) []float64 {
 		result := make([]float64, 0, len(v))
 		for i, n := range v {
 			f := float64(n)
-			c.logger.Debugf("GetFloatSlice[%s] - 整数[%d] %d -> %f", key, i, n, f)
+			if debug {
+				c.logger.Debugf("GetFloatSlice[%s] - 整数[%d] %d -> %f", key, i, n, f)
+			}
 			result = append(result, f)
 		}
-		c.logger.Debugf("GetFloatSlice[%s] - []int转换结果: %v (长度: %d)", key, result, len(result))
+		if debug {
+			c.logger.Debugf("GetFloatSlice[%s] - []int转换结果: %v (长度: %d)", key, result, len(result))
+		}
 		return result
 
 	case []float32:
@@ -438,19 +466,27 @@ func (c *Config) GetFloatSlice(key string) []float64 {
 		result := make([]float64, 0, len(v))
 		for i, f32 := range v {
 			f64 := float64(f32)
-			c.logger.Debugf("GetFloatSlice[%s] - float32[%d] %f -> %f", key, i, f32, f64)
+			if debug {
+				c.logger.Debugf("GetFloatSlice[%s] - float32[%d] %f -> %f", key, i, f32, f64)
+			}
 			result = append(result, f64)
 		}
-		c.logger.Debugf("GetFloatSlice[%s] - []float32转换结果: %v (长度: %d)", key, result, len(result))
+		if debug {
+			c.logger.Debugf("GetFloatSlice[%s] - []float32转换结果: %v (长度: %d)", key, result, len(result))
+		}
 		return result
 
 	default:
 		// 尝试作为单个值转换
 		if f, err := cast.ToFloat64E(val); err == nil {
-			c.logger.Debugf("GetFloatSlice[%s] - 单个值转换: %v -> [%f]", key, val, f)
+			if debug {
+				c.logger.Debugf("GetFloatSlice[%s] - 单个值转换: %v -> [%f]", key, val, f)
+			}
 			return []float64{f}
 		}
-		c.logger.Debugf("GetFloatSlice[%s] - 无法转换类型 %T，返回空切片", key, val)
+		if debug {
+			c.logger.Debugf("GetFloatSlice[%s] - 无法转换类型 %T，返回空切片", key, val)
+		}
 		return []float64{}
 	}
 }
@@ -528,7 +564,7 @@ func cloneStringMapString(src map[string]string) map[string]string {
 	return dst
 }
 
-// GetTime 获取时间配置
+// GetTime 获取时间配置，优先按 WithTimeLayouts 指定的格式解析
 //
 // 参数:
 //   - key: 配置键名
@@ -542,7 +578,7 @@ func (c *Config) GetTime(key string) time.Time {
 
 	// 使用新的原子存储系统
 	if val, exists := c.getRaw(key); exists {
-		if result, err := cast.ToTimeE(val); err == nil {
+		if result, err := c.parseTime(val); err == nil {
 			return result
 		}
 	}
@@ -563,6 +599,12 @@ func (c *Config) GetDuration(key string) time.Duration {
 
 	// 使用新的原子存储系统
 	if val, exists := c.getRaw(key); exists {
+		if _, ok := val.(time.Duration); !ok {
+			if err := c.checkCoercion(key, val, reflect.TypeFor[time.Duration]()); err != nil {
+				c.logger.Warnf("%v, using default", err)
+				return 0
+			}
+		}
 		if result, err := cast.ToDurationE(val); err == nil {
 			return result
 		}
@@ -570,6 +612,110 @@ func (c *Config) GetDuration(key string) time.Duration {
 	return 0
 }
 
+// GetDurationJittered 获取时间间隔配置并叠加随机抖动，用于重试、退避等需要错开各实例节奏的场景
+//
+// 参数:
+//   - key: 配置键名
+//   - jitter: 抖动比例，0.1 表示在 ±10% 范围内均匀随机（取值限制在 [0, 1]）
+//
+// 返回值:
+//   - 抖动后的时间间隔，键不存在时返回 0
+func (c *Config) GetDurationJittered(key string, jitter float64) time.Duration {
+	return jitterDuration(c.GetDuration(key), jitter)
+}
+
+// jitterDuration 在 d 的 ±jitter 比例范围内均匀随机，jitter 限制在 [0, 1]
+func jitterDuration(d time.Duration, jitter float64) time.Duration {
+	jitter = min(max(jitter, 0), 1)
+	if d == 0 || jitter == 0 {
+		return d
+	}
+	factor := 1 + jitter*(2*rand.Float64()-1)
+	return time.Duration(float64(d) * factor)
+}
+
+// GetIntBetween 获取整数区间配置并返回区间内（含两端）的随机值，用于分散负载等场景
+//
+// 支持的配置形式: "100-200" 字符串、[100, 200] 两元素列表，或单个整数（直接返回）
+//
+// 参数:
+//   - key: 配置键名
+//   - def: 可选默认值，键不存在或格式无效时返回
+//
+// 返回值:
+//   - 区间内的随机整数
+func (c *Config) GetIntBetween(key string, def ...int) int {
+	fallback := firstDefault(def)
+	if key == "" {
+		return fallback
+	}
+
+	val, exists := c.getRaw(key)
+	if !exists {
+		return fallback
+	}
+	lo, hi, err := parseIntRange(val)
+	if err != nil {
+		c.logger.Warnf("Invalid int range for key '%s': %v", key, err)
+		return fallback
+	}
+	return randomInRange(lo, hi)
+}
+
+// randomInRange 返回 [lo, hi] 内的随机整数。区间宽度按 uint64 计算，
+// 跨越整个 int 取值范围时也不会溢出；结果按补码回绕到区间内
+func randomInRange(lo, hi int) int {
+	width := uint64(hi) - uint64(lo)
+	if width == math.MaxUint64 {
+		return int(rand.Uint64())
+	}
+	return lo + int(rand.Uint64N(width+1))
+}
+
+// parseIntRange 解析整数区间，返回有序的上下界
+func parseIntRange(val any) (lo, hi int, err error) {
+	switch v := val.(type) {
+	case string:
+		s := strings.TrimSpace(v)
+		// 跳过首字符以支持负数下界，如 "-10-10"
+		idx := strings.Index(s[min(1, len(s)):], "-")
+		if idx < 0 {
+			lo, err = strconv.Atoi(s)
+			return lo, lo, err
+		}
+		idx++
+		if lo, err = strconv.Atoi(strings.TrimSpace(s[:idx])); err != nil {
+			return 0, 0, err
+		}
+		if hi, err = strconv.Atoi(strings.TrimSpace(s[idx+1:])); err != nil {
+			return 0, 0, err
+		}
+	case []any:
+		if len(v) != 2 {
+			return 0, 0, fmt.Errorf("range list must have 2 elements, got %d", len(v))
+		}
+		if lo, err = cast.ToIntE(v[0]); err != nil {
+			return 0, 0, err
+		}
+		if hi, err = cast.ToIntE(v[1]); err != nil {
+			return 0, 0, err
+		}
+	case []int:
+		items := make([]any, len(v))
+		for i, n := range v {
+			items[i] = n
+		}
+		return parseIntRange(items)
+	default:
+		lo, err = cast.ToIntE(val)
+		return lo, lo, err
+	}
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return lo, hi, nil
+}
+
 // GetWithError 获取配置值并返回错误信息
 //
 // 参数:
@@ -577,15 +723,28 @@ func (c *Config) GetDuration(key string) time.Duration {
 //
 // 返回值:
 //   - 配置值和可能的错误
-func (c *Config) GetWithError(key string) (any, error) {
+func (c *Config) GetWithError(key string, opts ...GetOption) (any, error) {
 	if key == "" {
 		return nil, fmt.Errorf("empty configuration key")
 	}
 
+	o := applyGetOptions(opts)
 	// 使用新的原子存储系统
-	val, exists := c.getRaw(key)
+	val, exists := c.getRawWith(key, &o)
 	if !exists {
+		if o.hasDef {
+			return o.def, nil
+		}
 		return nil, fmt.Errorf("configuration key '%s' not found", key)
 	}
 	return val, nil
 }
+
+// firstDefault 返回可选默认值参数中的第一个，未提供时返回零值
+func firstDefault[T any](def []T) T {
+	if len(def) > 0 {
+		return def[0]
+	}
+	var zero T
+	return zero
+}
@@ -263,3 +263,70 @@ func TestGettersReturnDefensiveCopies(t *testing.T) {
 func TestGetEnvPrefix(t *testing.T) {
 	t.Skip("环境变量设置测试依赖于文件系统，暂时跳过。")
 }
+
+func TestGetDurationJitteredAndIntBetween(t *testing.T) {
+	c, err := New(WithContent("retry:\n  base: 1s\n  spread: 100-200\n  negative: -10--5\n  list: [30, 10]\n  fixed: 7\n  bad: abc\n"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	for range 200 {
+		d := c.GetDurationJittered("retry.base", 0.1)
+		require.GreaterOrEqual(t, d, 900*time.Millisecond)
+		require.LessOrEqual(t, d, 1100*time.Millisecond)
+
+		n := c.GetIntBetween("retry.spread")
+		require.True(t, n >= 100 && n <= 200, "out of range: %d", n)
+		n = c.GetIntBetween("retry.negative")
+		require.True(t, n >= -10 && n <= -5, "out of range: %d", n)
+		n = c.GetIntBetween("retry.list")
+		require.True(t, n >= 10 && n <= 30, "out of range: %d", n)
+	}
+
+	assert.Equal(t, time.Second, c.GetDurationJittered("retry.base", 0))
+	assert.Equal(t, time.Duration(0), c.GetDurationJittered("retry.missing", 0.5))
+	assert.Equal(t, 7, c.GetIntBetween("retry.fixed"))
+	assert.Equal(t, 42, c.GetIntBetween("retry.bad", 42))
+	assert.Equal(t, 5, c.GetIntBetween("retry.missing", 5))
+}
+
+func TestGetIntBetweenWideRanges(t *testing.T) {
+	c, err := New(WithContent("range:\n  full: \"-9223372036854775808-9223372036854775807\"\n" +
+		"  upper: [0, 9223372036854775807]\n  lower: [-9223372036854775808, -1]\n"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	for range 200 {
+		require.NotPanics(t, func() { c.GetIntBetween("range.full") })
+		n := c.GetIntBetween("range.upper")
+		require.GreaterOrEqual(t, n, 0)
+		n = c.GetIntBetween("range.lower")
+		require.Less(t, n, 0)
+	}
+}
+
+func TestGetStringSliceOptions(t *testing.T) {
+	cfg, err := New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	require.NoError(t, cfg.Set("hosts", "a.example.com; b.example.com;;c.example.com "))
+	require.NoError(t, cfg.Set("list", []string{"x;y", "z"}))
+	require.NoError(t, cfg.Set("empty", ""))
+
+	assert.Equal(t, []string{"a.example.com", "b.example.com", "c.example.com"},
+		cfg.GetStringSlice("hosts", WithDelimiter(";")))
+	assert.Equal(t, []string{"x;y", "z"}, cfg.GetStringSlice("list", WithDelimiter(";")),
+		"分隔符只作用于字符串值")
+
+	def := []string{"localhost"}
+	assert.Equal(t, def, cfg.GetStringSlice("missing", WithDefaultSlice(def)))
+	assert.Equal(t, def, cfg.GetStringSlice("empty", WithDefaultSlice(def), WithDelimiter(";")))
+	assert.Equal(t, []string{"x;y", "z"}, cfg.GetStringSlice("list", WithDefaultSlice(def)))
+
+	got := cfg.GetStringSlice("missing", WithDefaultSlice(def))
+	got[0] = "mutated"
+	assert.Equal(t, "localhost", def[0], "默认值应返回副本")
+
+	assert.Equal(t, []string{"a.example.com;", "b.example.com;;c.example.com"},
+		cfg.GetStringSlice("hosts"), "未设置分隔符时保持按空白分隔")
+}
@@ -0,0 +1,66 @@
+package sysconf
+
+import (
+	"github.com/hashicorp/hcl"
+)
+
+// hclModes HCL 格式的模式名（tfvars 为 Terraform 变量文件）
+var hclModes = []string{"hcl", "tfvars"}
+
+// hclCodec 实现 viper.Codec，只支持读取。viper 已不再内置 HCL 支持，由此补齐；
+// HCL 为只读格式，Set/SetMultiple 在修改前即由 checkWritableFormat 拒绝
+type hclCodec struct{}
+
+// Encode 实现 viper.Encoder，HCL 不支持写回
+func (hclCodec) Encode(map[string]any) ([]byte, error) {
+	return nil, ErrFormatNotWritable
+}
+
+// Decode 实现 viper.Decoder
+func (hclCodec) Decode(b []byte, v map[string]any) error {
+	nested, err := parseHCL(b)
+	if err != nil {
+		return err
+	}
+	for key, value := range nested {
+		v[key] = value
+	}
+	return nil
+}
+
+// parseHCL 解析 HCL（v1）内容。块语法（server { port = 80 }）被 HCL 解码为单元素的对象列表，
+// 此处展开为嵌套 map，使 server.port 可按层级访问
+func parseHCL(data []byte) (map[string]any, error) {
+	var result map[string]any
+	if err := hcl.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return unwrapHCLBlocks(result).(map[string]any), nil
+}
+
+// unwrapHCLBlocks 递归将单元素的对象列表展开为对象
+func unwrapHCLBlocks(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = unwrapHCLBlocks(item)
+		}
+		return v
+	case []map[string]any:
+		if len(v) == 1 {
+			return unwrapHCLBlocks(v[0])
+		}
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = unwrapHCLBlocks(item)
+		}
+		return items
+	case []any:
+		for i, item := range v {
+			v[i] = unwrapHCLBlocks(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,179 @@
+package sysconf
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// HealthEventType 健康事件类型
+type HealthEventType string
+
+const (
+	// HealthEventFileNotFound 配置文件被删除或移走，继续使用最后一次成功加载的配置
+	HealthEventFileNotFound HealthEventType = "FileNotFound"
+	// HealthEventFileRestored 配置文件重新出现并已成功重载
+	HealthEventFileRestored HealthEventType = "FileRestored"
+	// HealthEventReloadFailed 配置文件重载失败，继续使用最后一次成功加载的配置
+	HealthEventReloadFailed HealthEventType = "ReloadFailed"
+	// HealthEventReloaded 配置文件重载成功
+	HealthEventReloaded HealthEventType = "Reloaded"
+	// HealthEventRecoveredFromBackup 启动时主配置文件无法解密或解析，已加载上一版本备份（.prev）
+	HealthEventRecoveredFromBackup HealthEventType = "RecoveredFromBackup"
+	// HealthEventWatcherError 文件监听器出错或断开（如事件队列溢出），监听器将按退避自动重建
+	HealthEventWatcherError HealthEventType = "WatcherError"
+	// HealthEventWatcherRestarted 文件监听器已重建
+	HealthEventWatcherRestarted HealthEventType = "WatcherRestarted"
+)
+
+// maxHealthEvents 保留的最近健康事件数量
+const maxHealthEvents = 32
+
+// HealthEvent 配置健康事件
+type HealthEvent struct {
+	Type    HealthEventType // 事件类型
+	File    string          // 相关配置文件
+	Message string          // 事件描述
+	Err     error           // 关联错误（可能为 nil）
+	Time    time.Time       // 事件发生时间
+	Watcher string          // 监听器名称（config、source、extra），仅监听器事件设置
+}
+
+// HealthStatus 配置健康状态快照
+type HealthStatus struct {
+	Healthy        bool          // 最近一次重载是否成功且配置文件存在
+	File           string        // 当前配置文件路径
+	FileMissing    bool          // 配置文件是否缺失（此时使用最后一次成功加载的配置）
+	LastReloadAt   time.Time     // 最近一次成功重载时间
+	LastError      error         // 最近一次错误
+	LastErrorAt    time.Time     // 最近一次错误时间
+	ReloadCount    int64         // 成功重载次数
+	ReloadFailures int64         // 失败重载次数
+	RecentEvents   []HealthEvent // 最近的健康事件（按时间先后）
+
+	WatcherDown     bool     // 存在已断开、等待重建的文件监听器（期间对应文件的变更不会自动重载）
+	DownWatchers    []string // 已断开的监听器名称：config（配置文件）、source（附加数据源）、extra（WatchExtraPath）
+	WatcherErrors   int64    // 文件监听错误次数
+	WatcherRestarts int64    // 文件监听器重建次数
+	DroppedEvents   int64    // 事件队列溢出次数，每次至少丢失一个文件事件
+}
+
+// healthState 健康状态记录器，独立加锁，避免与 mu 产生锁顺序问题
+type healthState struct {
+	mu        sync.Mutex
+	status    HealthStatus
+	listeners map[uint64]func(HealthEvent)
+	nextID    uint64
+}
+
+// Health 返回当前配置的健康状态
+func (c *Config) Health() HealthStatus {
+	c.health.mu.Lock()
+	defer c.health.mu.Unlock()
+
+	status := c.health.status
+	status.File = c.configFilePath()
+	status.DownWatchers = slices.Clone(c.health.status.DownWatchers)
+	status.WatcherDown = len(status.DownWatchers) > 0
+	status.Healthy = !status.FileMissing && !status.WatcherDown &&
+		(status.LastErrorAt.IsZero() || !status.LastReloadAt.Before(status.LastErrorAt))
+	status.RecentEvents = append([]HealthEvent(nil), c.health.status.RecentEvents...)
+	return status
+}
+
+// OnHealthEvent 注册健康事件回调，返回取消注册函数。
+// 回调在触发事件的 goroutine 中同步执行，不应长时间阻塞。
+func (c *Config) OnHealthEvent(fn func(HealthEvent)) func() {
+	if fn == nil {
+		return func() {}
+	}
+
+	c.health.mu.Lock()
+	if c.health.listeners == nil {
+		c.health.listeners = make(map[uint64]func(HealthEvent))
+	}
+	c.health.nextID++
+	id := c.health.nextID
+	c.health.listeners[id] = fn
+	c.health.mu.Unlock()
+
+	return func() {
+		c.health.mu.Lock()
+		delete(c.health.listeners, id)
+		c.health.mu.Unlock()
+	}
+}
+
+// emitHealthEvent 记录健康事件并通知监听者（调用者不得持有 mu）
+func (c *Config) emitHealthEvent(eventType HealthEventType, message string, err error) {
+	c.emitHealthEventFor(c.configFilePath(), eventType, message, err)
+}
+
+// emitHealthEventFor 记录关联到指定文件（主配置文件或附加数据源）的健康事件（调用者不得持有 mu）
+func (c *Config) emitHealthEventFor(file string, eventType HealthEventType, message string, err error) {
+	c.emitHealth(HealthEvent{
+		Type:    eventType,
+		File:    file,
+		Message: message,
+		Err:     err,
+		Time:    time.Now(),
+	})
+}
+
+// emitHealth 更新健康状态并通知监听者（调用者不得持有 mu）
+func (c *Config) emitHealth(event HealthEvent) {
+	file, err := event.File, event.Err
+	c.health.mu.Lock()
+	status := &c.health.status
+	switch event.Type {
+	case HealthEventFileNotFound:
+		status.FileMissing = true
+		status.LastError = err
+		status.LastErrorAt = event.Time
+	case HealthEventReloadFailed:
+		status.ReloadFailures++
+		status.LastError = err
+		status.LastErrorAt = event.Time
+	case HealthEventRecoveredFromBackup:
+		status.LastError = err
+		status.LastErrorAt = event.Time
+	case HealthEventWatcherError:
+		status.WatcherErrors++
+		if errors.Is(err, ErrWatcherOverflow) {
+			status.DroppedEvents++
+		}
+		if event.Watcher != "" && !slices.Contains(status.DownWatchers, event.Watcher) {
+			status.DownWatchers = append(status.DownWatchers, event.Watcher)
+		}
+	case HealthEventWatcherRestarted:
+		status.WatcherRestarts++
+		status.DownWatchers = slices.DeleteFunc(status.DownWatchers, func(name string) bool { return name == event.Watcher })
+	case HealthEventReloaded, HealthEventFileRestored:
+		if file == c.configFilePath() {
+			status.FileMissing = false
+		}
+		status.ReloadCount++
+		status.LastReloadAt = event.Time
+	}
+	status.RecentEvents = append(status.RecentEvents, event)
+	if len(status.RecentEvents) > maxHealthEvents {
+		status.RecentEvents = status.RecentEvents[len(status.RecentEvents)-maxHealthEvents:]
+	}
+	listeners := make([]func(HealthEvent), 0, len(c.health.listeners))
+	for _, fn := range c.health.listeners {
+		listeners = append(listeners, fn)
+	}
+	c.health.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(event)
+	}
+}
+
+// isFileMissing 当前是否处于配置文件缺失状态
+func (c *Config) isFileMissing() bool {
+	c.health.mu.Lock()
+	defer c.health.mu.Unlock()
+	return c.health.status.FileMissing
+}
@@ -0,0 +1,89 @@
+package sysconf
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchFileDeleteRecreateCycles(t *testing.T) {
+	for _, engine := range []Engine{CompatibilityEngine, NativeEngine} {
+		t.Run(engine.String(), func(t *testing.T) {
+			cfg, configFile := newWatchTestConfig(t, WithEngine(engine))
+
+			events := make(chan HealthEvent, 32)
+			t.Cleanup(cfg.OnHealthEvent(func(e HealthEvent) { events <- e }))
+
+			changed := make(chan struct{}, 8)
+			stop := cfg.WatchWithContext(context.Background(), func() { changed <- struct{}{} })
+			t.Cleanup(stop)
+
+			for i, value := range []string{"first", "second"} {
+				require.NoError(t, os.Remove(configFile))
+				waitHealthEvent(t, events, HealthEventFileNotFound)
+
+				health := cfg.Health()
+				require.True(t, health.FileMissing, "cycle %d", i)
+				require.False(t, health.Healthy, "cycle %d", i)
+				require.Equal(t, "initial", cfg.GetString("key"), "last known good config must be kept")
+
+				require.NoError(t, os.WriteFile(configFile, []byte("key: "+value+"\n"), 0o644))
+				waitHealthEvent(t, events, HealthEventFileRestored)
+				waitSignal(t, changed, "watch callback should fire after file reappears")
+				require.Equal(t, value, cfg.GetString("key"))
+
+				health = cfg.Health()
+				require.False(t, health.FileMissing)
+				require.True(t, health.Healthy)
+
+				// 越过防抖窗口后恢复为初始内容，便于下一轮校验保留行为
+				time.Sleep(50 * time.Millisecond)
+				require.NoError(t, os.WriteFile(configFile, []byte("key: initial\n"), 0o644))
+				require.Eventually(t, func() bool { return cfg.GetString("key") == "initial" }, 3*time.Second, 10*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestHealthReloadFailureKeepsLastKnownGood(t *testing.T) {
+	cfg, configFile := newWatchTestConfig(t)
+
+	events := make(chan HealthEvent, 16)
+	t.Cleanup(cfg.OnHealthEvent(func(e HealthEvent) { events <- e }))
+	stop := cfg.WatchWithContext(context.Background(), func() {})
+	t.Cleanup(stop)
+
+	require.NoError(t, os.WriteFile(configFile, []byte("key: [unterminated\n"), 0o644))
+	event := waitHealthEvent(t, events, HealthEventReloadFailed)
+	require.Error(t, event.Err)
+
+	health := cfg.Health()
+	require.False(t, health.Healthy)
+	require.Positive(t, health.ReloadFailures)
+	require.Equal(t, "initial", cfg.GetString("key"))
+}
+
+func TestIsConfigFileMissingError(t *testing.T) {
+	_, err := os.ReadFile("/definitely/not/here.yaml")
+	require.True(t, isConfigFileMissingError(err))
+	require.False(t, isConfigFileMissingError(errors.New("parse failed")))
+}
+
+func waitHealthEvent(t *testing.T, ch <-chan HealthEvent, want HealthEventType) HealthEvent {
+	t.Helper()
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case e := <-ch:
+			if e.Type == want {
+				return e
+			}
+		case <-deadline:
+			t.Fatalf("health event %s not received", want)
+		}
+	}
+}
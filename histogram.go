@@ -0,0 +1,78 @@
+package sysconf
+
+import (
+	"math"
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	histSubBits    = 3                // 每个 2 的幂区间细分的位数
+	histSubBuckets = 1 << histSubBits // 每个区间的子桶数，相对误差约 1/8
+	histBuckets    = 64 * histSubBuckets
+)
+
+// latencyHistogram 无锁的对数分桶延迟直方图（HDR 风格），记录一次仅需一次原子加法
+type latencyHistogram struct {
+	buckets [histBuckets]atomic.Int64
+}
+
+// histBucket 返回纳秒值所在的桶
+func histBucket(ns int64) int {
+	if ns < histSubBuckets {
+		return int(max(ns, 0))
+	}
+	exp := bits.Len64(uint64(ns)) - 1
+	sub := int(ns>>(exp-histSubBits)) & (histSubBuckets - 1)
+	return (exp-histSubBits+1)*histSubBuckets + sub
+}
+
+// histBucketUpper 返回桶的上界（纳秒）
+func histBucketUpper(idx int) int64 {
+	if idx < histSubBuckets {
+		return int64(idx)
+	}
+	exp := idx/histSubBuckets + histSubBits - 1
+	sub := int64(idx % histSubBuckets)
+	width := int64(1) << (exp - histSubBits)
+	return (histSubBuckets+sub)*width + width - 1
+}
+
+// record 记录一次耗时
+func (h *latencyHistogram) record(d time.Duration) {
+	h.buckets[histBucket(int64(d))].Add(1)
+}
+
+// reset 清空所有桶
+func (h *latencyHistogram) reset() {
+	for i := range h.buckets {
+		h.buckets[i].Store(0)
+	}
+}
+
+// quantiles 返回各分位数（0~1）对应的耗时估计（所在桶的上界），无样本时返回零值
+func (h *latencyHistogram) quantiles(qs ...float64) []time.Duration {
+	var counts [histBuckets]int64
+	var total int64
+	for i := range h.buckets {
+		counts[i] = h.buckets[i].Load()
+		total += counts[i]
+	}
+	result := make([]time.Duration, len(qs))
+	if total == 0 {
+		return result
+	}
+	for i, q := range qs {
+		target := max(int64(math.Ceil(q*float64(total))), 1)
+		var cumulative int64
+		for idx, n := range counts {
+			cumulative += n
+			if cumulative >= target {
+				result[i] = time.Duration(histBucketUpper(idx))
+				break
+			}
+		}
+	}
+	return result
+}
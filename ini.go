@@ -0,0 +1,510 @@
+package sysconf
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// INISliceStyle INI 格式写入列表值的方式
+type INISliceStyle int
+
+const (
+	// INIRepeatedKeys 每个元素写为一行 key[] = value（默认），读取时还原为列表
+	INIRepeatedKeys INISliceStyle = iota
+	// INICommaList 写为一行 key = a, b, c，读取时为字符串，需配合 WithDelimiter(",") 读取为切片；
+	// 元素含逗号、为空或需要加引号时该键回退为 INIRepeatedKeys
+	INICommaList
+)
+
+// WithINISliceStyle 设置 ini 模式写入列表值的方式（默认 INIRepeatedKeys）
+func WithINISliceStyle(style INISliceStyle) Option {
+	return func(c *Config) {
+		c.iniSliceStyle = style
+	}
+}
+
+// WithINIComments 设置 ini 模式写回文件时是否保留注释（默认保留）。
+// 保留时，节与键之前的 ; 或 # 注释会随其一起写回；节与键的先后顺序总是按读取时的顺序保留。
+func WithINIComments(preserve bool) Option {
+	return func(c *Config) {
+		c.iniStripComments = !preserve
+	}
+}
+
+// iniLayout 读取 INI 内容时记录的布局，写回时按此恢复顺序与注释
+type iniLayout struct {
+	sections []string            // 节路径的出现顺序，根节为 ""
+	keys     map[string][]string // 节路径 → 键的出现顺序
+	comments map[string][]string // "[节路径]" 或键的完整路径 → 之前的注释行
+}
+
+// iniCodec 实现 viper.Codec，负责 ini 格式的读写（viper 已不再内置 ini 支持）
+type iniCodec struct{}
+
+// Encode 实现 viper.Encoder
+func (iniCodec) Encode(v map[string]any) ([]byte, error) {
+	return marshalINI(v, nil, INIRepeatedKeys)
+}
+
+// Decode 实现 viper.Decoder
+func (iniCodec) Decode(b []byte, v map[string]any) error {
+	nested, _, err := parseINI(b)
+	if err != nil {
+		return err
+	}
+	for key, value := range nested {
+		v[key] = value
+	}
+	return nil
+}
+
+// parseINI 解析 INI 内容并返回嵌套配置与布局。
+// [a.b] 形式的节名映射为嵌套层级，值一律按字符串保存；支持 ; 与 # 注释（行内注释需以空白开头）、
+// = 或 : 分隔符、双引号字符串（\" \\ \n \r \t 转义），key[] 或重复的键读取为列表。
+func parseINI(data []byte) (map[string]any, *iniLayout, error) {
+	result := make(map[string]any)
+	layout := &iniLayout{
+		sections: []string{""},
+		keys:     make(map[string][]string),
+		comments: make(map[string][]string),
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	section := ""
+	seen := make(map[string]bool)
+	var pending []string
+	for i, raw := range strings.Split(string(data), "\n") {
+		lineNo := i + 1
+		line := strings.TrimSpace(raw)
+		switch {
+		case line == "":
+			continue
+		case line[0] == ';' || line[0] == '#':
+			pending = append(pending, line)
+			continue
+		case line[0] == '[':
+			end := strings.IndexByte(line, ']')
+			if end < 0 || !iniRestIsComment(line[end+1:]) {
+				return nil, nil, fmt.Errorf("ini line %d: malformed section header %q", lineNo, line)
+			}
+			section = strings.TrimSpace(line[1:end])
+			if section == "" {
+				return nil, nil, fmt.Errorf("ini line %d: empty section name", lineNo)
+			}
+			if _, err := iniSectionMap(result, section); err != nil {
+				return nil, nil, fmt.Errorf("ini line %d: %w", lineNo, err)
+			}
+			if !slices.Contains(layout.sections, section) {
+				layout.sections = append(layout.sections, section)
+			}
+			if len(pending) > 0 {
+				layout.comments["["+section+"]"] = pending
+				pending = nil
+			}
+			continue
+		}
+
+		key, quoted, value, err := splitINILine(line)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ini line %d: %w", lineNo, err)
+		}
+		list := false
+		if !quoted {
+			key, list = strings.CutSuffix(key, "[]")
+		}
+		if key == "" {
+			return nil, nil, fmt.Errorf("ini line %d: empty key", lineNo)
+		}
+		path := joinKey(section, key)
+		parentPath, leaf := section, key
+		if idx := strings.LastIndexByte(path, '.'); idx >= 0 {
+			parentPath, leaf = path[:idx], path[idx+1:]
+		}
+		parent, err := iniSectionMap(result, parentPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ini line %d: %w", lineNo, err)
+		}
+		if _, isMap := parent[leaf].(map[string]any); isMap {
+			return nil, nil, fmt.Errorf("ini line %d: key %s conflicts with section of the same name", lineNo, path)
+		}
+
+		if !seen[path] {
+			layout.keys[section] = append(layout.keys[section], key)
+		}
+		if len(pending) > 0 {
+			layout.comments[path] = pending
+			pending = nil
+		}
+		if list || seen[path] {
+			items, isList := parent[leaf].([]any)
+			if !isList && seen[path] {
+				items = []any{parent[leaf]}
+			}
+			parent[leaf] = append(items, value)
+		} else {
+			parent[leaf] = value
+		}
+		seen[path] = true
+	}
+	return result, layout, nil
+}
+
+// iniSectionMap 返回点分路径对应的嵌套 map，不存在时创建；路径上已有非 map 值时返回错误
+func iniSectionMap(root map[string]any, path string) (map[string]any, error) {
+	current := root
+	if path == "" {
+		return current, nil
+	}
+	for _, part := range strings.Split(path, ".") {
+		next, exists := current[part]
+		if !exists {
+			child := make(map[string]any)
+			current[part] = child
+			current = child
+			continue
+		}
+		child, ok := next.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("section %s conflicts with key of the same name", path)
+		}
+		current = child
+	}
+	return current, nil
+}
+
+// splitINILine 拆分键值行，键可使用双引号包裹以包含分隔符等特殊字符，quoted 表示键加了引号
+func splitINILine(line string) (key string, quoted bool, value string, err error) {
+	var rest string
+	if line[0] == '"' {
+		quoted = true
+		key, rest, err = unquoteINI(line)
+		if err != nil {
+			return "", false, "", err
+		}
+		rest = strings.TrimSpace(rest)
+		if rest == "" || (rest[0] != '=' && rest[0] != ':') {
+			return "", false, "", fmt.Errorf("missing '=' after key %q", key)
+		}
+	} else {
+		idx := strings.IndexAny(line, "=:")
+		if idx < 0 {
+			return "", false, "", fmt.Errorf("missing '=' in %q", line)
+		}
+		key, rest = strings.TrimSpace(line[:idx]), line[idx:]
+	}
+	value, err = parseINIValue(rest[1:])
+	return key, quoted, value, err
+}
+
+// parseINIValue 解析值：双引号字符串处理转义，未加引号的值去掉行内注释与首尾空白
+func parseINIValue(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	if raw[0] == '"' {
+		value, rest, err := unquoteINI(raw)
+		if err != nil {
+			return "", err
+		}
+		if !iniRestIsComment(rest) {
+			return "", fmt.Errorf("unexpected text after quoted value: %q", strings.TrimSpace(rest))
+		}
+		return value, nil
+	}
+	for i := 1; i < len(raw); i++ {
+		if (raw[i] == ';' || raw[i] == '#') && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+			return strings.TrimSpace(raw[:i]), nil
+		}
+	}
+	return raw, nil
+}
+
+// iniRestIsComment 判断引号或节名之后的剩余内容是否为空或注释
+func iniRestIsComment(rest string) bool {
+	rest = strings.TrimSpace(rest)
+	return rest == "" || rest[0] == ';' || rest[0] == '#'
+}
+
+// unquoteINI 解析以双引号开头的字符串，返回内容与结束引号之后的剩余部分
+func unquoteINI(s string) (value, rest string, err error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch ch := s[i]; ch {
+		case '"':
+			return b.String(), s[i+1:], nil
+		case '\\':
+			if i+1 >= len(s) {
+				return "", "", fmt.Errorf("unterminated quoted string %q", s)
+			}
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return "", "", fmt.Errorf("unterminated quoted string %q", s)
+}
+
+// quoteINI 将字符串写为带转义的双引号字符串
+func quoteINI(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; ch {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(ch)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// iniKeyText 返回键的写入形式，含分隔符、注释符等特殊字符时加引号
+func iniKeyText(key string) string {
+	if key == "" || strings.HasSuffix(key, "[]") || key != strings.TrimSpace(key) ||
+		key[0] == '[' || key[0] == ';' || key[0] == '#' || strings.ContainsAny(key, "=:\"\\\n\r\t") {
+		return quoteINI(key)
+	}
+	return key
+}
+
+// iniValueText 返回字符串值的写入形式，读取时可能被截断或改变的值加引号
+func iniValueText(s string) string {
+	if s != strings.TrimSpace(s) || strings.HasPrefix(s, `"`) || strings.ContainsAny(s, ";#\n\r") {
+		return quoteINI(s)
+	}
+	return s
+}
+
+// iniSection 写入时的一个节：path 为节路径，values 为节内的非 map 值
+type iniSection struct {
+	path   string
+	values map[string]any
+}
+
+// marshalINI 将配置序列化为 INI 格式。嵌套 map 写为 [a.b] 节，仅含子节的中间节省略；
+// 节与键按 layout 记录的顺序写出，新增的节紧随其最近的已有上级节、新增的键排在已有键之后（按名称排序），
+// layout 中的注释写在对应的节或键之前。列表按 style 写出，列表中的元素必须为标量。
+func marshalINI(settings map[string]any, layout *iniLayout, style INISliceStyle) ([]byte, error) {
+	var sections []iniSection
+	collectINISections("", settings, &sections)
+	if layout != nil {
+		orderINISections(sections, layout)
+	}
+
+	var buf bytes.Buffer
+	for i, sec := range sections {
+		if sec.path != "" {
+			if strings.ContainsAny(sec.path, "[]\n\r") {
+				return nil, fmt.Errorf("ini: section name %q cannot contain brackets or newlines", sec.path)
+			}
+			if i > 0 {
+				buf.WriteByte('\n')
+			}
+			writeINIComments(&buf, layout, "["+sec.path+"]")
+			fmt.Fprintf(&buf, "[%s]\n", sec.path)
+		}
+		for _, key := range orderedINIKeys(sec, layout) {
+			path := joinKey(sec.path, key)
+			writeINIComments(&buf, layout, path)
+			if err := writeINIEntry(&buf, key, sec.values[key], style); err != nil {
+				return nil, fmt.Errorf("ini: key %s: %w", path, err)
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// collectINISections 深度优先收集节，同级子节按名称排序
+func collectINISections(path string, m map[string]any, out *[]iniSection) {
+	values := make(map[string]any)
+	var children []string
+	for key, value := range m {
+		if _, ok := value.(map[string]any); ok {
+			children = append(children, key)
+		} else {
+			values[key] = value
+		}
+	}
+	if path == "" || len(values) > 0 || len(children) == 0 {
+		*out = append(*out, iniSection{path: path, values: values})
+	}
+	sort.Strings(children)
+	for _, child := range children {
+		collectINISections(joinKey(path, child), m[child].(map[string]any), out)
+	}
+}
+
+// orderINISections 按布局中的出现顺序排列节；新增的节排在其最近的已有上级节之后
+func orderINISections(sections []iniSection, layout *iniLayout) {
+	rank := make(map[string]int, len(layout.sections))
+	for i, path := range layout.sections {
+		rank[path] = i
+	}
+	position := func(path string) (int, bool) {
+		if r, ok := rank[path]; ok {
+			return r, true
+		}
+		for parent := path; parent != ""; {
+			idx := strings.LastIndexByte(parent, '.')
+			if idx < 0 {
+				break
+			}
+			parent = parent[:idx]
+			if r, ok := rank[parent]; ok {
+				return r, false
+			}
+		}
+		if path == "" {
+			return -1, true
+		}
+		return len(layout.sections), false
+	}
+	sort.SliceStable(sections, func(i, j int) bool {
+		ri, known := position(sections[i].path)
+		rj, knownJ := position(sections[j].path)
+		if ri != rj {
+			return ri < rj
+		}
+		return known && !knownJ
+	})
+}
+
+// orderedINIKeys 返回节内键的写入顺序：布局中已有的键在前，新增的键按名称排序
+func orderedINIKeys(sec iniSection, layout *iniLayout) []string {
+	keys := make([]string, 0, len(sec.values))
+	if layout != nil {
+		for _, key := range layout.keys[sec.path] {
+			if _, ok := sec.values[key]; ok && !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	known := len(keys)
+	for key := range sec.values {
+		if !slices.Contains(keys[:known], key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys[known:])
+	return keys
+}
+
+// writeINIComments 写入节或键之前的注释
+func writeINIComments(buf *bytes.Buffer, layout *iniLayout, key string) {
+	if layout == nil {
+		return
+	}
+	for _, line := range layout.comments[key] {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+}
+
+// writeINIEntry 写入一个键值，列表按 style 写为多行或逗号列表
+func writeINIEntry(buf *bytes.Buffer, key string, value any, style INISliceStyle) error {
+	rv := reflect.ValueOf(value)
+	if value == nil || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || rv.Type().Elem().Kind() == reflect.Uint8 {
+		text, err := iniScalarText(value)
+		if err != nil {
+			return err
+		}
+		writeINILine(buf, iniKeyText(key), text)
+		return nil
+	}
+
+	items := make([]string, rv.Len())
+	commaSafe := rv.Len() > 0
+	for i := range items {
+		item := rv.Index(i).Interface()
+		if k := reflect.ValueOf(item).Kind(); k == reflect.Map || k == reflect.Slice || k == reflect.Array {
+			return fmt.Errorf("nested maps or lists inside a list are not supported")
+		}
+		text, err := iniScalarText(item)
+		if err != nil {
+			return err
+		}
+		if text == "" || strings.HasPrefix(text, `"`) || strings.Contains(text, ",") {
+			commaSafe = false
+		}
+		items[i] = text
+	}
+	switch {
+	case len(items) == 0:
+		// 空列表写为空值，读取时为空字符串，GetStringSlice 等返回空切片
+		writeINILine(buf, iniKeyText(key), "")
+	case style == INICommaList && commaSafe:
+		writeINILine(buf, iniKeyText(key), strings.Join(items, ", "))
+	default:
+		for _, item := range items {
+			writeINILine(buf, iniKeyText(key)+"[]", item)
+		}
+	}
+	return nil
+}
+
+// writeINILine 写入一行 key = value，空值不带尾随空格
+func writeINILine(buf *bytes.Buffer, key, value string) {
+	buf.WriteString(key)
+	buf.WriteString(" =")
+	if value != "" {
+		buf.WriteByte(' ')
+		buf.WriteString(value)
+	}
+	buf.WriteByte('\n')
+}
+
+// iniScalarText 将标量转换为 INI 值文本
+func iniScalarText(value any) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	s, err := cast.ToStringE(value)
+	if err != nil {
+		return "", err
+	}
+	return iniValueText(s), nil
+}
+
+// recordINILayout 记录 ini 内容的节顺序、键顺序与注释，供写回时恢复
+func (c *Config) recordINILayout(data []byte) {
+	_, layout, err := parseINI(data)
+	if err != nil {
+		return
+	}
+	if c.iniStripComments {
+		layout.comments = nil
+	}
+	c.iniLayout.Store(layout)
+}
+
+// marshalToINI 将配置转换为INI格式，按最近一次读取的布局保留节顺序与注释
+func (c *Config) marshalToINI(settings map[string]any) ([]byte, error) {
+	return marshalINI(settings, c.iniLayout.Load(), c.iniSliceStyle)
+}
@@ -0,0 +1,220 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestINIRoundTripEscaping(t *testing.T) {
+	settings := map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"dsn":     "user=admin;password=p#ss",
+			"banner":  "line1\nline2",
+			"padded":  "  spaced  ",
+			"quoted":  `"hello"`,
+			"path":    `C:\data\app`,
+			"empty":   "",
+			"port":    8080,
+			"enabled": true,
+			"tls": map[string]any{
+				"client": map[string]any{"cert": "/etc/cert.pem"},
+			},
+		},
+		"hosts":  []any{"a.example.com", "b,c", ""},
+		"ports":  []int{80, 443},
+		"single": []string{"only"},
+		"none":   []string{},
+		"weird":  map[string]any{"key=with:sep": "v", "list[]": "x"},
+	}
+
+	data, err := marshalINI(settings, nil, INIRepeatedKeys)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	parsed, _, err := parseINI(data)
+	if err != nil {
+		t.Fatalf("parse failed: %v\n%s", err, data)
+	}
+
+	want := map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"dsn":     "user=admin;password=p#ss",
+			"banner":  "line1\nline2",
+			"padded":  "  spaced  ",
+			"quoted":  `"hello"`,
+			"path":    `C:\data\app`,
+			"empty":   "",
+			"port":    "8080",
+			"enabled": "true",
+			"tls": map[string]any{
+				"client": map[string]any{"cert": "/etc/cert.pem"},
+			},
+		},
+		"hosts":  []any{"a.example.com", "b,c", ""},
+		"ports":  []any{"80", "443"},
+		"single": []any{"only"},
+		"none":   "",
+		"weird":  map[string]any{"key=with:sep": "v", "list[]": "x"},
+	}
+	if !reflect.DeepEqual(parsed, want) {
+		t.Fatalf("round trip mismatch\n got: %#v\nwant: %#v\n%s", parsed, want, data)
+	}
+	if strings.Contains(string(data), "[server]\n") == false || !strings.Contains(string(data), "[server.tls.client]") {
+		t.Fatalf("expected nested sections:\n%s", data)
+	}
+	if strings.Contains(string(data), "[server.tls]") {
+		t.Fatalf("intermediate section without keys should be omitted:\n%s", data)
+	}
+}
+
+func TestINICommaListStyle(t *testing.T) {
+	data, err := marshalINI(map[string]any{
+		"hosts": []string{"a", "b", "c"},
+		"mixed": []string{"x,y", "z"},
+	}, nil, INICommaList)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	text := string(data)
+	if !strings.Contains(text, "hosts = a, b, c\n") {
+		t.Fatalf("expected comma list:\n%s", text)
+	}
+	if !strings.Contains(text, "mixed[] = x,y\nmixed[] = z\n") {
+		t.Fatalf("elements containing commas should fall back to repeated keys:\n%s", text)
+	}
+
+	if _, err := marshalINI(map[string]any{"bad": []any{map[string]any{"a": 1}}}, nil, INIRepeatedKeys); err == nil {
+		t.Fatal("expected error for maps inside lists")
+	}
+}
+
+func TestINIParseSyntax(t *testing.T) {
+	content := "; header\n" +
+		"name = app ; inline comment\n" +
+		"url: http://example.com/#anchor\n" +
+		"tags = one\n" +
+		"tags = two\n" +
+		"\n" +
+		"[db]\n" +
+		"\"quoted key\" = \"value ; kept\" # comment\n" +
+		"pool.size = 5\n"
+	parsed, layout, err := parseINI([]byte(content))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	want := map[string]any{
+		"name": "app",
+		"url":  "http://example.com/#anchor",
+		"tags": []any{"one", "two"},
+		"db": map[string]any{
+			"quoted key": "value ; kept",
+			"pool":       map[string]any{"size": "5"},
+		},
+	}
+	if !reflect.DeepEqual(parsed, want) {
+		t.Fatalf("unexpected parse result: %#v", parsed)
+	}
+	if got := layout.comments["name"]; len(got) != 1 || got[0] != "; header" {
+		t.Fatalf("expected header comment attached to first key, got %v", layout.comments)
+	}
+
+	for _, bad := range []string{"[unterminated\n", "[]\n", "novalue\n", "a = \"open\n", "a = 1\n[a]\n"} {
+		if _, _, err := parseINI([]byte(bad)); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestINIPreservesLayout(t *testing.T) {
+	for _, engine := range []Engine{CompatibilityEngine, NativeEngine} {
+		t.Run(engine.String(), func(t *testing.T) {
+			dir := t.TempDir()
+			file := filepath.Join(dir, "app.ini")
+			original := "; application settings\n" +
+				"name = app\n" +
+				"\n" +
+				"# storage backend\n" +
+				"[zeta]\n" +
+				"; the host\n" +
+				"host = localhost\n" +
+				"port = 5432\n" +
+				"\n" +
+				"[alpha]\n" +
+				"level = info\n"
+			if err := os.WriteFile(file, []byte(original), 0o644); err != nil {
+				t.Fatalf("write config failed: %v", err)
+			}
+
+			cfg, err := New(
+				WithEngine(engine),
+				WithPath(dir),
+				WithName("app"),
+				WithMode("ini"),
+				WithWriteDebounceDelay(0),
+			)
+			if err != nil {
+				t.Fatalf("create config failed: %v", err)
+			}
+			testutil.Cleanup(t, cfg.Close)
+
+			if got := cfg.GetInt("zeta.port"); got != 5432 {
+				t.Fatalf("expected port 5432, got %d", got)
+			}
+			if err := cfg.SetMultiple(map[string]any{
+				"zeta.user":      "admin=root",
+				"zeta.pool.size": 5,
+				"beta.enabled":   true,
+				"alpha.tags":     []string{"a", "b"},
+			}); err != nil {
+				t.Fatalf("set failed: %v", err)
+			}
+
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("read config failed: %v", err)
+			}
+			want := "; application settings\n" +
+				"name = app\n" +
+				"\n" +
+				"# storage backend\n" +
+				"[zeta]\n" +
+				"; the host\n" +
+				"host = localhost\n" +
+				"port = 5432\n" +
+				"user = admin=root\n" +
+				"\n" +
+				"[zeta.pool]\n" +
+				"size = 5\n" +
+				"\n" +
+				"[alpha]\n" +
+				"level = info\n" +
+				"tags[] = a\n" +
+				"tags[] = b\n" +
+				"\n" +
+				"[beta]\n" +
+				"enabled = true\n"
+			if string(data) != want {
+				t.Fatalf("unexpected ini output:\n%s\nwant:\n%s", data, want)
+			}
+
+			reread, err := New(WithEngine(engine), WithPath(dir), WithName("app"), WithMode("ini"))
+			if err != nil {
+				t.Fatalf("reopen config failed: %v", err)
+			}
+			testutil.Cleanup(t, reread.Close)
+			if got := reread.GetString("zeta.user"); got != "admin=root" {
+				t.Fatalf("value with '=' should round-trip, got %q", got)
+			}
+			if got := reread.GetStringSlice("alpha.tags"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+				t.Fatalf("list should round-trip, got %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package sysconf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// initializeWithContext 执行初始化；ctx 可取消时在后台执行，使阻塞在无法中断的 I/O 上时也能按时返回
+func (c *Config) initializeWithContext(ctx context.Context) error {
+	if ctx.Done() == nil {
+		if err := c.initialize(); err != nil {
+			return fmt.Errorf("initialize config: %w", err)
+		}
+		return nil
+	}
+
+	c.initCtx = ctx
+	done := make(chan error, 1)
+	go func() { done <- c.initialize() }()
+
+	select {
+	case err := <-done:
+		c.initCtx = nil
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+				return initCanceledError(ctxErr)
+			}
+			return fmt.Errorf("initialize config: %w", err)
+		}
+		if err := ctx.Err(); err != nil {
+			_ = c.Close()
+			return initCanceledError(err)
+		}
+		return nil
+	case <-ctx.Done():
+		// 后台初始化会在下一个检查点退出；若已完成则关闭以释放监听等后台资源
+		go func() {
+			if err := <-done; err == nil {
+				_ = c.Close()
+			}
+		}()
+		return initCanceledError(ctx.Err())
+	}
+}
+
+// initContext 返回初始化阶段远程请求使用的上下文，NewWithContext 之外为 context.Background()
+func (c *Config) initContext() context.Context {
+	if c.initCtx != nil {
+		return c.initCtx
+	}
+	return context.Background()
+}
+
+// checkInitContext 在初始化的 I/O 步骤开始前检查 NewWithContext 的上下文是否已取消
+func (c *Config) checkInitContext() error {
+	if c.initCtx == nil {
+		return nil
+	}
+	if err := c.initCtx.Err(); err != nil {
+		return initCanceledError(err)
+	}
+	return nil
+}
+
+// initCanceledError 将上下文取消或超时包装为初始化错误
+func initCanceledError(err error) *ConfigError {
+	return &ConfigError{
+		Type:    ErrTypeInitialization,
+		Message: "配置初始化被取消或超时",
+		Cause:   err,
+	}
+}
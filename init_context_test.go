@@ -0,0 +1,75 @@
+package sysconf
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestNewWithContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewWithContext(ctx, WithContent("a: 1\n"))
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) || configErr.Type != ErrTypeInitialization {
+		t.Fatalf("expected initialization ConfigError, got %v", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled cause, got %v", err)
+	}
+}
+
+func TestNewWithContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := NewWithContext(ctx, WithMode("yaml"), WithURLSource(server.URL, 0, ""))
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("NewWithContext did not honor deadline, took %v", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) {
+		t.Fatalf("expected ConfigError, got %T", err)
+	}
+}
+
+func TestNewWithContextSucceeds(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cfg, err := NewWithContext(ctx,
+		WithPath(t.TempDir()),
+		WithName("app"),
+		WithMode("yaml"),
+		WithContent("server:\n  port: 8080\n"),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	cancel()
+
+	if got := cfg.GetInt("server.port"); got != 8080 {
+		t.Fatalf("expected port 8080, got %d", got)
+	}
+}
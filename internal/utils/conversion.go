@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/darkit/sysconf/internal/cache"
 	mapstructure "github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/cast"
 )
@@ -303,6 +302,16 @@ func SetDefaultValuesRecursive(val reflect.Value) error {
 			continue
 		}
 
+		// 结构体指针为 nil 表示该配置段不存在，不为其分配默认值
+		if field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.Struct {
+			if !field.IsNil() {
+				if err := SetDefaultValuesRecursive(field.Elem()); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
 		if tag != "" && IsZero(field) {
 			if err := SetFieldValue(field, tag); err != nil {
 				return fmt.Errorf("set field %s: %w", typ.Field(i).Name, err)
@@ -315,7 +324,24 @@ func SetDefaultValuesRecursive(val reflect.Value) error {
 
 // SetFieldValue 设置字段值
 func SetFieldValue(field reflect.Value, value string) error {
+	return setFieldValue(field, value, false)
+}
+
+// SetFieldValueLenient 同 SetFieldValue，数值按 ParseLenientInt/ParseLenientFloat 宽松解析
+func SetFieldValueLenient(field reflect.Value, value string) error {
+	return setFieldValue(field, value, true)
+}
+
+func setFieldValue(field reflect.Value, value string, lenientNumbers bool) error {
 	switch field.Kind() {
+	case reflect.Pointer:
+		// 指针字段分配新值后按指向的类型设置，nil 表示未配置
+		elem := reflect.New(field.Type().Elem())
+		if err := setFieldValue(elem.Elem(), value, lenientNumbers); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
 	case reflect.String:
 		field.SetString(value)
 		return nil
@@ -340,6 +366,14 @@ func SetFieldValue(field reflect.Value, value string) error {
 		}
 
 		// 普通整数字段
+		if lenientNumbers {
+			v, err := ParseLenientInt(value)
+			if err != nil {
+				return err
+			}
+			field.SetInt(v)
+			return nil
+		}
 		v, err := cast.ToInt64E(value)
 		if err != nil {
 			return fmt.Errorf("invalid integer value: %s", value)
@@ -347,6 +381,14 @@ func SetFieldValue(field reflect.Value, value string) error {
 		field.SetInt(v)
 		return nil
 	case reflect.Float32, reflect.Float64:
+		if lenientNumbers {
+			v, err := ParseLenientFloat(value)
+			if err != nil {
+				return err
+			}
+			field.SetFloat(v)
+			return nil
+		}
 		v, err := cast.ToFloat64E(value)
 		if err != nil {
 			return fmt.Errorf("invalid float value: %s", value)
@@ -426,7 +468,7 @@ func CamelToSnake(s string) string {
 	}
 
 	// 检查缓存
-	if v, ok := cache.GlobalManager.GetCamelToSnake(s); ok {
+	if v, ok := globalCache.GetCamelToSnake(s); ok {
 		return v
 	}
 
@@ -448,14 +490,14 @@ func CamelToSnake(s string) string {
 			// 转换为小写
 			result.WriteByte(byte(c - 'A' + 'a'))
 		} else {
-			result.WriteByte(byte(c))
+			result.WriteRune(c)
 		}
 	}
 
 	resultStr := result.String()
 
 	// 更新缓存
-	cache.GlobalManager.SetCamelToSnake(s, resultStr)
+	globalCache.SetCamelToSnake(s, resultStr)
 
 	return resultStr
 }
@@ -468,8 +510,9 @@ func SnakeToCamel(s string) string {
 		return ""
 	}
 
-	// 检查缓存
-	if v, ok := cache.GlobalManager.GetSnakeToCamel(s); ok {
+	// 检查缓存（以原始输入为键，下方会裁剪 s）
+	key := s
+	if v, ok := globalCache.GetSnakeToCamel(key); ok {
 		return v
 	}
 
@@ -536,7 +579,7 @@ func SnakeToCamel(s string) string {
 	resultStr := result.String()
 
 	// 更新缓存
-	cache.GlobalManager.SetSnakeToCamel(s, resultStr)
+	globalCache.SetSnakeToCamel(key, resultStr)
 
 	return resultStr
 }
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// normalizeNumber 去除数字中的千位分隔符（","、"_"、"'"、空格），逗号与撇号分组须为每组 3 位
+func normalizeNumber(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", fmt.Errorf("invalid number %q: empty string", s)
+	}
+	if digits := strings.TrimLeft(s, "+-"); len(digits) > 2 && digits[0] == '0' && strings.ContainsRune("xXoObB", rune(digits[1])) {
+		// 带进制前缀的数值仅允许 Go 语法的下划线分隔
+		return s, nil
+	}
+
+	intPart, frac := s, ""
+	if i := strings.IndexAny(s, ".eE"); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+	sign := ""
+	if intPart != "" && (intPart[0] == '-' || intPart[0] == '+') {
+		sign, intPart = intPart[:1], intPart[1:]
+	}
+
+	groups := strings.FieldsFunc(intPart, func(r rune) bool {
+		return r == ',' || r == '_' || r == '\'' || r == ' '
+	})
+	if len(groups) > 1 {
+		if strings.ContainsAny(intPart, ",'") {
+			for i, g := range groups {
+				if (i == 0 && (len(g) == 0 || len(g) > 3)) || (i > 0 && len(g) != 3) {
+					return "", fmt.Errorf("invalid number %q: malformed digit grouping", s)
+				}
+			}
+		}
+		intPart = strings.Join(groups, "")
+	}
+	return sign + intPart + strings.ReplaceAll(frac, "_", ""), nil
+}
+
+// ParseLenientInt 宽松解析整数：允许首尾空白、千位分隔符（1,000、1_000_000、1'000）
+// 与进制前缀（0xFF、0o17、0b101），以及小数部分为零的写法（如 "10.0"）
+func ParseLenientInt(s string) (int64, error) {
+	normalized, err := normalizeNumber(s)
+	if err != nil {
+		return 0, err
+	}
+	if v, err := strconv.ParseInt(normalized, 0, 64); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(normalized, 64)
+	if err != nil || f != float64(int64(f)) {
+		return 0, fmt.Errorf("invalid integer %q", s)
+	}
+	return int64(f), nil
+}
+
+// ParseLenientFloat 宽松解析浮点数，规则同 ParseLenientInt，另支持小数与科学计数法
+func ParseLenientFloat(s string) (float64, error) {
+	normalized, err := normalizeNumber(s)
+	if err != nil {
+		return 0, err
+	}
+	if f, err := strconv.ParseFloat(normalized, 64); err == nil {
+		return f, nil
+	}
+	if v, err := strconv.ParseInt(normalized, 0, 64); err == nil {
+		return float64(v), nil
+	}
+	return 0, fmt.Errorf("invalid number %q", s)
+}
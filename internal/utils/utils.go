@@ -12,6 +12,9 @@ type CacheManager struct {
 	mu                sync.RWMutex
 }
 
+// globalCache 命名转换结果的全局缓存
+var globalCache = NewCacheManager(1000)
+
 // NewCacheManager 创建新的缓存管理器
 func NewCacheManager(maxSize int) *CacheManager {
 	return &CacheManager{
@@ -105,3 +108,20 @@ func (cm *CacheManager) GetSnakeToCamel(key string) (string, bool) {
 	value, exists := cm.snakeToCamelCache[key]
 	return value, exists
 }
+
+// ClearAll 清空所有缓存
+func (cm *CacheManager) ClearAll() {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	cm.camelToSnakeCache = make(map[string]string)
+	cm.snakeToCamelCache = make(map[string]string)
+}
+
+// Stats 返回缓存条目数
+func (cm *CacheManager) Stats() (camelToSnakeCount, snakeToCamelCount int) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	return len(cm.camelToSnakeCache), len(cm.snakeToCamelCache)
+}
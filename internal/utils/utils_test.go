@@ -98,3 +98,20 @@ func TestSetDefaultValues(t *testing.T) {
 		t.Errorf("Bool 默认值错误，期望=%v, 实际=%v", true, config.Bool)
 	}
 }
+
+func TestParseLenientNumbers(t *testing.T) {
+	ints := map[string]int64{"1,000": 1000, "1_000_000": 1000000, "1'000": 1000, " 42 ": 42, "0xFF": 255, "-0b101": -5, "10.0": 10}
+	for in, want := range ints {
+		if got, err := ParseLenientInt(in); err != nil || got != want {
+			t.Errorf("ParseLenientInt(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	if got, err := ParseLenientFloat("-1,234,567.25"); err != nil || got != -1234567.25 {
+		t.Errorf("ParseLenientFloat = %v, %v", got, err)
+	}
+	for _, in := range []string{"1,00", "12,3456", "abc", "", "1.5"} {
+		if _, err := ParseLenientInt(in); err == nil {
+			t.Errorf("ParseLenientInt(%q) should fail", in)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package sysconf
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// jsoncHeaderKey 文档头部注释（第一个键之前）在注释表中的键
+const jsoncHeaderKey = ""
+
+// WithJSONCComments 设置 jsonc 模式写回文件时是否保留注释（默认保留）。
+// 保留时，键前的注释会随键一起写回；键被删除时其注释一并丢弃。
+func WithJSONCComments(preserve bool) Option {
+	return func(c *Config) {
+		c.jsoncStripComments = !preserve
+	}
+}
+
+// stripJSONC 将 JSONC 内容（含 // 与 /* */ 注释、尾随逗号）转换为标准 JSON。
+// 注释被替换为空白并保留换行，错误信息中的行号与原文件一致。
+func stripJSONC(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		ch := data[i]
+		switch {
+		case ch == '"':
+			end, err := jsoncStringEnd(data, i)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, data[i:end]...)
+			i = end - 1
+		case ch == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				out = append(out, ' ')
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case ch == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, fmt.Errorf("jsonc: unterminated block comment")
+			}
+			for _, b := range data[i : i+2+end+2] {
+				if b == '\n' {
+					out = append(out, '\n')
+				} else {
+					out = append(out, ' ')
+				}
+			}
+			i += 2 + end + 1
+		default:
+			out = append(out, ch)
+		}
+	}
+	return removeTrailingCommas(out), nil
+}
+
+// jsoncStringEnd 返回从 start 处开始的字符串字面量结束位置（不含）
+func jsoncStringEnd(data []byte, start int) (int, error) {
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("jsonc: unterminated string")
+}
+
+// removeTrailingCommas 删除 } 或 ] 之前的尾随逗号（输入中已不含注释）
+func removeTrailingCommas(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		ch := data[i]
+		if ch == '"' {
+			end, err := jsoncStringEnd(data, i)
+			if err != nil {
+				return append(out, data[i:]...)
+			}
+			out = append(out, data[i:end]...)
+			i = end - 1
+			continue
+		}
+		if ch == ',' {
+			j := i + 1
+			for j < len(data) && isJSONSpace(data[j]) {
+				j++
+			}
+			if j < len(data) && (data[j] == '}' || data[j] == ']') {
+				out = append(out, ' ')
+				continue
+			}
+		}
+		out = append(out, ch)
+	}
+	return out
+}
+
+func isJSONSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// parseJSONC 解析 JSONC 内容为嵌套 map
+func parseJSONC(data []byte) (map[string]any, error) {
+	clean, err := stripJSONC(data)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]any)
+	if len(bytes.TrimSpace(clean)) == 0 {
+		return result, nil
+	}
+	if err := json.Unmarshal(clean, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// collectJSONCComments 收集每个对象键之前的注释，结果以点分键路径为索引
+func collectJSONCComments(data []byte) map[string][]string {
+	comments := make(map[string][]string)
+
+	type frame struct {
+		object    bool
+		path      string
+		expectKey bool
+		lastKey   string
+	}
+	var stack []frame
+	var pending []string
+
+	for i := 0; i < len(data); i++ {
+		ch := data[i]
+		switch {
+		case ch == '/' && i+1 < len(data) && data[i+1] == '/':
+			end := bytes.IndexByte(data[i:], '\n')
+			if end < 0 {
+				end = len(data) - i
+			}
+			pending = append(pending, strings.TrimRight(string(data[i:i+end]), "\r"))
+			i += end - 1
+		case ch == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return comments
+			}
+			pending = append(pending, string(data[i:i+2+end+2]))
+			i += 2 + end + 1
+		case ch == '"':
+			end, err := jsoncStringEnd(data, i)
+			if err != nil {
+				return comments
+			}
+			if n := len(stack); n > 0 && stack[n-1].object && stack[n-1].expectKey {
+				var key string
+				_ = json.Unmarshal(data[i:end], &key)
+				path := joinKey(stack[n-1].path, key)
+				if len(pending) > 0 {
+					comments[path] = pending
+					pending = nil
+				}
+				stack[n-1].lastKey = key
+				stack[n-1].expectKey = false
+			}
+			i = end - 1
+		case ch == '{' || ch == '[':
+			path := ""
+			if n := len(stack); n > 0 {
+				path = stack[n-1].path
+				if stack[n-1].object {
+					path = joinKey(stack[n-1].path, stack[n-1].lastKey)
+				}
+			}
+			if len(stack) == 0 && len(pending) > 0 {
+				comments[jsoncHeaderKey] = pending
+			}
+			pending = nil
+			stack = append(stack, frame{object: ch == '{', path: path, expectKey: ch == '{'})
+		case ch == '}' || ch == ']':
+			pending = nil
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case ch == ',':
+			if n := len(stack); n > 0 && stack[n-1].object {
+				stack[n-1].expectKey = true
+			}
+		}
+	}
+	return comments
+}
+
+// marshalJSONC 序列化为带缩进的 JSON，并在对应键之前写回注释
+func marshalJSONC(settings map[string]any, comments map[string][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, line := range comments[jsoncHeaderKey] {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	if err := writeJSONCValue(&buf, settings, "", 0, comments); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// writeJSONCValue 递归写入 JSON 值
+func writeJSONCValue(buf *bytes.Buffer, value any, path string, depth int, comments map[string][]string) error {
+	object, ok := value.(map[string]any)
+	if !ok {
+		prefix := strings.Repeat("  ", depth)
+		encoded, err := json.MarshalIndent(value, prefix, "  ")
+		if err != nil {
+			return err
+		}
+		buf.Write(encoded)
+		return nil
+	}
+
+	if len(object) == 0 {
+		buf.WriteString("{}")
+		return nil
+	}
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	indent := strings.Repeat("  ", depth+1)
+	buf.WriteString("{\n")
+	for i, key := range keys {
+		childPath := joinKey(path, key)
+		for _, line := range comments[childPath] {
+			buf.WriteString(indent)
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+		}
+		encodedKey, _ := json.Marshal(key)
+		buf.WriteString(indent)
+		buf.Write(encodedKey)
+		buf.WriteString(": ")
+		if err := writeJSONCValue(buf, object[key], childPath, depth+1, comments); err != nil {
+			return err
+		}
+		if i < len(keys)-1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString(strings.Repeat("  ", depth))
+	buf.WriteByte('}')
+	return nil
+}
+
+// isJSONC 当前配置是否为 jsonc 模式
+func (c *Config) isJSONC() bool {
+	return c.mode == "jsonc"
+}
+
+// viperConfigType 返回交给 viper 的配置类型；jsonc 在去除注释后按 json 解析
+func (c *Config) viperConfigType() string {
+	if c.isJSONC() {
+		return "json"
+	}
+	return c.mode
+}
+
+// prepareContent 读取前按格式预处理内容：jsonc 去除注释，ini 记录节顺序与注释
+func (c *Config) prepareContent(data []byte) ([]byte, error) {
+	if c.mode == "ini" {
+		c.recordINILayout(data)
+		return data, nil
+	}
+	return c.prepareJSONC(data)
+}
+
+// prepareJSONC 记录 jsonc 内容中的注释并返回去除注释与尾随逗号后的标准 JSON；
+// 非 jsonc 模式原样返回
+func (c *Config) prepareJSONC(data []byte) ([]byte, error) {
+	if !c.isJSONC() {
+		return data, nil
+	}
+	clean, err := stripJSONC(data)
+	if err != nil {
+		return nil, err
+	}
+	if !c.jsoncStripComments {
+		comments := collectJSONCComments(data)
+		c.jsoncComments.Store(&comments)
+	}
+	return clean, nil
+}
+
+// marshalJSONCSettings 按 jsonc 格式序列化配置，保留注释时写回最近一次读取到的注释
+func (c *Config) marshalJSONCSettings(settings map[string]any) ([]byte, error) {
+	var comments map[string][]string
+	if !c.jsoncStripComments {
+		if stored := c.jsoncComments.Load(); stored != nil {
+			comments = *stored
+		}
+	}
+	return marshalJSONC(settings, comments)
+}
@@ -0,0 +1,157 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+const jsoncSample = `// 服务配置
+{
+  // 应用名称
+  "app": {
+    "name": "demo", // 行尾注释
+    /* 监听端口 */
+    "port": 8080,
+  },
+  "url": "http://example.com/a//b",
+  "tags": ["a", "b",],
+}
+`
+
+func TestStripJSONC(t *testing.T) {
+	clean, err := stripJSONC([]byte(jsoncSample))
+	if err != nil {
+		t.Fatalf("strip failed: %v", err)
+	}
+	if strings.Count(string(clean), "\n") != strings.Count(jsoncSample, "\n") {
+		t.Fatalf("line count changed after stripping:\n%s", clean)
+	}
+
+	nested, err := parseJSONC([]byte(jsoncSample))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if nested["url"] != "http://example.com/a//b" {
+		t.Fatalf("comment markers inside strings must be kept, got %v", nested["url"])
+	}
+	if tags, ok := nested["tags"].([]any); !ok || len(tags) != 2 {
+		t.Fatalf("unexpected tags: %#v", nested["tags"])
+	}
+
+	if _, err := stripJSONC([]byte(`{"a": 1 /* open`)); err == nil {
+		t.Fatalf("expected unterminated comment error")
+	}
+}
+
+func TestCollectJSONCComments(t *testing.T) {
+	comments := collectJSONCComments([]byte(jsoncSample))
+	if got := comments[jsoncHeaderKey]; len(got) != 1 || got[0] != "// 服务配置" {
+		t.Fatalf("unexpected header comments: %#v", got)
+	}
+	if got := comments["app"]; len(got) != 1 || got[0] != "// 应用名称" {
+		t.Fatalf("unexpected app comments: %#v", got)
+	}
+	if got := comments["app.port"]; len(got) != 2 || got[1] != "/* 监听端口 */" {
+		t.Fatalf("unexpected port comments: %#v", got)
+	}
+}
+
+func TestJSONCModeRoundTrip(t *testing.T) {
+	for _, engine := range []Engine{CompatibilityEngine, NativeEngine} {
+		t.Run(engine.String(), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			configFile := filepath.Join(tmpDir, "app.jsonc")
+			if err := os.WriteFile(configFile, []byte(jsoncSample), 0o644); err != nil {
+				t.Fatalf("write config failed: %v", err)
+			}
+
+			cfg, err := New(
+				WithPath(tmpDir),
+				WithName("app"),
+				WithMode("jsonc"),
+				WithEngine(engine),
+				WithWriteDebounceDelay(0),
+			)
+			if err != nil {
+				t.Fatalf("create config failed: %v", err)
+			}
+			testutil.Cleanup(t, cfg.Close)
+
+			if got := cfg.GetInt("app.port"); got != 8080 {
+				t.Fatalf("expected port 8080, got %d", got)
+			}
+			if err := cfg.Set("app.port", 9090); err != nil {
+				t.Fatalf("set failed: %v", err)
+			}
+
+			written, err := os.ReadFile(configFile)
+			if err != nil {
+				t.Fatalf("read config failed: %v", err)
+			}
+			for _, want := range []string{"// 服务配置", "// 应用名称", "/* 监听端口 */", `"port": 9090`} {
+				if !strings.Contains(string(written), want) {
+					t.Fatalf("expected %q in written file:\n%s", want, written)
+				}
+			}
+			if _, err := parseJSONC(written); err != nil {
+				t.Fatalf("written file is not valid jsonc: %v", err)
+			}
+		})
+	}
+}
+
+func TestJSONCStripCommentsOnWrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg, err := New(
+		WithPath(tmpDir),
+		WithName("app"),
+		WithMode("jsonc"),
+		WithContent(jsoncSample),
+		WithJSONCComments(false),
+		WithWriteDebounceDelay(0),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if err := cfg.Set("app.name", "changed"); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	written, err := os.ReadFile(filepath.Join(tmpDir, "app.jsonc"))
+	if err != nil {
+		t.Fatalf("read config failed: %v", err)
+	}
+	if strings.Contains(string(written), "应用名称") {
+		t.Fatalf("comments should be stripped:\n%s", written)
+	}
+	if got := cfg.GetString("app.name"); got != "changed" {
+		t.Fatalf("expected changed, got %s", got)
+	}
+}
+
+func TestJSONCMemoryOnly(t *testing.T) {
+	cfg, err := New(WithMode("jsonc"), WithContent(jsoncSample))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("app.name"); got != "demo" {
+		t.Fatalf("expected demo, got %q", got)
+	}
+}
+
+func TestLintJSONC(t *testing.T) {
+	findings, err := LintBytes([]byte("{\n  // comment\n  \"a\": 1,\n  \"a\": 2,\n}\n"), LintOptions{Format: "jsonc"})
+	if err != nil {
+		t.Fatalf("lint failed: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Rule != LintRuleDuplicateKey || findings[0].Line != 4 {
+		t.Fatalf("unexpected findings: %+v", findings)
+	}
+}
@@ -0,0 +1,157 @@
+package sysconf
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// keyAliasIndex 某一数据快照的规范键索引（规范形式 → 实际键，包含各级父键）
+type keyAliasIndex struct {
+	data    map[string]any // 建立索引时的数据快照
+	aliases map[string]string
+}
+
+// WithDashUnderscoreEquivalence 设置键中的 "-" 与 "_" 是否视为等价（默认不等价）。
+// 启用后 "rate-limit" 与 "rate_limit" 在 Get/Set/Unmarshal 中指向同一配置项，
+// 写入已存在的键时保留其原有拼写，新键按调用方的拼写写入。
+func WithDashUnderscoreEquivalence(enabled bool) Option {
+	return func(c *Config) {
+		c.dashEquivalent = enabled
+	}
+}
+
+// WithKeyDelimiter 设置调用方传入的键使用的层级分隔符（默认 "."），便于迁移使用 viper.KeyDelimiter 的代码：
+// 设置为 "::" 后 Get("database::host") 与 Get("database.host") 指向同一配置项。
+// 配置数据内部仍以 "." 分隔层级，AllKeys、Origin 等返回的键使用 "."。
+func WithKeyDelimiter(delim string) Option {
+	return func(c *Config) {
+		if delim == "." {
+			delim = ""
+		}
+		c.keyDelim = delim
+	}
+}
+
+// canonicalKey 返回键的规范形式：Unicode NFC 组合并转为小写（与 viper 对文件键的处理一致）
+func canonicalKey(key string) string {
+	if isCanonicalASCII(key) {
+		return key
+	}
+	if !isASCII(key) {
+		key = norm.NFC.String(key)
+	}
+	return strings.ToLower(key)
+}
+
+// matchKey 返回键在比较时使用的形式，启用 WithDashUnderscoreEquivalence 时 "-" 视同 "_"；
+// WithKeyDelimiter 的分隔符转换为 "."；
+// dotenv 格式下不含 "." 的键按变量名映射为层级键（DATABASE_HOST → database.host）
+func (c *Config) matchKey(key string) string {
+	key = c.dotenvKey(canonicalKey(c.delimKey(key)))
+	if c.dashEquivalent {
+		key = strings.ReplaceAll(key, "-", "_")
+	}
+	return key
+}
+
+// resolveKey 将调用方传入的键解析为数据中的实际键；不存在时返回规范形式。
+// 所有读取与写入路径都经过此处，使大小写、Unicode 组合形式与（可选的）连字符差异保持一致。
+func (c *Config) resolveKey(data map[string]any, key string) string {
+	if _, ok := data[key]; ok {
+		return key
+	}
+	if actual, ok := c.keyAliases(data)[c.matchKey(key)]; ok {
+		return actual
+	}
+	return c.dotenvKey(canonicalKey(c.delimKey(key)))
+}
+
+// delimKey 将使用 WithKeyDelimiter 分隔符的键转换为 "." 分隔
+func (c *Config) delimKey(key string) string {
+	if c.keyDelim == "" {
+		return key
+	}
+	return strings.ReplaceAll(key, c.keyDelim, ".")
+}
+
+// dotenvKey 在 dotenv 格式下将变量名形式的键（不含 "."）映射为层级键，其他情况原样返回
+func (c *Config) dotenvKey(key string) string {
+	if !c.dotenvKeys.Load() || strings.Contains(key, ".") {
+		return key
+	}
+	return dotenvKeyPath(key)
+}
+
+// keyAliases 返回数据快照的规范键索引，快照未变化时复用
+func (c *Config) keyAliases(data map[string]any) map[string]string {
+	if idx := c.keyIndex.Load(); idx != nil && sameMap(idx.data, data) {
+		return idx.aliases
+	}
+	aliases := make(map[string]string, len(data))
+	add := func(actual string) {
+		form := c.matchKey(actual)
+		// 多个拼写归一到同一形式时取字典序最小者，保证结果确定
+		if prev, ok := aliases[form]; !ok || actual < prev {
+			aliases[form] = actual
+		}
+	}
+	for key := range data {
+		add(key)
+		for i := strings.LastIndexByte(key, '.'); i > 0; i = strings.LastIndexByte(key[:i], '.') {
+			add(key[:i])
+		}
+	}
+	c.keyIndex.Store(&keyAliasIndex{data: data, aliases: aliases})
+	return aliases
+}
+
+// resolveKeys 解析批量写入的键，不修改调用方传入的映射
+func (c *Config) resolveKeys(values map[string]any) map[string]any {
+	data := c.loadData()
+	resolved := make(map[string]any, len(values))
+	// 按键排序处理，多个键解析到同一配置项时结果确定（后者覆盖前者）
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		resolved[c.resolveKey(data, key)] = values[key]
+	}
+	return resolved
+}
+
+// matchName 返回 Unmarshal 使用的字段名匹配函数
+func (c *Config) matchName() func(mapKey, fieldName string) bool {
+	if !c.dashEquivalent {
+		return cachedMatchName
+	}
+	return func(mapKey, fieldName string) bool {
+		return cachedMatchName(strings.ReplaceAll(mapKey, "-", "_"), strings.ReplaceAll(fieldName, "-", "_"))
+	}
+}
+
+// sameMap 判断两个 map 是否为同一实例
+func sameMap(a, b map[string]any) bool {
+	return reflect.ValueOf(a).UnsafePointer() == reflect.ValueOf(b).UnsafePointer()
+}
+
+// isCanonicalASCII 判断键是否为不含大写字母的 ASCII 字符串
+func isCanonicalASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if b := s[i]; b >= utf8.RuneSelf || ('A' <= b && b <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// isASCII 判断字符串是否只包含 ASCII 字符
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
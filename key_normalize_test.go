@@ -0,0 +1,87 @@
+package sysconf
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestKeyNormalizationDefault(t *testing.T) {
+	cfg, err := New(WithContent("caf\u00e9: latte\nserver:\n  max_conn: 3\nrate-limit: 5\n"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	for _, key := range []string{"caf\u00e9", "cafe\u0301", "CAF\u00c9", "Server.Max_Conn", "SERVER.MAX_CONN"} {
+		if !cfg.IsSet(key) {
+			t.Fatalf("expected %q to resolve to an existing key", key)
+		}
+	}
+	if cfg.IsSet("rate_limit") {
+		t.Fatal("dash and underscore must stay distinct by default")
+	}
+
+	if err := cfg.Set("Server.Max_Conn", 10); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if got := cfg.GetInt("server.max_conn"); got != 10 {
+		t.Fatalf("expected mixed-case write to update existing key, got %d", got)
+	}
+	if err := cfg.Set("New.Key", 1); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if keys := cfg.Keys(); !slices.Contains(keys, "new.key") || slices.Contains(keys, "New.Key") {
+		t.Fatalf("expected new key stored in canonical form, got %v", keys)
+	}
+}
+
+func TestKeyNormalizationDashEquivalence(t *testing.T) {
+	cfg, err := New(
+		WithContent("rate-limit: 5\nhttp_server:\n  read-timeout: 3s\n"),
+		WithDashUnderscoreEquivalence(true),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("http-server.read_timeout"); got != "3s" {
+		t.Fatalf("expected dash/underscore equivalent lookup, got %q", got)
+	}
+	if got := cfg.GetStringMap("http-server"); len(got) != 1 {
+		t.Fatalf("expected equivalent section lookup, got %v", got)
+	}
+
+	if err := cfg.Set("rate_limit", 7); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	keys := cfg.Keys()
+	if !slices.Contains(keys, "rate-limit") || slices.Contains(keys, "rate_limit") {
+		t.Fatalf("write must keep the existing spelling, got %v", keys)
+	}
+
+	var target struct {
+		RateLimit int `config:"rate_limit"`
+		Server    struct {
+			ReadTimeout string `config:"read_timeout"`
+		} `config:"http-server"`
+	}
+	if err := cfg.Unmarshal(&target); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if target.RateLimit != 7 || target.Server.ReadTimeout != "3s" {
+		t.Fatalf("struct tags must match equivalent keys, got %+v", target)
+	}
+}
+
+func TestDeriveEnvKeysDashes(t *testing.T) {
+	cfg := &Config{}
+	keys := cfg.deriveEnvKeys(EnvOptions{Prefix: "APP"}, "server.rate-limit")
+	for _, want := range []string{"APP_SERVER_RATE_LIMIT", "APP_SERVER_RATE-LIMIT"} {
+		if !slices.Contains(keys, want) {
+			t.Fatalf("expected %s among derived env keys %v", want, keys)
+		}
+	}
+}
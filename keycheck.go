@@ -0,0 +1,66 @@
+package sysconf
+
+import (
+	"fmt"
+	"os"
+)
+
+// VerifyEncryptionKey 用当前密钥完整解密配置文件以确认密钥能够通过认证，不修改已加载的配置。
+// 未启用加密、配置文件不存在或文件为明文时返回 nil；密钥错误时返回 ErrTypeDecryption 类型的 *ConfigError，
+// 消息中包含文件记录的密钥标识与当前密钥标识，便于轮换密钥或部署前快速确认。
+func (c *Config) VerifyEncryptionKey() error {
+	if !c.keepsBackup() || c.name == "" {
+		return nil
+	}
+	c.mu.RLock()
+	configFile := c.configFilePath()
+	c.mu.RUnlock()
+
+	data, err := os.ReadFile(configFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+	if err := c.checkEncryptionKey(configFile, data); err != nil {
+		return err
+	}
+	if _, _, err := decryptWith(c.crypto, data); err != nil {
+		return c.encryptionKeyError(configFile, data, err)
+	}
+	return nil
+}
+
+// checkEncryptionKey 只核对信封头部的算法与密钥标识，不解密内容，供启动时在解析前快速失败。
+// 无信封的内容（明文或早期版本密文）无从核对，返回 nil。
+func (c *Config) checkEncryptionKey(configFile string, data []byte) error {
+	if !hasEnvelope(data) {
+		return nil
+	}
+	header, _, err := openEnvelope(data)
+	if err == nil {
+		err = checkEnvelopeKey(c.crypto, header)
+	}
+	if err != nil {
+		return c.encryptionKeyError(configFile, data, err)
+	}
+	return nil
+}
+
+// encryptionKeyError 将解密失败包装为 ErrTypeDecryption 错误，并在消息中附上文件与当前密钥的标识
+func (c *Config) encryptionKeyError(configFile string, data []byte, cause error) *ConfigError {
+	message := "当前密钥无法解密配置文件"
+	if header, ok := ReadEnvelopeHeader(data); ok {
+		fileKey, currentKey := header.KeyID, cryptoKeyID(c.crypto)
+		if fileKey == "" {
+			fileKey = "未记录"
+		}
+		if currentKey == "" {
+			currentKey = "未提供"
+		}
+		message += fmt.Sprintf("（文件密钥标识 %s，当前密钥标识 %s，文件算法 %s，当前算法 %s）",
+			fileKey, currentKey, header.Algorithm, cryptoTypeName(c.crypto))
+	}
+	return &ConfigError{Type: ErrTypeDecryption, Message: message, File: configFile, Cause: cause}
+}
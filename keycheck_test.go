@@ -0,0 +1,84 @@
+package sysconf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestVerifyEncryptionKey(t *testing.T) {
+	tmpDir := t.TempDir()
+	open := func(key string) (*Config, error) {
+		return New(
+			WithPath(tmpDir),
+			WithName("app"),
+			WithMode("yaml"),
+			WithContent("app:\n  version: 1\n"),
+			WithEncryption(key),
+			WithWriteDebounceDelay(0),
+		)
+	}
+
+	cfg, err := open("right-key")
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	if err := cfg.Set("app.version", 2); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if err := cfg.VerifyEncryptionKey(); err != nil {
+		t.Fatalf("expected key to verify, got %v", err)
+	}
+	if err := cfg.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	_, err = open("wrong-key")
+	if err == nil {
+		t.Fatal("expected New to fail with wrong key")
+	}
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) || configErr.Type != ErrTypeDecryption {
+		t.Fatalf("expected decryption ConfigError, got %v", err)
+	}
+	if !errors.Is(err, ErrEncryptionMismatch) {
+		t.Fatalf("expected ErrEncryptionMismatch, got %v", err)
+	}
+	right, _ := NewDefaultCrypto("right-key")
+	wrong, _ := NewDefaultCrypto("wrong-key")
+	if !strings.Contains(configErr.Message, right.KeyID()) || !strings.Contains(configErr.Message, wrong.KeyID()) {
+		t.Fatalf("expected both key ids in message, got %q", configErr.Message)
+	}
+
+	cfg, err = open("right-key")
+	if err != nil {
+		t.Fatalf("reopen with right key failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if got := cfg.GetInt("app.version"); got != 2 {
+		t.Fatalf("expected version 2, got %d", got)
+	}
+}
+
+func TestVerifyEncryptionKeyWithoutEncryptedFile(t *testing.T) {
+	cfg, err := New(WithContent("app:\n  name: demo\n"), WithMode("yaml"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if err := cfg.VerifyEncryptionKey(); err != nil {
+		t.Fatalf("expected nil without encryption, got %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	enc, err := New(WithPath(tmpDir), WithName("missing"), WithMode("yaml"), WithEncryption("some-key"))
+	if err != nil {
+		t.Fatalf("create encrypted config failed: %v", err)
+	}
+	testutil.Cleanup(t, enc.Close)
+	if err := enc.VerifyEncryptionKey(); err != nil {
+		t.Fatalf("expected nil without config file, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package sysconf
+
+import (
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+// legacySettings 模拟 YAML v2 风格解码器产生的数据
+func legacySettings() map[string]any {
+	return map[string]any{
+		"service": map[any]any{
+			"name": "api",
+			"ports": map[any]any{
+				8080: "http",
+				9090: "metrics",
+			},
+			"backends": []any{
+				map[any]any{"host": "a.internal", "weight": 2},
+				map[any]any{"host": "b.internal", "weight": 1},
+			},
+		},
+	}
+}
+
+func TestFlattenSettingsLegacyMaps(t *testing.T) {
+	flat := make(map[string]any)
+	flattenSettings("", legacySettings(), flat)
+
+	if flat["service.name"] != "api" {
+		t.Fatalf("expected nested legacy map to be flattened, got %v", flat)
+	}
+	if flat["service.ports.8080"] != "http" {
+		t.Fatalf("expected non-string keys to be kept as text, got %v", flat)
+	}
+	backends, ok := flat["service.backends"].([]any)
+	if !ok || len(backends) != 2 {
+		t.Fatalf("expected backends slice, got %#v", flat["service.backends"])
+	}
+	if _, ok := backends[0].(map[string]any); !ok {
+		t.Fatalf("expected slice items to be normalized, got %T", backends[0])
+	}
+}
+
+func TestReloadWithLegacyMaps(t *testing.T) {
+	cfg, err := New(WithContent("service:\n  name: placeholder\n"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	// 模拟重载路径直接存入未展开的 map[any]any
+	cfg.mu.Lock()
+	cfg.storeData(map[string]any{"service": legacySettings()["service"]})
+	cfg.mu.Unlock()
+
+	section := cfg.GetStringMap("service")
+	if section["name"] != "api" {
+		t.Fatalf("GetStringMap must handle legacy maps, got %v", section)
+	}
+	if got := cfg.GetString("service.ports.9090"); got != "metrics" {
+		t.Fatalf("expected nested lookup through legacy map, got %q", got)
+	}
+
+	var target struct {
+		Service struct {
+			Name     string
+			Ports    map[string]string
+			Backends []struct {
+				Host   string
+				Weight int
+			}
+		}
+	}
+	if err := cfg.Unmarshal(&target); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if target.Service.Name != "api" || target.Service.Ports["8080"] != "http" {
+		t.Fatalf("unexpected unmarshal result: %+v", target.Service)
+	}
+	if len(target.Service.Backends) != 2 || target.Service.Backends[0].Weight != 2 {
+		t.Fatalf("unexpected backends: %+v", target.Service.Backends)
+	}
+}
+
+func TestViperFallbackLegacyMaps(t *testing.T) {
+	cfg, err := New(WithContent("other: 1\n"), WithEnv("LEGACYMAP"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	v := cfg.Viper()
+	if v == nil {
+		t.Skip("viper engine not available")
+	}
+	v.Set("plugin", map[any]any{"enabled": true, "opts": map[any]any{"level": 3}})
+
+	plugin, ok := cfg.Get("plugin").(map[string]any)
+	if !ok {
+		t.Fatalf("expected viper fallback value to be normalized, got %#v", cfg.Get("plugin"))
+	}
+	if opts, ok := plugin["opts"].(map[string]any); !ok || opts["level"] != 3 {
+		t.Fatalf("expected nested values to be normalized, got %#v", plugin["opts"])
+	}
+}
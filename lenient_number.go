@@ -0,0 +1,74 @@
+package sysconf
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/darkit/sysconf/internal/utils"
+	mapstructure "github.com/go-viper/mapstructure/v2"
+)
+
+// durationType time.Duration 的反射类型，由 Duration 专用钩子处理
+var durationType = reflect.TypeFor[time.Duration]()
+
+// WithLenientNumbers 启用宽松的数值解析：字符串形式的数值允许首尾空白、千位分隔符
+// （"1,000"、"1_000_000"、"1'000"）与进制前缀（"0xFF"、"0o17"、"0b101"）。
+// 作用于 GetInt、GetFloat 及其派生方法、Unmarshal 的数值字段与 default 标签；
+// 仍无法解析时 Getter 记录警告并返回默认值，Unmarshal 返回指明键与原始值的错误。
+func WithLenientNumbers(enabled bool) Option {
+	return func(c *Config) {
+		c.lenientNumbers = enabled
+	}
+}
+
+// lenientInt 宽松解析字符串形式的整数，未启用或值不是字符串时返回 false
+func (c *Config) lenientInt(key string, val any) (int, bool) {
+	s, ok := val.(string)
+	if !ok || !c.lenientNumbers {
+		return 0, false
+	}
+	v, err := utils.ParseLenientInt(s)
+	if err != nil {
+		c.logger.Warnf("Failed to parse number for key '%s': %v", key, err)
+		return 0, false
+	}
+	return int(v), true
+}
+
+// lenientFloat 宽松解析字符串形式的浮点数，未启用或值不是字符串时返回 false
+func (c *Config) lenientFloat(key string, val any) (float64, bool) {
+	s, ok := val.(string)
+	if !ok || !c.lenientNumbers {
+		return 0, false
+	}
+	v, err := utils.ParseLenientFloat(s)
+	if err != nil {
+		c.logger.Warnf("Failed to parse number for key '%s': %v", key, err)
+		return 0, false
+	}
+	return v, true
+}
+
+// numberDecodeHook Unmarshal 使用的数值解码钩子，启用 WithLenientNumbers 时宽松解析数值字符串
+func (c *Config) numberDecodeHook() mapstructure.DecodeHookFunc {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if !c.lenientNumbers || from.Kind() != reflect.String || to == durationType {
+			return data, nil
+		}
+		s := data.(string)
+		switch to.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return utils.ParseLenientInt(s)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v, err := utils.ParseLenientInt(s)
+			if err == nil && v < 0 {
+				return nil, fmt.Errorf("invalid unsigned integer %q", s)
+			}
+			return v, err
+		case reflect.Float32, reflect.Float64:
+			return utils.ParseLenientFloat(s)
+		}
+		return data, nil
+	}
+}
@@ -0,0 +1,68 @@
+package sysconf
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestLenientNumbers(t *testing.T) {
+	content := `workers: "1,000"
+limit: "1_000_000"
+mask: "0xFF"
+ratio: "1,234.5"
+broken: "12,34"
+`
+	strict, err := New(WithContent(content), WithMode(YAML))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, strict.Close)
+	if got := strict.GetInt("workers", -1); got != -1 {
+		t.Fatalf("strict parsing should reject thousand separators, got %d", got)
+	}
+
+	cfg, err := New(WithContent(content), WithMode(YAML), WithLenientNumbers(true))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetInt("workers"); got != 1000 {
+		t.Fatalf("workers = %d", got)
+	}
+	if got := cfg.GetInt("limit"); got != 1000000 {
+		t.Fatalf("limit = %d", got)
+	}
+	if got := cfg.GetInt("mask"); got != 255 {
+		t.Fatalf("mask = %d", got)
+	}
+	if got := cfg.GetFloat("ratio"); got != 1234.5 {
+		t.Fatalf("ratio = %v", got)
+	}
+	if got := cfg.GetInt("broken", 7); got != 7 {
+		t.Fatalf("malformed grouping should fall back to default, got %d", got)
+	}
+
+	var out struct {
+		Workers int     `config:"workers"`
+		Mask    uint8   `config:"mask"`
+		Ratio   float64 `config:"ratio"`
+		Backlog int     `config:"backlog" default:"2,048"`
+	}
+	if err := cfg.Unmarshal(&out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.Workers != 1000 || out.Mask != 255 || out.Ratio != 1234.5 || out.Backlog != 2048 {
+		t.Fatalf("unexpected result: %+v", out)
+	}
+
+	var bad struct {
+		Broken int `config:"broken"`
+	}
+	err = cfg.Unmarshal(&bad)
+	if err == nil || !strings.Contains(err.Error(), "broken") || !strings.Contains(err.Error(), `"12,34"`) {
+		t.Fatalf("expected error naming key and value, got %v", err)
+	}
+}
@@ -0,0 +1,403 @@
+package sysconf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/darkit/sysconf/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// LintSeverity 检查结果严重级别
+type LintSeverity string
+
+const (
+	// LintError 错误级别，CI 中应视为失败
+	LintError LintSeverity = "error"
+	// LintWarning 警告级别
+	LintWarning LintSeverity = "warning"
+)
+
+// 检查规则名称
+const (
+	LintRuleSyntax          = "syntax"           // 配置内容无法解析
+	LintRuleUnknownKey      = "unknown-key"      // 配置键不在结构体定义中
+	LintRuleDeprecatedKey   = "deprecated-key"   // 使用了已废弃的配置键
+	LintRuleValidation      = "validation"       // 值未通过校验
+	LintRuleDuplicateKey    = "duplicate-key"    // YAML/JSON 中存在重复键
+	LintRulePlaintextSecret = "plaintext-secret" // 疑似明文存储的凭据
+)
+
+// LintOptions 配置检查选项
+type LintOptions struct {
+	// Format 配置格式，为空时按文件扩展名推断
+	Format string
+	// Schema 结构体（或其指针），用于检测未知键并按 validate/required 标签校验值
+	Schema any
+	// Deprecated 已废弃的配置键及其提示信息（如替代键）
+	Deprecated map[string]string
+	// Validators 额外执行的配置验证器
+	Validators []ConfigValidator
+	// Crypto 用于解密加密配置文件的实现
+	Crypto ConfigCrypto
+	// SkipSecretScan 是否跳过明文凭据扫描
+	SkipSecretScan bool
+}
+
+// LintFinding 单条检查结果
+type LintFinding struct {
+	Rule     string       // 规则名称
+	Severity LintSeverity // 严重级别
+	Key      string       // 相关配置键（可能为空）
+	Line     int          // 所在行号（仅 YAML 可用，未知时为 0）
+	Message  string       // 详细说明
+}
+
+// String 返回便于在 CI 日志中输出的单行描述
+func (f LintFinding) String() string {
+	location := f.Key
+	if f.Line > 0 {
+		location = fmt.Sprintf("line %d: %s", f.Line, f.Key)
+	}
+	if location == "" {
+		return fmt.Sprintf("[%s] %s: %s", f.Severity, f.Rule, f.Message)
+	}
+	return fmt.Sprintf("[%s] %s (%s): %s", f.Severity, f.Rule, location, f.Message)
+}
+
+// Lint 读取并检查配置文件，但不会将其应用到任何 Config 实例。
+// 检查项包括：语法错误、未知键（对照 Schema）、废弃键、校验失败、YAML 重复键以及疑似明文凭据。
+// 文件无法读取或解密时返回错误；内容问题均以 LintFinding 形式返回。
+func Lint(path string, opts LintOptions) ([]LintFinding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+	if opts.Format == "" {
+		opts.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	return LintBytes(data, opts)
+}
+
+// LintBytes 检查内存中的配置内容，opts.Format 为空时按 yaml 处理
+func LintBytes(data []byte, opts LintOptions) ([]LintFinding, error) {
+	format := opts.Format
+	if format == "" {
+		format = "yaml"
+	}
+
+	if opts.Crypto != nil {
+		decrypted, _, err := decryptWith(opts.Crypto, data)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt config file: %w", err)
+		}
+		data = decrypted
+	}
+	data, err := decompressContent(data)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &linter{opts: opts, lines: make(map[string]int)}
+	nested, ok := l.parse(data, format)
+	if !ok {
+		return l.findings, nil
+	}
+
+	flat := make(map[string]any, len(nested))
+	flattenSettings("", nested, flat)
+
+	l.checkDeprecated(flat)
+	if opts.Schema != nil {
+		doc, err := DocumentStruct(opts.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("document schema: %w", err)
+		}
+		l.checkSchema(doc, flat)
+	}
+	for _, v := range opts.Validators {
+		if v == nil {
+			continue
+		}
+		if err := v.Validate(nested); err != nil {
+			l.add(LintRuleValidation, LintError, "", fmt.Sprintf("validator %s: %v", v.GetName(), err))
+		}
+		if warner, ok := v.(validation.WarningValidator); ok {
+			for _, issue := range warner.Warnings(nested) {
+				l.add(LintRuleValidation, LintWarning, issue.Key, fmt.Sprintf("validator %s: %s", v.GetName(), issue.Message))
+			}
+		}
+	}
+	if !opts.SkipSecretScan {
+		l.checkSecrets(flat)
+	}
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		a, b := l.findings[i], l.findings[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Key < b.Key
+	})
+	return l.findings, nil
+}
+
+// linter 单次检查的状态
+type linter struct {
+	opts     LintOptions
+	lines    map[string]int // 扁平键 → 行号
+	findings []LintFinding
+}
+
+// add 追加一条检查结果
+func (l *linter) add(rule string, severity LintSeverity, key, message string) {
+	l.findings = append(l.findings, LintFinding{
+		Rule:     rule,
+		Severity: severity,
+		Key:      key,
+		Line:     l.lines[key],
+		Message:  message,
+	})
+}
+
+// parse 解析配置内容；YAML 额外记录行号与重复键
+func (l *linter) parse(data []byte, format string) (map[string]any, bool) {
+	var (
+		nested map[string]any
+		err    error
+	)
+	switch format {
+	case "yaml", "yml":
+		nested, err = l.parseYAML(data)
+	case "json", "jsonc":
+		if format == "jsonc" {
+			// 注释替换为空白且保留换行，去除后行号不变
+			if data, err = stripJSONC(data); err != nil {
+				break
+			}
+		}
+		if dup, _ := findJSONDuplicate(data); dup != nil {
+			l.findings = append(l.findings, LintFinding{
+				Rule:     LintRuleDuplicateKey,
+				Severity: LintError,
+				Key:      dup.Key,
+				Line:     dup.SecondLine,
+				Message:  fmt.Sprintf("key %q defined at line %d and again at line %d", dup.Key, dup.FirstLine, dup.SecondLine),
+			})
+		}
+		nested, err = parseContentMap(data, format)
+	case "toml":
+		nested, err = parseContentMap(data, format)
+	default:
+		v := newViper()
+		v.SetConfigType(format)
+		if err = v.ReadConfig(bytes.NewReader(data)); err == nil {
+			nested = v.AllSettings()
+		}
+	}
+	if err != nil {
+		l.add(LintRuleSyntax, LintError, "", err.Error())
+		return nil, false
+	}
+	if nested == nil {
+		nested = map[string]any{}
+	}
+	return nested, true
+}
+
+// parseYAML 基于节点树解析 YAML，检测重复键并记录每个键的行号
+func (l *linter) parseYAML(data []byte) (map[string]any, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 {
+		return map[string]any{}, nil
+	}
+	l.walkYAML(root.Content[0], "")
+
+	result := make(map[string]any)
+	if err := root.Content[0].Decode(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// walkYAML 递归记录键行号；重复键记录后从节点中移除，仅保留最后一次定义以便继续解码
+func (l *linter) walkYAML(node *yaml.Node, prefix string) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	seen := make(map[string]int)
+	kept := make([]*yaml.Node, 0, len(node.Content))
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		key := keyNode.Value
+		if prefix != "" {
+			key = prefix + "." + keyNode.Value
+		}
+		if first, dup := seen[keyNode.Value]; dup {
+			l.findings = append(l.findings, LintFinding{
+				Rule:     LintRuleDuplicateKey,
+				Severity: LintError,
+				Key:      key,
+				Line:     keyNode.Line,
+				Message:  fmt.Sprintf("key %q defined at line %d and again at line %d", key, first, keyNode.Line),
+			})
+			kept = removeYAMLPair(kept, keyNode.Value)
+		}
+		seen[keyNode.Value] = keyNode.Line
+		l.lines[key] = keyNode.Line
+		l.walkYAML(valueNode, key)
+		kept = append(kept, keyNode, valueNode)
+	}
+	node.Content = kept
+}
+
+// removeYAMLPair 从键值对列表中移除指定键
+func removeYAMLPair(pairs []*yaml.Node, key string) []*yaml.Node {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i].Value == key {
+			return append(pairs[:i], pairs[i+2:]...)
+		}
+	}
+	return pairs
+}
+
+// checkDeprecated 检查废弃键
+func (l *linter) checkDeprecated(flat map[string]any) {
+	for oldKey, hint := range l.opts.Deprecated {
+		for key := range flat {
+			if key != oldKey && !strings.HasPrefix(key, oldKey+".") {
+				continue
+			}
+			message := fmt.Sprintf("key %q is deprecated", oldKey)
+			if hint != "" {
+				message += ": " + hint
+			}
+			l.add(LintRuleDeprecatedKey, LintWarning, key, message)
+		}
+	}
+}
+
+// checkSchema 对照结构体检查未知键、必填缺失与校验规则
+func (l *linter) checkSchema(doc *StructDoc, flat map[string]any) {
+	leaves := doc.Leaves()
+	known := make(map[string]FieldDoc, len(leaves))
+	var open []string // 值为 map/slice/interface 的键，其下任意子键均合法
+	for _, f := range leaves {
+		norm := normalizeLintKey(f.Key)
+		known[norm] = f
+		if f.goType != nil {
+			switch f.goType.Kind() {
+			case reflect.Map, reflect.Interface, reflect.Slice:
+				open = append(open, norm+".")
+			}
+		}
+	}
+
+	present := make(map[string]any, len(flat))
+	for key, value := range flat {
+		norm := normalizeLintKey(key)
+		present[norm] = value
+		if _, ok := known[norm]; ok {
+			continue
+		}
+		if hasAnyPrefix(norm, open) || l.isDeprecated(key) || key == ContentVersionKey {
+			continue
+		}
+		l.add(LintRuleUnknownKey, LintWarning, key, fmt.Sprintf("key %q is not defined in %s", key, doc.Name))
+	}
+
+	for norm, f := range known {
+		value, ok := present[norm]
+		if !ok {
+			if f.Required && f.Default == "" && !hasChildPrefix(norm, present) {
+				l.add(LintRuleValidation, LintError, f.Key, "required key is missing")
+			}
+			continue
+		}
+		if f.Validation == "" {
+			continue
+		}
+		if err := validateTagRules(value, f.Validation); err != nil {
+			l.add(LintRuleValidation, LintError, originalKey(flat, norm, f.Key), err.Error())
+		}
+	}
+}
+
+// isDeprecated 判断键是否属于废弃键
+func (l *linter) isDeprecated(key string) bool {
+	for oldKey := range l.opts.Deprecated {
+		if key == oldKey || strings.HasPrefix(key, oldKey+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// lintSecretReferencePrefixes 视为引用而非明文的值前缀
+var lintSecretReferencePrefixes = []string{"${", "$", "env:", "file://", "vault:", "ENC(", "enc:"}
+
+// checkSecrets 扫描疑似明文凭据
+func (l *linter) checkSecrets(flat map[string]any) {
+	for key, value := range flat {
+		str, ok := value.(string)
+		if !ok || str == "" {
+			continue
+		}
+		_, matched := validation.DetectSecret(str)
+		if matched {
+			l.add(LintRulePlaintextSecret, LintWarning, key, "value looks like a credential")
+		}
+		if matched || !isSecretKey(key) || hasAnyPrefix(str, lintSecretReferencePrefixes) {
+			continue
+		}
+		l.add(LintRulePlaintextSecret, LintWarning, key, "secret stored in plaintext; consider encryption or an environment variable")
+	}
+}
+
+// normalizeLintKey 归一化键名，兼容驼峰、蛇形与大小写差异（与 Unmarshal 的匹配规则一致）
+func normalizeLintKey(key string) string {
+	segments := strings.Split(key, ".")
+	for i, seg := range segments {
+		seg = strings.ToLower(camelToSnake(seg))
+		segments[i] = strings.NewReplacer("_", "", "-", "").Replace(seg)
+	}
+	return strings.Join(segments, ".")
+}
+
+// hasAnyPrefix 判断 s 是否以任一前缀开头
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasChildPrefix 判断是否存在以 norm 为父级的键（如 map 字段的子键）
+func hasChildPrefix(norm string, present map[string]any) bool {
+	for key := range present {
+		if strings.HasPrefix(key, norm+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// originalKey 返回文件中与归一化键对应的原始键名
+func originalKey(flat map[string]any, norm, fallback string) string {
+	for key := range flat {
+		if normalizeLintKey(key) == norm {
+			return key
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,28 @@
+package sysconf
+
+// Config 内部锁的全局获取顺序（外层 → 内层），任何路径只能按此顺序嵌套获取：
+//
+//	replicas.mu → writeMu → applyMu → warmMu → cacheBuildMu → mu → 叶子锁（cacheMu、health.mu、commits.mu、数据源与通知器内部锁）
+//
+//   - replicas.mu 保护多写者（Writer）的版本判定与发布，持有期间只可读取配置，不得调用 set
+//     （set 会同步执行 WatchSync 回调，回调中的 Writer.Get 会再次获取 replicas.mu）。
+//   - writeMu 串行化落盘：持有期间可获取快照，但获取 writeMu 时不得持有 mu，
+//     否则一次缓慢的写入（文件锁等待、大文件加密）会让所有读写路径在 mu 上排队。
+//   - applyMu 串行化应用器执行，执行期间回滚会获取 mu。
+//   - warmMu 串行化预热键重建，持有期间按完整查找链读取（会短暂获取 mu 读锁）。
+//   - cacheBuildMu 与 mu 始终成对获取，使用 lockState/unlockState。
+//   - 叶子锁持有期间不得再获取以上任何锁；Watch 回调、应用器与健康事件监听均在释放 mu 后调用。
+//
+// viper 实例仅在持有 mu 时访问。
+
+// lockState 按 cacheBuildMu → mu 的顺序获取状态锁
+func (c *Config) lockState() {
+	c.cacheBuildMu.Lock()
+	c.mu.Lock()
+}
+
+// unlockState 按相反顺序释放 lockState 获取的锁
+func (c *Config) unlockState() {
+	c.mu.Unlock()
+	c.cacheBuildMu.Unlock()
+}
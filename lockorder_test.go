@@ -0,0 +1,128 @@
+package sysconf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// runWithWatchdog 在超时后输出全部 goroutine 栈并使测试失败，用于暴露死锁
+func runWithWatchdog(t *testing.T, timeout time.Duration, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		var stacks strings.Builder
+		_ = pprof.Lookup("goroutine").WriteTo(&stacks, 2)
+		t.Fatalf("possible deadlock: stress run did not finish within %v\n%s", timeout, stacks.String())
+	}
+}
+
+// TestLockOrderStress 并发执行 Set、读取、Watch 订阅/取消、外部文件修改与立即/防抖写盘，
+// 配合 -race 检查数据竞争，并由看门狗检测死锁。
+func TestLockOrderStress(t *testing.T) {
+	for _, delay := range []time.Duration{0, 5 * time.Millisecond} {
+		t.Run(fmt.Sprintf("writeDelay=%v", delay), func(t *testing.T) {
+			dir := t.TempDir()
+			file := filepath.Join(dir, "stress.yaml")
+			if err := os.WriteFile(file, []byte("counter: 0\n"), 0o644); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+			cfg, err := New(
+				WithPath(dir),
+				WithName("stress"),
+				WithMode(YAML),
+				WithWriteDebounceDelay(delay),
+				WithWatchDebounce(time.Millisecond),
+				WithCacheTiming(0, time.Millisecond),
+			)
+			if err != nil {
+				t.Fatalf("new config: %v", err)
+			}
+
+			const (
+				workers    = 8
+				iterations = 100
+			)
+			runWithWatchdog(t, 30*time.Second, func() {
+				var wg sync.WaitGroup
+				for w := range workers {
+					wg.Go(func() {
+						for i := range iterations {
+							_ = cfg.Set(fmt.Sprintf("worker%d.value", w), i)
+							_ = cfg.Set("counter", i)
+						}
+					})
+					wg.Go(func() {
+						for range iterations {
+							_ = cfg.GetInt("counter")
+							_ = cfg.AllSettings()
+							_ = cfg.Keys()
+						}
+					})
+				}
+				wg.Go(func() {
+					for range iterations / 4 {
+						ctx, cancel := context.WithCancel(context.Background())
+						stop := cfg.WatchWithContext(ctx, func() { _ = cfg.GetString("counter") })
+						stop()
+						cancel()
+					}
+				})
+				wg.Go(func() {
+					for i := range iterations / 10 {
+						tmp := file + ".tmp"
+						_ = os.WriteFile(tmp, fmt.Appendf(nil, "counter: %d\n", -i), 0o644)
+						_ = os.Rename(tmp, file)
+						time.Sleep(time.Millisecond)
+					}
+				})
+				wg.Wait()
+				if err := cfg.Close(); err != nil {
+					t.Errorf("close: %v", err)
+				}
+			})
+		})
+	}
+}
+
+// TestSetNoopFastPath 相同的值不会重新写盘
+func TestSetNoopFastPath(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := New(WithPath(dir), WithName("app"), WithContent("port: 80\n"), WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	file := filepath.Join(dir, "app.yaml")
+	before, err := os.Stat(file)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := cfg.Set("port", 80); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	after, _ := os.Stat(file)
+	if !after.ModTime().Equal(before.ModTime()) {
+		t.Fatalf("unchanged value should not rewrite the config file")
+	}
+	if !cfg.setIsNoop("port", 80) || cfg.setIsNoop("port", 81) || cfg.setIsNoop("missing", 1) {
+		t.Fatalf("unexpected fast path decision")
+	}
+	if err := cfg.Set("port", 81); err != nil || cfg.GetInt("port") != 81 {
+		t.Fatalf("changed value should be applied: %v", err)
+	}
+}
@@ -24,9 +24,64 @@ type Logger interface {
 	Fatalf(format string, args ...any)
 }
 
+// LogLevel 日志级别
+type LogLevel int
+
+// 日志级别，数值越大越严重
+const (
+	DebugLevel LogLevel = iota // 调试
+	InfoLevel                  // 信息
+	WarnLevel                  // 警告
+	ErrorLevel                 // 错误
+	FatalLevel                 // 致命错误
+)
+
+// String 返回级别名称
+func (l LogLevel) String() string {
+	switch l {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	case FatalLevel:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+// LevelEnabler 可由日志实现额外实现，报告指定级别的日志是否会被输出。
+// 未实现时视为全部级别均输出；实现后，热路径上的调试日志在级别关闭时不会构造任何参数。
+type LevelEnabler interface {
+	Enabled(level LogLevel) bool
+}
+
+// logEnabled 判断 logger 是否输出指定级别的日志
+func logEnabled(logger Logger, level LogLevel) bool {
+	if logger == nil {
+		return false
+	}
+	if e, ok := logger.(LevelEnabler); ok {
+		return e.Enabled(level)
+	}
+	return true
+}
+
+// debugEnabled 判断是否需要构造调试日志，用于在读取等热路径上避免无谓的格式化与分配
+func (c *Config) debugEnabled() bool {
+	return logEnabled(c.logger, DebugLevel)
+}
+
 // NopLogger 空日志实现，不执行任何操作
 type NopLogger struct{}
 
+// Enabled 实现 LevelEnabler 接口，所有级别均不输出
+func (l *NopLogger) Enabled(LogLevel) bool { return false }
+
 // Debug 实现Logger接口
 func (l *NopLogger) Debug(args ...any) {}
 
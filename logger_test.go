@@ -1,6 +1,10 @@
 package sysconf
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
 
 // 覆盖 NopLogger 空实现
 func TestNopLogger(t *testing.T) {
@@ -16,3 +20,51 @@ func TestNopLogger(t *testing.T) {
 	l.Fatal("a")
 	l.Fatalf("%s", "a")
 }
+
+type countingLogger struct {
+	NopLogger
+	enabled bool
+	debugs  int
+}
+
+func (l *countingLogger) Enabled(level LogLevel) bool { return l.enabled || level > DebugLevel }
+
+func (l *countingLogger) Debugf(format string, args ...any) { l.debugs++ }
+
+// 调试级别关闭时热路径不构造日志参数
+func TestDebugLoggingIsFreeWhenDisabled(t *testing.T) {
+	cfg, err := New(WithContent("name: app\nratios: [0.5, 1.5]\n"))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = cfg.Get("name")
+	})
+	if allocs != 0 {
+		t.Fatalf("expected zero allocations with NopLogger, got %.1f", allocs)
+	}
+
+	logger := &countingLogger{}
+	cfg.logger = logger
+	_ = cfg.GetFloatSlice("ratios")
+	if logger.debugs != 0 {
+		t.Fatalf("disabled debug level should skip Debugf, got %d calls", logger.debugs)
+	}
+	logger.enabled = true
+	_ = cfg.GetFloatSlice("ratios")
+	if logger.debugs == 0 {
+		t.Fatalf("enabled debug level should log")
+	}
+}
+
+func TestLogLevelString(t *testing.T) {
+	for level, want := range map[LogLevel]string{
+		DebugLevel: "debug", InfoLevel: "info", WarnLevel: "warn", ErrorLevel: "error", FatalLevel: "fatal", LogLevel(42): "unknown",
+	} {
+		if got := level.String(); got != want {
+			t.Fatalf("LogLevel(%d).String() = %q, want %q", level, got, want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package sysconf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field 结构化日志字段
+type Field struct {
+	Key   string
+	Value any
+}
+
+// F 创建结构化日志字段
+func F(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
+
+// LoggerV2 带级别与结构化字段的日志接口，便于对接 slog、zap、zerolog 等日志库。
+// 通过 WithLoggerV2 设置；库内部仍按 Logger 接口调用，由适配层转换为 Log 调用。
+type LoggerV2 interface {
+	// Log 记录一条日志，fields 为附加的结构化字段
+	Log(level LogLevel, msg string, fields ...Field)
+	// Enabled 报告指定级别的日志是否会被输出，关闭的级别不会构造日志内容
+	Enabled(level LogLevel) bool
+}
+
+// WithLoggerV2 设置结构化日志记录器
+func WithLoggerV2(logger LoggerV2) Option {
+	return func(c *Config) {
+		if logger == nil {
+			c.logger = nil
+			return
+		}
+		c.logger = &loggerV2Adapter{logger: logger}
+	}
+}
+
+// WithLogLevel 设置最低日志级别，低于该级别的日志被丢弃。
+// 例如 WithLogLevel(sysconf.WarnLevel) 可屏蔽每次写入时的 "Writing config file" 等 Info 日志。
+// 对 WithLogger 与 WithLoggerV2 设置的日志记录器均生效，与选项顺序无关。
+func WithLogLevel(level LogLevel) Option {
+	return func(c *Config) {
+		c.logLevel = level
+	}
+}
+
+// AsLoggerV2 将旧版 Logger 包装为 LoggerV2，字段以 key=value 形式追加到消息之后
+func AsLoggerV2(logger Logger) LoggerV2 {
+	if adapter, ok := logger.(*loggerV2Adapter); ok {
+		return adapter.logger
+	}
+	return &loggerV1Shim{logger: logger}
+}
+
+// applyLogLevel 按 WithLogLevel 为日志记录器加上级别过滤
+func (c *Config) applyLogLevel() {
+	if c.logger == nil || c.logLevel <= DebugLevel {
+		return
+	}
+	c.logger = &levelLogger{Logger: c.logger, min: c.logLevel}
+}
+
+// logEvent 记录带结构化字段的日志：LoggerV2 直接接收字段，旧版 Logger 以 key=value 追加到消息
+func (c *Config) logEvent(level LogLevel, msg string, fields ...Field) {
+	if !logEnabled(c.logger, level) {
+		return
+	}
+	logger := c.logger
+	if filtered, ok := logger.(*levelLogger); ok {
+		logger = filtered.Logger
+	}
+	AsLoggerV2(logger).Log(level, msg, fields...)
+}
+
+// loggerV2Adapter 以 Logger 接口调用 LoggerV2
+type loggerV2Adapter struct {
+	logger LoggerV2
+}
+
+func (a *loggerV2Adapter) Enabled(level LogLevel) bool { return a.logger.Enabled(level) }
+
+func (a *loggerV2Adapter) log(level LogLevel, args []any) {
+	if a.logger.Enabled(level) {
+		a.logger.Log(level, fmt.Sprint(args...))
+	}
+}
+
+func (a *loggerV2Adapter) logf(level LogLevel, format string, args []any) {
+	if a.logger.Enabled(level) {
+		a.logger.Log(level, fmt.Sprintf(format, args...))
+	}
+}
+
+func (a *loggerV2Adapter) Debug(args ...any)                 { a.log(DebugLevel, args) }
+func (a *loggerV2Adapter) Debugf(format string, args ...any) { a.logf(DebugLevel, format, args) }
+func (a *loggerV2Adapter) Info(args ...any)                  { a.log(InfoLevel, args) }
+func (a *loggerV2Adapter) Infof(format string, args ...any)  { a.logf(InfoLevel, format, args) }
+func (a *loggerV2Adapter) Warn(args ...any)                  { a.log(WarnLevel, args) }
+func (a *loggerV2Adapter) Warnf(format string, args ...any)  { a.logf(WarnLevel, format, args) }
+func (a *loggerV2Adapter) Error(args ...any)                 { a.log(ErrorLevel, args) }
+func (a *loggerV2Adapter) Errorf(format string, args ...any) { a.logf(ErrorLevel, format, args) }
+func (a *loggerV2Adapter) Fatal(args ...any)                 { a.log(FatalLevel, args) }
+func (a *loggerV2Adapter) Fatalf(format string, args ...any) { a.logf(FatalLevel, format, args) }
+
+// loggerV1Shim 以 LoggerV2 接口调用旧版 Logger
+type loggerV1Shim struct {
+	logger Logger
+}
+
+func (s *loggerV1Shim) Enabled(level LogLevel) bool { return logEnabled(s.logger, level) }
+
+func (s *loggerV1Shim) Log(level LogLevel, msg string, fields ...Field) {
+	if len(fields) > 0 {
+		var b strings.Builder
+		b.WriteString(msg)
+		for _, f := range fields {
+			fmt.Fprintf(&b, " %s=%v", f.Key, f.Value)
+		}
+		msg = b.String()
+	}
+	switch level {
+	case DebugLevel:
+		s.logger.Debug(msg)
+	case InfoLevel:
+		s.logger.Info(msg)
+	case WarnLevel:
+		s.logger.Warn(msg)
+	case ErrorLevel:
+		s.logger.Error(msg)
+	default:
+		s.logger.Fatal(msg)
+	}
+}
+
+// levelLogger 丢弃低于最低级别的日志
+type levelLogger struct {
+	Logger
+	min LogLevel
+}
+
+func (l *levelLogger) Enabled(level LogLevel) bool {
+	return level >= l.min && logEnabled(l.Logger, level)
+}
+
+func (l *levelLogger) Debug(args ...any) {
+	if l.min <= DebugLevel {
+		l.Logger.Debug(args...)
+	}
+}
+
+func (l *levelLogger) Debugf(format string, args ...any) {
+	if l.min <= DebugLevel {
+		l.Logger.Debugf(format, args...)
+	}
+}
+
+func (l *levelLogger) Info(args ...any) {
+	if l.min <= InfoLevel {
+		l.Logger.Info(args...)
+	}
+}
+
+func (l *levelLogger) Infof(format string, args ...any) {
+	if l.min <= InfoLevel {
+		l.Logger.Infof(format, args...)
+	}
+}
+
+func (l *levelLogger) Warn(args ...any) {
+	if l.min <= WarnLevel {
+		l.Logger.Warn(args...)
+	}
+}
+
+func (l *levelLogger) Warnf(format string, args ...any) {
+	if l.min <= WarnLevel {
+		l.Logger.Warnf(format, args...)
+	}
+}
+
+func (l *levelLogger) Error(args ...any) {
+	if l.min <= ErrorLevel {
+		l.Logger.Error(args...)
+	}
+}
+
+func (l *levelLogger) Errorf(format string, args ...any) {
+	if l.min <= ErrorLevel {
+		l.Logger.Errorf(format, args...)
+	}
+}
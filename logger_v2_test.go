@@ -0,0 +1,130 @@
+package sysconf
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+type recordedLog struct {
+	level  LogLevel
+	msg    string
+	fields []Field
+}
+
+type recordingLoggerV2 struct {
+	mu   sync.Mutex
+	min  LogLevel
+	logs []recordedLog
+}
+
+func (l *recordingLoggerV2) Log(level LogLevel, msg string, fields ...Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logs = append(l.logs, recordedLog{level: level, msg: msg, fields: fields})
+}
+
+func (l *recordingLoggerV2) Enabled(level LogLevel) bool { return level >= l.min }
+
+func (l *recordingLoggerV2) find(msg string) (recordedLog, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, entry := range l.logs {
+		if entry.msg == msg {
+			return entry, true
+		}
+	}
+	return recordedLog{}, false
+}
+
+func TestWithLoggerV2ReceivesLevelsAndFields(t *testing.T) {
+	logger := &recordingLoggerV2{min: InfoLevel}
+	cfg, err := New(
+		WithPath(t.TempDir()),
+		WithName("app"),
+		WithContent("port: 80\n"),
+		WithLoggerV2(logger),
+		WithWriteDebounceDelay(0),
+	)
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if err := cfg.Set("port", 81); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	entry, ok := logger.find("Config file written")
+	if !ok {
+		t.Fatalf("expected structured write log, got %+v", logger.logs)
+	}
+	if entry.level != InfoLevel || len(entry.fields) != 1 || entry.fields[0].Key != "file" {
+		t.Fatalf("unexpected log entry %+v", entry)
+	}
+	if _, ok := logger.find("Writing config file"); !ok {
+		t.Fatalf("printf-style internal logs should be adapted to Log")
+	}
+	for _, entry := range logger.logs {
+		if entry.level < InfoLevel {
+			t.Fatalf("disabled level reached LoggerV2: %+v", entry)
+		}
+	}
+}
+
+func TestWithLogLevelSilencesInfo(t *testing.T) {
+	logger := &recordingLoggerV2{}
+	cfg, err := New(
+		WithLogLevel(WarnLevel),
+		WithPath(t.TempDir()),
+		WithName("app"),
+		WithContent("port: 80\n"),
+		WithLoggerV2(logger),
+		WithWriteDebounceDelay(0),
+	)
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if err := cfg.Set("port", 81); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	for _, entry := range logger.logs {
+		if entry.level < WarnLevel {
+			t.Fatalf("WithLogLevel(WarnLevel) should drop %s log %q", entry.level, entry.msg)
+		}
+	}
+	if cfg.debugEnabled() {
+		t.Fatalf("debug should be reported as disabled")
+	}
+}
+
+type textLogger struct {
+	NopLogger
+	lines []string
+}
+
+func (l *textLogger) Info(args ...any) { l.lines = append(l.lines, args[0].(string)) }
+
+func TestAsLoggerV2Shim(t *testing.T) {
+	old := &textLogger{}
+	v2 := AsLoggerV2(old)
+	v2.Log(InfoLevel, "reloaded", F("file", "app.yaml"), F("keys", 3))
+	if len(old.lines) != 1 || old.lines[0] != "reloaded file=app.yaml keys=3" {
+		t.Fatalf("unexpected shim output %q", old.lines)
+	}
+	if v2.Enabled(InfoLevel) {
+		t.Fatalf("shim should report the wrapped logger's levels")
+	}
+
+	native := &recordingLoggerV2{}
+	cfg := &Config{}
+	WithLoggerV2(native)(cfg)
+	if AsLoggerV2(cfg.logger) != native {
+		t.Fatalf("adapted LoggerV2 should be unwrapped")
+	}
+	cfg.logger.Infof("hello %s", "world")
+	if entry, ok := native.find("hello world"); !ok || entry.level != InfoLevel {
+		t.Fatalf("Infof should be forwarded as Log, got %+v", native.logs)
+	}
+}
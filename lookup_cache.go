@@ -0,0 +1,78 @@
+package sysconf
+
+import (
+	"fmt"
+	"time"
+)
+
+// lookupEntry 环境变量/回退查询结果的缓存条目
+type lookupEntry struct {
+	value     any
+	found     bool
+	expiresAt int64 // 过期时间（UnixNano）
+}
+
+// WithLookupTTL 设置环境变量与回退查询结果的缓存时长。
+// ttl > 0 时，命中与未命中（负缓存）的查询结果都会在 ttl 内复用，减少 os.LookupEnv 系统调用；
+// ttl <= 0 时禁用缓存（默认）。缓存会在 RefreshEnvBindings、SetEnvPrefix、Set 与配置重载时失效。
+func WithLookupTTL(ttl time.Duration) Option {
+	return func(c *Config) {
+		if ttl < 0 {
+			ttl = 0
+		}
+		c.lookupTTL = ttl
+	}
+}
+
+// loadLookupEntry 读取未过期的缓存条目
+func (c *Config) loadLookupEntry(cacheKey string) (lookupEntry, bool) {
+	if c.lookupTTL <= 0 {
+		return lookupEntry{}, false
+	}
+	cached, ok := c.lookupCache.Load(cacheKey)
+	if !ok {
+		return lookupEntry{}, false
+	}
+	entry := cached.(lookupEntry)
+	if time.Now().UnixNano() >= entry.expiresAt {
+		c.lookupCache.Delete(cacheKey)
+		return lookupEntry{}, false
+	}
+	return entry, true
+}
+
+// storeLookupEntry 写入缓存条目
+func (c *Config) storeLookupEntry(cacheKey string, value any, found bool) {
+	if c.lookupTTL <= 0 {
+		return
+	}
+	c.lookupCache.Store(cacheKey, lookupEntry{
+		value:     value,
+		found:     found,
+		expiresAt: time.Now().Add(c.lookupTTL).UnixNano(),
+	})
+}
+
+// invalidateLookupCache 清空查询缓存
+func (c *Config) invalidateLookupCache() {
+	if c.lookupTTL <= 0 {
+		return
+	}
+	c.lookupCache.Clear()
+}
+
+// RefreshEnvBindings 重新扫描并绑定环境变量，同时清空环境变量查询缓存。
+// 适用于运行期间修改了进程环境变量、需要立即生效的场景。
+func (c *Config) RefreshEnvBindings() error {
+	if c.closed.Load() {
+		return ErrAlreadyClosed
+	}
+
+	c.envKeyCache.Clear()
+	c.invalidateLookupCache()
+	if err := c.reinitialize(); err != nil {
+		return fmt.Errorf("refresh env bindings: %w", err)
+	}
+	c.invalidateCache()
+	return nil
+}
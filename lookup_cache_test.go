@@ -0,0 +1,74 @@
+package sysconf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestLookupTTLCachesEnvValues(t *testing.T) {
+	t.Setenv("LTTL_SERVICE_NAME", "first")
+
+	cfg, err := New(
+		WithContent("service:\n  name: file\n"),
+		WithEnv("LTTL"),
+		WithLookupTTL(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("service.name"); got != "first" {
+		t.Fatalf("expected env value, got %q", got)
+	}
+
+	t.Setenv("LTTL_SERVICE_NAME", "second")
+	if got := cfg.GetString("service.name"); got != "first" {
+		t.Fatalf("expected cached env value within ttl, got %q", got)
+	}
+
+	if err := cfg.RefreshEnvBindings(); err != nil {
+		t.Fatalf("refresh env bindings failed: %v", err)
+	}
+	if got := cfg.GetString("service.name"); got != "second" {
+		t.Fatalf("expected refreshed env value, got %q", got)
+	}
+}
+
+func TestLookupTTLNegativeCacheExpires(t *testing.T) {
+	cfg, err := New(
+		WithContent("app:\n  name: demo\n"),
+		WithEnv("LTTLNEG"),
+		WithLookupTTL(20*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if cfg.IsSet("feature") {
+		t.Fatalf("feature should not exist yet")
+	}
+
+	t.Setenv("LTTLNEG_FEATURE", "on")
+	if cfg.IsSet("feature") {
+		t.Fatalf("negative lookup should be cached within ttl")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if got := cfg.GetString("feature"); got != "on" {
+		t.Fatalf("expected env value after ttl expiry, got %q", got)
+	}
+}
+
+func TestLookupTTLDisabledByDefault(t *testing.T) {
+	cfg := newTestConfig(t)
+	testutil.Cleanup(t, cfg.Close)
+
+	cfg.storeLookupEntry("env|x", "v", true)
+	if _, ok := cfg.loadLookupEntry("env|x"); ok {
+		t.Fatalf("lookup cache should be disabled without WithLookupTTL")
+	}
+}
@@ -18,7 +18,7 @@ import (
 //   - error: 序列化过程中遇到的错误，成功则为nil
 func (c *Config) Marshal(value any, prefix ...string) error {
 	c.mu.RLock()
-	if c.viper == nil {
+	if c.viper == nil && !c.isNative() {
 		c.mu.RUnlock()
 		return fmt.Errorf("viper instance not initialized")
 	}
@@ -30,7 +30,7 @@ func (c *Config) Marshal(value any, prefix ...string) error {
 	}
 
 	c.mu.RLock()
-	configMap = deepMerge(c.snapshotAllSettings(), configMap)
+	configMap = DeepMerge(c.snapshotAllSettings(), configMap, MergeOverwrite)
 	c.mu.RUnlock()
 
 	if len(prefix) > 0 {
@@ -81,42 +81,3 @@ func (c *Config) setMapToViper(prefix string, m map[string]any) error {
 	}
 	return nil
 }
-
-// deepMerge 深度合并两个 map（原地修改 m1）
-//
-// 参数:
-//   - m1: 目标映射，合并结果将存储在此
-//   - m2: 源映射，其值将合并到m1中
-//
-// 返回值:
-//   - map[string]any: 合并后的映射
-func deepMerge(m1, m2 map[string]any) map[string]any {
-	if m1 == nil {
-		m1 = make(map[string]any)
-	}
-
-	for k, v2 := range m2 {
-		// 处理 v2 为 nil 的情况
-		if v2 == nil {
-			m1[k] = nil
-			continue
-		}
-
-		// 查看 m1 中是否有相同的键
-		if v1, ok := m1[k]; ok && v1 != nil {
-			// 如果 m1 和 m2 在这个键上的值都是 map，递归合并
-			m1Map, ok1 := v1.(map[string]any)
-			m2Map, ok2 := v2.(map[string]any)
-
-			if ok1 && ok2 {
-				// 原地递归合并这两个子 map
-				deepMerge(m1Map, m2Map)
-				continue
-			}
-		}
-		// 如果不是 map 类型或 m1 中不存在该键，直接赋值
-		m1[k] = v2
-	}
-
-	return m1
-}
@@ -0,0 +1,90 @@
+package sysconf
+
+import "reflect"
+
+// MergeStrategy DeepMerge 处理同一键上非 map 冲突值的策略
+type MergeStrategy int
+
+const (
+	MergeOverwrite    MergeStrategy = iota // src 的值覆盖 dst（默认）
+	MergeKeepExisting                      // 保留 dst 已有的值，只补充 dst 缺失的键
+	MergeAppendSlices                      // 两侧均为切片时拼接（dst 在前），其余冲突同 MergeOverwrite
+)
+
+// String 返回策略名称
+func (s MergeStrategy) String() string {
+	switch s {
+	case MergeOverwrite:
+		return "overwrite"
+	case MergeKeepExisting:
+		return "keep-existing"
+	case MergeAppendSlices:
+		return "append-slices"
+	default:
+		return "unknown"
+	}
+}
+
+// Flatten 将嵌套配置展开为点分键的扁平 map，如 {"db": {"host": "x"}} → {"db.host": "x"}，
+// 与 Config 内部存储的键形式一致，便于为验证器准备数据或比较两份配置。
+// 返回新 map，值经过深拷贝；map[any]any 同样被展开，空 map 不产生键。
+func Flatten(settings map[string]any) map[string]any {
+	flat := make(map[string]any, len(settings))
+	flattenSettings("", settings, flat)
+	return flat
+}
+
+// DeepMerge 将 src 深度合并到 dst 并返回 dst（dst 为 nil 时新建）。
+// 两侧均为 map 的键递归合并，其余冲突按 strategy 处理；src 的值经过深拷贝，合并后修改 dst 不会影响 src。
+func DeepMerge(dst, src map[string]any, strategy MergeStrategy) map[string]any {
+	if dst == nil {
+		dst = make(map[string]any, len(src))
+	}
+	for key, srcValue := range src {
+		srcValue = sanitizeValue(srcValue)
+		dstValue, exists := dst[key]
+		if !exists {
+			dst[key] = srcValue
+			continue
+		}
+		if legacy, ok := dstValue.(map[any]any); ok {
+			dstValue = sanitizeValue(legacy)
+		}
+		if dstMap, ok := dstValue.(map[string]any); ok {
+			if srcMap, ok := srcValue.(map[string]any); ok {
+				dst[key] = DeepMerge(dstMap, srcMap, strategy)
+				continue
+			}
+		}
+		switch strategy {
+		case MergeKeepExisting:
+			continue
+		case MergeAppendSlices:
+			if merged, ok := appendSlices(dstValue, srcValue); ok {
+				dst[key] = merged
+				continue
+			}
+		}
+		dst[key] = srcValue
+	}
+	return dst
+}
+
+// appendSlices 拼接两个切片（结果为新切片）；元素类型相同时保留原类型，否则统一为 []any
+func appendSlices(a, b any) (any, bool) {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Kind() != reflect.Slice || bv.Kind() != reflect.Slice {
+		return nil, false
+	}
+	if av.Type() == bv.Type() {
+		merged := reflect.MakeSlice(av.Type(), 0, av.Len()+bv.Len())
+		return reflect.AppendSlice(reflect.AppendSlice(merged, av), bv).Interface(), true
+	}
+	merged := make([]any, 0, av.Len()+bv.Len())
+	for _, v := range []reflect.Value{av, bv} {
+		for i := range v.Len() {
+			merged = append(merged, v.Index(i).Interface())
+		}
+	}
+	return merged, true
+}
@@ -0,0 +1,88 @@
+package sysconf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	nested := map[string]any{
+		"server": map[string]any{
+			"host": "localhost",
+			"tls":  map[any]any{"enabled": true},
+		},
+		"tags":  []string{"a", "b"},
+		"empty": map[string]any{},
+	}
+	flat := Flatten(nested)
+	want := map[string]any{
+		"server.host":        "localhost",
+		"server.tls.enabled": true,
+		"tags":               []string{"a", "b"},
+	}
+	if !reflect.DeepEqual(flat, want) {
+		t.Fatalf("unexpected flatten result: %#v", flat)
+	}
+
+	flat["tags"].([]string)[0] = "changed"
+	if nested["tags"].([]string)[0] != "a" {
+		t.Fatal("Flatten must not share slices with the input")
+	}
+}
+
+func TestDeepMergeStrategies(t *testing.T) {
+	newDst := func() map[string]any {
+		return map[string]any{
+			"server": map[string]any{"host": "localhost", "port": 8080},
+			"tags":   []string{"a"},
+			"plugin": map[any]any{"name": "old"},
+		}
+	}
+	src := map[string]any{
+		"server": map[string]any{"port": 9090, "timeout": "30s"},
+		"tags":   []string{"b"},
+		"plugin": map[string]any{"name": "new"},
+		"extra":  []any{1},
+	}
+
+	got := DeepMerge(newDst(), src, MergeOverwrite)
+	want := map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 9090, "timeout": "30s"},
+		"tags":   []string{"b"},
+		"plugin": map[string]any{"name": "new"},
+		"extra":  []any{1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("overwrite: unexpected result %#v", got)
+	}
+
+	got = DeepMerge(newDst(), src, MergeKeepExisting)
+	want = map[string]any{
+		"server": map[string]any{"host": "localhost", "port": 8080, "timeout": "30s"},
+		"tags":   []string{"a"},
+		"plugin": map[string]any{"name": "old"},
+		"extra":  []any{1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("keep-existing: unexpected result %#v", got)
+	}
+
+	got = DeepMerge(newDst(), src, MergeAppendSlices)
+	if tags := got["tags"]; !reflect.DeepEqual(tags, []string{"a", "b"}) {
+		t.Fatalf("append-slices: unexpected tags %#v", tags)
+	}
+	got = DeepMerge(map[string]any{"mixed": []int{1}}, map[string]any{"mixed": []string{"x"}}, MergeAppendSlices)
+	if mixed := got["mixed"]; !reflect.DeepEqual(mixed, []any{1, "x"}) {
+		t.Fatalf("append-slices: unexpected mixed slice %#v", mixed)
+	}
+}
+
+func TestDeepMergeCopiesSource(t *testing.T) {
+	src := map[string]any{"db": map[string]any{"hosts": []string{"a"}}}
+	merged := DeepMerge(nil, src, MergeOverwrite)
+
+	merged["db"].(map[string]any)["hosts"].([]string)[0] = "changed"
+	if src["db"].(map[string]any)["hosts"].([]string)[0] != "a" {
+		t.Fatal("DeepMerge must not share values with src")
+	}
+}
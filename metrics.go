@@ -1,6 +1,7 @@
 package sysconf
 
 import (
+	"encoding/json"
 	"fmt"
 	"maps"
 	"sync"
@@ -31,25 +32,53 @@ type OperationStats struct {
 	MinNs   int64 `json:"min_ns"`
 	MaxNs   int64 `json:"max_ns"`
 	LastNs  int64 `json:"last_ns"`
+	P50Ns   int64 `json:"p50_ns"` // 分位数为对数分桶的估计值（所在桶的上界）
+	P95Ns   int64 `json:"p95_ns"`
+	P99Ns   int64 `json:"p99_ns"`
+
+	hist *latencyHistogram // 延迟分布
 }
 
 // Metrics 配置性能指标
 type Metrics struct {
-	mu             sync.RWMutex
-	StartTime      time.Time                  `json:"start_time"`
-	GetCount       int64                      `json:"get_count"`
-	SetCount       int64                      `json:"set_count"`
-	CacheHits      int64                      `json:"cache_hits"`
-	CacheMisses    int64                      `json:"cache_misses"`
-	LastGetTime    time.Time                  `json:"last_get_time"`
-	LastSetTime    time.Time                  `json:"last_set_time"`
-	ErrorCount     int64                      `json:"error_count"`
-	OperationTimes map[string]time.Duration   `json:"operation_times"` // 向后兼容：最后一次操作时间
-	OperationStats map[string]*OperationStats `json:"operation_stats"` // 新增：累积统计
+	mu                 sync.RWMutex
+	StartTime          time.Time                  `json:"start_time"`
+	GetCount           int64                      `json:"get_count"`
+	SetCount           int64                      `json:"set_count"`
+	CacheHits          int64                      `json:"cache_hits"`
+	CacheMisses        int64                      `json:"cache_misses"`
+	LastGetTime        time.Time                  `json:"last_get_time"` // Deprecated: 仅在 GetStats 时刷新，请读取 MetricsSnapshot
+	LastSetTime        time.Time                  `json:"last_set_time"` // Deprecated: 仅在 GetStats 时刷新，请读取 MetricsSnapshot
+	ErrorCount         int64                      `json:"error_count"`
+	ReloadCount        int64                      `json:"reload_count"`        // 成功重载次数（文件与远程配置源）
+	ReloadFailures     int64                      `json:"reload_failures"`     // 重载失败次数
+	ValidationFailures int64                      `json:"validation_failures"` // 验证失败次数（Set 与远程配置）
+	WatcherRestarts    int64                      `json:"watcher_restarts"`    // 文件监听重新启动或重新挂载的次数
+	WatcherErrors      int64                      `json:"watcher_errors"`      // 文件监听错误次数
+	DroppedEvents      int64                      `json:"dropped_events"`      // 文件监听事件队列溢出次数
+	OperationTimes     map[string]time.Duration   `json:"operation_times"`     // 向后兼容：最后一次操作时间
+	OperationStats     map[string]*OperationStats `json:"operation_stats"`     // 新增：累积统计
 
 	// 内部计数器
-	totalGetTime int64 // 累积的Get操作时间（纳秒）
-	totalSetTime int64 // 累积的Set操作时间（纳秒）
+	totalGetTime int64            // 累积的Get操作时间（纳秒）
+	totalSetTime int64            // 累积的Set操作时间（纳秒）
+	lastGetNs    atomic.Int64     // 最后一次Get的时间（UnixNano），避免热路径加锁
+	lastSetNs    atomic.Int64     // 最后一次Set的时间（UnixNano）
+	getHist      latencyHistogram // Get 延迟分布
+	setHist      latencyHistogram // Set 延迟分布
+}
+
+// LatencyPercentiles 延迟分位数（对数分桶估计值，相对误差约 12.5%）
+type LatencyPercentiles struct {
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+}
+
+// percentiles 读取直方图的 p50/p90/p99
+func (h *latencyHistogram) percentiles() LatencyPercentiles {
+	p := h.quantiles(0.50, 0.90, 0.99)
+	return LatencyPercentiles{P50: p[0], P90: p[1], P99: p[2]}
 }
 
 // NewMetrics 创建新的性能指标实例
@@ -65,6 +94,7 @@ func NewMetrics() *Metrics {
 func (m *Metrics) RecordGet(duration time.Duration, cacheHit bool) {
 	atomic.AddInt64(&m.GetCount, 1)
 	atomic.AddInt64(&m.totalGetTime, int64(duration))
+	m.getHist.record(duration)
 
 	if cacheHit {
 		atomic.AddInt64(&m.CacheHits, 1)
@@ -72,19 +102,15 @@ func (m *Metrics) RecordGet(duration time.Duration, cacheHit bool) {
 		atomic.AddInt64(&m.CacheMisses, 1)
 	}
 
-	m.mu.Lock()
-	m.LastGetTime = time.Now()
-	m.mu.Unlock()
+	m.lastGetNs.Store(time.Now().UnixNano())
 }
 
 // RecordSet 记录Set操作
 func (m *Metrics) RecordSet(duration time.Duration) {
 	atomic.AddInt64(&m.SetCount, 1)
 	atomic.AddInt64(&m.totalSetTime, int64(duration))
-
-	m.mu.Lock()
-	m.LastSetTime = time.Now()
-	m.mu.Unlock()
+	m.setHist.record(duration)
+	m.lastSetNs.Store(time.Now().UnixNano())
 }
 
 // RecordError 记录错误
@@ -92,6 +118,34 @@ func (m *Metrics) RecordError() {
 	atomic.AddInt64(&m.ErrorCount, 1)
 }
 
+// RecordReload 记录一次配置重载，err 非空时计为失败
+func (m *Metrics) RecordReload(duration time.Duration, err error) {
+	if err != nil {
+		atomic.AddInt64(&m.ReloadFailures, 1)
+	} else {
+		atomic.AddInt64(&m.ReloadCount, 1)
+	}
+	m.RecordOperation("reload", duration)
+}
+
+// RecordValidationFailure 记录一次验证失败
+func (m *Metrics) RecordValidationFailure() {
+	atomic.AddInt64(&m.ValidationFailures, 1)
+}
+
+// RecordWatcherRestart 记录一次文件监听重启
+func (m *Metrics) RecordWatcherRestart() {
+	atomic.AddInt64(&m.WatcherRestarts, 1)
+}
+
+// RecordWatcherError 记录一次文件监听错误，dropped 表示事件队列溢出导致事件丢失
+func (m *Metrics) RecordWatcherError(dropped bool) {
+	atomic.AddInt64(&m.WatcherErrors, 1)
+	if dropped {
+		atomic.AddInt64(&m.DroppedEvents, 1)
+	}
+}
+
 // RecordOperation 记录自定义操作时间
 func (m *Metrics) RecordOperation(name string, duration time.Duration) {
 	m.mu.Lock()
@@ -107,9 +161,11 @@ func (m *Metrics) RecordOperation(name string, duration time.Duration) {
 		stats = &OperationStats{
 			MinNs: durationNs,
 			MaxNs: durationNs,
+			hist:  &latencyHistogram{},
 		}
 		m.OperationStats[name] = stats
 	}
+	stats.hist.record(duration)
 
 	stats.Count++
 	stats.TotalNs += durationNs
@@ -124,8 +180,11 @@ func (m *Metrics) RecordOperation(name string, duration time.Duration) {
 
 // GetStats 获取统计信息
 func (m *Metrics) GetStats() MetricsSnapshot {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.LastGetTime = unixNanoTime(m.lastGetNs.Load())
+	m.LastSetTime = unixNanoTime(m.lastSetNs.Load())
 
 	getCount := atomic.LoadInt64(&m.GetCount)
 	setCount := atomic.LoadInt64(&m.SetCount)
@@ -133,17 +192,25 @@ func (m *Metrics) GetStats() MetricsSnapshot {
 	totalSetTime := atomic.LoadInt64(&m.totalSetTime)
 
 	stats := MetricsSnapshot{
-		StartTime:      m.StartTime,
-		Uptime:         time.Since(m.StartTime),
-		GetCount:       getCount,
-		SetCount:       setCount,
-		CacheHits:      atomic.LoadInt64(&m.CacheHits),
-		CacheMisses:    atomic.LoadInt64(&m.CacheMisses),
-		ErrorCount:     atomic.LoadInt64(&m.ErrorCount),
-		LastGetTime:    m.LastGetTime,
-		LastSetTime:    m.LastSetTime,
-		OperationTimes: make(map[string]time.Duration),
-		OperationStats: make(map[string]*OperationStats),
+		StartTime:          m.StartTime,
+		Uptime:             time.Since(m.StartTime),
+		GetCount:           getCount,
+		SetCount:           setCount,
+		CacheHits:          atomic.LoadInt64(&m.CacheHits),
+		CacheMisses:        atomic.LoadInt64(&m.CacheMisses),
+		ErrorCount:         atomic.LoadInt64(&m.ErrorCount),
+		ReloadCount:        atomic.LoadInt64(&m.ReloadCount),
+		ReloadFailures:     atomic.LoadInt64(&m.ReloadFailures),
+		ValidationFailures: atomic.LoadInt64(&m.ValidationFailures),
+		WatcherRestarts:    atomic.LoadInt64(&m.WatcherRestarts),
+		WatcherErrors:      atomic.LoadInt64(&m.WatcherErrors),
+		DroppedEvents:      atomic.LoadInt64(&m.DroppedEvents),
+		LastGetTime:        m.LastGetTime,
+		LastSetTime:        m.LastSetTime,
+		GetLatency:         m.getHist.percentiles(),
+		SetLatency:         m.setHist.percentiles(),
+		OperationTimes:     make(map[string]time.Duration),
+		OperationStats:     make(map[string]*OperationStats),
 	}
 
 	// 复制操作时间
@@ -151,12 +218,16 @@ func (m *Metrics) GetStats() MetricsSnapshot {
 
 	// 复制操作统计
 	for k, v := range m.OperationStats {
+		p := v.hist.quantiles(0.50, 0.95, 0.99)
 		stats.OperationStats[k] = &OperationStats{
 			Count:   v.Count,
 			TotalNs: v.TotalNs,
 			MinNs:   v.MinNs,
 			MaxNs:   v.MaxNs,
 			LastNs:  v.LastNs,
+			P50Ns:   int64(p[0]),
+			P95Ns:   int64(p[1]),
+			P99Ns:   int64(p[2]),
 		}
 	}
 
@@ -187,8 +258,18 @@ func (m *Metrics) Reset() {
 	atomic.StoreInt64(&m.CacheHits, 0)
 	atomic.StoreInt64(&m.CacheMisses, 0)
 	atomic.StoreInt64(&m.ErrorCount, 0)
+	atomic.StoreInt64(&m.ReloadCount, 0)
+	atomic.StoreInt64(&m.ReloadFailures, 0)
+	atomic.StoreInt64(&m.ValidationFailures, 0)
+	atomic.StoreInt64(&m.WatcherRestarts, 0)
+	atomic.StoreInt64(&m.WatcherErrors, 0)
+	atomic.StoreInt64(&m.DroppedEvents, 0)
 	atomic.StoreInt64(&m.totalGetTime, 0)
 	atomic.StoreInt64(&m.totalSetTime, 0)
+	m.lastGetNs.Store(0)
+	m.lastSetNs.Store(0)
+	m.getHist.reset()
+	m.setHist.reset()
 
 	m.StartTime = time.Now()
 	m.LastGetTime = time.Time{}
@@ -197,22 +278,38 @@ func (m *Metrics) Reset() {
 	m.OperationStats = make(map[string]*OperationStats)
 }
 
+// unixNanoTime 将 UnixNano 转换为时间，0 表示未记录
+func unixNanoTime(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
 // MetricsSnapshot 性能指标快照
 type MetricsSnapshot struct {
-	StartTime      time.Time                  `json:"start_time"`
-	Uptime         time.Duration              `json:"uptime"`
-	GetCount       int64                      `json:"get_count"`
-	SetCount       int64                      `json:"set_count"`
-	CacheHits      int64                      `json:"cache_hits"`
-	CacheMisses    int64                      `json:"cache_misses"`
-	CacheHitRatio  float64                    `json:"cache_hit_ratio"`
-	ErrorCount     int64                      `json:"error_count"`
-	AvgGetTime     time.Duration              `json:"avg_get_time"`
-	AvgSetTime     time.Duration              `json:"avg_set_time"`
-	LastGetTime    time.Time                  `json:"last_get_time"`
-	LastSetTime    time.Time                  `json:"last_set_time"`
-	OperationTimes map[string]time.Duration   `json:"operation_times"`
-	OperationStats map[string]*OperationStats `json:"operation_stats"`
+	StartTime          time.Time                  `json:"start_time"`
+	Uptime             time.Duration              `json:"uptime"`
+	GetCount           int64                      `json:"get_count"`
+	SetCount           int64                      `json:"set_count"`
+	CacheHits          int64                      `json:"cache_hits"`
+	CacheMisses        int64                      `json:"cache_misses"`
+	CacheHitRatio      float64                    `json:"cache_hit_ratio"`
+	ErrorCount         int64                      `json:"error_count"`
+	ReloadCount        int64                      `json:"reload_count"`
+	ReloadFailures     int64                      `json:"reload_failures"`
+	ValidationFailures int64                      `json:"validation_failures"`
+	WatcherRestarts    int64                      `json:"watcher_restarts"`
+	WatcherErrors      int64                      `json:"watcher_errors"`
+	DroppedEvents      int64                      `json:"dropped_events"`
+	AvgGetTime         time.Duration              `json:"avg_get_time"`
+	AvgSetTime         time.Duration              `json:"avg_set_time"`
+	GetLatency         LatencyPercentiles         `json:"get_latency"` // Get 延迟分位数，平均值会掩盖长尾
+	SetLatency         LatencyPercentiles         `json:"set_latency"` // Set 延迟分位数
+	LastGetTime        time.Time                  `json:"last_get_time"`
+	LastSetTime        time.Time                  `json:"last_set_time"`
+	OperationTimes     map[string]time.Duration   `json:"operation_times"`
+	OperationStats     map[string]*OperationStats `json:"operation_stats"`
 }
 
 // GetSummary 获取性能摘要字符串
@@ -223,15 +320,29 @@ func (s MetricsSnapshot) GetSummary() string {
 			"  Operations: %d gets, %d sets\n"+
 			"  Cache: %.2f%% hit ratio (%d hits, %d misses)\n"+
 			"  Avg Times: %v get, %v set\n"+
-			"  Errors: %d\n",
+			"  Get Latency: p50 %v, p90 %v, p99 %v\n"+
+			"  Set Latency: p50 %v, p90 %v, p99 %v\n"+
+			"  Errors: %d\n"+
+			"  Reloads: %d ok, %d failed; %d validation failures\n"+
+			"  Watcher: %d restarts, %d errors, %d dropped event batches\n",
 		s.Uptime,
 		s.GetCount, s.SetCount,
 		s.CacheHitRatio, s.CacheHits, s.CacheMisses,
 		s.AvgGetTime, s.AvgSetTime,
+		s.GetLatency.P50, s.GetLatency.P90, s.GetLatency.P99,
+		s.SetLatency.P50, s.SetLatency.P90, s.SetLatency.P99,
 		s.ErrorCount,
+		s.ReloadCount, s.ReloadFailures, s.ValidationFailures,
+		s.WatcherRestarts, s.WatcherErrors, s.DroppedEvents,
 	)
 }
 
+// MetricsJSON 以 JSON 导出性能指标快照，供无法使用 Prometheus 的轻量采集器或仪表盘抓取。
+// 时长字段以纳秒为单位。
+func (c *Config) MetricsJSON() ([]byte, error) {
+	return json.Marshal(c.GetMetrics())
+}
+
 // GetMetrics 获取配置的性能指标（使用全局监控器）
 func (c *Config) GetMetrics() MetricsSnapshot {
 	return GetGlobalMetrics()
@@ -284,6 +395,38 @@ func recordSetOperation(duration time.Duration) {
 	getGlobalMetrics().RecordSet(duration)
 }
 
+// recordReloadOperation 记录配置重载（内部使用）
+func recordReloadOperation(duration time.Duration, err error) {
+	if !metricsEnabled.Load() {
+		return
+	}
+	getGlobalMetrics().RecordReload(duration, err)
+}
+
+// recordValidationFailure 记录验证失败（内部使用）
+func recordValidationFailure() {
+	if !metricsEnabled.Load() {
+		return
+	}
+	getGlobalMetrics().RecordValidationFailure()
+}
+
+// recordWatcherRestart 记录文件监听重启（内部使用）
+func recordWatcherRestart() {
+	if !metricsEnabled.Load() {
+		return
+	}
+	getGlobalMetrics().RecordWatcherRestart()
+}
+
+// recordNamedOperation 记录分类操作耗时（内部使用）
+func recordNamedOperation(name string, duration time.Duration) {
+	if !metricsEnabled.Load() {
+		return
+	}
+	getGlobalMetrics().RecordOperation(name, duration)
+}
+
 // recordErrorOperation 记录错误操作（内部使用）
 func recordErrorOperation() {
 	if !metricsEnabled.Load() {
@@ -323,8 +466,8 @@ func (pm *PerformanceMonitor) Start() {
 					pm.config.logger.Warnf("Low cache hit ratio: %.1f%%", stats.CacheHitRatio)
 				}
 
-				if stats.AvgGetTime > 10*time.Millisecond {
-					pm.config.logger.Warnf("Slow get operations: avg %v", stats.AvgGetTime)
+				if stats.GetLatency.P99 > 10*time.Millisecond {
+					pm.config.logger.Warnf("Slow get operations: p99 %v (avg %v)", stats.GetLatency.P99, stats.AvgGetTime)
 				}
 
 			case <-pm.done:
@@ -339,3 +482,11 @@ func (pm *PerformanceMonitor) Stop() {
 	pm.ticker.Stop()
 	close(pm.done)
 }
+
+// recordWatcherError 记录文件监听错误（内部使用）
+func recordWatcherError(dropped bool) {
+	if !metricsEnabled.Load() {
+		return
+	}
+	getGlobalMetrics().RecordWatcherError(dropped)
+}
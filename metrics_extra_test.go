@@ -1,9 +1,13 @@
 package sysconf
 
 import (
+	"encoding/json"
+	"errors"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
 )
 
 func TestMetricsSnapshotsAndReset(t *testing.T) {
@@ -144,3 +148,119 @@ func TestMetricsSummary(t *testing.T) {
 		t.Error("summary should not be empty")
 	}
 }
+
+func TestLatencyHistogramQuantiles(t *testing.T) {
+	var h latencyHistogram
+	if got := h.quantiles(0.5); got[0] != 0 {
+		t.Fatalf("empty histogram should report zero, got %v", got)
+	}
+	for i := 1; i <= 100; i++ {
+		h.record(time.Duration(i) * time.Millisecond)
+	}
+	p := h.quantiles(0.50, 0.95, 0.99)
+	want := []time.Duration{50 * time.Millisecond, 95 * time.Millisecond, 99 * time.Millisecond}
+	for i := range want {
+		// 对数分桶的相对误差不超过 1/8
+		if p[i] < want[i] || p[i] > want[i]+want[i]/8 {
+			t.Fatalf("quantile %d: got %v, want about %v", i, p[i], want[i])
+		}
+	}
+	for _, ns := range []int64{0, 7, 8, 15, 16, 1 << 40, 1<<62 + 5} {
+		if upper := histBucketUpper(histBucket(ns)); upper < ns {
+			t.Fatalf("bucket upper bound %d below value %d", upper, ns)
+		}
+	}
+}
+
+func TestMetricsCountersAndJSON(t *testing.T) {
+	m := NewMetrics()
+	m.RecordReload(time.Millisecond, nil)
+	m.RecordReload(2*time.Millisecond, errors.New("bad"))
+	m.RecordValidationFailure()
+	m.RecordWatcherRestart()
+	m.RecordWatcherError(false)
+	m.RecordWatcherError(true)
+	for i := 1; i <= 10; i++ {
+		m.RecordOperation("unmarshal", time.Duration(i)*time.Microsecond)
+	}
+
+	snap := m.GetStats()
+	if snap.ReloadCount != 1 || snap.ReloadFailures != 1 || snap.ValidationFailures != 1 || snap.WatcherRestarts != 1 {
+		t.Fatalf("unexpected counters: %+v", snap)
+	}
+	if snap.WatcherErrors != 2 || snap.DroppedEvents != 1 {
+		t.Fatalf("unexpected watcher counters: %+v", snap)
+	}
+	if snap.OperationStats["reload"].Count != 2 {
+		t.Fatalf("reloads should be recorded as operations: %+v", snap.OperationStats["reload"])
+	}
+	op := snap.OperationStats["unmarshal"]
+	if op.P50Ns < int64(5*time.Microsecond) || op.P99Ns < op.P95Ns || op.P95Ns < op.P50Ns {
+		t.Fatalf("unexpected percentiles: %+v", op)
+	}
+
+	// 配置操作累计到全局指标
+	ResetGlobalMetrics()
+	cfg, err := New(WithContent("server:\n  port: 8080\n"), WithValidator(&wholeConfigValidator{}))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if err := cfg.Set("database.port", 80); err == nil {
+		t.Fatalf("expected validation failure")
+	}
+
+	data, err := cfg.MetricsJSON()
+	if err != nil {
+		t.Fatalf("metrics json failed: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("invalid metrics json: %v", err)
+	}
+	if decoded["validation_failures"].(float64) < 1 {
+		t.Fatalf("expected validation failure in json: %s", data)
+	}
+	for _, field := range []string{"reload_count", "reload_failures", "watcher_restarts", "operation_stats"} {
+		if _, ok := decoded[field]; !ok {
+			t.Fatalf("missing field %s in json: %s", field, data)
+		}
+	}
+}
+
+func TestMetricsLatencyPercentiles(t *testing.T) {
+	m := NewMetrics()
+	for range 98 {
+		m.RecordGet(time.Microsecond, true)
+	}
+	// 少量慢请求只影响尾部分位数
+	m.RecordGet(50*time.Millisecond, false)
+	m.RecordGet(50*time.Millisecond, false)
+	m.RecordSet(2 * time.Millisecond)
+
+	snap := m.GetStats()
+	if snap.GetLatency.P50 > 2*time.Microsecond || snap.GetLatency.P90 > 2*time.Microsecond {
+		t.Fatalf("p50/p90 should reflect fast requests: %+v", snap.GetLatency)
+	}
+	if snap.GetLatency.P99 < 50*time.Millisecond {
+		t.Fatalf("p99 should expose tail latency: %+v", snap.GetLatency)
+	}
+	if snap.SetLatency.P50 < 2*time.Millisecond || snap.LastSetTime.IsZero() || snap.LastGetTime.IsZero() {
+		t.Fatalf("unexpected set stats: %+v", snap)
+	}
+
+	m.Reset()
+	snap = m.GetStats()
+	if snap.GetLatency != (LatencyPercentiles{}) || !snap.LastGetTime.IsZero() {
+		t.Fatalf("reset should clear histograms: %+v", snap)
+	}
+}
+
+func BenchmarkMetricsRecordGet(b *testing.B) {
+	m := NewMetrics()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			m.RecordGet(time.Microsecond, true)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package sysconf
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Mode 配置文件格式。使用常量代替字符串可以在编译期发现拼写错误。
+type Mode string
+
+// 支持的配置文件格式
+const (
+	YAML       Mode = "yaml"       // YAML（默认）
+	JSON       Mode = "json"       // JSON
+	JSONC      Mode = "jsonc"      // 带注释与尾随逗号的 JSON
+	TOML       Mode = "toml"       // TOML
+	INI        Mode = "ini"        // INI
+	Properties Mode = "properties" // Java properties
+	Dotenv     Mode = "dotenv"     // dotenv（KEY=VALUE）
+	HCL        Mode = "hcl"        // HCL
+)
+
+// String 返回格式名称
+func (m Mode) String() string {
+	return string(m)
+}
+
+// modeByExt 文件扩展名（不含点，小写）到配置格式的映射
+var modeByExt = map[string]Mode{
+	"yaml":       YAML,
+	"yml":        YAML,
+	"json":       JSON,
+	"jsonc":      JSONC,
+	"toml":       TOML,
+	"ini":        INI,
+	"properties": Properties,
+	"props":      Properties,
+	"prop":       Properties,
+	"env":        Dotenv,
+	"dotenv":     Dotenv,
+	"hcl":        HCL,
+	"tfvars":     HCL,
+}
+
+// ModeFromPath 根据文件名推断配置格式，如 "config/app.yml" 返回 YAML；
+// ".env" 等无扩展名的隐藏文件按文件名本身识别。无法识别时返回 false。
+func ModeFromPath(path string) (Mode, bool) {
+	base := filepath.Base(path)
+	ext := strings.TrimPrefix(filepath.Ext(base), ".")
+	mode, ok := modeByExt[strings.ToLower(ext)]
+	return mode, ok
+}
+
+// writableModes 支持写回配置文件的格式（含扩展名别名）
+var writableModes = []string{"yaml", "yml", "json", "jsonc", "toml", "ini", "properties", "props", "prop", "dotenv", "env"}
+
+// Writable 报告格式是否支持写回配置文件；HCL 只能读取
+func (m Mode) Writable() bool {
+	return slices.Contains(writableModes, strings.ToLower(string(m)))
+}
+
+// checkWritableFormat 配置文件格式不支持写回时返回 ErrFormatNotWritable，纯内存配置不检查
+func (c *Config) checkWritableFormat() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.checkWritableFormatLocked()
+}
+
+// checkWritableFormatLocked 同 checkWritableFormat（调用者需持有 mu）
+func (c *Config) checkWritableFormatLocked() error {
+	if c.configFilePath() == "" || Mode(c.mode).Writable() {
+		return nil
+	}
+	return &ConfigError{
+		Type:    ErrTypeInvalidFormat,
+		Message: fmt.Sprintf("配置格式 %s 不支持写回，可写格式: %s", c.mode, strings.Join(writableModes, ", ")),
+		File:    c.configFilePath(),
+		Cause:   ErrFormatNotWritable,
+	}
+}
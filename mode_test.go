@@ -0,0 +1,122 @@
+package sysconf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestModeFromPath(t *testing.T) {
+	cases := map[string]Mode{
+		"config/app.yaml":  YAML,
+		"app.YML":          YAML,
+		"app.json":         JSON,
+		"app.jsonc":        JSONC,
+		"app.toml":         TOML,
+		"app.ini":          INI,
+		"app.properties":   Properties,
+		"deploy/.env":      Dotenv,
+		"infra/main.hcl":   HCL,
+		"vars.auto.tfvars": HCL,
+	}
+	for path, want := range cases {
+		got, ok := ModeFromPath(path)
+		if !ok || got != want {
+			t.Fatalf("ModeFromPath(%q) = %q, %v; want %q", path, got, ok, want)
+		}
+	}
+	if _, ok := ModeFromPath("config/app"); ok {
+		t.Fatalf("path without extension should not be recognized")
+	}
+}
+
+func TestWithModeAcceptsTypedAndString(t *testing.T) {
+	c := &Config{}
+	WithMode(TOML)(c)
+	if c.mode != "toml" {
+		t.Fatalf("typed mode not applied: %q", c.mode)
+	}
+	WithMode("json")(c)
+	if c.mode != "json" {
+		t.Fatalf("string mode not applied: %q", c.mode)
+	}
+}
+
+func TestWithFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "config", "app.yml")
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(file, []byte("server:\n  port: 8080\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := New(WithFile(file), WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if cfg.name != "app" || cfg.mode != string(YAML) {
+		t.Fatalf("unexpected name/mode: %q/%q", cfg.name, cfg.mode)
+	}
+	if got := cfg.GetInt("server.port"); got != 8080 {
+		t.Fatalf("expected port 8080, got %d", got)
+	}
+	if err := cfg.Set("server.port", 9090); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(file), "app.yaml")); !os.IsNotExist(err) {
+		t.Fatalf("writes should go to the given file name, not app.yaml")
+	}
+	data, err := os.ReadFile(file)
+	if err != nil || !strings.Contains(string(data), "9090") {
+		t.Fatalf("expected update persisted to %s, got %q (%v)", file, data, err)
+	}
+}
+
+func TestSetRejectsReadOnlyFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "app.hcl")
+	content := "app_name = \"demo\"\n\nserver {\n  port = 8080\n}\n"
+	if err := os.WriteFile(configFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+
+	cfg, err := New(WithPath(tmpDir), WithName("app"), WithMode("hcl"), WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if got := cfg.GetInt("server.port"); got != 8080 {
+		t.Fatalf("expected HCL block to map to nested keys, got %d", got)
+	}
+
+	err = cfg.Set("app_name", "changed")
+	if !errors.Is(err, ErrFormatNotWritable) {
+		t.Fatalf("expected ErrFormatNotWritable, got %v", err)
+	}
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) || !strings.Contains(configErr.Message, "yaml") {
+		t.Fatalf("expected error listing writable formats, got %v", err)
+	}
+	if err := cfg.SetMultiple(map[string]any{"a": 1}); !errors.Is(err, ErrFormatNotWritable) {
+		t.Fatalf("expected ErrFormatNotWritable from SetMultiple, got %v", err)
+	}
+	if got := cfg.GetString("app_name"); got != "demo" {
+		t.Fatalf("rejected change must not be applied, got %q", got)
+	}
+	data, err := os.ReadFile(configFile)
+	if err != nil || string(data) != content {
+		t.Fatalf("config file must stay untouched, got %q (%v)", data, err)
+	}
+
+	if !YAML.Writable() || !Dotenv.Writable() || HCL.Writable() {
+		t.Fatal("unexpected Mode.Writable result")
+	}
+}
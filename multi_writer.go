@@ -0,0 +1,275 @@
+package sysconf
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrWriteConflict Writer 的写入与并发写入冲突，冲突解决后未采纳该写入的值
+var ErrWriteConflict = errors.New("write conflict")
+
+// VectorClock 向量时钟：写入者标识 → 该写入者的写入计数
+type VectorClock map[string]uint64
+
+// ClockOrder 两个向量时钟之间的因果关系
+type ClockOrder int
+
+const (
+	ClockEqual      ClockOrder = iota // 两者相同
+	ClockBefore                       // 前者发生在后者之前
+	ClockAfter                        // 前者发生在后者之后（已观察到后者）
+	ClockConcurrent                   // 并发写入，互不知晓
+)
+
+// Compare 比较 v 与 other 的因果关系
+func (v VectorClock) Compare(other VectorClock) ClockOrder {
+	var less, greater bool
+	for id, n := range v {
+		if n > other[id] {
+			greater = true
+		} else if n < other[id] {
+			less = true
+		}
+	}
+	for id, n := range other {
+		if _, ok := v[id]; !ok && n > 0 {
+			less = true
+		}
+	}
+	switch {
+	case less && greater:
+		return ClockConcurrent
+	case greater:
+		return ClockAfter
+	case less:
+		return ClockBefore
+	default:
+		return ClockEqual
+	}
+}
+
+// Merge 返回 v 与 other 逐项取最大值的新时钟
+func (v VectorClock) Merge(other VectorClock) VectorClock {
+	merged := make(VectorClock, len(v)+len(other))
+	maps.Copy(merged, v)
+	for id, n := range other {
+		if n > merged[id] {
+			merged[id] = n
+		}
+	}
+	return merged
+}
+
+// KeyVersion 经 Writer 写入的配置键版本
+type KeyVersion struct {
+	Writer string      // 写入者标识
+	Value  any         // 写入的值
+	Clock  VectorClock // 写入时的向量时钟
+	Time   time.Time   // 写入时间
+}
+
+// WriteConflict 同一配置键的并发写入及其解决结果
+type WriteConflict struct {
+	Key      string     // 配置键
+	Current  KeyVersion // 冲突前的当前版本
+	Incoming KeyVersion // 新到达的写入
+	Resolved KeyVersion // 冲突解决后采用的版本
+}
+
+// ConflictResolver 决定并发写入冲突时采用的版本。为保证各写入者按任意到达顺序都收敛到同一结果，
+// 实现应只依赖两个版本本身且满足交换律；返回值可以是任一版本或合并后的新值。
+type ConflictResolver func(current, incoming KeyVersion) KeyVersion
+
+// LastWriterWins 默认冲突解决策略：写入时间较晚者胜出，时间相同时写入者标识较大者胜出
+func LastWriterWins(current, incoming KeyVersion) KeyVersion {
+	if incoming.Time.After(current.Time) || (incoming.Time.Equal(current.Time) && incoming.Writer > current.Writer) {
+		return incoming
+	}
+	return current
+}
+
+// WithConflictResolver 设置 Writer 并发写入冲突的解决函数，默认使用 LastWriterWins
+func WithConflictResolver(resolver ConflictResolver) Option {
+	return func(c *Config) {
+		c.resolver = resolver
+	}
+}
+
+// replicaState 多写者写入的键版本与冲突监听，独立加锁
+type replicaState struct {
+	mu        sync.Mutex
+	versions  map[string]KeyVersion
+	listeners map[uint64]func(WriteConflict)
+	nextID    uint64
+}
+
+// Writer 带身份的写入者，用于多个组件（如插件）并发写入重叠配置键的场景。
+// 每次写入携带向量时钟：写入者读取或写入过的版本视为已观察到，基于其后的写入直接生效；
+// 与其他写入者互不知晓的并发写入由冲突解决函数决定胜者，所有写入者按任意顺序写入都收敛到相同结果。
+type Writer struct {
+	cfg     *Config
+	id      string
+	mu      sync.Mutex
+	counter uint64
+	seen    map[string]VectorClock
+}
+
+// Writer 返回标识为 id 的写入者，不同组件应使用不同的标识。
+// 版本只跟踪经 Writer 写入的键；普通 Set 不参与冲突检测。
+func (c *Config) Writer(id string) *Writer {
+	return &Writer{cfg: c, id: id, seen: make(map[string]VectorClock)}
+}
+
+// ID 返回写入者标识
+func (w *Writer) ID() string {
+	return w.id
+}
+
+// Get 读取配置值，并记录该键的当前版本为已观察到
+func (w *Writer) Get(key string) any {
+	r := &w.cfg.replicas
+	r.mu.Lock()
+	value := w.cfg.Get(key)
+	clock := r.versions[key].Clock
+	r.mu.Unlock()
+
+	w.mu.Lock()
+	w.seen[key] = clock.Merge(nil)
+	w.mu.Unlock()
+	return value
+}
+
+// Set 以该写入者身份写入配置值。写入基于已观察到的版本时直接生效；与并发写入冲突时按
+// 冲突解决函数处理并通知 OnWriteConflict 监听者，未采纳该写入的值时返回 ErrWriteConflict。
+// 写入（含冲突）完成后视为已观察到该键的当前版本。验证等写入错误与 Set 相同。
+func (w *Writer) Set(key string, value any) error {
+	if key == "" {
+		return ErrInvalidKey
+	}
+	w.mu.Lock()
+	clock := w.seen[key].Merge(nil)
+	w.counter = max(w.counter, clock[w.id]) + 1
+	clock[w.id] = w.counter
+	w.mu.Unlock()
+	incoming := KeyVersion{Writer: w.id, Value: sanitizeValue(value), Clock: clock, Time: time.Now()}
+
+	// 提交期间不持有 w.mu：WatchSync 回调可能通过同一写入者读取配置
+	stored, err := w.cfg.commitVersion(key, incoming)
+	if stored != nil {
+		w.mu.Lock()
+		w.seen[key] = w.seen[key].Merge(stored)
+		w.mu.Unlock()
+	}
+	return err
+}
+
+// OnWriteConflict 注册 Writer 并发写入冲突的回调，返回取消注册函数。回调在写入者的 goroutine 中同步执行。
+func (c *Config) OnWriteConflict(fn func(WriteConflict)) func() {
+	if fn == nil {
+		return func() {}
+	}
+	r := &c.replicas
+	r.mu.Lock()
+	if r.listeners == nil {
+		r.listeners = make(map[uint64]func(WriteConflict))
+	}
+	r.nextID++
+	id := r.nextID
+	r.listeners[id] = fn
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		delete(r.listeners, id)
+		r.mu.Unlock()
+	}
+}
+
+// KeyVersion 返回经 Writer 写入的配置键的当前版本；键未经 Writer 写入时返回 false
+func (c *Config) KeyVersion(key string) (KeyVersion, bool) {
+	c.replicas.mu.Lock()
+	defer c.replicas.mu.Unlock()
+	version, ok := c.replicas.versions[key]
+	if !ok {
+		return KeyVersion{}, false
+	}
+	version.Value = deepCloneValue(version.Value)
+	version.Clock = version.Clock.Merge(nil)
+	return version, true
+}
+
+// resolveVersion 判定写入与当前版本的因果关系，返回应采用的版本与冲突（调用者需持有 replicas.mu）
+func (c *Config) resolveVersion(key string, current KeyVersion, exists bool, incoming KeyVersion) (KeyVersion, *WriteConflict) {
+	if !exists || incoming.Clock.Compare(current.Clock) == ClockAfter {
+		return incoming, nil
+	}
+	resolver := c.resolver
+	if resolver == nil {
+		resolver = LastWriterWins
+	}
+	resolved := resolver(current, incoming)
+	resolved.Clock = current.Clock.Merge(incoming.Clock)
+	return resolved, &WriteConflict{Key: key, Current: current, Incoming: incoming, Resolved: resolved}
+}
+
+// commitVersion 判定写入与当前版本的因果关系并提交胜出的值，返回提交后该键的时钟（写入失败且无冲突时为 nil）。
+// 版本判定与发布持有 replicas.mu，写入配置值（会同步执行 WatchSync 回调）在释放该锁后进行；
+// 发布前若已有其他写入发布了新版本，则基于新版本重新判定。
+func (c *Config) commitVersion(key string, incoming KeyVersion) (VectorClock, error) {
+	r := &c.replicas
+	for retry := false; ; retry = true {
+		r.mu.Lock()
+		current, exists := r.versions[key]
+		resolved, conflict := c.resolveVersion(key, current, exists, incoming)
+		r.mu.Unlock()
+
+		// 重试时配置中的值可能来自任一写入，需重新写入胜出的值（未变化时 set 为空操作）
+		if retry || !exists || !reflect.DeepEqual(resolved.Value, current.Value) {
+			if err := c.set(key, resolved.Value, false); err != nil {
+				if conflict != nil {
+					return current.Clock, err
+				}
+				return nil, err
+			}
+		}
+
+		r.mu.Lock()
+		latest, stillExists := r.versions[key]
+		if stillExists != exists || (exists && latest.Clock.Compare(current.Clock) != ClockEqual) {
+			// 判定期间其他写入发布了新版本，基于新版本重新判定
+			r.mu.Unlock()
+			continue
+		}
+		if r.versions == nil {
+			r.versions = make(map[string]KeyVersion)
+		}
+		r.versions[key] = resolved
+		var listeners []func(WriteConflict)
+		if conflict != nil {
+			for _, id := range slices.Sorted(maps.Keys(r.listeners)) {
+				listeners = append(listeners, r.listeners[id])
+			}
+		}
+		r.mu.Unlock()
+
+		if conflict == nil {
+			return resolved.Clock, nil
+		}
+		c.logger.Warnf("Resolved concurrent writes to %s from %s and %s in favor of %s", key, current.Writer, incoming.Writer, resolved.Writer)
+		for _, fn := range listeners {
+			event := *conflict
+			event.Current.Value = deepCloneValue(event.Current.Value)
+			event.Incoming.Value = deepCloneValue(event.Incoming.Value)
+			event.Resolved.Value = deepCloneValue(event.Resolved.Value)
+			fn(event)
+		}
+		if !reflect.DeepEqual(resolved.Value, incoming.Value) {
+			return resolved.Clock, ErrWriteConflict
+		}
+		return resolved.Clock, nil
+	}
+}
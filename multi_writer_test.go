@@ -0,0 +1,140 @@
+package sysconf
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestVectorClockCompare(t *testing.T) {
+	cases := []struct {
+		a, b VectorClock
+		want ClockOrder
+	}{
+		{VectorClock{"a": 1}, VectorClock{"a": 1}, ClockEqual},
+		{VectorClock{"a": 2}, VectorClock{"a": 1}, ClockAfter},
+		{VectorClock{"a": 1}, VectorClock{"a": 1, "b": 1}, ClockBefore},
+		{VectorClock{"a": 1}, VectorClock{"b": 1}, ClockConcurrent},
+		{nil, VectorClock{}, ClockEqual},
+	}
+	for _, tc := range cases {
+		if got := tc.a.Compare(tc.b); got != tc.want {
+			t.Fatalf("%v vs %v: expected %v, got %v", tc.a, tc.b, tc.want, got)
+		}
+	}
+}
+
+func TestWriterCausalWritesDoNotConflict(t *testing.T) {
+	cfg, err := New(WithContent("plugins:\n  cache:\n    size: 10\n"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	conflicts := 0
+	cfg.OnWriteConflict(func(WriteConflict) { conflicts++ })
+
+	a, b := cfg.Writer("plugin-a"), cfg.Writer("plugin-b")
+	if err := a.Set("plugins.cache.size", 20); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	// b 读取后再写入，已观察到 a 的版本，不构成冲突
+	if got := b.Get("plugins.cache.size"); got != 20 {
+		t.Fatalf("unexpected value %v", got)
+	}
+	if err := b.Set("plugins.cache.size", 30); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if conflicts != 0 {
+		t.Fatalf("expected no conflicts, got %d", conflicts)
+	}
+	version, ok := cfg.KeyVersion("plugins.cache.size")
+	if !ok || version.Writer != "plugin-b" || version.Clock["plugin-a"] != 1 || version.Clock["plugin-b"] != 1 {
+		t.Fatalf("unexpected version %+v", version)
+	}
+}
+
+func TestWriterConcurrentWritesConverge(t *testing.T) {
+	// 无论到达顺序如何，自定义的交换律解决函数都得到相同结果
+	maxWins := func(current, incoming KeyVersion) KeyVersion {
+		if incoming.Value.(int) > current.Value.(int) {
+			return incoming
+		}
+		return current
+	}
+	for _, order := range [][2]string{{"a", "b"}, {"b", "a"}} {
+		cfg, err := New(WithContent("pool:\n  size: 1\n"), WithConflictResolver(maxWins))
+		if err != nil {
+			t.Fatalf("create config failed: %v", err)
+		}
+		testutil.Cleanup(t, cfg.Close)
+
+		var seen []WriteConflict
+		cfg.OnWriteConflict(func(c WriteConflict) { seen = append(seen, c) })
+		values := map[string]int{"a": 50, "b": 5}
+		var errs []error
+		for _, id := range order {
+			errs = append(errs, cfg.Writer(id).Set("pool.size", values[id]))
+		}
+
+		if got := cfg.GetInt("pool.size"); got != 50 {
+			t.Fatalf("order %v: expected converged value 50, got %d", order, got)
+		}
+		if len(seen) != 1 || seen[0].Resolved.Writer != "a" {
+			t.Fatalf("order %v: unexpected conflicts %+v", order, seen)
+		}
+		if order[1] == "b" && !errors.Is(errs[1], ErrWriteConflict) {
+			t.Fatalf("losing write should report ErrWriteConflict, got %v", errs[1])
+		}
+		if order[1] == "a" && errs[1] != nil {
+			t.Fatalf("winning write should succeed, got %v", errs[1])
+		}
+	}
+}
+
+func TestLastWriterWins(t *testing.T) {
+	now := time.Now()
+	older := KeyVersion{Writer: "b", Value: 1, Time: now}
+	newer := KeyVersion{Writer: "a", Value: 2, Time: now.Add(time.Millisecond)}
+	if got := LastWriterWins(older, newer); got.Value != 2 {
+		t.Fatalf("expected newer write to win, got %v", got.Value)
+	}
+	if got := LastWriterWins(newer, older); got.Value != 2 {
+		t.Fatalf("expected newer write to win regardless of order, got %v", got.Value)
+	}
+	tieA := KeyVersion{Writer: "a", Value: "a", Time: now}
+	tieB := KeyVersion{Writer: "b", Value: "b", Time: now}
+	if LastWriterWins(tieA, tieB).Writer != "b" || LastWriterWins(tieB, tieA).Writer != "b" {
+		t.Fatal("ties should be broken by writer id")
+	}
+}
+
+func TestWriterSetWithSyncWatcherReadingWriter(t *testing.T) {
+	cfg, err := New(WithContent("pool:\n  size: 1\n"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	w := cfg.Writer("plugin-a")
+	var observed any
+	stop := cfg.WatchSync(context.Background(), func() {
+		observed = w.Get("pool.size")
+	})
+	defer stop()
+
+	runWithWatchdog(t, 5*time.Second, func() {
+		if err := w.Set("pool.size", 8); err != nil {
+			t.Errorf("set failed: %v", err)
+		}
+	})
+	if observed != 8 {
+		t.Fatalf("sync watcher should observe the written value, got %v", observed)
+	}
+	if version, ok := cfg.KeyVersion("pool.size"); !ok || version.Value != 8 {
+		t.Fatalf("unexpected version %+v", version)
+	}
+}
@@ -0,0 +1,125 @@
+package sysconf
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// NormalizerFunc 写入前对配置值进行规范化，返回规范化后的值
+type NormalizerFunc func(value any) (any, error)
+
+// AddNormalizer 为配置键注册写入时的规范化函数，在验证与存储之前按注册顺序执行。
+// 写入父键（如 Set("server", map...)）时同样作用于其中对应的子键。
+//
+//	cfg.AddNormalizer("server.host", sysconf.NormalizeLower)
+func (c *Config) AddNormalizer(key string, fn NormalizerFunc) {
+	if key == "" || fn == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.normalizers == nil {
+		c.normalizers = make(map[string][]NormalizerFunc)
+	}
+	c.normalizers[key] = append(c.normalizers[key], fn)
+}
+
+// normalizeValueUnsafe 对写入值执行已注册的规范化函数（调用者需持有 mu）
+func (c *Config) normalizeValueUnsafe(key string, value any) (any, error) {
+	if len(c.normalizers) == 0 {
+		return value, nil
+	}
+	if nested, ok := value.(map[string]any); ok && c.hasChildNormalizers(key) {
+		normalized := make(map[string]any, len(nested))
+		for k, v := range nested {
+			child, err := c.normalizeValueUnsafe(key+"."+k, v)
+			if err != nil {
+				return nil, err
+			}
+			normalized[k] = child
+		}
+		value = normalized
+	}
+	for _, fn := range c.normalizers[key] {
+		normalized, err := fn(value)
+		if err != nil {
+			return nil, fmt.Errorf("normalize %s: %w", key, err)
+		}
+		value = normalized
+	}
+	return value, nil
+}
+
+// hasChildNormalizers 判断 key 下是否注册了子键规范化函数
+func (c *Config) hasChildNormalizers(key string) bool {
+	prefix := key + "."
+	for k := range c.normalizers {
+		if strings.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeTrimSpace 去除字符串首尾空白，非字符串值原样返回
+func NormalizeTrimSpace(value any) (any, error) {
+	if s, ok := value.(string); ok {
+		return strings.TrimSpace(s), nil
+	}
+	return value, nil
+}
+
+// NormalizeLower 将字符串转为小写并去除首尾空白（适用于主机名等），非字符串值原样返回
+func NormalizeLower(value any) (any, error) {
+	if s, ok := value.(string); ok {
+		return strings.ToLower(strings.TrimSpace(s)), nil
+	}
+	return value, nil
+}
+
+// NormalizeExpandHome 将以 ~ 开头的路径展开为用户主目录并清理路径，非字符串值原样返回
+func NormalizeExpandHome(value any) (any, error) {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return value, nil
+	}
+	s, err := expandHome(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+	return filepath.Clean(s), nil
+}
+
+// NormalizeURL 规范化 URL：小写 scheme 与主机名、去除默认端口及末尾多余的 "/"，非字符串值原样返回
+func NormalizeURL(value any) (any, error) {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return value, nil
+	}
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	switch {
+	case port != "":
+		host = net.JoinHostPort(host, port)
+	case strings.Contains(host, ":"):
+		host = "[" + host + "]"
+	}
+	u.Host = host
+	if u.Path != "/" {
+		u.Path = strings.TrimSuffix(u.Path, "/")
+	} else if u.RawQuery == "" && u.Fragment == "" {
+		u.Path = ""
+	}
+	u.RawPath = ""
+	return u.String(), nil
+}
@@ -0,0 +1,83 @@
+package sysconf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestAddNormalizer(t *testing.T) {
+	cfg, err := New(WithContent("server:\n  host: localhost\n"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	cfg.AddNormalizer("server.host", NormalizeLower)
+	cfg.AddNormalizer("server.url", NormalizeURL)
+	cfg.AddValidateFunc(func(config map[string]any) error {
+		if host, _ := config["server.host"].(string); host != "" && host != "example.com" {
+			return errors.New("unexpected host " + host)
+		}
+		return nil
+	})
+
+	// 规范化先于验证执行
+	if err := cfg.Set("server.host", "  Example.COM "); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if got := cfg.GetString("server.host"); got != "example.com" {
+		t.Fatalf("expected normalized host, got %q", got)
+	}
+
+	if err := cfg.Set("server", map[string]any{"host": "EXAMPLE.com", "url": "HTTPS://Example.com:443/api/"}); err != nil {
+		t.Fatalf("set parent failed: %v", err)
+	}
+	if got := cfg.GetString("server.url"); got != "https://example.com/api" {
+		t.Fatalf("expected nested normalization, got %q", got)
+	}
+
+	values := map[string]any{"server.host": "Example.Com"}
+	if err := cfg.SetMultiple(values); err != nil {
+		t.Fatalf("set multiple failed: %v", err)
+	}
+	if values["server.host"] != "Example.Com" {
+		t.Fatalf("caller map should not be modified")
+	}
+
+	if err := cfg.Set("server.url", "http://[::1"); err == nil {
+		t.Fatalf("expected normalization error")
+	}
+}
+
+func TestBuiltinNormalizers(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skipf("no home directory: %v", err)
+	}
+	if got, _ := NormalizeExpandHome("~/data//logs/"); got != filepath.Join(home, "data", "logs") {
+		t.Fatalf("unexpected expanded path: %v", got)
+	}
+	if got, _ := NormalizeTrimSpace(" a "); got != "a" {
+		t.Fatalf("unexpected trimmed value: %v", got)
+	}
+	if got, _ := NormalizeLower(42); got != 42 {
+		t.Fatalf("non-string values should pass through, got %v", got)
+	}
+
+	cases := map[string]string{
+		"HTTP://Host.Example:80/":     "http://host.example",
+		"https://host:8443/path/":     "https://host:8443/path",
+		"http://[::1]:80/?q=1":        "http://[::1]/?q=1",
+		"postgres://USER@DB:5432/app": "postgres://USER@db:5432/app",
+	}
+	for input, want := range cases {
+		got, err := NormalizeURL(input)
+		if err != nil || got != want {
+			t.Fatalf("NormalizeURL(%q) = %v, %v; want %q", input, got, err, want)
+		}
+	}
+}
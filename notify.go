@@ -0,0 +1,326 @@
+package sysconf
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	notifyRetryInterval = 200 * time.Millisecond // 与代理断开后的重连间隔
+	notifyWriteTimeout  = time.Second            // 单条通知的写超时
+	notifyMessagePrefix = "reload "              // 通知消息格式：reload <实例标识>
+)
+
+// WithLocalNotify 启用同一主机上多个进程之间的配置变更通知：任一进程写入配置文件后，
+// 通过本地 Unix 域套接字（Windows 10+ 同样支持 AF_UNIX）立即通知其他进程重新加载，无需等待 fsnotify。
+// 第一个启动的进程在 address 上充当内嵌代理，其余进程作为客户端连接；代理退出后由剩余进程自动接管。
+// address 为空时根据配置文件路径在当前用户的缓存目录（权限 0700）下生成套接字路径。仅在使用配置文件时生效。
+// 连接前会校验套接字属于当前用户，拒绝其他用户创建的套接字。
+func WithLocalNotify(address string) Option {
+	return func(c *Config) {
+		c.notifyEnabled = true
+		c.notifyAddr = address
+	}
+}
+
+// localNotifier 本地变更通知通道，同时承担代理与客户端两种角色
+type localNotifier struct {
+	addr    string
+	id      string
+	pending chan struct{} // 合并待处理的重载请求
+
+	mu       sync.Mutex
+	conn     net.Conn              // 客户端模式下与代理的连接
+	listener net.Listener          // 代理模式下的监听器
+	lock     *fileLock             // 代理模式下持有的选举锁
+	peers    map[net.Conn]struct{} // 代理模式下已连接的客户端
+}
+
+// defaultNotifyAddress 根据配置文件路径生成默认套接字路径，保证同一用户下同一文件的进程使用相同地址
+func defaultNotifyAddress(configFile string) (string, error) {
+	if abs, err := filepath.Abs(configFile); err == nil {
+		configFile = abs
+	}
+	dir, err := notifyDir()
+	if err != nil {
+		return "", err
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(configFile))
+	return filepath.Join(dir, fmt.Sprintf("%x.sock", h.Sum64())), nil
+}
+
+// notifyDir 返回当前用户私有的套接字目录：优先使用用户缓存目录，不可用时退回临时目录下按用户区分的子目录。
+// 目录权限收紧为 0700 并校验属主，避免共享临时目录中可预测的套接字与锁文件被其他用户抢占
+func notifyDir() (string, error) {
+	dir := filepath.Join(os.TempDir(), fmt.Sprintf("sysconf-%d", os.Getuid()))
+	if cache, err := os.UserCacheDir(); err == nil {
+		dir = filepath.Join(cache, "sysconf", "notify")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("create notify directory: %w", err)
+	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("notify directory %s is not a directory", dir)
+	}
+	if err := checkNotifyOwner(dir, info); err != nil {
+		return "", err
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		if err := os.Chmod(dir, 0o700); err != nil {
+			return "", fmt.Errorf("restrict notify directory: %w", err)
+		}
+	}
+	return dir, nil
+}
+
+// startLocalNotify 启动本地变更通知（未启用 WithLocalNotify 或没有配置文件时不做任何事）
+func (c *Config) startLocalNotify() {
+	configFile := c.configFilePath()
+	if !c.notifyEnabled || configFile == "" {
+		return
+	}
+	if !localNotifySupported {
+		c.logger.Warnf("Local change notification is not supported on this platform")
+		return
+	}
+	addr := c.notifyAddr
+	if addr == "" {
+		var err error
+		if addr, err = defaultNotifyAddress(configFile); err != nil {
+			c.logger.Warnf("Local change notification disabled: %v", err)
+			return
+		}
+	}
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	n := &localNotifier{
+		addr:    addr,
+		id:      hex.EncodeToString(id),
+		pending: make(chan struct{}, 1),
+	}
+	c.notifier = n
+	stopChan := c.stopChan
+
+	c.wg.Go(func() {
+		<-stopChan
+		n.close()
+	})
+	c.wg.Go(func() {
+		for {
+			select {
+			case <-stopChan:
+				return
+			case <-n.pending:
+				c.logger.Debugf("Change notification received, reloading config")
+				c.reloadChangedFile(configFile, true)
+			}
+		}
+	})
+	c.wg.Go(func() {
+		for {
+			if err := n.serve(stopChan); err != nil {
+				c.logger.Debugf("Local notify channel %s: %v", addr, err)
+			}
+			select {
+			case <-stopChan:
+				return
+			case <-time.After(notifyRetryInterval):
+			}
+		}
+	})
+}
+
+// notifyPeers 通知其他进程配置文件已写入
+func (c *Config) notifyPeers() {
+	if c.notifier != nil {
+		c.notifier.publish()
+	}
+}
+
+// serve 连接已有代理；不存在代理时竞选为代理。连接断开或代理停止后返回
+func (n *localNotifier) serve(stop <-chan struct{}) error {
+	if info, err := os.Lstat(n.addr); err == nil {
+		if err := checkNotifyOwner(n.addr, info); err != nil {
+			return err
+		}
+	}
+	if conn, err := net.Dial("unix", n.addr); err == nil {
+		return n.serveClient(conn, stop)
+	}
+	// 以文件锁选举代理，避免多个进程同时删除并重建套接字
+	lock, err := acquireFileLock(n.addr+".lock", 0)
+	if err != nil {
+		return err
+	}
+	_ = os.Remove(n.addr) // 清理上一个代理遗留的套接字文件
+	listener, err := net.Listen("unix", n.addr)
+	if err != nil {
+		_ = lock.release()
+		return err
+	}
+	return n.serveBroker(listener, lock, stop)
+}
+
+// serveClient 以客户端身份接收代理转发的通知
+func (n *localNotifier) serveClient(conn net.Conn, stop <-chan struct{}) error {
+	n.mu.Lock()
+	select {
+	case <-stop:
+		n.mu.Unlock()
+		return conn.Close()
+	default:
+	}
+	n.conn = conn
+	n.mu.Unlock()
+	defer func() {
+		n.mu.Lock()
+		n.conn = nil
+		n.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		n.receive(scanner.Text())
+	}
+	return errors.Join(errors.New("broker connection closed"), scanner.Err())
+}
+
+// serveBroker 以代理身份接受客户端连接并转发通知，监听器关闭后返回
+func (n *localNotifier) serveBroker(listener net.Listener, lock *fileLock, stop <-chan struct{}) error {
+	n.mu.Lock()
+	select {
+	case <-stop:
+		n.mu.Unlock()
+		_ = listener.Close()
+		_ = lock.release()
+		return nil
+	default:
+	}
+	n.listener = listener
+	n.lock = lock
+	n.peers = make(map[net.Conn]struct{})
+	n.mu.Unlock()
+
+	var wg sync.WaitGroup
+	defer func() {
+		n.closeBroker()
+		wg.Wait()
+	}()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		n.mu.Lock()
+		if n.peers == nil {
+			n.mu.Unlock()
+			_ = conn.Close()
+			return nil
+		}
+		n.peers[conn] = struct{}{}
+		n.mu.Unlock()
+
+		wg.Go(func() {
+			defer func() {
+				n.mu.Lock()
+				delete(n.peers, conn)
+				n.mu.Unlock()
+				_ = conn.Close()
+			}()
+			scanner := bufio.NewScanner(conn)
+			for scanner.Scan() {
+				line := scanner.Text()
+				n.broadcast(line+"\n", conn)
+				n.receive(line)
+			}
+		})
+	}
+}
+
+// receive 处理一条通知，忽略本实例发出的消息
+func (n *localNotifier) receive(line string) {
+	sender, ok := strings.CutPrefix(line, notifyMessagePrefix)
+	if !ok || sender == n.id {
+		return
+	}
+	select {
+	case n.pending <- struct{}{}:
+	default:
+	}
+}
+
+// publish 向其他进程广播本实例已写入配置文件
+func (n *localNotifier) publish() {
+	n.broadcast(notifyMessagePrefix+n.id+"\n", nil)
+}
+
+// broadcast 将消息写入代理连接（客户端模式）或除 except 之外的所有客户端（代理模式）
+func (n *localNotifier) broadcast(msg string, except net.Conn) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	targets := make([]net.Conn, 0, len(n.peers)+1)
+	if n.conn != nil {
+		targets = append(targets, n.conn)
+	}
+	for conn := range n.peers {
+		if conn != except {
+			targets = append(targets, conn)
+		}
+	}
+	for _, conn := range targets {
+		_ = conn.SetWriteDeadline(time.Now().Add(notifyWriteTimeout))
+		_, _ = conn.Write([]byte(msg))
+	}
+}
+
+// peerCount 返回代理模式下已连接的客户端数量
+func (n *localNotifier) peerCount() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.peers)
+}
+
+// close 关闭所有连接；代理模式下释放选举锁并移除套接字文件
+func (n *localNotifier) close() {
+	n.mu.Lock()
+	if n.conn != nil {
+		_ = n.conn.Close()
+	}
+	n.mu.Unlock()
+	n.closeBroker()
+}
+
+// closeBroker 停止代理：关闭监听器与客户端连接并释放选举锁
+func (n *localNotifier) closeBroker() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for conn := range n.peers {
+		_ = conn.Close()
+	}
+	n.peers = nil
+	if n.listener != nil {
+		_ = n.listener.Close()
+		n.listener = nil
+		_ = os.Remove(n.addr)
+	}
+	if n.lock != nil {
+		_ = n.lock.release()
+		n.lock = nil
+	}
+}
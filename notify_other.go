@@ -0,0 +1,10 @@
+//go:build !unix
+
+package sysconf
+
+import "os"
+
+// checkNotifyOwner 非 Unix 平台不校验属主；Windows 的用户缓存目录（%LocalAppData%）已按用户隔离
+func checkNotifyOwner(string, os.FileInfo) error {
+	return nil
+}
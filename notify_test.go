@@ -0,0 +1,117 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestLocalNotifyReloadsPeer(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "app.yaml"), []byte("key: initial\n"), 0o644); err != nil {
+		t.Fatalf("write config failed: %v", err)
+	}
+	addr := filepath.Join(tmpDir, "notify.sock")
+
+	newPeer := func() *Config {
+		cfg, err := New(WithPath(tmpDir), WithMode("yaml"), WithName("app"),
+			WithWriteDebounceDelay(0), WithLocalNotify(addr))
+		if err != nil {
+			t.Fatalf("create config failed: %v", err)
+		}
+		testutil.Cleanup(t, cfg.Close)
+		return cfg
+	}
+	writer := newPeer()
+	reader := newPeer()
+
+	// 等待其中一个实例成为代理且另一个实例已连接
+	deadline := time.Now().Add(3 * time.Second)
+	for writer.notifier.peerCount()+reader.notifier.peerCount() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("peers did not connect")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// reader 未启用文件监听，只能通过变更通知感知写入
+	if err := writer.Set("key", "updated"); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	deadline = time.Now().Add(3 * time.Second)
+	for reader.GetString("key") != "updated" {
+		if time.Now().After(deadline) {
+			t.Fatalf("peer should see updated value, got %q", reader.GetString("key"))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestLocalNotifyDisabledWithoutFile(t *testing.T) {
+	cfg, err := New(WithContent("key: value\n"), WithLocalNotify(""))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if cfg.notifier != nil {
+		t.Fatalf("notify channel should not start without a config file")
+	}
+}
+
+func TestDefaultNotifyAddressIsPrivate(t *testing.T) {
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+	t.Setenv("HOME", cache)
+
+	// 预先以宽松权限创建目录，确认会被收紧为 0700
+	if err := os.MkdirAll(filepath.Join(cache, "sysconf", "notify"), 0o755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+	addr, err := defaultNotifyAddress("app.yaml")
+	if err != nil {
+		t.Fatalf("defaultNotifyAddress: %v", err)
+	}
+	if !strings.HasPrefix(addr, cache) {
+		t.Fatalf("socket %s should live in the per-user cache directory", addr)
+	}
+	other, _ := defaultNotifyAddress(filepath.Join(".", "app.yaml"))
+	if other != addr {
+		t.Fatalf("same config file should map to the same socket: %s vs %s", addr, other)
+	}
+	if runtime.GOOS == "windows" {
+		return
+	}
+	info, err := os.Stat(filepath.Dir(addr))
+	if err != nil {
+		t.Fatalf("stat notify dir: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o700 {
+		t.Fatalf("notify directory permissions = %o, want 700", perm)
+	}
+}
+
+func TestLocalNotifyRejectsForeignSocket(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("changing file ownership requires root")
+	}
+	addr := filepath.Join(t.TempDir(), "notify.sock")
+	if err := os.WriteFile(addr, nil, 0o600); err != nil {
+		t.Fatalf("write socket placeholder: %v", err)
+	}
+	if err := os.Chown(addr, 65534, 65534); err != nil {
+		t.Skipf("chown not permitted: %v", err)
+	}
+	n := &localNotifier{addr: addr, pending: make(chan struct{}, 1)}
+	err := n.serve(make(chan struct{}))
+	if err == nil || !strings.Contains(err.Error(), "not the current user") {
+		t.Fatalf("expected foreign socket to be rejected, got %v", err)
+	}
+	if _, statErr := os.Stat(addr); statErr != nil {
+		t.Fatalf("foreign socket must not be removed: %v", statErr)
+	}
+}
@@ -0,0 +1,21 @@
+//go:build unix
+
+package sysconf
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkNotifyOwner 确认通知目录或套接字属于当前用户，避免连接到其他用户预先创建的套接字
+func checkNotifyOwner(path string, info os.FileInfo) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	if uid := os.Getuid(); int(stat.Uid) != uid {
+		return fmt.Errorf("%s is owned by uid %d, not the current user (uid %d)", path, stat.Uid, uid)
+	}
+	return nil
+}
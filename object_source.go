@@ -0,0 +1,222 @@
+package sysconf
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ErrObjectNotModified 对象自 knownVersion 以来未发生变化，ObjectClient 可返回该错误以省去下载
+var ErrObjectNotModified = errors.New("object not modified")
+
+// Object 对象存储中读取到的配置对象
+type Object struct {
+	Data    []byte // 对象内容
+	Version string // 版本标识（S3 VersionId、GCS generation 或 ETag），为空时按内容判断是否变化
+}
+
+// ObjectClient 对象存储最小客户端接口，由使用方基于 AWS/GCS 等 SDK 实现。
+// knownVersion 为上次成功加载的版本，对象未变化时可返回 ErrObjectNotModified。
+type ObjectClient interface {
+	GetObject(ctx context.Context, bucket, key, knownVersion string) (*Object, error)
+}
+
+// ObjectClientFunc 函数形式的 ObjectClient
+type ObjectClientFunc func(ctx context.Context, bucket, key, knownVersion string) (*Object, error)
+
+// GetObject 实现 ObjectClient
+func (f ObjectClientFunc) GetObject(ctx context.Context, bucket, key, knownVersion string) (*Object, error) {
+	return f(ctx, bucket, key, knownVersion)
+}
+
+// ObjectSourceOptions 对象存储配置源选项
+type ObjectSourceOptions struct {
+	Client       ObjectClient  // 对象存储客户端（必填）
+	PollInterval time.Duration // 轮询间隔，<=0 时仅在启动时读取一次
+	Timeout      time.Duration // 单次读取超时，默认 30 秒
+	// CacheFile 最后一次成功加载的对象的本地缓存路径，对象存储不可用时用于启动；
+	// 为空时使用配置目录下的 .<对象名>.cache，相对路径基于配置目录解析
+	CacheFile    string
+	DisableCache bool // 关闭本地缓存
+}
+
+// objectSource 对象存储配置源状态
+type objectSource struct {
+	uri    string
+	bucket string
+	key    string
+	opts   ObjectSourceOptions
+
+	mu       sync.Mutex
+	version  string   // 最近一次成功加载的版本
+	checksum [32]byte // 最近一次成功加载的内容摘要
+	started  bool     // 轮询协程是否已启动
+}
+
+// WithObjectSource 从对象存储（如 "s3://bucket/app.yaml"、"gs://bucket/app.yaml"）加载配置。
+// URI 的主机部分为 bucket，路径为对象键；对象键带有已知扩展名时自动设置配置格式。
+// 对象版本未变化时跳过重载；新内容通过全部验证器后才替换当前配置，并写入本地缓存，
+// 对象存储不可用时从缓存启动。
+func WithObjectSource(uri string, opts ObjectSourceOptions) Option {
+	return func(c *Config) {
+		src := &objectSource{uri: uri, opts: opts}
+		if u, err := url.Parse(uri); err == nil {
+			src.bucket = u.Host
+			src.key = strings.TrimPrefix(u.Path, "/")
+			ext := strings.TrimPrefix(path.Ext(src.key), ".")
+			if ext != "" && (slices.Contains(viper.SupportedExts, ext) || ext == "jsonc") {
+				c.mode = ext
+			}
+		}
+		c.objectSource = src
+	}
+}
+
+// initObjectSourceLocked 启动时读取对象并启动轮询（调用者需持有 mu）。
+// 读取失败时依次回退到本地缓存与本地配置，均不可用时返回错误。
+func (c *Config) initObjectSourceLocked() error {
+	src := c.objectSource
+	if src == nil {
+		return nil
+	}
+	if src.opts.Client == nil {
+		return c.wrapError(fmt.Errorf("object source %s requires a client", src.uri), "加载对象存储配置")
+	}
+	if src.bucket == "" || src.key == "" {
+		return c.wrapError(fmt.Errorf("invalid object source uri: %s", src.uri), "加载对象存储配置")
+	}
+	if src.opts.Timeout <= 0 {
+		src.opts.Timeout = 30 * time.Second
+	}
+	if !src.opts.DisableCache {
+		if src.opts.CacheFile == "" {
+			src.opts.CacheFile = "." + path.Base(src.key) + ".cache"
+		}
+		if !filepath.IsAbs(src.opts.CacheFile) && c.path != "" {
+			src.opts.CacheFile = filepath.Join(c.path, src.opts.CacheFile)
+		}
+	}
+
+	data, changed, err := c.fetchObjectSource(c.initContext())
+	if err == nil && changed {
+		if err = c.applyRemoteContentLocked(data); err == nil {
+			c.cacheObject(data)
+		}
+	}
+	if err != nil {
+		c.logger.Errorf("Failed to load config from %s: %v", src.uri, err)
+		if cacheErr := c.loadObjectCacheLocked(); cacheErr != nil && c.name == "" && c.content == "" {
+			return c.wrapError(err, "加载对象存储配置")
+		}
+	}
+
+	src.mu.Lock()
+	start := !src.started && src.opts.PollInterval > 0
+	src.started = true
+	src.mu.Unlock()
+	if start {
+		stopChan := c.stopChan
+		c.wg.Go(func() { c.pollObjectSource(stopChan) })
+	}
+	return nil
+}
+
+// fetchObjectSource 读取对象；版本或内容未变化时 changed 为 false
+func (c *Config) fetchObjectSource(ctx context.Context) (data []byte, changed bool, err error) {
+	src := c.objectSource
+	ctx, cancel := context.WithTimeout(ctx, src.opts.Timeout)
+	defer cancel()
+
+	src.mu.Lock()
+	known := src.version
+	src.mu.Unlock()
+
+	obj, err := src.opts.Client.GetObject(ctx, src.bucket, src.key, known)
+	if errors.Is(err, ErrObjectNotModified) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("get object %s: %w", src.uri, err)
+	}
+	if obj == nil {
+		return nil, false, fmt.Errorf("get object %s: client returned no object", src.uri)
+	}
+
+	sum := sha256.Sum256(obj.Data)
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	if obj.Version != "" && obj.Version == src.version {
+		return nil, false, nil
+	}
+	if obj.Version == "" && sum == src.checksum {
+		return nil, false, nil
+	}
+	src.version = obj.Version
+	src.checksum = sum
+	return obj.Data, true, nil
+}
+
+// pollObjectSource 按轮询间隔检查对象变化，应用成功后刷新本地缓存
+func (c *Config) pollObjectSource(stopChan <-chan struct{}) {
+	src := c.objectSource
+	c.pollRemote(stopChan, src.opts.PollInterval, src.uri, func(ctx context.Context) ([]byte, bool, error) {
+		data, changed, err := c.fetchObjectSource(ctx)
+		// 在此直接应用以便仅在成功替换后刷新缓存，并告知 pollRemote 无需再次重载
+		if err == nil && changed {
+			if c.reloadRemote(src.uri, data) {
+				c.cacheObject(data)
+			}
+			return nil, false, nil
+		}
+		return data, changed, err
+	})
+}
+
+// cacheObject 将最后一次成功加载的对象写入本地缓存（先写临时文件再原子替换）
+func (c *Config) cacheObject(data []byte) {
+	cacheFile := c.objectSource.opts.CacheFile
+	if c.objectSource.opts.DisableCache || cacheFile == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(cacheFile), 0o755); err != nil {
+		c.logger.Warnf("Failed to create object cache directory: %v", err)
+		return
+	}
+	tmp := cacheFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		c.logger.Warnf("Failed to write object cache: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, cacheFile); err != nil {
+		_ = os.Remove(tmp)
+		c.logger.Warnf("Failed to replace object cache: %v", err)
+	}
+}
+
+// loadObjectCacheLocked 从本地缓存加载最后一次成功读取的对象（调用者需持有 mu）
+func (c *Config) loadObjectCacheLocked() error {
+	src := c.objectSource
+	if src.opts.DisableCache || src.opts.CacheFile == "" {
+		return fmt.Errorf("object cache disabled")
+	}
+	data, err := os.ReadFile(src.opts.CacheFile)
+	if err != nil {
+		return err
+	}
+	if err := c.applyRemoteContentLocked(data); err != nil {
+		return fmt.Errorf("load object cache: %w", err)
+	}
+	c.logger.Warnf("Object storage unavailable, loaded cached config: %s", src.opts.CacheFile)
+	return nil
+}
@@ -0,0 +1,135 @@
+package sysconf
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+// fakeObjectStore 内存版对象存储客户端
+type fakeObjectStore struct {
+	mu      sync.Mutex
+	data    string
+	version string
+	fail    bool
+	calls   atomic.Int32
+}
+
+func (s *fakeObjectStore) put(data, version string) {
+	s.mu.Lock()
+	s.data, s.version = data, version
+	s.mu.Unlock()
+}
+
+func (s *fakeObjectStore) GetObject(_ context.Context, bucket, key, knownVersion string) (*Object, error) {
+	s.calls.Add(1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		return nil, errors.New("storage unavailable")
+	}
+	if bucket != "configs" || key != "prod/app.yaml" {
+		return nil, errors.New("no such object")
+	}
+	if knownVersion == s.version {
+		return nil, ErrObjectNotModified
+	}
+	return &Object{Data: []byte(s.data), Version: s.version}, nil
+}
+
+func TestObjectSourcePollAndCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &fakeObjectStore{}
+	store.put("app:\n  name: v1\n", "1")
+
+	cfg, err := New(
+		WithPath(tmpDir),
+		WithObjectSource("s3://configs/prod/app.yaml", ObjectSourceOptions{
+			Client:       store,
+			PollInterval: 20 * time.Millisecond,
+		}),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("app.name"); got != "v1" {
+		t.Fatalf("expected v1, got %q", got)
+	}
+	cacheFile := filepath.Join(tmpDir, ".app.yaml.cache")
+	if cached, err := os.ReadFile(cacheFile); err != nil || string(cached) != "app:\n  name: v1\n" {
+		t.Fatalf("expected cached object, got %q, %v", cached, err)
+	}
+
+	var reloads atomic.Int32
+	cfg.Watch(func() { reloads.Add(1) })
+
+	// 版本未变化时不触发重载
+	calls := store.calls.Load()
+	for store.calls.Load() < calls+3 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if reloads.Load() != 0 {
+		t.Fatalf("unchanged version should not reload")
+	}
+
+	store.put("app:\n  name: v2\n", "2")
+	deadline := time.Now().Add(3 * time.Second)
+	for cfg.GetString("app.name") != "v2" {
+		if time.Now().After(deadline) {
+			t.Fatalf("new object version not applied")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	deadline = time.Now().Add(3 * time.Second)
+	for {
+		if cached, _ := os.ReadFile(cacheFile); string(cached) == "app:\n  name: v2\n" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("cache not refreshed after reload")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if reloads.Load() != 1 {
+		t.Fatalf("expected exactly one reload, got %d", reloads.Load())
+	}
+}
+
+func TestObjectSourceFallsBackToCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "last-good.yaml"), []byte("app:\n  name: cached\n"), 0o600); err != nil {
+		t.Fatalf("write cache failed: %v", err)
+	}
+	store := &fakeObjectStore{fail: true}
+
+	cfg, err := New(
+		WithPath(tmpDir),
+		WithObjectSource("s3://configs/prod/app.yaml", ObjectSourceOptions{Client: store, CacheFile: "last-good.yaml"}),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if got := cfg.GetString("app.name"); got != "cached" {
+		t.Fatalf("expected cached config, got %q", got)
+	}
+
+	if _, err := New(
+		WithPath(t.TempDir()),
+		WithObjectSource("s3://configs/prod/app.yaml", ObjectSourceOptions{Client: store}),
+	); err == nil {
+		t.Fatalf("expected error without cache or local config")
+	}
+	if _, err := New(WithObjectSource("s3://configs/prod/app.yaml", ObjectSourceOptions{})); err == nil {
+		t.Fatalf("expected error for missing client")
+	}
+}
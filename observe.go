@@ -0,0 +1,142 @@
+package sysconf
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// ObserveOptions Observe 的行为选项
+type ObserveOptions struct {
+	Debounce    time.Duration // 变化后等待该时长内无新变化再回调，期间多次变化只交付最后的值；0 表示立即回调
+	InitialFire bool          // 注册时立即以当前值同步回调一次（old 为零值）
+}
+
+// observer 单个键的观察者，记录最后交付的值用于去重。注册后的读取、比较与回调都在观察者自己的
+// goroutine 中依次执行，last 无需加锁，交付顺序与提交顺序一致
+type observer[T any] struct {
+	cfg      *Config
+	key      string
+	fn       func(old, new T)
+	debounce time.Duration
+	last     T
+	stopped  atomic.Bool
+}
+
+// Observe 观察单个配置键，值变化时以 T 类型的旧值与新值回调，如 Observe[string]、Observe[time.Duration]
+// 或 Observe[DatabaseConfig]，回调类型在编译期检查。
+// 转换规则与 Unmarshal 一致；键不存在时交付零值，转换失败时记录警告并跳过本次变化。
+// 配置重载与 Set/SetMultiple 提交后均会检查，只有转换后的值与上次交付（或注册时）的值不同才回调，值未变的重载不会触发。
+// 除 InitialFire 外，回调在每个观察者独立的 goroutine 中异步执行（不占用 Watch 回调的执行顺序），
+// 因此回调中可以直接调用 Set；同一观察者的回调按顺序执行，短时间内的多次变化可能合并为一次回调并交付最新值。
+// 返回的取消函数停止观察并丢弃尚未到期的防抖回调；配置关闭后观察随之结束，Close 会等待正在执行的回调返回。
+func Observe[T any](c *Config, key string, opts ObserveOptions, fn func(old, new T)) (context.CancelFunc, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("observe callback must not be nil")
+	}
+	if key == "" {
+		return nil, ErrInvalidKey
+	}
+	if opts.Debounce < 0 {
+		return nil, fmt.Errorf("observe debounce must not be negative: %v", opts.Debounce)
+	}
+
+	o := &observer[T]{cfg: c, key: key, fn: fn, debounce: opts.Debounce}
+	// 先订阅再读取初始值，避免遗漏两者之间提交的变化
+	signals, unsubscribe := c.watchCommits()
+	initial, err := o.current()
+	if err != nil {
+		unsubscribe()
+		return nil, fmt.Errorf("observe %s: %w", key, err)
+	}
+	o.last = initial
+	if opts.InitialFire {
+		var zero T
+		o.call(zero, initial)
+	}
+
+	c.wg.Go(func() { o.run(signals) })
+	return func() {
+		o.stopped.Store(true)
+		unsubscribe()
+	}, nil
+}
+
+// run 处理数据发布通知直到通道关闭（取消观察或配置关闭），按防抖设置立即或延迟交付
+func (o *observer[T]) run(signals <-chan struct{}) {
+	var timer *time.Timer
+	var fire <-chan time.Time
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		select {
+		case _, ok := <-signals:
+			if !ok {
+				return
+			}
+			if o.debounce == 0 {
+				o.deliver()
+				continue
+			}
+			if timer == nil {
+				timer = time.NewTimer(o.debounce)
+			} else {
+				timer.Reset(o.debounce)
+			}
+			fire = timer.C
+		case <-fire:
+			fire = nil
+			o.deliver()
+		}
+	}
+}
+
+// current 读取当前值并转换为 T
+func (o *observer[T]) current() (T, error) {
+	var value T
+	raw, ok := o.cfg.lookupRaw(o.key)
+	if !ok || raw == nil {
+		return value, nil
+	}
+	if reflect.TypeFor[T]().Kind() == reflect.Interface {
+		if v, ok := deepCloneValue(raw).(T); ok {
+			value = v
+		}
+		return value, nil
+	}
+	err := o.cfg.Unmarshal(&value, o.key)
+	return value, err
+}
+
+// deliver 读取最新值，与上次交付的值不同时回调
+func (o *observer[T]) deliver() {
+	if o.stopped.Load() {
+		return
+	}
+	value, err := o.current()
+	if err != nil {
+		o.cfg.logger.Warnf("Failed to convert observed key %s: %v", o.key, err)
+		return
+	}
+	if reflect.DeepEqual(o.last, value) {
+		return
+	}
+	old := o.last
+	o.last = value
+	o.call(old, value)
+}
+
+// call 调用回调，回调 panic 时记录错误
+func (o *observer[T]) call(old, value T) {
+	defer func() {
+		if r := recover(); r != nil {
+			o.cfg.logger.Errorf("Observer callback for %s panicked: %v", o.key, r)
+		}
+	}()
+	o.fn(old, value)
+}
@@ -0,0 +1,226 @@
+package sysconf
+
+import (
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestObserveTypedAndDeduplicated(t *testing.T) {
+	cfg, err := New(WithContent("logging:\n  level: info\nserver:\n  timeout: 5s\n"), WithWriteDebounceDelay(0))
+	require.NoError(t, err)
+	testutil.Cleanup(t, cfg.Close)
+
+	var mu sync.Mutex
+	var levels []string
+	stop, err := Observe(cfg, "logging.level", ObserveOptions{InitialFire: true}, func(_, level string) {
+		mu.Lock()
+		levels = append(levels, level)
+		mu.Unlock()
+	})
+	require.NoError(t, err)
+	defer stop()
+	snapshot := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(levels)
+	}
+	assert.Equal(t, []string{"info"}, snapshot(), "InitialFire 应在注册时交付当前值")
+
+	timeouts := make(chan time.Duration, 4)
+	stopTimeout, err := Observe(cfg, "server.timeout", ObserveOptions{}, func(_, d time.Duration) {
+		timeouts <- d
+	})
+	require.NoError(t, err)
+	defer stopTimeout()
+
+	require.NoError(t, cfg.Set("logging.level", "debug"))
+	require.NoError(t, cfg.Set("logging.level", "debug"))
+	require.NoError(t, cfg.Set("server.timeout", "10s"))
+	select {
+	case d := <-timeouts:
+		assert.Equal(t, 10*time.Second, d)
+	case <-time.After(2 * time.Second):
+		t.Fatal("observer was not called")
+	}
+	assert.Eventually(t, func() bool { return len(snapshot()) == 2 }, 2*time.Second, 5*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []string{"info", "debug"}, snapshot(), "值未变化时不应回调")
+
+	stop()
+	require.NoError(t, cfg.Set("logging.level", "warn"))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []string{"info", "debug"}, snapshot(), "取消后不应回调")
+}
+
+func TestObserveCallbackCanSet(t *testing.T) {
+	cfg, err := New(WithContent("logging:\n  level: info\n  mirror: info\n"), WithWriteDebounceDelay(0))
+	require.NoError(t, err)
+	testutil.Cleanup(t, cfg.Close)
+
+	mirrored := make(chan error, 1)
+	stop, err := Observe(cfg, "logging.level", ObserveOptions{}, func(_, level string) {
+		mirrored <- cfg.Set("logging.mirror", level)
+	})
+	require.NoError(t, err)
+	defer stop()
+
+	runWithWatchdog(t, 5*time.Second, func() {
+		require.NoError(t, cfg.Set("logging.level", "debug"))
+		require.NoError(t, <-mirrored)
+	})
+	assert.Equal(t, "debug", cfg.GetString("logging.mirror"))
+}
+
+func TestObserveDoesNotBlockSetInWatchCallback(t *testing.T) {
+	cfg, configFile := newWatchTestConfig(t, WithWriteDebounceDelay(0))
+
+	stop, err := Observe(cfg, "key", ObserveOptions{}, func(_, _ string) {})
+	require.NoError(t, err)
+	defer stop()
+
+	// 存在观察者时，普通 Watch 回调中的 Set 不应等待自身的回调票号
+	var once sync.Once
+	watched := make(chan error, 1)
+	cfg.Watch(func() {
+		once.Do(func() { watched <- cfg.Set("touched", true) })
+	})
+	require.NoError(t, os.WriteFile(configFile, []byte("key: changed\n"), 0o644))
+
+	runWithWatchdog(t, 5*time.Second, func() {
+		cfg.reloadChangedFile(configFile, true)
+		require.NoError(t, <-watched)
+	})
+	assert.True(t, cfg.GetBool("touched"))
+}
+
+func TestObserveDebounceDeliversLastValue(t *testing.T) {
+	cfg, err := New(WithContent("logging:\n  level: info\n"), WithWriteDebounceDelay(0))
+	require.NoError(t, err)
+	testutil.Cleanup(t, cfg.Close)
+
+	var mu sync.Mutex
+	var levels []string
+	delivered := make(chan struct{}, 4)
+	stop, err := Observe(cfg, "logging.level", ObserveOptions{Debounce: 50 * time.Millisecond}, func(_, level string) {
+		mu.Lock()
+		levels = append(levels, level)
+		mu.Unlock()
+		delivered <- struct{}{}
+	})
+	require.NoError(t, err)
+	defer stop()
+
+	for _, level := range []string{"debug", "warn", "error"} {
+		require.NoError(t, cfg.Set("logging.level", level))
+	}
+	select {
+	case <-delivered:
+	case <-time.After(2 * time.Second):
+		t.Fatal("debounced observer was not called")
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"error"}, levels, "防抖期间只应交付最后的值")
+}
+
+func TestObserveValidation(t *testing.T) {
+	cfg, err := New(WithContent("a: 1\n"))
+	require.NoError(t, err)
+	testutil.Cleanup(t, cfg.Close)
+
+	_, err = Observe[int](cfg, "a", ObserveOptions{}, nil)
+	assert.Error(t, err)
+	_, err = Observe(cfg, "", ObserveOptions{}, func(_, _ int) {})
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	_, err = Observe(cfg, "a", ObserveOptions{Debounce: -time.Second}, func(_, _ int) {})
+	assert.Error(t, err)
+	_, err = Observe(cfg, "a", ObserveOptions{}, func(_, _ struct{ Name string }) {})
+	assert.Error(t, err, "无法转换的初始值应返回错误")
+}
+
+func TestObserveFollowsFileReload(t *testing.T) {
+	cfg, configFile := newWatchTestConfig(t)
+
+	values := make(chan string, 4)
+	stop, err := Observe(cfg, "key", ObserveOptions{}, func(_, v string) { values <- v })
+	require.NoError(t, err)
+	defer stop()
+
+	require.NoError(t, os.WriteFile(configFile, []byte("key: reloaded\n"), 0o644))
+	select {
+	case v := <-values:
+		assert.Equal(t, "reloaded", v)
+	case <-time.After(5 * time.Second):
+		t.Fatal("observer did not see the file reload")
+	}
+}
+
+func TestObserveDeliversInCommitOrder(t *testing.T) {
+	cfg, err := New(WithContent("counter: 0\n"), WithWriteDebounceDelay(0))
+	require.NoError(t, err)
+	testutil.Cleanup(t, cfg.Close)
+
+	type change struct{ old, new int }
+	changes := make(chan change, 256)
+	stop, err := Observe(cfg, "counter", ObserveOptions{}, func(old, new int) {
+		changes <- change{old, new}
+	})
+	require.NoError(t, err)
+	defer stop()
+
+	for i := 1; i <= 100; i++ {
+		require.NoError(t, cfg.Set("counter", i))
+	}
+	// 合并的通知可能跳过中间值，但每次交付的旧值都应是上一次交付的新值，且新值单调递增
+	last := 0
+	for last != 100 {
+		select {
+		case c := <-changes:
+			assert.Equal(t, last, c.old, "旧值应为上一次交付的值")
+			assert.Greater(t, c.new, last, "不应交付比已交付值更旧的值")
+			last = c.new
+		case <-time.After(2 * time.Second):
+			t.Fatalf("observer stopped at %d", last)
+		}
+	}
+}
+
+func TestObserveCloseWaitsForCallback(t *testing.T) {
+	cfg, err := New(WithContent("key: a\n"), WithWriteDebounceDelay(0))
+	require.NoError(t, err)
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	_, err = Observe(cfg, "key", ObserveOptions{}, func(_, _ string) {
+		close(entered)
+		<-release
+	})
+	require.NoError(t, err)
+	require.NoError(t, cfg.Set("key", "b"))
+	<-entered
+
+	closed := make(chan error, 1)
+	go func() { closed <- cfg.Close() }()
+	select {
+	case <-closed:
+		t.Fatal("Close returned while an observer callback was still running")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case err := <-closed:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not return after the callback finished")
+	}
+}
@@ -54,10 +54,31 @@ func isHiddenFileWithoutExtension(fileName string) bool {
 		!strings.Contains(fileName[1:], ".")
 }
 
-// WithMode 设置配置文件模式
-func WithMode(mode string) Option {
+// WithMode 设置配置文件模式，既可传入类型化常量（sysconf.YAML、sysconf.JSON 等），也兼容字符串形式（"yaml"）
+func WithMode[M ~string](mode M) Option {
 	return func(c *Config) {
-		c.mode = mode
+		c.mode = string(mode)
+	}
+}
+
+// WithFile 以单个文件路径同时设置配置目录、文件名与格式，等价于 WithPath+WithName+WithMode 的组合：
+//
+//	sysconf.WithFile("config/app.yaml") // 目录 config，名称 app，格式 yaml
+//
+// 格式按扩展名推断（见 ModeFromPath），无法识别时沿用 WithMode 或默认格式；
+// 文件按给定文件名精确读写，因此 app.yml、.env 等扩展名同样适用。
+func WithFile(path string) Option {
+	return func(c *Config) {
+		fileName := filepath.Base(path)
+		c.path = filepath.Dir(path)
+		c.configFileName = fileName
+		c.name = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+		if c.name == "" {
+			c.name = fileName
+		}
+		if mode, ok := ModeFromPath(fileName); ok {
+			c.mode = string(mode)
+		}
 	}
 }
 
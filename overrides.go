@@ -0,0 +1,204 @@
+package sysconf
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SourceOverrides WithOverrides 与 ApplyOverrideString 设置的覆盖值，始终为最高优先级，不参与 WithSourcePriority 排序
+const SourceOverrides Source = "overrides"
+
+// WithOverrides 以 "key=value" 形式设置覆盖值，例如 WithOverrides("server.port=9090", "logging.level=debug")，
+// 便于实现 `myapp --set server.port=9090` 形式的命令行参数。覆盖值优先级高于环境变量、命令行标志与
+// 所有配置来源，只影响读取（Get 系列方法与 Unmarshal），不会写入配置文件。
+// 值按 YAML 标量推断类型："9090" 为整数，"true" 为布尔值，"[a, b]" 为列表，无法解析时按字符串处理。
+// 格式错误时 New 返回错误。
+func WithOverrides(pairs ...string) Option {
+	return func(c *Config) {
+		c.overrideArgs = append(c.overrideArgs, pairs...)
+	}
+}
+
+// ApplyOverrideString 解析一个 "key=value" 覆盖值并立即生效，规则与 WithOverrides 相同。
+// 同一键多次设置时以最后一次为准。
+func (c *Config) ApplyOverrideString(s string) error {
+	if c.closed.Load() {
+		return ErrAlreadyClosed
+	}
+	key, value, err := parseOverride(s)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.storeOverrideUnsafe(key, value)
+	c.mu.Unlock()
+
+	c.invalidateLookupCache()
+	c.invalidateCache()
+	if c.debugEnabled() {
+		c.logger.Debugf("Applied override: %s = %v", key, value)
+	}
+	return nil
+}
+
+// Overrides 返回当前生效的覆盖值（扁平键 → 值）的副本
+func (c *Config) Overrides() map[string]any {
+	if p := c.overrides.Load(); p != nil {
+		return deepCloneMap(*p)
+	}
+	return map[string]any{}
+}
+
+// initOverrides 解析 WithOverrides 设置的覆盖值
+func (c *Config) initOverrides() error {
+	for _, pair := range c.overrideArgs {
+		key, value, err := parseOverride(pair)
+		if err != nil {
+			return err
+		}
+		c.storeOverrideUnsafe(key, value)
+	}
+	return nil
+}
+
+// parseOverride 解析 "key=value"，值按 YAML 标量推断类型
+func parseOverride(s string) (string, any, error) {
+	key, raw, ok := strings.Cut(s, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" || strings.HasPrefix(key, ".") || strings.HasSuffix(key, ".") || strings.Contains(key, "..") {
+		return "", nil, fmt.Errorf("%w: override %q must be in key=value form", ErrInvalidKey, s)
+	}
+	return key, inferOverrideValue(raw), nil
+}
+
+// inferOverrideValue 按 YAML 标量推断值的类型，空值与无法解析的值按字符串处理
+func inferOverrideValue(raw string) any {
+	if strings.TrimSpace(raw) == "" {
+		return raw
+	}
+	var value any
+	if err := yaml.Unmarshal([]byte(raw), &value); err != nil || value == nil {
+		return raw
+	}
+	return sanitizeValue(value)
+}
+
+// storeOverrideUnsafe 写入覆盖值，替换该键及其子键上已有的覆盖值（调用者需持有 mu）。
+// 覆盖值按键的比较形式保存，与数据中键的拼写无关。
+func (c *Config) storeOverrideUnsafe(key string, value any) {
+	key = c.matchKey(key)
+	next := make(map[string]any)
+	if p := c.overrides.Load(); p != nil {
+		for k, v := range *p {
+			if k != key && !strings.HasPrefix(k, key+".") && !strings.HasPrefix(key, k+".") {
+				next[k] = v
+			}
+		}
+	}
+	if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+		flattenSettings(key, nested, next)
+	} else {
+		next[key] = value
+	}
+	c.overrides.Store(&next)
+}
+
+// deleteOverridesUnsafe 移除键及其子键上的覆盖值，返回是否有覆盖值被移除（调用者需持有 mu）
+func (c *Config) deleteOverridesUnsafe(keys ...string) bool {
+	p := c.overrides.Load()
+	if p == nil || len(keys) == 0 {
+		return false
+	}
+	next := maps.Clone(*p)
+	for _, key := range keys {
+		key = c.matchKey(key)
+		for k := range next {
+			if k == key || strings.HasPrefix(k, key+".") {
+				delete(next, k)
+			}
+		}
+	}
+	if len(next) == len(*p) {
+		return false
+	}
+	c.overrides.Store(&next)
+	return true
+}
+
+// overrideValue 返回键自身的覆盖值（不含子键）
+func (c *Config) overrideValue(key string) (any, bool) {
+	p := c.overrides.Load()
+	if p == nil {
+		return nil, false
+	}
+	value, ok := (*p)[c.matchKey(key)]
+	return value, ok
+}
+
+// hasOverrides 是否设置了覆盖值
+func (c *Config) hasOverrides() bool {
+	p := c.overrides.Load()
+	return p != nil && len(*p) > 0
+}
+
+// applyOverrides 将覆盖值合并到键 key 的查找结果中：叶子键直接替换，父键返回合并了子键覆盖值的副本
+func (c *Config) applyOverrides(key string, value any, found bool) (any, bool) {
+	p := c.overrides.Load()
+	if p == nil || len(*p) == 0 {
+		return value, found
+	}
+	overrides := *p
+	form := c.matchKey(key)
+	if v, ok := overrides[form]; ok {
+		return v, true
+	}
+	prefix := form + "."
+	var section map[string]any
+	for _, k := range slices.Sorted(maps.Keys(overrides)) {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		if section == nil {
+			section, _ = value.(map[string]any)
+			if section = deepCloneMap(section); section == nil {
+				section = make(map[string]any)
+			}
+		}
+		setNestedMapValue(section, c.relativeOverrideKey(key, k), overrides[k])
+	}
+	if section == nil {
+		return value, found
+	}
+	return section, true
+}
+
+// applyOverridesUnsafe 将覆盖值写入 prefix 下的嵌套配置，prefix 为空时 settings 为完整配置
+func (c *Config) applyOverridesUnsafe(prefix string, settings map[string]any) {
+	p := c.overrides.Load()
+	if p == nil {
+		return
+	}
+	form := c.matchKey(prefix)
+	for _, k := range slices.Sorted(maps.Keys(*p)) {
+		if prefix == "" || strings.HasPrefix(k, form+".") {
+			setNestedMapValue(settings, c.relativeOverrideKey(prefix, k), (*p)[k])
+		}
+	}
+}
+
+// relativeOverrideKey 返回覆盖键相对于 prefix 的路径，已存在的键沿用数据中的拼写
+func (c *Config) relativeOverrideKey(prefix, overrideKey string) string {
+	data := c.loadData()
+	target := c.resolveKey(data, overrideKey)
+	if prefix == "" {
+		return target
+	}
+	if resolved := c.resolveKey(data, prefix) + "."; strings.HasPrefix(target, resolved) {
+		return strings.TrimPrefix(target, resolved)
+	}
+	return overrideKey[len(c.matchKey(prefix))+1:]
+}
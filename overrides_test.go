@@ -0,0 +1,98 @@
+package sysconf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestWithOverrides(t *testing.T) {
+	t.Setenv("APP_SERVER_PORT", "7070")
+	cfg, err := New(
+		WithContent("server:\n  port: 8080\n  host: localhost\nlogging:\n  level: info\n"),
+		WithEnv("APP"),
+		WithOverrides("server.port=9090", "logging.level=debug", "features=[a, b]", "debug=true"),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.Get("server.port"); got != 9090 {
+		t.Fatalf("override must beat env and file with inferred int, got %#v", got)
+	}
+	if got := cfg.GetString("logging.level"); got != "debug" {
+		t.Fatalf("expected overridden level, got %q", got)
+	}
+	if got := cfg.Get("debug"); got != true {
+		t.Fatalf("expected inferred bool, got %#v", got)
+	}
+	if got := cfg.GetStringSlice("features"); len(got) != 2 || got[1] != "b" {
+		t.Fatalf("expected inferred list, got %v", got)
+	}
+	if got := GetAs[int](cfg, "server.port"); got != 9090 {
+		t.Fatalf("GetAs must see override, got %d", got)
+	}
+	section := cfg.GetStringMap("server")
+	if section["port"] != 9090 || section["host"] != "localhost" {
+		t.Fatalf("section must merge overrides, got %v", section)
+	}
+
+	var target struct {
+		Server struct {
+			Port int
+			Host string
+		}
+	}
+	if err := cfg.Unmarshal(&target); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if target.Server.Port != 9090 || target.Server.Host != "localhost" {
+		t.Fatalf("unmarshal must apply overrides, got %+v", target)
+	}
+
+	explained := cfg.Explain("server.port")
+	if len(explained) == 0 || explained[0].Source != SourceOverrides || !explained[0].Active {
+		t.Fatalf("expected overrides to be the active source, got %+v", explained)
+	}
+}
+
+func TestApplyOverrideString(t *testing.T) {
+	cfg, err := New(WithContent("server:\n  port: 8080\n"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if err := cfg.ApplyOverrideString("server.port=9090"); err != nil {
+		t.Fatalf("apply override failed: %v", err)
+	}
+	if err := cfg.ApplyOverrideString("server.name = api"); err != nil {
+		t.Fatalf("apply override failed: %v", err)
+	}
+	if got := cfg.GetInt("server.port"); got != 9090 {
+		t.Fatalf("expected override to take effect, got %d", got)
+	}
+	if got := cfg.GetString("server.name"); got != "api" {
+		t.Fatalf("expected surrounding spaces to be trimmed, got %q", got)
+	}
+	if err := cfg.Set("server.port", 1); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if got := cfg.GetInt("server.port"); got != 9090 {
+		t.Fatalf("override must stay above Set values, got %d", got)
+	}
+	if got := cfg.Overrides(); len(got) != 2 {
+		t.Fatalf("expected two overrides, got %v", got)
+	}
+
+	for _, bad := range []string{"no-equals", "=1", "a..b=1", ".a=1"} {
+		if err := cfg.ApplyOverrideString(bad); !errors.Is(err, ErrInvalidKey) {
+			t.Fatalf("expected ErrInvalidKey for %q, got %v", bad, err)
+		}
+	}
+	if _, err := New(WithOverrides("broken")); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected New to reject malformed override, got %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package sysconf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// WithPathKeys 声明路径类配置键（按 "." 分段，支持 * 与 **，语法同 WatchKeysGlob）。
+// GetString、GetStringSlice、GetPath 与 Unmarshal 读取这些键时会规范化路径：
+// 展开 ~ 与 $VAR/${VAR}，统一为当前系统的路径分隔符并清理路径。结构体字段也可通过 `path:"true"` 标签声明。
+//
+//	cfg, _ := sysconf.New(sysconf.WithPathKeys("storage.*_dir", "log.file"))
+func WithPathKeys(patterns ...string) Option {
+	return func(c *Config) {
+		for _, pattern := range patterns {
+			if pattern != "" {
+				c.pathKeys = append(c.pathKeys, strings.Split(pattern, "."))
+			}
+		}
+	}
+}
+
+// WithPathsRelativeToConfig 将路径类配置键中的相对路径解析为相对配置文件所在目录的绝对路径
+// （纯内存配置没有配置文件，相对路径保持不变）
+func WithPathsRelativeToConfig() Option {
+	return func(c *Config) {
+		c.pathsRelative = true
+	}
+}
+
+// GetPath 按路径规则读取配置值（无论键是否通过 WithPathKeys 声明）：展开 ~ 与环境变量、
+// 统一路径分隔符，并在启用 WithPathsRelativeToConfig 时将相对路径解析为相对配置文件目录的绝对路径
+func (c *Config) GetPath(key string, def ...string) string {
+	val, exists := c.getRaw(key)
+	if !exists {
+		if len(def) > 0 {
+			return c.normalizePath(def[0])
+		}
+		return ""
+	}
+	s, err := cast.ToStringE(val)
+	if err != nil {
+		if len(def) > 0 {
+			return c.normalizePath(def[0])
+		}
+		return ""
+	}
+	return c.normalizePath(s)
+}
+
+// isPathKey 判断键是否声明为路径类配置键
+func (c *Config) isPathKey(key string) bool {
+	if len(c.pathKeys) == 0 {
+		return false
+	}
+	parts := strings.Split(key, ".")
+	for _, pattern := range c.pathKeys {
+		if matchKeyPattern(pattern, parts) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePath 规范化路径值；无法确定主目录时保留 ~ 原样
+func (c *Config) normalizePath(p string) string {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return p
+	}
+	if strings.Contains(p, "$") {
+		p = os.ExpandEnv(p)
+	}
+	if expanded, err := expandHome(p); err == nil {
+		p = expanded
+	}
+	if filepath.Separator == '/' {
+		p = strings.ReplaceAll(p, `\`, "/")
+	} else {
+		p = filepath.FromSlash(p)
+	}
+	if c.pathsRelative && !filepath.IsAbs(p) {
+		if dir := c.configDir(); dir != "" {
+			p = filepath.Join(dir, p)
+			if abs, err := filepath.Abs(p); err == nil {
+				p = abs
+			}
+		}
+	}
+	return filepath.Clean(p)
+}
+
+// configDir 返回配置文件所在目录，纯内存配置返回空字符串
+func (c *Config) configDir() string {
+	c.mu.RLock()
+	file := c.configFilePath()
+	c.mu.RUnlock()
+	if file == "" {
+		return ""
+	}
+	return filepath.Dir(file)
+}
+
+// expandHome 将以 ~ 开头的路径展开为用户主目录
+func expandHome(p string) (string, error) {
+	if p != "~" && !strings.HasPrefix(p, "~/") && !strings.HasPrefix(p, `~\`) {
+		return p, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, p[1:]), nil
+}
+
+// normalizePathValue 规范化字符串或字符串列表形式的路径值，其他类型原样返回
+func (c *Config) normalizePathValue(val any) any {
+	switch v := val.(type) {
+	case string:
+		return c.normalizePath(v)
+	case []string:
+		out := make([]string, len(v))
+		for i, item := range v {
+			out[i] = c.normalizePath(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			if s, ok := item.(string); ok {
+				out[i] = c.normalizePath(s)
+			} else {
+				out[i] = item
+			}
+		}
+		return out
+	}
+	return val
+}
+
+// applyPathKeys 对 Unmarshal 输入中匹配 WithPathKeys 的键规范化路径（输入需为可修改的副本）
+func (c *Config) applyPathKeys(input any, prefix string) {
+	m, ok := input.(map[string]any)
+	if !ok {
+		return
+	}
+	for key, val := range m {
+		fullKey := joinKey(prefix, key)
+		if nested, isMap := val.(map[string]any); isMap {
+			c.applyPathKeys(nested, fullKey)
+			continue
+		}
+		if c.isPathKey(fullKey) {
+			m[key] = c.normalizePathValue(val)
+		}
+	}
+}
+
+// applyFieldPaths 对带 `path:"true"` 标签的结构体字段对应的输入值规范化路径
+func (c *Config) applyFieldPaths(input any, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	m, ok := input.(map[string]any)
+	if !ok || t.Kind() != reflect.Struct {
+		return
+	}
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, inline, skip := docFieldKey(sf)
+		if skip {
+			continue
+		}
+		if inline {
+			c.applyFieldPaths(m, sf.Type)
+			continue
+		}
+		key, found := matchInputKey(m, name)
+		if !found {
+			continue
+		}
+		if sf.Tag.Get("path") == "true" {
+			m[key] = c.normalizePathValue(m[key])
+			continue
+		}
+		c.applyFieldPaths(m[key], sf.Type)
+	}
+}
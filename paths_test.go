@@ -0,0 +1,105 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestPathKeysNormalization(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	t.Setenv("SYSCONF_TEST_DATA", "/srv/data")
+
+	dir := t.TempDir()
+	content := "storage:\n  cache_dir: ~/cache\n  data_dir: ${SYSCONF_TEST_DATA}/app/../db\n  name: ~/not-a-path\nlog:\n  file: logs\\app.log\n  extra:\n    - ./a\n    - b/c\n"
+	if err := os.WriteFile(filepath.Join(dir, "paths.yaml"), []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, err := New(
+		WithPath(dir), WithName("paths"), WithMode("yaml"),
+		WithPathKeys("storage.*_dir", "log.**"),
+		WithPathsRelativeToConfig(),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got, want := cfg.GetString("storage.cache_dir"), filepath.Join(home, "cache"); got != want {
+		t.Fatalf("cache_dir = %q, want %q", got, want)
+	}
+	if got, want := cfg.GetString("storage.data_dir"), filepath.FromSlash("/srv/data/db"); got != want {
+		t.Fatalf("data_dir = %q, want %q", got, want)
+	}
+	if got := cfg.GetString("storage.name"); got != "~/not-a-path" {
+		t.Fatalf("undeclared keys must not be normalized, got %q", got)
+	}
+	if got, want := cfg.GetString("log.file"), filepath.Join(dir, "logs", "app.log"); got != want {
+		t.Fatalf("log.file = %q, want %q", got, want)
+	}
+	extra := cfg.GetStringSlice("log.extra")
+	if len(extra) != 2 || extra[0] != filepath.Join(dir, "a") || extra[1] != filepath.Join(dir, "b", "c") {
+		t.Fatalf("log.extra = %v", extra)
+	}
+	if got, want := cfg.GetPath("storage.name"), filepath.Join(home, "not-a-path"); got != want {
+		t.Fatalf("GetPath() = %q, want %q", got, want)
+	}
+	if got := cfg.Acquire().GetString("log.file"); got != filepath.Join(dir, "logs", "app.log") {
+		t.Fatalf("snapshot log.file = %q", got)
+	}
+
+	// 原始值保持不变，写回文件时不会被替换为绝对路径
+	if raw := cfg.Get("log.file"); raw != `logs\app.log` {
+		t.Fatalf("raw value changed: %v", raw)
+	}
+
+	var settings struct {
+		Storage struct {
+			CacheDir string `config:"cache_dir"`
+			Name     string `config:"name"`
+		} `config:"storage"`
+		Log struct {
+			Extra []string `config:"extra"`
+		} `config:"log"`
+	}
+	if err := cfg.Unmarshal(&settings); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if settings.Storage.CacheDir != filepath.Join(home, "cache") || settings.Storage.Name != "~/not-a-path" {
+		t.Fatalf("unexpected storage: %+v", settings.Storage)
+	}
+	if len(settings.Log.Extra) != 2 || settings.Log.Extra[0] != filepath.Join(dir, "a") {
+		t.Fatalf("unexpected log.extra: %v", settings.Log.Extra)
+	}
+}
+
+func TestPathTagNormalization(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	cfg, err := New(WithContent("app:\n  workdir: ~/work/./tmp/..\n  label: ~/literal\n"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	var app struct {
+		Workdir string `config:"workdir" path:"true"`
+		Label   string `config:"label"`
+	}
+	if err := cfg.Unmarshal(&app, "app"); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if want := filepath.Join(home, "work"); app.Workdir != want {
+		t.Fatalf("Workdir = %q, want %q", app.Workdir, want)
+	}
+	if app.Label != "~/literal" {
+		t.Fatalf("untagged field must not be normalized, got %q", app.Label)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !js && !wasip1
+
+package sysconf
+
+// 平台能力开关，WebAssembly 构建见 platform_wasm.go
+const (
+	envSweepSupported    = true // 智能大小写匹配时扫描 os.Environ
+	dirPermissionProbe   = true // 创建默认配置文件前以临时文件探测目录写权限
+	localNotifySupported = true // 基于本地套接字的跨进程变更通知（WithLocalNotify）
+)
@@ -0,0 +1,11 @@
+//go:build js || wasip1
+
+package sysconf
+
+// 平台能力开关：WebAssembly（浏览器 js/wasm、WASI 边缘运行时）构建只保留内存配置、WithContent 与 URL 等远程配置源，
+// 关闭依赖宿主操作系统的功能。显式键的环境变量查询（os.LookupEnv）仍然可用。
+const (
+	envSweepSupported    = false // 不扫描 os.Environ，智能大小写匹配退化为标准大写匹配
+	dirPermissionProbe   = false // 不在目录中创建临时文件探测写权限
+	localNotifySupported = false // 没有 Unix 域套接字，WithLocalNotify 不生效
+)
@@ -0,0 +1,70 @@
+package sysconf
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Unmarshal 指针字段语义：
+//
+//   - 标量指针（*int、*bool、*string、*time.Duration 等）：键不存在或值为 null 时保持 nil；
+//     键存在时指向解码后的值，即使该值是 0、false 或空字符串，从而区分“未配置”与“显式零值”。
+//   - default 标签：键不存在时指针指向默认值；键存在时以配置值为准（包括零值）。
+//   - required 标签：指针非 nil 即满足，指向零值同样视为已配置。
+//   - 结构体指针：配置段不存在时保持 nil，不分配也不填充默认值；配置段存在时分配结构体，
+//     先填充其字段的默认值再解码，并对其中字段执行 required 校验。
+
+// preparePointerSections 为输入中存在的配置段预先分配 nil 结构体指针并填充默认值，
+// 使其与非指针结构体字段的默认值行为一致
+func preparePointerSections(val reflect.Value, input any, lenientNumbers bool) error {
+	m, ok := input.(map[string]any)
+	if !ok || val.Kind() != reflect.Struct {
+		return nil
+	}
+	t := val.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		field := val.Field(i)
+		if !sf.IsExported() || !field.CanSet() {
+			continue
+		}
+		name, inline, skip := docFieldKey(sf)
+		if skip {
+			continue
+		}
+		if inline {
+			if field.Kind() == reflect.Struct {
+				if err := preparePointerSections(field, m, lenientNumbers); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		key, found := matchInputKey(m, name)
+		if !found {
+			continue
+		}
+		switch {
+		case field.Kind() == reflect.Struct:
+			if err := preparePointerSections(field, m[key], lenientNumbers); err != nil {
+				return err
+			}
+		case field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.Struct && field.Type().Elem() != timeType:
+			section, isMap := m[key].(map[string]any)
+			if !isMap {
+				continue
+			}
+			if field.IsNil() {
+				ptr := reflect.New(field.Type().Elem())
+				if err := decodePlanFor(ptr.Type().Elem()).applyDefaults(ptr.Elem(), lenientNumbers); err != nil {
+					return fmt.Errorf("set defaults for %s: %w", sf.Name, err)
+				}
+				field.Set(ptr)
+			}
+			if err := preparePointerSections(field.Elem(), section, lenientNumbers); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
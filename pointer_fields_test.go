@@ -0,0 +1,93 @@
+package sysconf
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+type pointerTLS struct {
+	Enabled *bool  `config:"enabled" default:"true"`
+	Cert    string `config:"cert" default:"server.crt"`
+}
+
+type pointerSettings struct {
+	Port     *int           `config:"port"`
+	Debug    *bool          `config:"debug"`
+	Name     *string        `config:"name"`
+	Timeout  *time.Duration `config:"timeout"`
+	Missing  *int           `config:"missing"`
+	Null     *int           `config:"null_val"`
+	Retries  *int           `config:"retries" default:"3"`
+	Workers  *int           `config:"workers" default:"8"`
+	TLS      *pointerTLS    `config:"tls"`
+	Proxy    *pointerTLS    `config:"proxy"`
+	Required *bool          `config:"debug" required:"true"`
+}
+
+func TestUnmarshalPointerFields(t *testing.T) {
+	content := `port: 0
+debug: false
+name: ""
+timeout: 0s
+null_val: ~
+workers: 0
+tls:
+  cert: custom.crt
+`
+	cfg, err := New(WithContent(content), WithMode(YAML))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	var out pointerSettings
+	if err := cfg.Unmarshal(&out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if out.Port == nil || *out.Port != 0 || out.Debug == nil || *out.Debug || out.Name == nil || *out.Name != "" {
+		t.Fatalf("explicit zero values should yield non-nil pointers: %+v", out)
+	}
+	if out.Timeout == nil || *out.Timeout != 0 {
+		t.Fatalf("timeout = %v", out.Timeout)
+	}
+	if out.Missing != nil || out.Null != nil {
+		t.Fatalf("absent and null keys should stay nil: missing=%v null=%v", out.Missing, out.Null)
+	}
+	if out.Retries == nil || *out.Retries != 3 {
+		t.Fatalf("default should apply to absent pointer field: %v", out.Retries)
+	}
+	if out.Workers == nil || *out.Workers != 0 {
+		t.Fatalf("explicit zero should override default: %v", *out.Workers)
+	}
+	if out.TLS == nil || out.TLS.Cert != "custom.crt" || out.TLS.Enabled == nil || !*out.TLS.Enabled {
+		t.Fatalf("present section should be allocated with defaults: %+v", out.TLS)
+	}
+	if out.Proxy != nil {
+		t.Fatalf("absent section should stay nil: %+v", out.Proxy)
+	}
+}
+
+func TestUnmarshalPointerRequired(t *testing.T) {
+	cfg, err := New(WithContent("other: 1\n"), WithMode(YAML))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	var out struct {
+		Limit *int `config:"limit" validate:"required"`
+	}
+	if err := cfg.Unmarshal(&out); err == nil || !strings.Contains(err.Error(), "Limit") {
+		t.Fatalf("absent required pointer should fail, got %v", err)
+	}
+	if err := cfg.Set("limit", 0); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := cfg.Unmarshal(&out); err != nil || out.Limit == nil || *out.Limit != 0 {
+		t.Fatalf("explicit zero should satisfy required pointer: %v", err)
+	}
+}
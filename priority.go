@@ -0,0 +1,308 @@
+package sysconf
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// Source 配置值的来源
+type Source string
+
+const (
+	SourceFlags    Source = "flags"    // 显式设置的命令行标志
+	SourceEnv      Source = "env"      // 环境变量
+	SourceRemote   Source = "remote"   // 附加数据源与远程配置源（URL、对象存储、数据库、表格等）
+	SourceFile     Source = "file"     // 配置文件、WithContent 内容及 Set 写入的值
+	SourceDefaults Source = "defaults" // 未显式设置的命令行标志默认值（结构体 default 标签始终只填充缺失值）
+)
+
+// defaultSourcePriority 默认优先级（从高到低），与未设置 WithSourcePriority 时的行为一致
+var defaultSourcePriority = []Source{SourceEnv, SourceFlags, SourceRemote, SourceFile, SourceDefaults}
+
+// SourceValue 某个来源为配置键提供的候选值
+type SourceValue struct {
+	Source Source // 来源
+	Value  any    // 该来源提供的值
+	Active bool   // 是否为当前生效值
+}
+
+// WithSourcePriority 设置配置来源的优先级（从高到低），例如
+// WithSourcePriority(SourceFlags, SourceEnv, SourceRemote, SourceFile, SourceDefaults)。
+// 未列出的来源按默认顺序排在已列出来源之后；默认顺序为 Env、Flags、Remote、File、Defaults。
+// 优先级同时作用于 Get 系列方法、Unmarshal 以及 Origin/Explain 报告。
+// URL、对象存储与数据库配置源整体替换主配置，其值按 Remote 报告。
+func WithSourcePriority(order ...Source) Option {
+	return func(c *Config) {
+		c.sourcePriority = slices.Clone(order)
+	}
+}
+
+// SourcePriority 返回当前生效的来源优先级（从高到低）
+func (c *Config) SourcePriority() []Source {
+	return slices.Clone(c.sourceOrder)
+}
+
+// initSourcePriority 校验并补全来源优先级
+func (c *Config) initSourcePriority() error {
+	order := make([]Source, 0, len(defaultSourcePriority))
+	for _, source := range c.sourcePriority {
+		if !slices.Contains(defaultSourcePriority, source) {
+			return fmt.Errorf("unknown config source: %q", source)
+		}
+		if slices.Contains(order, source) {
+			return fmt.Errorf("duplicate config source in priority: %q", source)
+		}
+		order = append(order, source)
+	}
+	for _, source := range defaultSourcePriority {
+		if !slices.Contains(order, source) {
+			order = append(order, source)
+		}
+	}
+	c.sourceOrder = order
+	return nil
+}
+
+// customPriority 是否设置了与默认顺序不同的优先级
+func (c *Config) customPriority() bool {
+	return c.sourceOrder != nil && !slices.Equal(c.sourceOrder, defaultSourcePriority)
+}
+
+// outranks 判断来源 a 的优先级是否高于 b
+func (c *Config) outranks(a, b Source) bool {
+	order := c.sourceOrder
+	if order == nil {
+		order = defaultSourcePriority
+	}
+	return slices.Index(order, a) < slices.Index(order, b)
+}
+
+// baseSource 主配置数据的来源：远程配置源加载成功时为 Remote，否则为 File
+func (c *Config) baseSource() Source {
+	if c.remoteLoaded.Load() {
+		return SourceRemote
+	}
+	return SourceFile
+}
+
+// flagKey 返回命令行标志对应的配置键
+func (c *Config) flagKey(f *pflag.Flag) string {
+	if c.pflagOptions.KeyMapper != nil {
+		return c.pflagOptions.KeyMapper(f)
+	}
+	return f.Name
+}
+
+// recordViperOrigins 按 viper 的合并顺序（Set > 已修改的标志 > 环境变量 > 配置文件 > 标志默认值）
+// 记录兼容引擎合并进数据的标志与环境变量键（调用者需持有 mu）
+func (c *Config) recordViperOrigins(flatData map[string]any) {
+	if c.viper == nil {
+		return
+	}
+	origins := make(map[string]Source)
+	for _, flagSet := range c.pflags {
+		flagSet.VisitAll(func(f *pflag.Flag) {
+			key := c.flagKey(f)
+			if _, exists := flatData[key]; !exists || c.viperOverridden(key) {
+				return
+			}
+			switch {
+			case f.Changed:
+				origins[key] = SourceFlags
+			case !c.viper.InConfig(key):
+				origins[key] = SourceDefaults
+			}
+		})
+	}
+	if c.viperMergesEnv() {
+		for key := range flatData {
+			if source, ok := origins[key]; ok && source == SourceFlags || c.viperOverridden(key) {
+				continue
+			}
+			if _, ok := c.findEnvValue(c.envOptions, key); ok {
+				origins[key] = SourceEnv
+			}
+		}
+	}
+	c.flagOrigins.Store(&origins)
+}
+
+// viperMergesEnv 兼容引擎是否将环境变量合并进主配置数据（见 initializeEnv）
+func (c *Config) viperMergesEnv() bool {
+	return c.viper != nil && c.envEnabled.Load() && !c.customPriority() && len(c.envAllow) == 0
+}
+
+// viperOverridden 判断键（或其上级键）是否通过 viper.Set 写入
+func (c *Config) viperOverridden(key string) bool {
+	for k := key; ; {
+		if _, ok := c.viperOverrides[k]; ok {
+			return true
+		}
+		idx := strings.LastIndexByte(k, '.')
+		if idx < 0 {
+			return false
+		}
+		k = k[:idx]
+	}
+}
+
+// originsFor 返回新数据快照的来源记录：重载时取本次加载记录的标志与环境变量来源，
+// 写入与数据源更新时沿用当前记录，并移除被写入的键（写入的值来自主配置）
+func (c *Config) originsFor(op RecordOp, data map[string]any, written []string) map[string]Source {
+	var base map[string]Source
+	switch op {
+	case RecordSet, RecordSource:
+		if p := c.keyOrigins.Load(); p != nil {
+			base = *p
+		}
+	default:
+		if p := c.flagOrigins.Load(); p != nil {
+			base = *p
+		}
+	}
+	origins := make(map[string]Source, len(base))
+	for key, source := range base {
+		if _, ok := data[key]; !ok || slices.ContainsFunc(written, func(w string) bool {
+			return key == w || strings.HasPrefix(key, w+".")
+		}) {
+			continue
+		}
+		origins[key] = source
+	}
+	return origins
+}
+
+// originOf 返回数据中某个键的来源；键不存在时 found 为 false
+func (c *Config) originOf(data map[string]any, key string) (source Source, found bool) {
+	_, exists := data[key]
+	if !exists {
+		prefix := key + "."
+		for k := range data {
+			if strings.HasPrefix(k, prefix) {
+				return c.baseSource(), true
+			}
+		}
+		return "", false
+	}
+	if origins := c.keyOrigins.Load(); origins != nil {
+		if source, ok := (*origins)[key]; ok {
+			return source, true
+		}
+	}
+	return c.baseSource(), true
+}
+
+// envWins 判断环境变量是否应覆盖数据中的值
+func (c *Config) envWins(data map[string]any, key string) bool {
+	if c.sourceOrder == nil || c.sourceOrder[0] == SourceEnv {
+		return true
+	}
+	origin, found := c.originOf(data, key)
+	return !found || origin == SourceEnv || c.outranks(SourceEnv, origin)
+}
+
+// applyEnvOverridesUnsafe 按优先级将环境变量覆盖写入 prefix 下的嵌套配置（调用者需持有 mu 读锁）。
+// prefix 为空时 settings 为完整配置，否则为 prefix 对应的子配置。
+func (c *Config) applyEnvOverridesUnsafe(prefix string, settings map[string]any) {
+	if !c.envEnabled.Load() {
+		return
+	}
+	if prefix != "" {
+		prefix += "."
+	}
+	data := c.loadData()
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		value, ok := c.lookupEnvWithOptions(c.envOptions, key)
+		if ok && c.envWins(data, key) {
+			setNestedMapValue(settings, strings.TrimPrefix(key, prefix), value)
+		}
+	}
+}
+
+// Origin 返回配置键当前生效值的来源；键不存在时返回 false
+func (c *Config) Origin(key string) (Source, bool) {
+	for _, candidate := range c.Explain(key) {
+		if candidate.Active {
+			return candidate.Source, true
+		}
+	}
+	return "", false
+}
+
+// Explain 按优先级列出各来源为配置键提供的值，并标记当前生效值。
+// 被更高优先级来源覆盖的文件值不单独保留，因此 File 仅在其为生效值时列出。
+func (c *Config) Explain(key string) []SourceValue {
+	if c.closed.Load() || key == "" {
+		return nil
+	}
+
+	c.mu.RLock()
+	flagSets := c.pflags
+	order := c.sourceOrder
+	c.mu.RUnlock()
+	if order == nil {
+		order = defaultSourcePriority
+	}
+
+	candidates := make(map[Source]any)
+	for _, flagSet := range flagSets {
+		flagSet.VisitAll(func(f *pflag.Flag) {
+			if c.flagKey(f) != key {
+				return
+			}
+			if f.Changed {
+				candidates[SourceFlags] = nativeFlagValue(f)
+			} else if !c.pflagOptions.OnlyChanged {
+				candidates[SourceDefaults] = nativeFlagValue(f)
+			}
+		})
+	}
+	if value, ok := c.lookupEnvValue(key, 0); ok {
+		candidates[SourceEnv] = value
+	}
+	if overlay := c.sourceValues.Load(); overlay != nil {
+		if value, ok := (*overlay)[key]; ok {
+			candidates[SourceRemote] = value
+		}
+	}
+
+	data := c.loadData()
+	active, found := c.originOf(data, key)
+	var value any
+	if found {
+		if v, ok := data[key]; ok {
+			value = v
+		} else {
+			value, _ = c.getNestedValueFromData(data, key)
+		}
+	}
+	if _, ok := candidates[SourceEnv]; ok && (!found || c.envWins(data, key)) {
+		// 兼容引擎默认将环境变量合并进数据，此时数据中的值只是环境变量的副本
+		if found && active != SourceEnv {
+			candidates[active] = value
+		}
+		active, found = SourceEnv, true
+	} else if found {
+		candidates[active] = value
+	}
+
+	result := make([]SourceValue, 0, len(candidates)+1)
+	// 覆盖值始终为最高优先级
+	override, overridden := c.overrideValue(key)
+	if overridden {
+		result = append(result, SourceValue{Source: SourceOverrides, Value: override, Active: true})
+	}
+	for _, source := range order {
+		if value, ok := candidates[source]; ok {
+			result = append(result, SourceValue{Source: source, Value: value, Active: !overridden && found && source == active})
+		}
+	}
+	return result
+}
@@ -0,0 +1,227 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+	"github.com/spf13/pflag"
+)
+
+func TestSourcePriorityDefaultEnvWins(t *testing.T) {
+	t.Setenv("PRIO_SERVER_HOST", "env-host")
+
+	cfg, err := New(WithContent("server:\n  host: file-host\n  port: 80\n"), WithEnv("PRIO"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("server.host"); got != "env-host" {
+		t.Fatalf("expected env-host, got %q", got)
+	}
+	if source, ok := cfg.Origin("server.host"); !ok || source != SourceEnv {
+		t.Fatalf("expected env origin, got %q, %v", source, ok)
+	}
+	if source, _ := cfg.Origin("server.port"); source != SourceFile {
+		t.Fatalf("expected file origin for port, got %q", source)
+	}
+
+	// 兼容引擎默认将环境变量合并进数据，被覆盖的文件值不再保留
+	explained := cfg.Explain("server.host")
+	if len(explained) != 1 || explained[0].Source != SourceEnv || !explained[0].Active {
+		t.Fatalf("unexpected explain result: %+v", explained)
+	}
+
+	// Unmarshal 与 Get 使用同一优先级
+	var target struct {
+		Server struct {
+			Host string `config:"host"`
+		} `config:"server"`
+	}
+	if err := cfg.Unmarshal(&target); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if target.Server.Host != "env-host" {
+		t.Fatalf("unmarshal should apply env override, got %q", target.Server.Host)
+	}
+	var section struct {
+		Host string `config:"host"`
+	}
+	if err := cfg.Unmarshal(&section, "server"); err != nil {
+		t.Fatalf("unmarshal section failed: %v", err)
+	}
+	if section.Host != "env-host" {
+		t.Fatalf("section unmarshal should apply env override, got %q", section.Host)
+	}
+	if _, ok := cfg.Origin("server.missing"); ok {
+		t.Fatalf("missing key should have no origin")
+	}
+}
+
+func TestSourcePriorityFileOverEnv(t *testing.T) {
+	t.Setenv("PRIO_SERVER_HOST", "env-host")
+	t.Setenv("PRIO_SERVER_NAME", "env-name")
+
+	cfg, err := New(
+		WithContent("server:\n  host: file-host\n"),
+		WithEnv("PRIO"),
+		WithSourcePriority(SourceFile, SourceEnv),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("server.host"); got != "file-host" {
+		t.Fatalf("file should outrank env, got %q", got)
+	}
+	if got := cfg.GetString("server.name"); got != "env-name" {
+		t.Fatalf("env should fill keys missing from file, got %q", got)
+	}
+	if source, _ := cfg.Origin("server.host"); source != SourceFile {
+		t.Fatalf("expected file origin, got %q", source)
+	}
+
+	var target struct {
+		Server struct {
+			Host string `config:"host"`
+		} `config:"server"`
+	}
+	if err := cfg.Unmarshal(&target); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if target.Server.Host != "file-host" {
+		t.Fatalf("unmarshal should keep file value, got %q", target.Server.Host)
+	}
+
+	want := []Source{SourceFile, SourceEnv, SourceFlags, SourceRemote, SourceDefaults}
+	got := cfg.SourcePriority()
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected priority order: %v", got)
+		}
+	}
+}
+
+func TestSourcePriorityFlags(t *testing.T) {
+	t.Setenv("PRIO_PORT", "7000")
+
+	for _, engine := range []Engine{CompatibilityEngine, NativeEngine} {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flags.Int("port", 8080, "port")
+		flags.String("level", "info", "log level")
+		if err := flags.Parse([]string{"--port=9000"}); err != nil {
+			t.Fatalf("parse flags failed: %v", err)
+		}
+
+		cfg, err := New(
+			WithEngine(engine),
+			WithContent("port: 80\nlevel: debug\n"),
+			WithEnv("PRIO"),
+			WithPFlags(flags),
+			WithSourcePriority(SourceFlags, SourceEnv, SourceRemote, SourceDefaults, SourceFile),
+		)
+		if err != nil {
+			t.Fatalf("create config failed: %v", err)
+		}
+
+		if got := cfg.GetInt("port"); got != 9000 {
+			t.Fatalf("engine %v: changed flag should outrank env, got %d", engine, got)
+		}
+		if source, _ := cfg.Origin("port"); source != SourceFlags {
+			t.Fatalf("engine %v: expected flags origin, got %q", engine, source)
+		}
+		// Defaults 排在 File 之前时，未修改的标志默认值覆盖文件值
+		if got := cfg.GetString("level"); got != "info" {
+			t.Fatalf("engine %v: expected flag default to outrank file, got %q", engine, got)
+		}
+		if source, _ := cfg.Origin("level"); source != SourceDefaults {
+			t.Fatalf("engine %v: expected defaults origin, got %q", engine, source)
+		}
+
+		explained := cfg.Explain("port")
+		if len(explained) != 2 || explained[0].Source != SourceFlags || explained[1].Source != SourceEnv || explained[1].Active {
+			t.Fatalf("engine %v: unexpected explain result: %+v", engine, explained)
+		}
+		_ = cfg.Close()
+	}
+}
+
+func TestSourcePriorityFileOverRemoteSource(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "routes.csv"), []byte("path\n/a\n"), 0o644); err != nil {
+		t.Fatalf("write table failed: %v", err)
+	}
+
+	cfg, err := New(
+		WithPath(tmpDir),
+		WithContent("routes: local\nlimits: local\n"),
+		WithTableSource("routes", "routes.csv"),
+		WithSourcePriority(SourceFile, SourceRemote),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("routes"); got != "local" {
+		t.Fatalf("file should outrank table source, got %#v", cfg.Get("routes"))
+	}
+	if source, _ := cfg.Origin("routes"); source != SourceFile {
+		t.Fatalf("expected file origin, got %q", source)
+	}
+	explained := cfg.Explain("routes")
+	if len(explained) != 2 || explained[0].Source != SourceFile || explained[1].Source != SourceRemote {
+		t.Fatalf("unexpected explain result: %+v", explained)
+	}
+
+	if _, err := New(WithSourcePriority(Source("vault"))); err == nil {
+		t.Fatalf("expected error for unknown source")
+	}
+	if _, err := New(WithSourcePriority(SourceEnv, SourceEnv)); err == nil {
+		t.Fatalf("expected error for duplicate source")
+	}
+}
+
+func TestSourcePriorityFileValueEqualToOverlay(t *testing.T) {
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "app.yaml")
+	if err := os.WriteFile(file, []byte("other: 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var value atomic.Value
+	value.Store("x")
+	layer := &sourceLayer{name: "test source", key: "mode", load: func() (any, error) { return value.Load(), nil }}
+
+	cfg, err := New(
+		WithFile(file),
+		func(c *Config) { c.addSource(layer) },
+		WithSourcePriority(SourceFile, SourceRemote),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if source, _ := cfg.Origin("mode"); source != SourceRemote || cfg.GetString("mode") != "x" {
+		t.Fatalf("expected remote value x, got %q from %q", cfg.GetString("mode"), source)
+	}
+
+	// 文件新增的值恰好等于数据源的值，仍应视为来自文件
+	if err := os.WriteFile(file, []byte("other: 1\nmode: x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg.reloadChangedFile(file, true)
+	if source, _ := cfg.Origin("mode"); source != SourceFile {
+		t.Fatalf("expected file origin after reload, got %q", source)
+	}
+
+	value.Store("y")
+	cfg.reloadSource(layer)
+	if got := cfg.GetString("mode"); got != "x" {
+		t.Fatalf("file should outrank the source, got %q", got)
+	}
+}
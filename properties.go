@@ -0,0 +1,273 @@
+package sysconf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// propertiesModes Java properties 格式的模式名（与 viper.SupportedExts 一致）
+var propertiesModes = []string{"properties", "props", "prop"}
+
+// codecRegistry 注册了 sysconf 自带编解码器的 viper 编解码表，
+// viper 已不再内置 properties、ini 与 hcl 支持，由此补齐；dotenv 替换为按层级映射键名的实现。
+var codecRegistry = func() *viper.DefaultCodecRegistry {
+	r := viper.NewCodecRegistry()
+	for _, mode := range propertiesModes {
+		_ = r.RegisterCodec(mode, propertiesCodec{})
+	}
+	_ = r.RegisterCodec("ini", iniCodec{})
+	for _, mode := range dotenvModes {
+		_ = r.RegisterCodec(mode, dotenvCodec{})
+	}
+	for _, mode := range hclModes {
+		_ = r.RegisterCodec(mode, hclCodec{})
+	}
+	return r
+}()
+
+// newViper 创建使用 sysconf 编解码表的 viper 实例
+func newViper() *viper.Viper {
+	return viper.NewWithOptions(viper.WithCodecRegistry(codecRegistry))
+}
+
+// propertiesCodec 实现 viper.Codec，负责 properties 格式的读写
+type propertiesCodec struct{}
+
+// Encode 实现 viper.Encoder
+func (propertiesCodec) Encode(v map[string]any) ([]byte, error) {
+	return marshalProperties(v)
+}
+
+// Decode 实现 viper.Decoder
+func (propertiesCodec) Decode(b []byte, v map[string]any) error {
+	nested, err := parseProperties(b)
+	if err != nil {
+		return err
+	}
+	for key, value := range nested {
+		v[key] = value
+	}
+	return nil
+}
+
+// parseProperties 解析 Java properties 内容。
+// 键中的点号映射为嵌套层级（a.b.c=1 → {a: {b: {c: "1"}}}），值一律按字符串保存；
+// 支持 #/! 注释、=/:/空白分隔符、行尾反斜杠续行与 \uXXXX 转义。
+func parseProperties(data []byte) (map[string]any, error) {
+	result := make(map[string]any)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		start := lineNo
+		line := strings.TrimLeft(scanner.Text(), " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		// 逻辑行：以奇数个反斜杠结尾时与下一行拼接，续行的前导空白忽略
+		for endsWithContinuation(line) && scanner.Scan() {
+			lineNo++
+			line = line[:len(line)-1] + strings.TrimLeft(scanner.Text(), " \t\f")
+		}
+		if endsWithContinuation(line) {
+			line = line[:len(line)-1]
+		}
+
+		rawKey, rawValue := splitPropertyLine(line)
+		key, err := unescapeProperty(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("properties line %d: %w", start, err)
+		}
+		value, err := unescapeProperty(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("properties line %d: %w", start, err)
+		}
+		if key == "" {
+			continue
+		}
+		setNestedMapValue(result, key, value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// endsWithContinuation 判断行尾是否为未转义的反斜杠
+func endsWithContinuation(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitPropertyLine 按第一个未转义的 =、: 或空白拆分键与值
+func splitPropertyLine(line string) (key, value string) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		if ch == '\\' {
+			i++
+			continue
+		}
+		if ch == '=' || ch == ':' || ch == ' ' || ch == '\t' || ch == '\f' {
+			end = i
+			break
+		}
+	}
+	key = line[:end]
+	rest := strings.TrimLeft(line[end:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	return key, rest
+}
+
+// unescapeProperty 处理 properties 转义序列
+func unescapeProperty(s string) (string, error) {
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if ch != '\\' || i+1 >= len(s) {
+			b.WriteByte(ch)
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+4 >= len(s) {
+				return "", fmt.Errorf("malformed \\u escape: %q", s[i-1:])
+			}
+			code, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", fmt.Errorf("malformed \\u escape: %q", s[i-1:i+5])
+			}
+			i += 4
+			r := rune(code)
+			// 代理对（如 \uD83D\uDE00）组合为单个字符
+			if utf16.IsSurrogate(r) && i+6 < len(s) && s[i+1] == '\\' && s[i+2] == 'u' {
+				if low, err := strconv.ParseUint(s[i+3:i+7], 16, 16); err == nil {
+					if combined := utf16.DecodeRune(r, rune(low)); combined != unicode.ReplacementChar {
+						r = combined
+						i += 6
+					}
+				}
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// marshalProperties 将配置序列化为 properties 格式：嵌套键以点号拼接，按键名排序，
+// 列表以逗号连接，非 ASCII 字符写为 \uXXXX 以兼容 JVM 的 ISO-8859-1 读取。
+func marshalProperties(settings map[string]any) ([]byte, error) {
+	flat := make(map[string]any, len(settings))
+	flattenSettings("", settings, flat)
+
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, key := range keys {
+		value, err := propertyString(flat[key])
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", key, err)
+		}
+		buf.WriteString(escapeProperty(key, true))
+		buf.WriteString(" = ")
+		buf.WriteString(escapeProperty(value, false))
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// propertyString 将值转换为 properties 文本
+func propertyString(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case []string:
+		return strings.Join(v, ","), nil
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			s, err := cast.ToStringE(item)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = s
+		}
+		return strings.Join(parts, ","), nil
+	default:
+		return cast.ToStringE(v)
+	}
+}
+
+// escapeProperty 转义键或值；键额外转义分隔符与空格，值仅转义前导空格
+func escapeProperty(s string, isKey bool) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			if isKey || i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		case ' ':
+			if isKey || i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		default:
+			if r < 0x20 || r > 0x7e {
+				for _, unit := range utf16.Encode([]rune{r}) {
+					fmt.Fprintf(&b, `\u%04X`, unit)
+				}
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,138 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+const propertiesSample = `# 数据源配置
+! 也是注释
+spring.datasource.url=jdbc:mysql://localhost:3306/app
+spring.datasource.username : admin
+server.port 8080
+app.greeting = \u4F60\u597D, \
+               world
+app.path = C:\\data\\app
+app.emoji = \uD83D\uDE00
+key\ with\ spaces = value
+`
+
+func TestParseProperties(t *testing.T) {
+	nested, err := parseProperties([]byte(propertiesSample))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	flat := make(map[string]any)
+	flattenSettings("", nested, flat)
+
+	expected := map[string]string{
+		"spring.datasource.url":      "jdbc:mysql://localhost:3306/app",
+		"spring.datasource.username": "admin",
+		"server.port":                "8080",
+		"app.greeting":               "你好, world",
+		"app.path":                   `C:\data\app`,
+		"app.emoji":                  "😀",
+		"key with spaces":            "value",
+	}
+	for key, want := range expected {
+		if got := flat[key]; got != want {
+			t.Fatalf("%s: expected %q, got %#v", key, want, got)
+		}
+	}
+
+	if _, err := parseProperties([]byte(`bad = \u12`)); err == nil {
+		t.Fatalf("expected malformed escape error")
+	}
+}
+
+func TestMarshalPropertiesRoundTrip(t *testing.T) {
+	settings := map[string]any{
+		"app": map[string]any{
+			"name":  "演示",
+			"hosts": []any{"a", "b"},
+			"lead":  " spaced",
+		},
+		"key with=sep": "x",
+		"port":         8080,
+	}
+	data, err := marshalProperties(settings)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `app.name = \u6F14\u793A`) {
+		t.Fatalf("expected unicode escapes:\n%s", data)
+	}
+
+	parsed, err := parseProperties(data)
+	if err != nil {
+		t.Fatalf("reparse failed: %v", err)
+	}
+	flat := make(map[string]any)
+	flattenSettings("", parsed, flat)
+	for key, want := range map[string]string{
+		"app.name":     "演示",
+		"app.hosts":    "a,b",
+		"app.lead":     " spaced",
+		"key with=sep": "x",
+		"port":         "8080",
+	} {
+		if flat[key] != want {
+			t.Fatalf("%s: expected %q, got %#v\n%s", key, want, flat[key], data)
+		}
+	}
+}
+
+func TestPropertiesModeRoundTrip(t *testing.T) {
+	for _, engine := range []Engine{CompatibilityEngine, NativeEngine} {
+		t.Run(engine.String(), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			configFile := filepath.Join(tmpDir, "application.properties")
+			if err := os.WriteFile(configFile, []byte(propertiesSample), 0o644); err != nil {
+				t.Fatalf("write config failed: %v", err)
+			}
+
+			cfg, err := New(
+				WithPath(tmpDir),
+				WithName("application"),
+				WithMode("properties"),
+				WithEngine(engine),
+				WithWriteDebounceDelay(0),
+			)
+			if err != nil {
+				t.Fatalf("create config failed: %v", err)
+			}
+			testutil.Cleanup(t, cfg.Close)
+
+			if got := cfg.GetInt("server.port"); got != 8080 {
+				t.Fatalf("expected port 8080, got %d", got)
+			}
+			if got := cfg.GetString("spring.datasource.username"); got != "admin" {
+				t.Fatalf("expected admin, got %q", got)
+			}
+			if err := cfg.Set("server.port", 9090); err != nil {
+				t.Fatalf("set failed: %v", err)
+			}
+
+			written, err := os.ReadFile(configFile)
+			if err != nil {
+				t.Fatalf("read config failed: %v", err)
+			}
+			if !strings.Contains(string(written), "server.port = 9090") {
+				t.Fatalf("expected updated port in written file:\n%s", written)
+			}
+			parsed, err := parseProperties(written)
+			if err != nil {
+				t.Fatalf("written file is not valid properties: %v", err)
+			}
+			flat := make(map[string]any)
+			flattenSettings("", parsed, flat)
+			if flat["app.greeting"] != "你好, world" {
+				t.Fatalf("greeting lost after write-back: %#v", flat["app.greeting"])
+			}
+		})
+	}
+}
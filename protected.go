@@ -0,0 +1,67 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrProtectedKey 写入受保护的配置键时未提供确认
+var ErrProtectedKey = errors.New("protected config key")
+
+// ChangeConfirmation 写入受保护键时需要显式传入的确认标记
+type ChangeConfirmation int
+
+// ConfirmDangerousChange 确认有意修改受保护的配置键
+const ConfirmDangerousChange ChangeConfirmation = 1
+
+// WithProtectedKeys 将匹配模式的配置键设为写保护，例如 WithProtectedKeys("database.*", "encryption.*")。
+// 模式语法与 WatchKeysGlob 相同（匹配某个键同时覆盖其子键）。受保护键通过 Set/SetMultiple 写入时返回
+// ErrProtectedKey，需改用 SetProtected 并传入 ConfirmDangerousChange；写入或覆盖包含受保护子键的父键同样受限。
+func WithProtectedKeys(patterns ...string) Option {
+	return func(c *Config) {
+		for _, pattern := range patterns {
+			if pattern = strings.TrimSpace(pattern); pattern != "" {
+				c.protectedKeys = append(c.protectedKeys, strings.Split(pattern, "."))
+			}
+		}
+	}
+}
+
+// SetProtected 写入配置值并允许修改受保护键，confirm 必须为 ConfirmDangerousChange
+func (c *Config) SetProtected(key string, value any, confirm ChangeConfirmation) error {
+	if confirm != ConfirmDangerousChange {
+		return fmt.Errorf("%w: %s requires ConfirmDangerousChange", ErrProtectedKey, key)
+	}
+	return c.set(key, value, true)
+}
+
+// checkProtected 检查写入 key 是否会修改受保护键；datasets 为写入前后的数据，用于覆盖被替换的子键
+func (c *Config) checkProtected(key string, datasets ...map[string]any) error {
+	if len(c.protectedKeys) == 0 {
+		return nil
+	}
+	if c.isProtected(key) {
+		return fmt.Errorf("%w: %s", ErrProtectedKey, key)
+	}
+	prefix := key + "."
+	for _, data := range datasets {
+		for k := range data {
+			if strings.HasPrefix(k, prefix) && c.isProtected(k) {
+				return fmt.Errorf("%w: %s (via %s)", ErrProtectedKey, k, key)
+			}
+		}
+	}
+	return nil
+}
+
+// isProtected 判断键是否匹配任一保护模式
+func (c *Config) isProtected(key string) bool {
+	segments := strings.Split(key, ".")
+	for _, pattern := range c.protectedKeys {
+		if matchKeyPattern(pattern, segments) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package sysconf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestProtectedKeys(t *testing.T) {
+	cfg, err := New(
+		WithContent("database:\n  host: db\n  port: 5432\nencryption:\n  key: secret\napp:\n  name: demo\n"),
+		WithProtectedKeys("database.*", "encryption.key"),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if err := cfg.Set("database.host", "other"); !errors.Is(err, ErrProtectedKey) {
+		t.Fatalf("expected ErrProtectedKey, got %v", err)
+	}
+	if got := cfg.GetString("database.host"); got != "db" {
+		t.Fatalf("protected value should be unchanged, got %q", got)
+	}
+	// 覆盖父键会替换受保护的子键
+	if err := cfg.Set("encryption", "none"); !errors.Is(err, ErrProtectedKey) {
+		t.Fatalf("expected parent write to be refused, got %v", err)
+	}
+	if err := cfg.SetMultiple(map[string]any{"app.name": "x", "database.port": 1}); !errors.Is(err, ErrProtectedKey) {
+		t.Fatalf("expected batch write to be refused, got %v", err)
+	}
+	if got := cfg.GetString("app.name"); got != "demo" {
+		t.Fatalf("batch should be rejected atomically, got %q", got)
+	}
+
+	if err := cfg.Set("app.name", "new"); err != nil {
+		t.Fatalf("unprotected key should be writable: %v", err)
+	}
+	if err := cfg.SetProtected("database.host", "other", 0); !errors.Is(err, ErrProtectedKey) {
+		t.Fatalf("expected missing confirmation to be refused, got %v", err)
+	}
+	if err := cfg.SetProtected("database.host", "other", ConfirmDangerousChange); err != nil {
+		t.Fatalf("confirmed write failed: %v", err)
+	}
+	if got := cfg.GetString("database.host"); got != "other" {
+		t.Fatalf("expected confirmed value, got %q", got)
+	}
+}
@@ -0,0 +1,97 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrTooManyWrites 对配置键的写入超过 WithSetRateLimit 设置的频率上限
+var ErrTooManyWrites = errors.New("too many config writes")
+
+// setRateRule 一条写入频率限制规则
+type setRateRule struct {
+	pattern   []string
+	perMinute int
+}
+
+// setRateLimiter 按键的令牌桶：每个键最多连续写入 perMinute 次，令牌按 perMinute/分钟 的速度恢复
+type setRateLimiter struct {
+	rules []setRateRule
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*rateBucket // 规则序号与键组成的桶标识 → 令牌桶
+}
+
+// rateBucket 单个键在某条规则下的令牌桶
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// WithSetRateLimit 限制匹配 pattern 的每个配置键通过 Set/SetMultiple 写入的频率，perMinute 为每分钟允许的次数
+// （允许短时间内连续写入 perMinute 次，之后按速率恢复）。超过上限的写入返回 ErrTooManyWrites 且不会生效，
+// 防止出错的组件循环调用 Set 时反复写盘并唤醒下游重载。模式语法同 WatchKeysGlob，可多次调用叠加规则；
+// 值未变化的写入、被验证拒绝的写入不计入次数。
+func WithSetRateLimit(pattern string, perMinute int) Option {
+	return func(c *Config) {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" || perMinute <= 0 {
+			return
+		}
+		if c.setRateLimiter == nil {
+			c.setRateLimiter = &setRateLimiter{now: time.Now, buckets: make(map[string]*rateBucket)}
+		}
+		c.setRateLimiter.rules = append(c.setRateLimiter.rules, setRateRule{
+			pattern:   strings.Split(pattern, "."),
+			perMinute: perMinute,
+		})
+	}
+}
+
+// allow 检查一批键是否都有剩余额度，全部允许时才扣减，使批量写入要么整体生效要么整体拒绝
+func (l *setRateLimiter) allow(keys ...string) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var taken []*rateBucket
+	for _, key := range slices.Sorted(slices.Values(keys)) {
+		segments := strings.Split(key, ".")
+		for i, rule := range l.rules {
+			if !matchKeyPattern(rule.pattern, segments) {
+				continue
+			}
+			bucket := l.bucket(fmt.Sprintf("%d:%s", i, key), rule, now)
+			if bucket.tokens < 1 {
+				return fmt.Errorf("%w: %s exceeds %d writes per minute", ErrTooManyWrites, key, rule.perMinute)
+			}
+			taken = append(taken, bucket)
+		}
+	}
+	for _, bucket := range taken {
+		bucket.tokens--
+	}
+	return nil
+}
+
+// bucket 返回按当前时间补充过令牌的桶（调用者需持有 l.mu）
+func (l *setRateLimiter) bucket(id string, rule setRateRule, now time.Time) *rateBucket {
+	capacity := float64(rule.perMinute)
+	bucket, ok := l.buckets[id]
+	if !ok {
+		bucket = &rateBucket{tokens: capacity, last: now}
+		l.buckets[id] = bucket
+		return bucket
+	}
+	bucket.tokens = min(capacity, bucket.tokens+now.Sub(bucket.last).Minutes()*capacity)
+	bucket.last = now
+	return bucket
+}
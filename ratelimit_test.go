@@ -0,0 +1,57 @@
+package sysconf
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestSetRateLimit(t *testing.T) {
+	cfg, err := New(
+		WithContent("status:\n  heartbeat: 0\napp:\n  name: demo\n"),
+		WithSetRateLimit("status.*", 3),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	now := time.Now()
+	cfg.setRateLimiter.now = func() time.Time { return now }
+
+	for i := 1; i <= 3; i++ {
+		if err := cfg.Set("status.heartbeat", i); err != nil {
+			t.Fatalf("write %d should be allowed: %v", i, err)
+		}
+	}
+	// 值未变化的写入不计入次数
+	if err := cfg.Set("status.heartbeat", 3); err != nil {
+		t.Fatalf("no-op write should be allowed: %v", err)
+	}
+	if err := cfg.Set("status.heartbeat", 4); !errors.Is(err, ErrTooManyWrites) {
+		t.Fatalf("expected ErrTooManyWrites, got %v", err)
+	}
+	if got := cfg.GetInt("status.heartbeat"); got != 3 {
+		t.Fatalf("rejected write should not apply, got %d", got)
+	}
+	if err := cfg.SetMultiple(map[string]any{"app.name": "other", "status.heartbeat": 5}); !errors.Is(err, ErrTooManyWrites) {
+		t.Fatalf("expected batch to be rejected, got %v", err)
+	}
+	if got := cfg.GetString("app.name"); got != "demo" {
+		t.Fatalf("rejected batch should not apply, got %q", got)
+	}
+	if err := cfg.Set("app.name", "unlimited"); err != nil {
+		t.Fatalf("unmatched key should not be limited: %v", err)
+	}
+
+	// 令牌按速率恢复：20 秒恢复 1 次额度
+	now = now.Add(20 * time.Second)
+	if err := cfg.Set("status.heartbeat", 6); err != nil {
+		t.Fatalf("write after refill should be allowed: %v", err)
+	}
+	if err := cfg.Set("status.heartbeat", 7); !errors.Is(err, ErrTooManyWrites) {
+		t.Fatalf("expected ErrTooManyWrites after single refill, got %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package sysconf
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"sync"
+	"time"
+)
+
+// RecordOp 变更记录的操作类型
+type RecordOp string
+
+const (
+	RecordLoad     RecordOp = "load"     // 初始加载
+	RecordSet      RecordOp = "set"      // Set/SetMultiple 等写入
+	RecordReload   RecordOp = "reload"   // 文件或远程配置重载
+	RecordSource   RecordOp = "source"   // 附加数据源（表格、命令等）更新
+	RecordRollback RecordOp = "rollback" // 验证或应用器失败后的回滚
+)
+
+// RecordEntry 变更记录中的一条记录，只包含相对上一状态发生变化的键
+type RecordEntry struct {
+	Time    time.Time         `json:"time"`
+	Op      RecordOp          `json:"op"`
+	Set     map[string]any    `json:"set,omitempty"`     // 新增或修改的扁平键及其值
+	Deleted []string          `json:"deleted,omitempty"` // 被移除的扁平键
+	Sources map[string]Source `json:"sources,omitempty"` // Set 中各键的来源
+}
+
+// recorder 以 JSON Lines 追加写入变更记录
+type recorder struct {
+	path string
+
+	mu     sync.Mutex
+	file   *os.File
+	closed bool
+	failed bool // 打开或写入失败后只记录一次错误日志
+}
+
+// WithRecorder 将每次生效的加载、重载、Set 与数据源更新以 JSON Lines 追加到 path，
+// 每条记录包含时间、操作类型、变化的键及其来源，可用 Replay 还原任意时刻生效的配置，
+// 便于排查"03:14 时到底是哪份配置在生效"。敏感键（见 IsSensitiveKey）的值以 "******" 记录。
+// 环境变量在读取时解析，不写入记录。
+func WithRecorder(path string) Option {
+	return func(c *Config) {
+		if path == "" {
+			c.recorder = nil
+			return
+		}
+		c.recorder = &recorder{path: path}
+	}
+}
+
+// recordChange 记录一次配置数据变化（调用者需持有 mu）
+func (c *Config) recordChange(op RecordOp, before, after map[string]any) {
+	rec := c.recorder
+	if rec == nil {
+		return
+	}
+	if len(before) == 0 && op != RecordRollback {
+		op = RecordLoad
+	}
+	changed := diffValueKeys(before, after)
+	if len(changed) == 0 {
+		return
+	}
+
+	var origins map[string]Source
+	if p := c.keyOrigins.Load(); p != nil {
+		origins = *p
+	}
+
+	entry := RecordEntry{Time: time.Now(), Op: op}
+	for _, key := range changed {
+		value, ok := after[key]
+		if !ok {
+			entry.Deleted = append(entry.Deleted, key)
+			continue
+		}
+		if entry.Set == nil {
+			entry.Set = make(map[string]any)
+			entry.Sources = make(map[string]Source)
+		}
+		if c.IsSensitiveKey(key) {
+			value = redactedValue
+		}
+		entry.Set[key] = value
+		if source, ok := origins[key]; ok {
+			entry.Sources[key] = source
+		} else {
+			entry.Sources[key] = SourceFile
+		}
+	}
+	if err := rec.append(entry); err != nil {
+		c.logger.Errorf("Failed to write config recording %s: %v", rec.path, err)
+	}
+}
+
+// append 写入一条记录；首次写入时打开文件，写入失败后不再重试以免每次变更都记录错误
+func (r *recorder) append(entry RecordEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || r.failed {
+		return nil
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if r.file == nil {
+		r.file, err = os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			r.failed = true
+			return err
+		}
+	}
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		r.failed = true
+		return err
+	}
+	return nil
+}
+
+// close 关闭记录文件，之后的变更不再记录
+func (r *recorder) close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// ReadRecording 读取 WithRecorder 写入的全部记录（按写入顺序）
+func ReadRecording(path string) ([]RecordEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var entries []RecordEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxURLSourceSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry RecordEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("recording %s line %d: %w", path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read recording %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// Replay 按记录还原 at 时刻生效的配置（嵌套结构），at 为零值时还原最后的状态。
+// 记录中的敏感值为 "******"，数值按 JSON 解码为 float64。
+func Replay(path string, at time.Time) (map[string]any, error) {
+	entries, err := ReadRecording(path)
+	if err != nil {
+		return nil, err
+	}
+	state := make(map[string]any)
+	for _, entry := range entries {
+		if !at.IsZero() && entry.Time.After(at) {
+			break
+		}
+		// 加载记录包含完整配置，进程重启后追加的记录不沿用上一次运行的状态
+		if entry.Op == RecordLoad {
+			clear(state)
+		}
+		for _, key := range entry.Deleted {
+			delete(state, key)
+		}
+		maps.Copy(state, entry.Set)
+	}
+	return nestFlatData(state), nil
+}
@@ -0,0 +1,85 @@
+package sysconf
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestRecorderReplay(t *testing.T) {
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "config.rec")
+
+	cfg, err := New(
+		WithContent("app:\n  name: demo\n  port: 8080\ndatabase:\n  password: hunter2\n"),
+		WithRecorder(logPath),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if err := cfg.Set("app.port", 9090); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	between := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	if err := cfg.Set("app.name", "renamed"); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	entries, err := ReadRecording(logPath)
+	if err != nil {
+		t.Fatalf("read recording failed: %v", err)
+	}
+	if len(entries) != 3 || entries[0].Op != RecordLoad || entries[1].Op != RecordSet {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	if entries[0].Set["database.password"] != redactedValue {
+		t.Fatalf("sensitive value should be redacted, got %v", entries[0].Set["database.password"])
+	}
+	if entries[1].Sources["app.port"] != SourceFile {
+		t.Fatalf("unexpected source: %v", entries[1].Sources)
+	}
+
+	state, err := Replay(logPath, between)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	app := state["app"].(map[string]any)
+	if app["name"] != "demo" || app["port"] != float64(9090) {
+		t.Fatalf("unexpected state at %v: %v", between, state)
+	}
+
+	latest, err := Replay(logPath, time.Time{})
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	if latest["app"].(map[string]any)["name"] != "renamed" {
+		t.Fatalf("unexpected latest state: %v", latest)
+	}
+}
+
+func TestReplayRestartResetsState(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "config.rec")
+	for _, content := range []string{"old: 1\nkept: a\n", "kept: b\n"} {
+		cfg, err := New(WithContent(content), WithRecorder(logPath))
+		if err != nil {
+			t.Fatalf("create config failed: %v", err)
+		}
+		if err := cfg.Close(); err != nil {
+			t.Fatalf("close failed: %v", err)
+		}
+	}
+
+	state, err := Replay(logPath, time.Time{})
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	if _, ok := state["old"]; ok || state["kept"] != "b" {
+		t.Fatalf("replay should start from the latest load, got %v", state)
+	}
+}
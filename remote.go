@@ -0,0 +1,169 @@
+package sysconf
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// remoteFetchFunc 拉取远程配置；内容未变化时 changed 为 false
+type remoteFetchFunc func(ctx context.Context) (data []byte, changed bool, err error)
+
+// pollRemote 按间隔拉取远程配置，内容变化时走校验后替换的重载流程，直到 stopChan 关闭
+func (c *Config) pollRemote(stopChan <-chan struct{}, interval time.Duration, origin string, fetch remoteFetchFunc) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			select {
+			case <-stopChan:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		data, changed, err := fetch(ctx)
+		cancel()
+		if err != nil {
+			c.logger.Errorf("Failed to poll remote config %s: %v", origin, err)
+			c.emitHealthEventFor(origin, HealthEventReloadFailed, "remote config poll failed, keeping last known good config", err)
+			continue
+		}
+		if !changed {
+			continue
+		}
+		c.reloadRemote(origin, data)
+	}
+}
+
+// reloadRemote 以远程内容重载配置：先校验、后替换，并触发变更回调。返回是否已应用
+func (c *Config) reloadRemote(origin string, data []byte) bool {
+	return c.reloadRemoteWith(origin, func() error { return c.applyRemoteContentLocked(data) })
+}
+
+// reloadRemoteWith 在持有 mu 时执行 apply，成功后刷新缓存、发出健康事件并触发变更回调
+func (c *Config) reloadRemoteWith(origin string, apply func() error) bool {
+	start := time.Now()
+	c.mu.Lock()
+	oldData, oldCache, oldOrigins, oldRemote := c.loadData(), c.loadReadCache(), c.keyOrigins.Load(), c.remoteLoaded.Load()
+	if err := apply(); err != nil {
+		c.mu.Unlock()
+		recordReloadOperation(time.Since(start), err)
+		c.logger.Errorf("Rejected remote config from %s: %v", origin, err)
+		c.emitHealthEventFor(origin, HealthEventReloadFailed, "remote config rejected, keeping last known good config", err)
+		return false
+	}
+	ticket, callbacks := c.watchCallbacksLocked()
+	c.mu.Unlock()
+
+	c.invalidateLookupCache()
+	c.invalidateCache()
+	if err := c.applyReload(oldData, oldCache, oldOrigins, oldRemote); err != nil {
+		c.runWatchCallbacks(ticket, nil)
+		recordReloadOperation(time.Since(start), err)
+		c.logger.Errorf("Config apply failed for %s, rolled back: %v", origin, err)
+		c.emitHealthEventFor(origin, HealthEventReloadFailed, "config apply failed, rolled back to previous config", err)
+		return false
+	}
+	recordReloadOperation(time.Since(start), nil)
+	c.logger.Infof("Config reloaded from %s", origin)
+	c.emitHealthEventFor(origin, HealthEventReloaded, "remote config reloaded", nil)
+
+	c.runWatchCallbacks(ticket, callbacks)
+	return true
+}
+
+// applyRemoteContentLocked 校验远程内容并替换当前配置（调用者需持有 mu）
+func (c *Config) applyRemoteContentLocked(data []byte) error {
+	if err := c.checkContent(data); err != nil {
+		return err
+	}
+	nested, err := c.parseConfigBytes(data)
+	if err != nil {
+		return fmt.Errorf("parse remote config: %w", err)
+	}
+	if err := c.validateRemoteLocked(nested); err != nil {
+		return err
+	}
+
+	prev := c.remoteLoaded.Swap(true)
+	if err := c.readConfigBytes(data, true); err != nil {
+		c.remoteLoaded.Store(prev)
+		return fmt.Errorf("load remote config: %w", err)
+	}
+	if !c.isNative() {
+		c.viperLoaded = true
+		c.syncFromViperUnsafe()
+	}
+	return nil
+}
+
+// applyRemoteSettingsLocked 校验嵌套配置并替换当前配置（调用者需持有 mu）
+func (c *Config) applyRemoteSettingsLocked(nested map[string]any) error {
+	if err := c.validateRemoteLocked(nested); err != nil {
+		return err
+	}
+
+	prev := c.remoteLoaded.Swap(true)
+	if c.isNative() {
+		flatData := make(map[string]any, len(nested)*12)
+		flattenSettings("", nested, flatData)
+		c.applyNativeFlags(flatData)
+		c.storeData(flatData)
+		return nil
+	}
+
+	// 兼容引擎经由 YAML 重建 viper 配置，保持与文件重载一致的键处理
+	data, err := yaml.Marshal(nested)
+	if err != nil {
+		c.remoteLoaded.Store(prev)
+		return fmt.Errorf("encode remote config: %w", err)
+	}
+	c.viper.SetConfigType("yaml")
+	err = c.viper.ReadConfig(bytes.NewReader(data))
+	if c.mode != "" {
+		c.viper.SetConfigType(c.viperConfigType())
+	}
+	if err != nil {
+		c.remoteLoaded.Store(prev)
+		return fmt.Errorf("load remote config: %w", err)
+	}
+	c.viperLoaded = true
+	c.syncFromViperUnsafe()
+	return nil
+}
+
+// validateRemoteLocked 使用全部验证器校验候选配置（调用者需持有 mu）
+func (c *Config) validateRemoteLocked(nested map[string]any) error {
+	for _, validator := range c.validators {
+		if err := validator.Validate(nested); err != nil {
+			recordValidationFailure()
+			return fmt.Errorf("validator %s: %w", validator.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// parseConfigBytes 按当前格式将配置内容解析为嵌套 map，不修改当前配置
+func (c *Config) parseConfigBytes(data []byte) (map[string]any, error) {
+	if slices.Contains(nativeSupportedModes, c.mode) {
+		return parseContentMap(data, c.mode)
+	}
+	v := newViper()
+	v.SetConfigType(c.viperConfigType())
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return v.AllSettings(), nil
+}
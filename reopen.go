@@ -0,0 +1,168 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// reopenTarget Reopen 切换前的文件定位与数据，用于失败时回滚
+type reopenTarget struct {
+	path           string
+	name           string
+	mode           string
+	configFileName string
+	viper          *viper.Viper
+	viperLoaded    bool
+	data           map[string]any
+	readCache      map[string]any
+	origins        *map[string]Source
+	remote         bool
+	fileInfo       *FileInfo
+}
+
+// Reopen 在运行时将配置切换到新的文件（如运维通过管理命令指向新的配置），path 为目录，name 为不含扩展名的文件名，
+// mode 为空时沿用当前格式。新文件不存在时按 WithContent 创建。
+//
+// 切换是原子的：新配置加载并通过全部验证器后才会生效，否则保持原文件与原配置并返回错误。
+// 切换前会先将待写入的更改刷新到原文件；已注册的验证器、Watch 回调与应用器保持不变，
+// 切换成功后应用器与 Watch 回调按一次完整变更执行。
+func (c *Config) Reopen(path, name string, mode Mode) error {
+	if c.closed.Load() {
+		return ErrAlreadyClosed
+	}
+	if name == "" {
+		return errors.New("reopen requires a config file name")
+	}
+	if c.sqlWriteBack() || c.urlSource != nil || c.objectSource != nil {
+		return errors.New("reopen is not supported with remote config sources")
+	}
+
+	if err := c.flushPendingWritesWithPending(false); err != nil {
+		return fmt.Errorf("flush pending writes before reopen: %w", err)
+	}
+
+	start := time.Now()
+	c.mu.Lock()
+	prev := reopenTarget{
+		path:           c.path,
+		name:           c.name,
+		mode:           c.mode,
+		configFileName: c.configFileName,
+		viper:          c.viper,
+		viperLoaded:    c.viperLoaded,
+		data:           c.loadData(),
+		readCache:      c.loadReadCache(),
+		origins:        c.keyOrigins.Load(),
+		remote:         c.remoteLoaded.Load(),
+		fileInfo:       c.fileInfo.Load(),
+	}
+	watching := c.watchStarted
+	c.stopFileWatcherLocked()
+
+	c.path, c.name, c.configFileName = path, name, ""
+	if mode != "" {
+		c.mode = string(mode)
+	}
+	err := c.reopenLocked()
+	if err == nil {
+		err = c.validateRemoteLocked(c.reconstructNestedStructure(deepCloneMap(c.loadData())))
+	}
+	if err != nil {
+		c.rollbackReopenLocked(prev)
+		if watching {
+			if werr := c.startWatchLocked(); werr != nil {
+				c.logger.Errorf("Failed to restart config watch after reopen rollback: %v", werr)
+			}
+		}
+		c.mu.Unlock()
+		recordReloadOperation(time.Since(start), err)
+		c.logger.Errorf("Failed to reopen config %s, keeping %s: %v", name, prev.name, err)
+		return fmt.Errorf("reopen config: %w", err)
+	}
+	c.lastUpdate = time.Now()
+	c.invalidateLookupCache()
+	if watching {
+		if err := c.startWatchLocked(); err != nil {
+			c.logger.Errorf("Failed to restart config watch after reopen: %v", err)
+		}
+	}
+	ticket, callbacks := c.watchCallbacksLocked()
+	configFile := c.configFilePath()
+	c.mu.Unlock()
+
+	c.invalidateCache()
+	if err := c.applyReload(prev.data, prev.readCache, prev.origins, prev.remote); err != nil {
+		c.runWatchCallbacks(ticket, nil)
+		// 应用器拒绝时恢复原文件定位，applyReload 已恢复原数据
+		c.mu.Lock()
+		c.stopFileWatcherLocked()
+		c.rollbackReopenLocked(prev)
+		if watching {
+			if werr := c.startWatchLocked(); werr != nil {
+				c.logger.Errorf("Failed to restart config watch after reopen rollback: %v", werr)
+			}
+		}
+		c.mu.Unlock()
+		recordReloadOperation(time.Since(start), err)
+		return fmt.Errorf("reopen config: %w", err)
+	}
+	recordReloadOperation(time.Since(start), nil)
+	c.logEvent(InfoLevel, "Config reopened", F("file", configFile))
+	c.emitHealthEvent(HealthEventReloaded, "config reopened from "+configFile, nil)
+
+	c.runWatchCallbacks(ticket, callbacks)
+	return nil
+}
+
+// reopenLocked 按当前文件定位重新加载配置：兼容引擎使用新的 viper 实例，避免旧文件的值残留（调用者需持有 mu）
+func (c *Config) reopenLocked() error {
+	c.remoteLoaded.Store(false)
+	if c.isNative() {
+		if err := c.configureNativeFileLocked(); err != nil {
+			return err
+		}
+		if err := c.initSection(); err != nil {
+			return err
+		}
+		return c.loadNativeConfigUnsafe()
+	}
+
+	c.viper = newViper()
+	c.viperLoaded = true
+	if err := c.initializeEnv(); err != nil {
+		return err
+	}
+	c.bindPFlagsLocked()
+	if err := c.configureViperFileLocked(); err != nil {
+		return err
+	}
+	if err := c.initSection(); err != nil {
+		return err
+	}
+	if err := c.loadOrCreateConfig(); err != nil {
+		return err
+	}
+	if c.viperLoaded {
+		c.syncFromViperUnsafe()
+	}
+	return nil
+}
+
+// rollbackReopenLocked 恢复切换前的文件定位与数据（调用者需持有 mu）
+func (c *Config) rollbackReopenLocked(prev reopenTarget) {
+	c.path, c.name, c.mode, c.configFileName = prev.path, prev.name, prev.mode, prev.configFileName
+	c.viper, c.viperLoaded = prev.viper, prev.viperLoaded
+	c.remoteLoaded.Store(prev.remote)
+	c.keyOrigins.Store(prev.origins)
+	if c.recorder != nil {
+		c.recordChange(RecordRollback, c.loadData(), prev.data)
+	}
+	c.data.Store(deepCloneMap(prev.data))
+	c.readCache.Store(deepCloneMap(prev.readCache))
+	c.fileInfo.Store(prev.fileInfo)
+	c.invalidateLookupCache()
+	c.commits.notify()
+}
@@ -0,0 +1,122 @@
+package sysconf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestReopenSwitchesFile(t *testing.T) {
+	for _, engine := range []Engine{CompatibilityEngine, NativeEngine} {
+		t.Run(engine.String(), func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "old.yaml"), []byte("port: 80\nonly_old: true\n"), 0o644); err != nil {
+				t.Fatalf("write old config: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "new.json"), []byte(`{"port": 8080}`), 0o644); err != nil {
+				t.Fatalf("write new config: %v", err)
+			}
+
+			cfg, err := New(WithPath(dir), WithName("old"), WithMode(YAML), WithEngine(engine), WithWriteDebounceDelay(0), WithWatchDebounce(10*time.Millisecond))
+			if err != nil {
+				t.Fatalf("new config: %v", err)
+			}
+			testutil.Cleanup(t, cfg.Close)
+
+			var fired atomic.Int32
+			cfg.Watch(func() { fired.Add(1) })
+			cfg.AddValidateFunc(func(config map[string]any) error {
+				if config["port"] == nil {
+					return errors.New("port required")
+				}
+				return nil
+			})
+
+			if err := cfg.Reopen(dir, "new", JSON); err != nil {
+				t.Fatalf("reopen: %v", err)
+			}
+			if got := cfg.GetInt("port"); got != 8080 {
+				t.Fatalf("expected port from new file, got %d", got)
+			}
+			if cfg.IsSet("only_old") {
+				t.Fatalf("values from the old file must not survive reopen")
+			}
+			if fired.Load() != 1 {
+				t.Fatalf("expected one full-change callback, got %d", fired.Load())
+			}
+			if len(cfg.GetValidators()) != 1 {
+				t.Fatalf("validators must stay registered")
+			}
+
+			// 外部修改新文件应触发已注册的监听（临时文件 + 重命名，避免截断写入的中间状态被防抖吞掉）
+			time.Sleep(50 * time.Millisecond)
+			tmp := filepath.Join(dir, "new.json.tmp")
+			if err := os.WriteFile(tmp, []byte(`{"port": 7070}`), 0o644); err != nil {
+				t.Fatalf("write replacement config: %v", err)
+			}
+			if err := os.Rename(tmp, filepath.Join(dir, "new.json")); err != nil {
+				t.Fatalf("replace new config: %v", err)
+			}
+			deadline := time.Now().Add(5 * time.Second)
+			for cfg.GetInt("port") != 7070 {
+				if time.Now().After(deadline) {
+					t.Fatalf("watcher did not follow the reopened file")
+				}
+				time.Sleep(20 * time.Millisecond)
+			}
+
+			if err := cfg.Set("port", 9090); err != nil {
+				t.Fatalf("set after reopen: %v", err)
+			}
+			data, _ := os.ReadFile(filepath.Join(dir, "new.json"))
+			old, _ := os.ReadFile(filepath.Join(dir, "old.yaml"))
+			if !strings.Contains(string(data), "9090") || strings.Contains(string(old), "9090") {
+				t.Fatalf("writes should go to the new file; new=%q old=%q", data, old)
+			}
+		})
+	}
+}
+
+func TestReopenRollsBackOnValidationFailure(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "good.yaml"), []byte("port: 80\n"), 0o644); err != nil {
+		t.Fatalf("write good config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bad.yaml"), []byte("host: x\n"), 0o644); err != nil {
+		t.Fatalf("write bad config: %v", err)
+	}
+
+	cfg, err := New(WithPath(dir), WithName("good"), WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	cfg.AddValidateFunc(func(config map[string]any) error {
+		if config["port"] == nil {
+			return errors.New("port required")
+		}
+		return nil
+	})
+
+	if err := cfg.Reopen(dir, "bad", ""); err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if cfg.GetInt("port") != 80 || cfg.IsSet("host") {
+		t.Fatalf("config should be rolled back, got %v", cfg.AllSettings())
+	}
+	if err := cfg.Set("port", 81); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "good.yaml")); !strings.Contains(string(data), "81") {
+		t.Fatalf("writes should still go to the original file, got %q", data)
+	}
+	if err := cfg.Reopen(dir, "", ""); err == nil {
+		t.Fatalf("expected error for empty name")
+	}
+}
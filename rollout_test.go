@@ -0,0 +1,88 @@
+package sysconf
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestInRollout(t *testing.T) {
+	cfg, err := New(WithContent("rollout:\n  feature_x:\n    percent: 25\n"), WithInstanceID("node-1"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	status := cfg.RolloutStatus("feature_x")
+	if status.State != RolloutInProgress || status.Percent != 25 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	want := rolloutBucket("feature_x", "node-1") < 2500
+	if cfg.InRollout("feature_x") != want {
+		t.Fatalf("InRollout should follow the instance bucket")
+	}
+	if cfg.RolloutStatus("unknown").State != RolloutPending || cfg.InRollout("unknown") {
+		t.Fatalf("unconfigured rollout should be pending and exclude all instances")
+	}
+
+	if err := cfg.AdvanceRollout("feature_x", 100); err != nil {
+		t.Fatalf("advance failed: %v", err)
+	}
+	if status := cfg.RolloutStatus("feature_x"); status.State != RolloutComplete || !status.Included {
+		t.Fatalf("complete rollout should include every instance: %+v", status)
+	}
+	if err := cfg.AbortRollout("feature_x"); err != nil {
+		t.Fatalf("abort failed: %v", err)
+	}
+	if status := cfg.RolloutStatus("feature_x"); status.State != RolloutAborted || status.Included || status.Percent != 100 {
+		t.Fatalf("aborted rollout should exclude every instance: %+v", status)
+	}
+	if err := cfg.AdvanceRollout("feature_x", 10); err != nil || cfg.RolloutStatus("feature_x").State != RolloutInProgress {
+		t.Fatalf("advance should clear the abort flag: %v", err)
+	}
+
+	if err := cfg.AdvanceRollout("feature_x", 120); err == nil {
+		t.Fatalf("expected error for percent out of range")
+	}
+	if err := cfg.AbortRollout("a.b"); err == nil {
+		t.Fatalf("expected error for invalid rollout name")
+	}
+}
+
+func TestRolloutBucketDistribution(t *testing.T) {
+	const fleet = 2000
+	included := func(name string, percent int) map[string]bool {
+		result := make(map[string]bool)
+		for i := range fleet {
+			id := fmt.Sprintf("host-%d", i)
+			if rolloutBucket(name, id) < percent*rolloutBuckets/100 {
+				result[id] = true
+			}
+		}
+		return result
+	}
+
+	at25 := included("feature_x", 25)
+	if n := len(at25); n < fleet*20/100 || n > fleet*30/100 {
+		t.Fatalf("expected about 25%% of the fleet, got %d/%d", n, fleet)
+	}
+	// 提高比例时已纳入的实例保持纳入
+	at50 := included("feature_x", 50)
+	for id := range at25 {
+		if !at50[id] {
+			t.Fatalf("instance %s dropped out when rollout advanced", id)
+		}
+	}
+	// 不同发布按名称加盐，选中的实例不同
+	other := included("feature_y", 25)
+	same := 0
+	for id := range at25 {
+		if other[id] {
+			same++
+		}
+	}
+	if same == len(at25) {
+		t.Fatalf("different rollouts should select different instances")
+	}
+}
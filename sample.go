@@ -0,0 +1,50 @@
+package sysconf
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GenerateOptions 示例配置生成选项
+type GenerateOptions struct {
+	// WithComments 是否输出字段说明、类型与校验规则注释（仅 YAML 格式支持注释）
+	WithComments bool
+	// Format 输出格式（yaml/json/toml），为空时使用当前配置的格式
+	Format string
+}
+
+// GenerateSample 根据结构体定义生成完整的示例配置文件内容。
+// 字段值取 default 标签中的默认值，敏感字段（secret:"true" 或 password/token 等键名）留空，
+// 适用于 CLI 的 --init-config 等初始化流程。
+func (c *Config) GenerateSample(obj any, opts GenerateOptions) ([]byte, error) {
+	doc, err := DocumentStruct(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = c.mode
+	}
+
+	switch format {
+	case "", "yaml", "yml":
+		return doc.renderYAML(opts.WithComments)
+	case "json", "toml":
+		content, err := doc.renderYAML(false)
+		if err != nil {
+			return nil, err
+		}
+		var settings map[string]any
+		if err := yaml.Unmarshal(content, &settings); err != nil {
+			return nil, fmt.Errorf("build sample settings: %w", err)
+		}
+		if settings == nil {
+			settings = map[string]any{}
+		}
+		return marshalSettings(settings, format)
+	default:
+		return nil, fmt.Errorf("unsupported sample format: %s", format)
+	}
+}
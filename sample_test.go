@@ -0,0 +1,65 @@
+package sysconf
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type sampleAppConfig struct {
+	Name     string `config:"name" default:"demo" desc:"应用名称"`
+	Password string `config:"password" default:"changeme" desc:"管理员密码"`
+	APIKey   string `config:"api" default:"k-123" secret:"true"`
+	Token    string `config:"token" default:"visible" secret:"false"`
+	Server   struct {
+		Port int `config:"port" default:"8080" desc:"监听端口"`
+	} `config:"server"`
+}
+
+func TestGenerateSampleWithComments(t *testing.T) {
+	cfg := newTestConfig(t)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	out, err := cfg.GenerateSample(&sampleAppConfig{}, GenerateOptions{WithComments: true})
+	require.NoError(t, err)
+	text := string(out)
+	require.Contains(t, text, "# 应用名称")
+	require.Contains(t, text, "# 监听端口")
+	require.Contains(t, text, "secret")
+
+	var parsed map[string]any
+	require.NoError(t, yaml.Unmarshal(out, &parsed))
+	require.Equal(t, "demo", parsed["name"])
+	require.Equal(t, "", parsed["password"], "secret inferred from key must be blank")
+	require.Equal(t, "", parsed["api"], "secret tag must be blank")
+	require.Equal(t, "visible", parsed["token"], "secret:\"false\" overrides key heuristics")
+	require.Equal(t, 8080, parsed["server"].(map[string]any)["port"])
+
+	plain, err := cfg.GenerateSample(&sampleAppConfig{}, GenerateOptions{})
+	require.NoError(t, err)
+	require.False(t, strings.Contains(string(plain), "#"))
+}
+
+func TestGenerateSampleFormats(t *testing.T) {
+	cfg := newTestConfig(t)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	out, err := cfg.GenerateSample(&sampleAppConfig{}, GenerateOptions{Format: "json"})
+	require.NoError(t, err)
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal(out, &parsed))
+	require.Equal(t, "demo", parsed["name"])
+
+	out, err = cfg.GenerateSample(&sampleAppConfig{}, GenerateOptions{Format: "toml"})
+	require.NoError(t, err)
+	loaded, err := New(WithMode("toml"), WithContent(string(out)))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = loaded.Close() })
+	require.Equal(t, 8080, loaded.GetInt("server.port"))
+
+	_, err = cfg.GenerateSample(&sampleAppConfig{}, GenerateOptions{Format: "xml"})
+	require.Error(t, err)
+}
@@ -0,0 +1,24 @@
+package sysconf
+
+import "sync"
+
+// scratchMapMaxKeys 超过该键数的临时 map 不放回复用池，避免长期持有个别超大配置的内存
+const scratchMapMaxKeys = 4096
+
+// scratchMapPool 临时 map 复用池。Set 构建候选数据、重载时扁平化 viper 数据等中间结果
+// 只在单次调用内使用，最终由 storeDataOp 复制后发布，因此可在调用结束后归还复用，降低高频写入时的 GC 压力
+var scratchMapPool = sync.Pool{New: func() any { return make(map[string]any) }}
+
+// getScratchMap 从复用池获取一个空的临时 map
+func getScratchMap() map[string]any {
+	return scratchMapPool.Get().(map[string]any)
+}
+
+// putScratchMap 清空临时 map 并归还复用池；调用方之后不得再使用或发布该 map
+func putScratchMap(m map[string]any) {
+	if m == nil || len(m) > scratchMapMaxKeys {
+		return
+	}
+	clear(m)
+	scratchMapPool.Put(m)
+}
@@ -0,0 +1,103 @@
+package sysconf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+// 复用的临时 map 不会被发布：Set 之后先前读取的数据与新数据互不影响
+func TestScratchMapsAreNotPublished(t *testing.T) {
+	cfg, err := New(WithContent("a: 1\nb: 2\n"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	before := cfg.loadData()
+	if err := cfg.Set("a", 10); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	afterFirst := cfg.loadData()
+	if err := cfg.SetMultiple(map[string]any{"b": 20, "c": 30}); err != nil {
+		t.Fatalf("set multiple failed: %v", err)
+	}
+
+	if len(before) != 2 || before["a"] != 1 {
+		t.Fatalf("previously loaded data was modified: %v", before)
+	}
+	if len(afterFirst) != 2 || afterFirst["a"] != 10 || afterFirst["b"] != 2 {
+		t.Fatalf("published data was reused by a later Set: %v", afterFirst)
+	}
+	if got := cfg.GetInt("a") + cfg.GetInt("b") + cfg.GetInt("c"); got != 60 {
+		t.Fatalf("unexpected values after batch set, sum %d", got)
+	}
+
+	m := getScratchMap()
+	m["x"] = 1
+	putScratchMap(m)
+	if reused := getScratchMap(); len(reused) != 0 {
+		t.Fatalf("scratch map must be cleared before reuse: %v", reused)
+	}
+}
+
+// benchContent 写入基准测试使用的配置内容
+const benchContent = `database:
+  host: localhost
+  port: 5432
+  connection:
+    host: db.example.com
+    timeout: 30
+server:
+  port: 8080
+  debug: true
+  timeout: 30s
+  hosts: [host1, host2, host3]
+`
+
+// benchPersistedConfig 创建带配置文件的实例，写盘延迟足够长，基准测试只衡量 Set 路径本身
+func benchPersistedConfig(b *testing.B) *Config {
+	b.Helper()
+	cfg, err := New(
+		WithPath(b.TempDir()),
+		WithName("bench"),
+		WithMode("yaml"),
+		WithContent(benchContent),
+		WithWriteDebounceDelay(time.Hour),
+	)
+	if err != nil {
+		b.Fatalf("create config failed: %v", err)
+	}
+	b.Cleanup(func() { _ = cfg.Close() })
+	return cfg
+}
+
+func BenchmarkSetPersisted(b *testing.B) {
+	cfg := benchPersistedConfig(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		_ = cfg.Set("bench.key", i)
+	}
+}
+
+func BenchmarkSetMultiplePersisted(b *testing.B) {
+	cfg := benchPersistedConfig(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		_ = cfg.SetMultiple(map[string]any{"bench.a": i, "bench.b": i + 1})
+	}
+}
+
+func BenchmarkSyncFromViper(b *testing.B) {
+	cfg := benchPersistedConfig(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		cfg.mu.Lock()
+		cfg.syncFromViperUnsafe()
+		cfg.mu.Unlock()
+	}
+}
@@ -0,0 +1,153 @@
+package sysconf
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+// sectionModes 支持 WithSection 的配置格式（需要能够完整解析并重新序列化整份文件）
+var sectionModes = []string{"yaml", "yml", "json", "toml", "properties", "props", "prop"}
+
+// WithSection 只使用配置文件中名为 name 的顶级段作为配置根，适用于多个服务共享一份公司级配置文件：
+//
+//	# company.yaml
+//	billing:
+//	  port: 8080
+//	search:
+//	  port: 9090
+//
+//	cfg, _ := sysconf.New(sysconf.WithFile("company.yaml"), sysconf.WithSection("billing"))
+//	cfg.GetInt("port") // 8080
+//
+// Set 写回时仅替换该段，其它段保持不变（写入前会重新读取文件，以保留其它服务期间所做的修改）。
+// 文件中缺少该段时以 WithContent 作为该段的默认内容。支持 yaml、json、toml 与 properties 格式。
+func WithSection(name string) Option {
+	return func(c *Config) {
+		c.section = name
+	}
+}
+
+// Section 返回 WithSection 指定的配置段名称，未指定时为空
+func (c *Config) Section() string {
+	return c.section
+}
+
+// initSection 校验配置段选项
+func (c *Config) initSection() error {
+	if c.section == "" {
+		return nil
+	}
+	if strings.Contains(c.section, ".") {
+		return fmt.Errorf("section %q must be a top-level key", c.section)
+	}
+	if !slices.Contains(sectionModes, c.mode) {
+		return fmt.Errorf("section is not supported for config mode %s (supported: %s)",
+			c.mode, strings.Join(sectionModes, ", "))
+	}
+	return nil
+}
+
+// extractSection 从整份文件内容中取出配置段，并记录其它段供写回时合并
+func (c *Config) extractSection(data []byte) ([]byte, error) {
+	if c.section == "" {
+		return data, nil
+	}
+	full, err := parseContentMap(data, c.mode)
+	if err != nil {
+		return nil, err
+	}
+	section, rest, err := c.splitSection(full)
+	if err != nil {
+		return nil, err
+	}
+	c.sectionRest.Store(&rest)
+	if section == nil {
+		if c.content == "" {
+			return marshalSettings(map[string]any{}, c.mode)
+		}
+		c.logger.Debugf("Section %s not found in config file, using default content", c.section)
+		return []byte(c.content), nil
+	}
+	return marshalSettings(section, c.mode)
+}
+
+// splitSection 将整份配置拆分为配置段与其它段；section 为 nil 表示文件中不存在该段
+func (c *Config) splitSection(full map[string]any) (section, rest map[string]any, err error) {
+	rest = maps.Clone(full)
+	if rest == nil {
+		rest = make(map[string]any)
+	}
+	value, ok := rest[c.section]
+	delete(rest, c.section)
+	if !ok || value == nil {
+		return nil, rest, nil
+	}
+	section, ok = value.(map[string]any)
+	if !ok {
+		return nil, nil, fmt.Errorf("section %q is not a mapping (got %T)", c.section, value)
+	}
+	return section, rest, nil
+}
+
+// wrapSection 将配置段放回整份配置中，其它段取自文件的最新内容（读取失败时使用加载时的快照）
+func (c *Config) wrapSection(settings map[string]any) map[string]any {
+	if c.section == "" {
+		return settings
+	}
+	var rest map[string]any
+	if latest, ok := c.currentSectionRest(); ok {
+		rest = latest
+	} else if cached := c.sectionRest.Load(); cached != nil {
+		rest = maps.Clone(*cached)
+	}
+	if rest == nil {
+		rest = make(map[string]any)
+	}
+	rest[c.section] = settings
+	return rest
+}
+
+// currentSectionRest 重新读取配置文件中除配置段以外的内容
+func (c *Config) currentSectionRest() (map[string]any, bool) {
+	configFile := c.configFilePath()
+	if configFile == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, false
+	}
+	if c.cryptoOptions.Enabled && c.crypto != nil {
+		if data, _, err = c.decryptContent(data); err != nil {
+			return nil, false
+		}
+	}
+	if data, err = decompressContent(data); err != nil {
+		return nil, false
+	}
+	full, err := parseContentMap(data, c.mode)
+	if err != nil {
+		return nil, false
+	}
+	_, rest, err := c.splitSection(full)
+	if err != nil {
+		return nil, false
+	}
+	c.sectionRest.Store(&rest)
+	return maps.Clone(rest), true
+}
+
+// sectionDefaultContent 生成首次创建文件时写入的内容：WithContent 作为配置段
+func (c *Config) sectionDefaultContent() ([]byte, error) {
+	if c.section == "" {
+		return []byte(c.content), nil
+	}
+	settings, err := parseContentMap([]byte(c.content), c.mode)
+	if err != nil {
+		return nil, fmt.Errorf("parse default content: %w", err)
+	}
+	return marshalSettings(c.wrapSection(settings), c.mode)
+}
@@ -0,0 +1,122 @@
+package sysconf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+	"gopkg.in/yaml.v3"
+)
+
+const companyYAML = `billing:
+  port: 8080
+  db:
+    host: billing-db
+search:
+  port: 9090
+  replicas: 3
+`
+
+func TestWithSectionReadAndWriteBack(t *testing.T) {
+	for _, engine := range []Engine{CompatibilityEngine, NativeEngine} {
+		t.Run(engine.String(), func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "company.yaml")
+			if err := os.WriteFile(file, []byte(companyYAML), 0o644); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			cfg, err := New(WithFile(file), WithSection("billing"), WithEngine(engine), WithWriteDebounceDelay(0))
+			if err != nil {
+				t.Fatalf("new config: %v", err)
+			}
+			testutil.Cleanup(t, cfg.Close)
+
+			if got := cfg.GetInt("port"); got != 8080 {
+				t.Fatalf("expected section port 8080, got %d", got)
+			}
+			if got := cfg.GetString("db.host"); got != "billing-db" {
+				t.Fatalf("expected db.host billing-db, got %q", got)
+			}
+			if cfg.IsSet("search.port") || cfg.IsSet("billing.port") {
+				t.Fatalf("other sections must not be visible: %v", cfg.Keys())
+			}
+
+			// 模拟其它服务在加载后修改了自己的段
+			other := strings.Replace(companyYAML, "replicas: 3", "replicas: 5", 1)
+			if err := os.WriteFile(file, []byte(other), 0o644); err != nil {
+				t.Fatalf("rewrite config: %v", err)
+			}
+
+			if err := cfg.Set("port", 8181); err != nil {
+				t.Fatalf("set: %v", err)
+			}
+
+			var full map[string]map[string]any
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("read back: %v", err)
+			}
+			if err := yaml.Unmarshal(data, &full); err != nil {
+				t.Fatalf("parse written file: %v\n%s", err, data)
+			}
+			if full["billing"]["port"] != 8181 {
+				t.Fatalf("billing.port not written under section: %s", data)
+			}
+			if full["search"]["port"] != 9090 || full["search"]["replicas"] != 5 {
+				t.Fatalf("other sections must be left untouched: %s", data)
+			}
+		})
+	}
+}
+
+func TestWithSectionDefaultContent(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "company.yaml")
+	if err := os.WriteFile(file, []byte("search:\n  port: 9090\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := New(WithFile(file), WithSection("billing"), WithContent("port: 7070\n"), WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if got := cfg.GetInt("port"); got != 7070 {
+		t.Fatalf("missing section should fall back to default content, got %d", got)
+	}
+	if cfg.Section() != "billing" {
+		t.Fatalf("unexpected section %q", cfg.Section())
+	}
+
+	// 文件不存在时以段的形式创建
+	fresh := filepath.Join(t.TempDir(), "fresh.yaml")
+	cfg2, err := New(WithFile(fresh), WithSection("billing"), WithContent("port: 7070\n"))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg2.Close)
+	data, _ := os.ReadFile(fresh)
+	if !strings.Contains(string(data), "billing:") || cfg2.GetInt("port") != 7070 {
+		t.Fatalf("default content should be created under section, got %q", data)
+	}
+}
+
+func TestWithSectionErrors(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "company.yaml")
+	if err := os.WriteFile(file, []byte("billing: 1\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := New(WithFile(file), WithSection("billing")); err == nil {
+		t.Fatalf("expected error for non-mapping section")
+	}
+	if _, err := New(WithPath(dir), WithName("app"), WithMode("ini"), WithSection("billing")); err == nil {
+		t.Fatalf("expected error for unsupported mode")
+	}
+	_, err := New(WithFile(file), WithSection("a.b"))
+	if err == nil || errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected error for nested section name, got %v", err)
+	}
+}
@@ -2,6 +2,8 @@ package sysconf
 
 import (
 	"fmt"
+	"maps"
+	"reflect"
 	"slices"
 	"strings"
 	"time"
@@ -13,6 +15,11 @@ var defaultFieldValidator = validation.NewDefaultValidator()
 
 // Set 设置配置值
 func (c *Config) Set(key string, value any) error {
+	return c.set(key, value, false)
+}
+
+// set 写入配置值，confirmed 表示调用方已确认修改受保护键
+func (c *Config) set(key string, value any, confirmed bool) (err error) {
 	if c.closed.Load() {
 		return ErrAlreadyClosed
 	}
@@ -27,6 +34,26 @@ func (c *Config) Set(key string, value any) error {
 		recordErrorOperation()
 		return ErrInvalidKey
 	}
+	if err := c.checkWritableFormat(); err != nil {
+		c.logger.Errorf("Change to key %s not applied: %v", key, err)
+		recordErrorOperation()
+		return err
+	}
+	key = c.resolveKey(c.loadData(), key)
+
+	// 规范化与审批在验证与存储之前执行；审批可能较慢，因此不持有写锁
+	prepared, err := c.prepareChanges(map[string]any{key: value})
+	if err != nil {
+		c.logger.Errorf("Change to key %s not applied: %v", key, err)
+		recordErrorOperation()
+		return err
+	}
+	value = prepared[key]
+
+	// 快速路径：值未变化时无需加锁、验证与写盘
+	if c.setIsNoop(key, value) {
+		return nil
+	}
 
 	// 统一持锁，避免并发写导致的状态丢失
 	c.mu.Lock()
@@ -38,15 +65,19 @@ func (c *Config) Set(key string, value any) error {
 	// 复制当前数据，准备生成候选快照
 	currentData := c.loadData()
 	var snap *snapshot
-	if c.name != "" {
+	if c.name != "" || c.sqlWriteBack() {
+		// 已发布的数据与读缓存不会被原地修改，快照直接引用即可，回滚时再深拷贝
 		snap = &snapshot{
-			data:      deepCloneMap(currentData),
-			readCache: deepCloneMap(c.loadReadCache()),
+			data:      currentData,
+			readCache: c.loadReadCache(),
+			origins:   c.keyOrigins.Load(),
 			timestamp: time.Now(),
 		}
 	}
 
-	newData := make(map[string]any, len(currentData)+1)
+	// 候选数据由 storeDataOp 复制后发布，本身只在本次调用内使用
+	newData := getScratchMap()
+	defer putScratchMap(newData)
 
 	// 移除当前键以及同前缀的旧值，确保写入后数据一致
 	prefix := key + "."
@@ -60,6 +91,21 @@ func (c *Config) Set(key string, value any) error {
 	// 合并新值（自动展开嵌套结构）
 	c.mergeValueIntoData(newData, key, value)
 
+	if !confirmed {
+		if err := c.checkProtected(key, currentData, newData); err != nil {
+			c.logger.Errorf("Refused to modify protected key %s", key)
+			recordErrorOperation()
+			c.mu.Unlock()
+			return err
+		}
+	}
+	if err := c.checkFrozen(newData); err != nil {
+		c.logger.Errorf("Refused to modify frozen key %s: %v", key, err)
+		recordErrorOperation()
+		c.mu.Unlock()
+		return err
+	}
+
 	// 拷贝验证器切片，避免锁内重复加锁
 	validators := make([]ConfigValidator, len(c.validators))
 	copy(validators, c.validators)
@@ -68,17 +114,38 @@ func (c *Config) Set(key string, value any) error {
 	if err := c.validateSingleFieldWithData(key, value, validators, newData); err != nil {
 		c.logger.Errorf("Validation failed for key %s: %v", key, err)
 		recordErrorOperation()
+		recordValidationFailure()
+		c.mu.Unlock()
+		return err
+	}
+	if err := c.setRateLimiter.allow(key); err != nil {
+		c.logger.Errorf("Refused to write key %s: %v", key, err)
+		recordErrorOperation()
 		c.mu.Unlock()
 		return err
 	}
 
 	// 验证通过后再原子提交数据与 viper
-	c.storeData(newData)
-	c.viper.Set(key, value)
+	c.storeDataOp(RecordSet, newData, key)
+	c.viperSet(key, value)
+	ticket, syncCallbacks, hasSync := c.syncWatchCallbacksLocked()
 	c.mu.Unlock()
+	if hasSync {
+		defer func() { c.runSyncWatchCallbacks(ticket, syncCallbacks, err) }()
+	}
 
+	c.invalidateLookupCache()
 	c.invalidateCache()
 
+	// 启用数据库回写时写入数据表而不是配置文件
+	if c.sqlWriteBack() {
+		if err := c.writeSQLSource(currentData, map[string]any{key: value}); err != nil {
+			c.restoreSnapshot(snap)
+			return fmt.Errorf("write failed and rolled back: %w", err)
+		}
+		return nil
+	}
+
 	// 如果配置文件名称不存在则不保存文件
 	if c.name == "" {
 		c.logger.Debugf("Config file name not set, skipping write")
@@ -96,44 +163,66 @@ func (c *Config) Set(key string, value any) error {
 	return nil
 }
 
-// flushPendingWritesWithPending 以统一锁顺序（cacheBuildMu -> mu.RLock -> writeMu）刷新待写入配置。
+// setIsNoop 无锁判断 Set 是否不会改变任何状态：键已存在且值相同（标量或切片），
+// 并且该值不来自环境变量、命令行标志或附加数据源（这些情况下 Set 会把值持久化到配置文件）。
+func (c *Config) setIsNoop(key string, value any) bool {
+	switch value.(type) {
+	case nil, map[string]any, map[string]string, map[any]any:
+		return false
+	}
+	if c.envEnabled.Load() || c.sqlWriteBack() || c.isFileMissing() {
+		return false
+	}
+	current, ok := c.loadData()[key]
+	if !ok || !reflect.DeepEqual(current, sanitizeValue(value)) {
+		return false
+	}
+	if origins := c.keyOrigins.Load(); origins != nil {
+		if _, notFile := (*origins)[key]; notFile {
+			return false
+		}
+	}
+	return true
+}
+
+// flushPendingWritesWithPending 按全局锁顺序（writeMu → cacheBuildMu → mu，见 lockorder.go）刷新待写入配置。
 // markPending 表示在写入锁内应当标记有待写入（用于 Set 调用路径）。
+// 先获取 writeMu 再短暂持有状态锁获取快照，写盘期间不持有 mu，读写路径不会被慢速写入阻塞。
 func (c *Config) flushPendingWritesWithPending(markPending bool) error {
-	// 按顺序获取锁，防止与 snapshotAllSettings 产生循环等待
-	c.cacheBuildMu.Lock()
-	c.mu.Lock()
 	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
 
+	c.lockState()
 	if markPending {
 		c.pendingWrites = true
 	}
-
 	if !c.pendingWrites {
+		c.unlockState()
 		c.logger.Debugf("No pending changes, skipping write operation")
-		c.writeMu.Unlock()
-		c.mu.Unlock()
-		c.cacheBuildMu.Unlock()
 		return nil
 	}
-
-	c.logger.Infof("Writing config file")
-
-	// 在持锁后获取配置快照，确保一致性
+	if err := c.checkWritableFormatLocked(); err != nil {
+		c.pendingWrites = false
+		c.nextFlushAt = time.Time{}
+		c.unlockState()
+		return err
+	}
+	// 在持锁时获取配置快照并标记已消费当前待写入状态，允许新的写入在锁外排队
 	settingsSnapshot := c.snapshotAllSettings()
-	// 标记已消费当前待写入状态，允许新的写入在锁外排队
 	c.pendingWrites = false
+	c.nextFlushAt = time.Time{}
+	c.flushing.Store(true)
+	c.unlockState()
+	defer c.flushing.Store(false)
 
-	// 释放读写锁，避免写盘阻塞写路径
-	c.mu.Unlock()
-	c.cacheBuildMu.Unlock()
-
-	if err := c.writeConfigFileWithData(settingsSnapshot); err != nil {
+	c.logger.Infof("Writing config file")
+	writeStart := time.Now()
+	err := c.writeConfigFileWithData(settingsSnapshot)
+	recordNamedOperation("write", time.Since(writeStart))
+	if err != nil {
 		c.logger.Errorf("Failed to write config file: %v", err)
-		c.writeMu.Unlock()
 		return err
 	}
-	// 写入完成后再释放写入锁，保证写入顺序
-	c.writeMu.Unlock()
 	c.logger.Infof("Config file written successfully")
 	return nil
 }
@@ -149,9 +238,9 @@ func (c *Config) scheduleDebouncedWrite() error {
 	}
 
 	// 标记待写入并重置定时器
-	c.cacheBuildMu.Lock()
-	c.mu.Lock()
+	c.lockState()
 	c.pendingWrites = true
+	c.nextFlushAt = time.Now().Add(c.writeDelay)
 	if c.writeTimer == nil {
 		c.writeTimer = time.AfterFunc(c.writeDelay, func() {
 			if err := c.flushPendingWritesWithPending(false); err != nil {
@@ -162,11 +251,32 @@ func (c *Config) scheduleDebouncedWrite() error {
 		c.writeTimer.Stop()
 		c.writeTimer.Reset(c.writeDelay)
 	}
-	c.mu.Unlock()
-	c.cacheBuildMu.Unlock()
+	c.unlockState()
 	return nil
 }
 
+// PendingWrites 报告是否有尚未写入磁盘的配置更改（包括等待延迟写入与正在写入的更改），
+// 便于关闭流程与管理接口确认配置是否已落盘
+func (c *Config) PendingWrites() bool {
+	if c.flushing.Load() {
+		return true
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.pendingWrites
+}
+
+// NextFlushAt 返回下一次延迟写入的计划时间；没有安排中的延迟写入时返回 false。
+// 每次写入都会重置延迟（见 WithWriteDebounceDelay），因此该时间可能随后续写入推迟。
+func (c *Config) NextFlushAt() (time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.pendingWrites || c.nextFlushAt.IsZero() {
+		return time.Time{}, false
+	}
+	return c.nextFlushAt, true
+}
+
 // validateSingleFieldWithData 基于候选数据验证单个字段，避免先写后回滚
 func (c *Config) validateSingleFieldWithData(
 	key string,
@@ -174,6 +284,11 @@ func (c *Config) validateSingleFieldWithData(
 	validators []ConfigValidator,
 	currentData map[string]any,
 ) error {
+	// 已注册枚举的键只接受枚举名称或已注册的值
+	if err := validateEnumKey(key, value); err != nil {
+		return err
+	}
+
 	// 没有验证器时使用默认验证器做基础类型校验
 	if len(validators) == 0 {
 		return defaultFieldValidator.ValidateField(key, value)
@@ -202,7 +317,20 @@ func (c *Config) validateSingleFieldWithData(
 	}
 
 	// 执行验证
+	var candidate map[string]any
 	for _, validator := range validators {
+		// 策略引擎等整体验证器基于完整的候选配置验证
+		if whole, ok := validator.(interface{ ValidatesWholeConfig() bool }); ok && whole.ValidatesWholeConfig() {
+			if candidate == nil {
+				candidate = nestFlatData(currentData)
+			}
+			if err := validator.Validate(candidate); err != nil {
+				c.logger.Errorf("Config validation failed for key %s with validator %s: %v", key, validator.GetName(), err)
+				return fmt.Errorf("config validation failed (%s): %w", validator.GetName(), err)
+			}
+			continue
+		}
+
 		if !c.validatorSupportsField(validator, key) {
 			continue
 		}
@@ -221,7 +349,9 @@ func (c *Config) validateSingleFieldWithData(
 		}
 	}
 
-	c.logger.Debugf("Field validation passed for key %s (%d validators checked)", key, len(validators))
+	if c.debugEnabled() {
+		c.logger.Debugf("Field validation passed for key %s (%d validators checked)", key, len(validators))
+	}
 	return nil
 }
 
@@ -251,7 +381,9 @@ func (c *Config) validatorSupportsField(validator ConfigValidator, key string) b
 	}
 
 	// 保守策略：未知验证器默认不支持
-	c.logger.Debugf("Unknown validator type %s, skipping field %s", validator.GetName(), key)
+	if c.debugEnabled() {
+		c.logger.Debugf("Unknown validator type %s, skipping field %s", validator.GetName(), key)
+	}
 	return false
 }
 
@@ -267,7 +399,7 @@ func (c *Config) validateSingleFieldWithStructValidator(
 
 	// 验证结构化规则
 	for _, rule := range rules {
-		if err := validation.Validate(value, rule); err != nil {
+		if err := validation.ValidateField(key, value, rule); err != nil {
 			return fmt.Errorf("field '%s': %w", key, err)
 		}
 	}
@@ -290,7 +422,7 @@ func (c *Config) validateSingleFieldWithStructValidator(
 //
 // 返回值:
 //   - error: 如果任何键值对验证失败，返回错误并回滚所有更改
-func (c *Config) SetMultiple(values map[string]any) error {
+func (c *Config) SetMultiple(values map[string]any) (err error) {
 	if c.closed.Load() {
 		return ErrAlreadyClosed
 	}
@@ -313,6 +445,20 @@ func (c *Config) SetMultiple(values map[string]any) error {
 		}
 	}
 
+	if err := c.checkWritableFormat(); err != nil {
+		c.logger.Errorf("Batch change not applied: %v", err)
+		recordErrorOperation()
+		return err
+	}
+
+	// 键解析、规范化与审批在验证与存储之前执行，不修改调用方传入的映射
+	values, err = c.prepareChanges(c.resolveKeys(values))
+	if err != nil {
+		c.logger.Errorf("Batch change not applied: %v", err)
+		recordErrorOperation()
+		return fmt.Errorf("batch set failed: %w", err)
+	}
+
 	c.mu.Lock()
 	if c.closed.Load() {
 		c.mu.Unlock()
@@ -322,15 +468,18 @@ func (c *Config) SetMultiple(values map[string]any) error {
 	// 复制当前数据
 	currentData := c.loadData()
 	var snap *snapshot
-	if c.name != "" {
+	if c.name != "" || c.sqlWriteBack() {
+		// 已发布的数据与读缓存不会被原地修改，快照直接引用即可，回滚时再深拷贝
 		snap = &snapshot{
-			data:      deepCloneMap(currentData),
-			readCache: deepCloneMap(c.loadReadCache()),
+			data:      currentData,
+			readCache: c.loadReadCache(),
+			origins:   c.keyOrigins.Load(),
 			timestamp: time.Now(),
 		}
 	}
 
-	newData := make(map[string]any, len(currentData)+len(values))
+	newData := getScratchMap()
+	defer putScratchMap(newData)
 
 	// 收集所有需要移除的键前缀
 	prefixes := make([]string, 0, len(values))
@@ -365,6 +514,21 @@ func (c *Config) SetMultiple(values map[string]any) error {
 		c.mergeValueIntoData(newData, key, value)
 	}
 
+	for key := range values {
+		if err := c.checkProtected(key, currentData, newData); err != nil {
+			c.logger.Errorf("Refused to modify protected key %s in batch operation", key)
+			recordErrorOperation()
+			c.mu.Unlock()
+			return fmt.Errorf("batch set failed at key '%s': %w", key, err)
+		}
+	}
+	if err := c.checkFrozen(newData); err != nil {
+		c.logger.Errorf("Refused to modify frozen key in batch operation: %v", err)
+		recordErrorOperation()
+		c.mu.Unlock()
+		return fmt.Errorf("batch set failed: %w", err)
+	}
+
 	// 拷贝验证器切片
 	validators := make([]ConfigValidator, len(c.validators))
 	copy(validators, c.validators)
@@ -374,20 +538,42 @@ func (c *Config) SetMultiple(values map[string]any) error {
 		if err := c.validateSingleFieldWithData(key, value, validators, newData); err != nil {
 			c.logger.Errorf("Validation failed for key %s in batch operation: %v", key, err)
 			recordErrorOperation()
+			recordValidationFailure()
 			c.mu.Unlock()
 			return fmt.Errorf("batch set failed at key '%s': %w", key, err)
 		}
 	}
+	if err := c.setRateLimiter.allow(slices.Collect(maps.Keys(values))...); err != nil {
+		c.logger.Errorf("Refused batch write: %v", err)
+		recordErrorOperation()
+		c.mu.Unlock()
+		return fmt.Errorf("batch set failed: %w", err)
+	}
 
 	// 验证通过后原子提交
-	c.storeData(newData)
+	c.storeDataOp(RecordSet, newData, slices.Collect(maps.Keys(values))...)
 	for key, value := range values {
-		c.viper.Set(key, value)
+		c.viperSet(key, value)
 	}
+	ticket, syncCallbacks, hasSync := c.syncWatchCallbacksLocked()
 	c.mu.Unlock()
+	if hasSync {
+		defer func() { c.runSyncWatchCallbacks(ticket, syncCallbacks, err) }()
+	}
 
+	c.invalidateLookupCache()
 	c.invalidateCache()
 
+	// 启用数据库回写时写入数据表而不是配置文件
+	if c.sqlWriteBack() {
+		if err := c.writeSQLSource(currentData, values); err != nil {
+			c.restoreSnapshot(snap)
+			return fmt.Errorf("batch write failed and rolled back: %w", err)
+		}
+		c.logger.Infof("Batch set completed: %d keys updated", len(values))
+		return nil
+	}
+
 	// 如果配置文件名称不存在则不保存文件
 	if c.name == "" {
 		c.logger.Debugf("Config file name not set, skipping write")
@@ -419,7 +605,7 @@ func (c *Config) SetEnvPrefix(prefix string) error {
 	}
 	c.envOptions.Prefix = prefix
 	c.envOptions.Enabled = prefix != "" // 如果有前缀就启用环境变量
-	c.envEnabled.Store(c.envOptions.Enabled)
+	c.envEnabled.Store(c.envOptions.Enabled || len(c.envSections) > 0)
 	c.mu.Unlock()
 
 	// 重新初始化（不在锁内调用以避免死锁）
@@ -258,3 +258,37 @@ func TestSet(t *testing.T) {
 func TestSetEnvPrefix(t *testing.T) {
 	t.Skip("环境变量设置测试依赖于文件系统，暂时跳过。")
 }
+
+func TestPendingWritesAndNextFlushAt(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg, err := New(
+		WithPath(tmpDir),
+		WithName("flush"),
+		WithMode("yaml"),
+		WithContent("a: 1\n"),
+		WithWriteDebounceDelay(time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+
+	assert.False(t, cfg.PendingWrites())
+	_, scheduled := cfg.NextFlushAt()
+	assert.False(t, scheduled)
+
+	before := time.Now()
+	assert.NoError(t, cfg.Set("a", 2))
+	assert.True(t, cfg.PendingWrites())
+	at, scheduled := cfg.NextFlushAt()
+	assert.True(t, scheduled)
+	assert.False(t, at.Before(before.Add(time.Hour)), "flush must be scheduled one debounce delay after the write")
+
+	assert.NoError(t, cfg.Close())
+	assert.False(t, cfg.PendingWrites())
+	_, scheduled = cfg.NextFlushAt()
+	assert.False(t, scheduled)
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "flush.yaml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "a: 2")
+}
@@ -0,0 +1,146 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ShadowChange 候选配置相对当前配置的单个键变化
+type ShadowChange struct {
+	Key      string // 扁平配置键
+	OldValue any    // 当前值（Added 时为 nil）
+	NewValue any    // 候选值（Removed 时为 nil）
+	Added    bool   // 候选配置新增的键
+	Removed  bool   // 候选配置删除的键
+}
+
+// ShadowApplier 单个应用器的演练结果
+type ShadowApplier struct {
+	Name    string // 应用器名称
+	Skipped bool   // 应用器未注册演练函数（见 RegisterApplierDryRun），未执行
+	Err     error  // 演练失败原因
+}
+
+// ShadowReport Shadow 的评估报告
+type ShadowReport struct {
+	Changes          []ShadowChange  // 按键排序的变化列表
+	ValidationErrors []error         // 各验证器的失败原因，为空表示通过全部验证
+	Appliers         []ShadowApplier // 按执行顺序排列的应用器演练结果
+}
+
+// Valid 报告候选配置是否通过全部验证器
+func (r *ShadowReport) Valid() bool {
+	return len(r.ValidationErrors) == 0
+}
+
+// OK 报告候选配置是否通过全部验证且没有应用器演练失败
+func (r *ShadowReport) OK() bool {
+	return r.Valid() && !slices.ContainsFunc(r.Appliers, func(a ShadowApplier) bool { return a.Err != nil })
+}
+
+// Err 将验证与演练失败合并为一个错误，全部通过时返回 nil
+func (r *ShadowReport) Err() error {
+	errs := slices.Clone(r.ValidationErrors)
+	for _, a := range r.Appliers {
+		if a.Err != nil {
+			errs = append(errs, fmt.Errorf("applier %q: %w", a.Name, a.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RegisterApplierDryRun 为已注册的应用器设置演练函数，供 Shadow 评估候选配置时调用。
+// 演练函数接收与应用器相同的新旧快照，只检查能否应用（如连接串能否解析、端口能否监听），不得产生副作用。
+func (c *Config) RegisterApplierDryRun(name string, fn ApplierFunc) error {
+	if fn == nil {
+		return fmt.Errorf("applier dry-run function is required")
+	}
+	c.applyMu.Lock()
+	defer c.applyMu.Unlock()
+	for _, a := range c.appliers {
+		if a.name == name {
+			a.dryRun = fn
+			return nil
+		}
+	}
+	return fmt.Errorf("applier %q not registered", name)
+}
+
+// Shadow 在不替换当前配置的前提下评估候选配置内容（格式同当前配置）：
+// 解析候选内容、运行全部验证器、计算与当前配置的差异，并按依赖顺序执行应用器的演练函数，
+// 便于部署工具在切换前回答"会改变什么、是否有效"。附加数据源挂载的键沿用当前值。
+// 候选内容无法解析时返回错误；验证或演练失败记录在报告中。
+func (c *Config) Shadow(content []byte) (*ShadowReport, error) {
+	if c.closed.Load() {
+		return nil, ErrAlreadyClosed
+	}
+	if err := c.checkContent(content); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	nested, err := c.parseConfigBytes(content)
+	validators := slices.Clone(c.validators)
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("parse candidate config: %w", err)
+	}
+	if nested == nil {
+		nested = map[string]any{}
+	}
+
+	current := c.loadData()
+	candidate := make(map[string]any, len(current))
+	flattenSettings("", nested, candidate)
+	for _, layer := range c.sources {
+		prefix := layer.key + "."
+		for key := range candidate {
+			if key == layer.key || strings.HasPrefix(key, prefix) {
+				delete(candidate, key)
+			}
+		}
+		for key, value := range current {
+			if key == layer.key || strings.HasPrefix(key, prefix) {
+				candidate[key] = value
+			}
+		}
+	}
+
+	report := &ShadowReport{}
+	for _, key := range diffValueKeys(current, candidate) {
+		oldValue, existed := current[key]
+		newValue, exists := candidate[key]
+		report.Changes = append(report.Changes, ShadowChange{
+			Key:      key,
+			OldValue: deepCloneValue(oldValue),
+			NewValue: deepCloneValue(newValue),
+			Added:    !existed,
+			Removed:  !exists,
+		})
+	}
+
+	candidateNested := c.reconstructNestedStructure(deepCloneMap(candidate))
+	for _, validator := range validators {
+		if err := validator.Validate(candidateNested); err != nil {
+			report.ValidationErrors = append(report.ValidationErrors, fmt.Errorf("validator %s: %w", validator.GetName(), err))
+		}
+	}
+
+	c.applyMu.Lock()
+	defer c.applyMu.Unlock()
+	ordered, err := orderAppliers(c.appliers)
+	if err != nil {
+		return nil, err
+	}
+	old, next := Snapshot{data: current}, Snapshot{data: candidate}
+	for _, a := range ordered {
+		result := ShadowApplier{Name: a.name, Skipped: a.dryRun == nil}
+		if a.dryRun != nil {
+			result.Err = a.dryRun(old, next)
+		}
+		report.Appliers = append(report.Appliers, result)
+	}
+	return report, nil
+}
@@ -0,0 +1,64 @@
+package sysconf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+	"github.com/darkit/sysconf/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShadowReport(t *testing.T) {
+	cfg, err := New(
+		WithContent("server:\n  port: 8080\n  host: localhost\nlegacy: true\n"),
+	)
+	require.NoError(t, err)
+	testutil.Cleanup(t, cfg.Close)
+
+	cfg.AddValidator(validation.ValidatorFunc(func(config map[string]any) error {
+		server, _ := config["server"].(map[string]any)
+		if port, ok := server["port"].(int); ok && port < 1024 {
+			return errors.New("server.port must be >= 1024")
+		}
+		return nil
+	}))
+
+	var applied bool
+	require.NoError(t, cfg.RegisterApplier("http", func(old, next Snapshot) error {
+		applied = true
+		return nil
+	}))
+	require.NoError(t, cfg.RegisterApplier("metrics", func(old, next Snapshot) error { return nil }, "http"))
+	require.NoError(t, cfg.RegisterApplierDryRun("http", func(old, next Snapshot) error {
+		if next.Get("server.host") == nil {
+			return errors.New("server.host is required")
+		}
+		return nil
+	}))
+	require.Error(t, cfg.RegisterApplierDryRun("missing", func(old, next Snapshot) error { return nil }))
+
+	report, err := cfg.Shadow([]byte("server:\n  port: 9090\n  host: localhost\ndebug: true\n"))
+	require.NoError(t, err)
+	assert.True(t, report.OK())
+	assert.NoError(t, report.Err())
+	require.Len(t, report.Changes, 3)
+	assert.Equal(t, ShadowChange{Key: "debug", NewValue: true, Added: true}, report.Changes[0])
+	assert.Equal(t, ShadowChange{Key: "legacy", OldValue: true, Removed: true}, report.Changes[1])
+	assert.Equal(t, "server.port", report.Changes[2].Key)
+	assert.Equal(t, []ShadowApplier{{Name: "http"}, {Name: "metrics", Skipped: true}}, report.Appliers)
+	assert.False(t, applied, "Shadow must not run appliers")
+	assert.Equal(t, 8080, cfg.GetInt("server.port"), "Shadow must not modify the active config")
+
+	report, err = cfg.Shadow([]byte("server:\n  port: 80\n"))
+	require.NoError(t, err)
+	assert.False(t, report.Valid())
+	assert.False(t, report.OK())
+	require.Error(t, report.Appliers[0].Err)
+	assert.ErrorContains(t, report.Err(), "server.port must be >= 1024")
+	assert.ErrorContains(t, report.Err(), "server.host is required")
+
+	_, err = cfg.Shadow([]byte("server: [unclosed\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrSliceElement 切片中存在无法转换为目标类型的元素（SliceError 策略或 GetSliceAsWithError）
+var ErrSliceElement = errors.New("unconvertible slice element")
+
+// SlicePolicy 切片读取遇到无法转换的元素（如 GetIntSlice 读取 ["a", 1]）时的处理策略
+type SlicePolicy int
+
+const (
+	// SliceDropWithWarning 丢弃无法转换的元素并记录警告（默认）
+	SliceDropWithWarning SlicePolicy = iota
+	// SliceDropSilently 静默丢弃无法转换的元素
+	SliceDropSilently
+	// SliceError 放弃整个切片：Getter 记录错误并返回默认值，GetSliceAsWithError 返回匹配 ErrSliceElement 的错误
+	SliceError
+)
+
+// WithSlicePolicy 设置切片读取遇到无法转换的元素时的处理策略（默认 SliceDropWithWarning），
+// 作用于 GetStringSlice、GetIntSlice、GetFloatSlice、GetBoolSlice、GetTimeSlice 与 GetSliceAs
+func WithSlicePolicy(policy SlicePolicy) Option {
+	return func(c *Config) {
+		c.slicePolicy = policy
+	}
+}
+
+// convertSlice 逐个转换切片元素，无法转换的元素按 policy 处理：丢弃（可记录警告）或返回错误
+func convertSlice[T any](c *Config, key string, items []any, policy SlicePolicy, convert func(any) (T, bool)) ([]T, error) {
+	result := make([]T, 0, len(items))
+	var dropped []string
+	for i, item := range items {
+		if v, ok := convert(item); ok {
+			result = append(result, v)
+			continue
+		}
+		// 只记录位置与类型，避免敏感值进入日志
+		dropped = append(dropped, fmt.Sprintf("[%d] (%T)", i, item))
+	}
+	if len(dropped) == 0 {
+		return result, nil
+	}
+	target := reflect.TypeFor[T]()
+	switch policy {
+	case SliceDropSilently:
+	case SliceError:
+		return nil, &ConfigError{
+			Type:    ErrTypeConversion,
+			Message: fmt.Sprintf("配置项 %s 中 %d 个元素无法转换为 %s: %s", key, len(dropped), target, strings.Join(dropped, ", ")),
+			Key:     key,
+			Cause:   ErrSliceElement,
+		}
+	default:
+		c.logger.Warnf("Dropped %d unconvertible element(s) of key '%s' when reading as []%s: %s",
+			len(dropped), key, target, strings.Join(dropped, ", "))
+	}
+	return result, nil
+}
+
+// castOK 将 cast 风格的转换函数适配为 convertSlice 使用的形式
+func castOK[T any](convert func(any) (T, error)) func(any) (T, bool) {
+	return func(item any) (T, bool) {
+		v, err := convert(item)
+		return v, err == nil
+	}
+}
+
+// sliceItems 将任意切片值展开为元素列表，非切片值返回 false
+func sliceItems(val any) ([]any, bool) {
+	if items, ok := val.([]any); ok {
+		return items, true
+	}
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	items := make([]any, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, true
+}
@@ -0,0 +1,112 @@
+package sysconf
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+const mixedSliceContent = `ports: ["a", 1, "2", {x: 1}]
+ratios: [0.5, "bad", 2]
+flags: [true, "false", 3]
+names: [api, {x: 1}, 3]
+`
+
+func (l *recordingLoggerV2) count(level LogLevel, substr string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := 0
+	for _, entry := range l.logs {
+		if entry.level == level && strings.Contains(entry.msg, substr) {
+			n++
+		}
+	}
+	return n
+}
+
+func newSlicePolicyConfig(t *testing.T, opts ...Option) (*Config, *recordingLoggerV2) {
+	t.Helper()
+	logger := &recordingLoggerV2{min: WarnLevel}
+	cfg, err := New(append([]Option{WithContent(mixedSliceContent), WithLoggerV2(logger)}, opts...)...)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	return cfg, logger
+}
+
+func TestSlicePolicyDropWithWarning(t *testing.T) {
+	cfg, logger := newSlicePolicyConfig(t)
+
+	if got := cfg.GetIntSlice("ports"); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("expected convertible elements kept, got %v", got)
+	}
+	if got := cfg.GetFloatSlice("ratios"); !slices.Equal(got, []float64{0.5, 2}) {
+		t.Fatalf("unexpected float slice %v", got)
+	}
+	if got := cfg.GetBoolSlice("flags"); !slices.Equal(got, []bool{true, false}) {
+		t.Fatalf("unexpected bool slice %v", got)
+	}
+	if got := cfg.GetStringSlice("names"); !slices.Equal(got, []string{"api", "3"}) {
+		t.Fatalf("unexpected string slice %v", got)
+	}
+	if got := GetSliceAs[int](cfg, "ports"); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("unexpected GetSliceAs result %v", got)
+	}
+	if n := logger.count(WarnLevel, "key 'ports'"); n != 2 {
+		t.Fatalf("expected a warning per read of ports, got %d", n)
+	}
+	if n := logger.count(WarnLevel, "unconvertible"); n != 5 {
+		t.Fatalf("expected 5 warnings, got %d", n)
+	}
+}
+
+func TestSlicePolicyDropSilently(t *testing.T) {
+	cfg, logger := newSlicePolicyConfig(t, WithSlicePolicy(SliceDropSilently))
+
+	if got := cfg.GetIntSlice("ports"); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("expected convertible elements kept, got %v", got)
+	}
+	if n := logger.count(WarnLevel, "unconvertible"); n != 0 {
+		t.Fatalf("expected no warnings, got %d", n)
+	}
+}
+
+func TestSlicePolicyError(t *testing.T) {
+	cfg, logger := newSlicePolicyConfig(t, WithSlicePolicy(SliceError))
+
+	if got := cfg.GetIntSlice("ports"); len(got) != 0 {
+		t.Fatalf("expected empty slice instead of partial slice, got %v", got)
+	}
+	if got := cfg.GetFloatSlice("ratios"); len(got) != 0 {
+		t.Fatalf("expected empty slice, got %v", got)
+	}
+	if got := GetSliceAs[int](cfg, "ports"); len(got) != 0 {
+		t.Fatalf("expected empty slice, got %v", got)
+	}
+	if n := logger.count(ErrorLevel, "无法转换"); n != 3 {
+		t.Fatalf("expected an error log per read, got %d", n)
+	}
+}
+
+func TestGetSliceAsWithError(t *testing.T) {
+	cfg, _ := newSlicePolicyConfig(t, WithSlicePolicy(SliceDropSilently))
+
+	_, err := GetSliceAsWithError[int](cfg, "ports")
+	var configErr *ConfigError
+	if !errors.Is(err, ErrSliceElement) || !errors.As(err, &configErr) || configErr.Key != "ports" {
+		t.Fatalf("expected ErrSliceElement regardless of policy, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "[0] (string)") || strings.Contains(err.Error(), "[2]") {
+		t.Fatalf("expected error to list unconvertible positions, got %v", err)
+	}
+	if got, err := GetSliceAsWithError[float64](cfg, "ports.missing"); err == nil {
+		t.Fatalf("expected error for missing key, got %v", got)
+	}
+	if got, err := GetSliceAsWithError[string](cfg, "names"); err == nil {
+		t.Fatalf("expected error for map element, got %v", got)
+	}
+}
@@ -0,0 +1,272 @@
+package sysconf
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sourceLayer 挂载到某个配置键上的附加数据源（如表格文件）。
+// 数据源的值覆盖主配置中同名键，且不会随 Set 写回主配置文件。
+type sourceLayer struct {
+	name  string              // 数据源描述，用于日志与错误信息
+	key   string              // 挂载的配置键
+	path  string              // 需要监听变更的本地文件，空表示不监听
+	load  func() (any, error) // 读取数据源的当前值
+	value any                 // 最近一次成功加载的值（受 mu 保护）
+
+	interval     time.Duration // 定时重新加载的间隔，<=0 表示不轮询
+	optional     bool          // 初始化加载失败时记录日志并继续，而不是使 New 失败
+	clearOnError bool          // 重新加载失败时清除该数据源的值，而不是保留最后一次成功加载的值
+}
+
+// origin 数据源在日志与健康事件中的标识：本地文件使用路径，其余使用描述
+func (l *sourceLayer) origin() string {
+	if l.path != "" {
+		return l.path
+	}
+	return l.name
+}
+
+// addSource 注册附加数据源（供 Option 使用）
+func (c *Config) addSource(layer *sourceLayer) {
+	c.sources = append(c.sources, layer)
+}
+
+// maxParallelSourceLoads 初始化时同时加载的附加数据源上限
+const maxParallelSourceLoads = 8
+
+// loadSourcesLocked 加载全部附加数据源并合并到配置数据（调用者需持有 mu）。
+// 各数据源以有限并发同时读取与解析，结果按注册顺序处理与合并，因此合并结果与报告的错误和串行加载一致。
+// 初始化阶段任一数据源加载失败都会使 New 返回错误。
+func (c *Config) loadSourcesLocked() error {
+	if len(c.sources) == 0 {
+		return nil
+	}
+	for _, layer := range c.sources {
+		// 相对路径基于配置目录解析，与主配置文件保持一致
+		if layer.path != "" && !filepath.IsAbs(layer.path) && c.path != "" {
+			layer.path = filepath.Join(c.path, layer.path)
+		}
+	}
+	if err := c.checkInitContext(); err != nil {
+		return err
+	}
+
+	type loadResult struct {
+		value any
+		err   error
+	}
+	results := make([]loadResult, len(c.sources))
+	sem := make(chan struct{}, maxParallelSourceLoads)
+	var wg sync.WaitGroup
+	for i, layer := range c.sources {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if err := c.checkInitContext(); err != nil {
+				results[i].err = err
+				return
+			}
+			results[i].value, results[i].err = layer.load()
+		})
+	}
+	wg.Wait()
+
+	for i, layer := range c.sources {
+		value, err := results[i].value, results[i].err
+		if err != nil {
+			if ctxErr := c.checkInitContext(); ctxErr != nil {
+				return ctxErr
+			}
+			if !layer.optional {
+				return c.wrapError(fmt.Errorf("load %s: %w", layer.name, err), "加载附加数据源")
+			}
+			c.logger.Errorf("Failed to load %s, continuing without it: %v", layer.name, err)
+			c.emitHealthEventFor(layer.origin(), HealthEventReloadFailed, "source load failed, continuing without it", err)
+		}
+		layer.value = value
+		if layer.interval > 0 {
+			stopChan := c.stopChan
+			c.wg.Go(func() { c.pollSource(stopChan, layer) })
+		}
+	}
+	c.publishSourcesLocked()
+	return nil
+}
+
+// pollSource 按数据源的轮询间隔重新加载，直到 stopChan 关闭
+func (c *Config) pollSource(stopChan <-chan struct{}, layer *sourceLayer) {
+	ticker := time.NewTicker(layer.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+			c.reloadSource(layer)
+		}
+	}
+}
+
+// publishSourcesLocked 根据各数据源的当前值重建覆盖层并重新存储配置数据（调用者需持有 mu）
+func (c *Config) publishSourcesLocked() {
+	overlay := make(map[string]any)
+	for _, layer := range c.sources {
+		if layer.value == nil {
+			continue
+		}
+		if nested, ok := layer.value.(map[string]any); ok {
+			flattenSettings(layer.key, nested, overlay)
+			continue
+		}
+		overlay[layer.key] = sanitizeValue(layer.value)
+	}
+	c.sourceValues.Store(&overlay)
+	c.storeDataOp(RecordSource, c.loadData())
+}
+
+// applySources 将附加数据源的值覆盖到扁平配置数据上，挂载键下原有的子键会被移除；
+// origins 为数据中各键的来源记录，移除与写入的键同步更新
+func (c *Config) applySources(data map[string]any, origins map[string]Source) {
+	overlay := c.sourceValues.Load()
+	applied := false
+	for _, source := range origins {
+		if source == SourceRemote {
+			applied = true
+			break
+		}
+	}
+	// 数据源被清除后仍需移除上次应用的值
+	if (overlay == nil || len(*overlay) == 0) && !applied {
+		return
+	}
+	if overlay == nil {
+		overlay = &map[string]any{}
+	}
+	// fromSource 数据中的值是否为上次应用的数据源值（而非主配置或 Set 写入的值）
+	fromSource := func(key string) bool {
+		return origins[key] == SourceRemote
+	}
+	// keepFlag 优先级高于数据源的标志与环境变量值保持不变
+	keepFlag := func(key string) bool {
+		source, ok := origins[key]
+		return ok && source != SourceRemote && c.outranks(source, SourceRemote)
+	}
+	keepBase := c.outranks(c.baseSource(), SourceRemote)
+
+	for _, layer := range c.sources {
+		prefix := layer.key + "."
+		inLayer := func(key string) bool { return key == layer.key || strings.HasPrefix(key, prefix) }
+
+		// 主配置优先级更高且已提供该键时，仅移除上次应用的数据源值
+		hasBase := false
+		for key := range data {
+			if inLayer(key) && !fromSource(key) && !keepFlag(key) {
+				hasBase = true
+				break
+			}
+		}
+		for key := range data {
+			if inLayer(key) && !keepFlag(key) && (!keepBase || !hasBase || fromSource(key)) {
+				delete(data, key)
+				delete(origins, key)
+			}
+		}
+		if keepBase && hasBase {
+			continue
+		}
+		for key, value := range *overlay {
+			if _, kept := data[key]; inLayer(key) && !kept {
+				data[key] = value
+				origins[key] = SourceRemote
+			}
+		}
+	}
+}
+
+// withoutSourceKeys 返回去掉附加数据源挂载键的嵌套配置副本，避免数据源内容写入主配置文件
+func (c *Config) withoutSourceKeys(settings map[string]any) map[string]any {
+	if len(c.sources) == 0 {
+		return settings
+	}
+	result := maps.Clone(settings)
+	for _, layer := range c.sources {
+		deleteNestedKey(result, strings.Split(layer.key, "."))
+	}
+	return result
+}
+
+// deleteNestedKey 删除嵌套 map 中的路径，沿途的 map 会被复制以避免修改调用方数据
+func deleteNestedKey(m map[string]any, path []string) {
+	if len(path) == 1 {
+		delete(m, path[0])
+		return
+	}
+	child, ok := m[path[0]].(map[string]any)
+	if !ok {
+		return
+	}
+	child = maps.Clone(child)
+	deleteNestedKey(child, path[1:])
+	if len(child) == 0 {
+		delete(m, path[0])
+		return
+	}
+	m[path[0]] = child
+}
+
+// sourceWatchPaths 返回需要监听的数据源文件路径
+func (c *Config) sourceWatchPaths() map[string]*sourceLayer {
+	paths := make(map[string]*sourceLayer)
+	for _, layer := range c.sources {
+		if layer.path != "" {
+			paths[filepath.Clean(layer.path)] = layer
+		}
+	}
+	return paths
+}
+
+// reloadSource 重新加载单个数据源；失败时保留最后一次成功加载的值（clearOnError 时清除）
+func (c *Config) reloadSource(layer *sourceLayer) {
+	// 文件被截断、内容尚未写入时等待后续写入事件，避免以空数据覆盖最后一次成功加载的值
+	if layer.path != "" {
+		if info, err := os.Stat(layer.path); err == nil && info.Size() == 0 {
+			c.logger.Debugf("Source file is empty, waiting for content: %s", layer.path)
+			return
+		}
+	}
+	value, err := layer.load()
+	if err != nil {
+		c.logger.Errorf("Failed to reload %s: %v", layer.name, err)
+		if !layer.clearOnError {
+			c.emitHealthEventFor(layer.origin(), HealthEventReloadFailed, "source reload failed, keeping last known good value", err)
+			return
+		}
+		c.emitHealthEventFor(layer.origin(), HealthEventReloadFailed, "source reload failed, value cleared", err)
+		value = nil
+	}
+
+	c.mu.Lock()
+	if err != nil && layer.value == nil {
+		c.mu.Unlock()
+		return
+	}
+	layer.value = value
+	c.publishSourcesLocked()
+	ticket, callbacks := c.watchCallbacksLocked()
+	c.mu.Unlock()
+
+	c.invalidateLookupCache()
+	c.invalidateCache()
+	if err == nil {
+		c.logger.Infof("Config source reloaded: %s", layer.name)
+		c.emitHealthEventFor(layer.origin(), HealthEventReloaded, "source reloaded", nil)
+	}
+
+	c.runWatchCallbacks(ticket, callbacks)
+}
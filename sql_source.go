@@ -0,0 +1,365 @@
+package sysconf
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sqlIdentifierPattern 表名与列名的合法格式（允许 schema.table），防止 SQL 注入
+var sqlIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// SQLSource 数据库配置源选项：从键值表加载配置。
+// 表结构默认为 (key, value, type, updated_at)，key 为点分配置路径，value 为文本形式的值，
+// type 取值 string/int/float/bool/duration/json（为空按 string 处理），updated_at 为最后修改时间。
+type SQLSource struct {
+	DB            *sql.DB // 数据库连接（必填，驱动由使用方引入）
+	Table         string  // 表名，默认 "sysconf"
+	KeyColumn     string  // 键列名，默认 "key"
+	ValueColumn   string  // 值列名，默认 "value"
+	TypeColumn    string  // 类型列名，默认 "type"
+	UpdatedColumn string  // 修改时间列名，默认 "updated_at"
+
+	// NumberedParams 使用 $1、$2 形式的占位符（PostgreSQL），默认使用 ?
+	NumberedParams bool
+	// PollInterval 轮询间隔，按行数与 max(updated_at) 判断是否变化；<=0 时不轮询
+	PollInterval time.Duration
+	// Notify 外部变更通知（如 PostgreSQL LISTEN/NOTIFY），收到信号时立即重新加载
+	Notify <-chan struct{}
+	// Timeout 单次查询超时，默认 30 秒
+	Timeout time.Duration
+	// WriteBack 为 true 时 Set/SetMultiple 的变更写回数据表而不是配置文件
+	WriteBack bool
+}
+
+// sqlSource 数据库配置源状态
+type sqlSource struct {
+	opts SQLSource
+
+	mu        sync.Mutex
+	signature string // 最近一次加载时的行数与 max(updated_at)
+	started   bool   // 轮询协程是否已启动
+}
+
+// WithSQLSource 从数据库键值表加载配置。
+// 数据表内容与远程配置源一样整体替换当前配置，新内容通过全部验证器后才生效；
+// 启用 WriteBack 后 Set 的变更以事务写回数据表，不再写入配置文件。
+func WithSQLSource(src SQLSource) Option {
+	return func(c *Config) {
+		c.sqlSource = &sqlSource{opts: src}
+	}
+}
+
+// origin 健康事件与日志中标识数据源的名称
+func (s *sqlSource) origin() string {
+	return "sql:" + s.opts.Table
+}
+
+// sqlWriteBack 是否将变更写回数据库
+func (c *Config) sqlWriteBack() bool {
+	return c.sqlSource != nil && c.sqlSource.opts.WriteBack
+}
+
+// initSQLSourceLocked 启动时加载数据表并启动刷新协程（调用者需持有 mu）。
+// 加载或校验失败时，若存在本地配置（WithName/WithContent）则保留并记录日志，否则返回错误。
+func (c *Config) initSQLSourceLocked() error {
+	src := c.sqlSource
+	if src == nil {
+		return nil
+	}
+	if src.opts.DB == nil {
+		return c.wrapError(fmt.Errorf("sql source requires a database handle"), "加载数据库配置")
+	}
+	applySQLSourceDefaults(&src.opts)
+	for _, name := range []string{src.opts.Table, src.opts.KeyColumn, src.opts.ValueColumn, src.opts.TypeColumn, src.opts.UpdatedColumn} {
+		if !sqlIdentifierPattern.MatchString(name) {
+			return c.wrapError(fmt.Errorf("invalid sql identifier: %q", name), "加载数据库配置")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.initContext(), src.opts.Timeout)
+	nested, err := c.loadSQLSource(ctx)
+	cancel()
+	if err == nil {
+		err = c.applyRemoteSettingsLocked(nested)
+	}
+	if err != nil {
+		if c.name == "" && c.content == "" {
+			return c.wrapError(err, "加载数据库配置")
+		}
+		c.logger.Errorf("Failed to load config from %s, keeping local config: %v", src.origin(), err)
+	}
+
+	src.mu.Lock()
+	start := !src.started && (src.opts.PollInterval > 0 || src.opts.Notify != nil)
+	src.started = true
+	src.mu.Unlock()
+	if start {
+		stopChan := c.stopChan
+		c.wg.Go(func() { c.pollSQLSource(stopChan) })
+	}
+	return nil
+}
+
+// applySQLSourceDefaults 填充数据库配置源的默认表名、列名与超时
+func applySQLSourceDefaults(opts *SQLSource) {
+	if opts.Table == "" {
+		opts.Table = "sysconf"
+	}
+	if opts.KeyColumn == "" {
+		opts.KeyColumn = "key"
+	}
+	if opts.ValueColumn == "" {
+		opts.ValueColumn = "value"
+	}
+	if opts.TypeColumn == "" {
+		opts.TypeColumn = "type"
+	}
+	if opts.UpdatedColumn == "" {
+		opts.UpdatedColumn = "updated_at"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+}
+
+// placeholder 返回第 n 个（从 1 开始）参数占位符
+func (s *sqlSource) placeholder(n int) string {
+	if s.opts.NumberedParams {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// querySignature 查询行数与 max(updated_at)，用于低成本判断数据表是否变化
+func (s *sqlSource) querySignature(ctx context.Context) (string, error) {
+	query := fmt.Sprintf("SELECT COUNT(*), MAX(%s) FROM %s", s.opts.UpdatedColumn, s.opts.Table)
+	var count int64
+	var latest any
+	if err := s.opts.DB.QueryRowContext(ctx, query).Scan(&count, &latest); err != nil {
+		return "", fmt.Errorf("query %s signature: %w", s.opts.Table, err)
+	}
+	return fmt.Sprintf("%d|%v", count, latest), nil
+}
+
+// loadSQLSource 读取整张数据表并按类型列转换为嵌套配置
+func (c *Config) loadSQLSource(ctx context.Context) (map[string]any, error) {
+	src := c.sqlSource
+	signature, err := src.querySignature(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT %s, %s, %s FROM %s",
+		src.opts.KeyColumn, src.opts.ValueColumn, src.opts.TypeColumn, src.opts.Table)
+	rows, err := src.opts.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query %s: %w", src.opts.Table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	nested := make(map[string]any)
+	for rows.Next() {
+		var key string
+		var raw, typ sql.NullString
+		if err := rows.Scan(&key, &raw, &typ); err != nil {
+			return nil, fmt.Errorf("scan %s: %w", src.opts.Table, err)
+		}
+		if key == "" || !raw.Valid {
+			continue
+		}
+		value, err := decodeSQLValue(raw.String, typ.String)
+		if err != nil {
+			return nil, fmt.Errorf("row %s: %w", key, err)
+		}
+		setNestedMapValue(nested, key, value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", src.opts.Table, err)
+	}
+
+	src.mu.Lock()
+	src.signature = signature
+	src.mu.Unlock()
+	return nested, nil
+}
+
+// pollSQLSource 按轮询间隔或外部通知刷新数据表配置
+func (c *Config) pollSQLSource(stopChan <-chan struct{}) {
+	src := c.sqlSource
+	var tick <-chan time.Time
+	if src.opts.PollInterval > 0 {
+		ticker := time.NewTicker(src.opts.PollInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	notify := src.opts.Notify
+
+	for {
+		force := false
+		select {
+		case <-stopChan:
+			return
+		case <-tick:
+		case _, ok := <-notify:
+			if !ok {
+				notify = nil
+				continue
+			}
+			force = true
+		}
+		c.refreshSQLSource(force)
+	}
+}
+
+// refreshSQLSource 数据表变化（或 force）时重新加载并替换配置
+func (c *Config) refreshSQLSource(force bool) {
+	src := c.sqlSource
+	ctx, cancel := context.WithTimeout(context.Background(), src.opts.Timeout)
+	defer cancel()
+
+	if !force {
+		signature, err := src.querySignature(ctx)
+		if err != nil {
+			c.logger.Errorf("Failed to poll config table %s: %v", src.opts.Table, err)
+			c.emitHealthEventFor(src.origin(), HealthEventReloadFailed, "config table poll failed, keeping last known good config", err)
+			return
+		}
+		src.mu.Lock()
+		unchanged := signature == src.signature
+		src.mu.Unlock()
+		if unchanged {
+			return
+		}
+	}
+
+	nested, err := c.loadSQLSource(ctx)
+	if err != nil {
+		c.logger.Errorf("Failed to load config table %s: %v", src.opts.Table, err)
+		c.emitHealthEventFor(src.origin(), HealthEventReloadFailed, "config table load failed, keeping last known good config", err)
+		return
+	}
+	c.reloadRemoteWith(src.origin(), func() error { return c.applyRemoteSettingsLocked(nested) })
+}
+
+// writeSQLSource 以事务将 values 写回数据表：覆盖的键先删除旧行（含旧的子键）再插入叶子值
+func (c *Config) writeSQLSource(previous map[string]any, values map[string]any) error {
+	src := c.sqlSource
+	rows := make(map[string]any)
+	var deletes []string
+	for key, value := range values {
+		if m, ok := value.(map[string]any); ok {
+			flattenSettings(key, m, rows)
+		} else {
+			rows[key] = value
+		}
+		prefix := key + "."
+		for old := range previous {
+			if old == key || strings.HasPrefix(old, prefix) {
+				deletes = append(deletes, old)
+			}
+		}
+	}
+	for key := range rows {
+		if !slices.Contains(deletes, key) {
+			deletes = append(deletes, key)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), src.opts.Timeout)
+	defer cancel()
+	tx, err := src.opts.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	deleteQuery := fmt.Sprintf("DELETE FROM %s WHERE %s = %s", src.opts.Table, src.opts.KeyColumn, src.placeholder(1))
+	for _, key := range deletes {
+		if _, err := tx.ExecContext(ctx, deleteQuery, key); err != nil {
+			return fmt.Errorf("delete %s: %w", key, err)
+		}
+	}
+
+	insertQuery := fmt.Sprintf("INSERT INTO %s (%s, %s, %s, %s) VALUES (%s, %s, %s, %s)",
+		src.opts.Table, src.opts.KeyColumn, src.opts.ValueColumn, src.opts.TypeColumn, src.opts.UpdatedColumn,
+		src.placeholder(1), src.placeholder(2), src.placeholder(3), src.placeholder(4))
+	now := time.Now().UTC()
+	for _, key := range slices.Sorted(maps.Keys(rows)) {
+		raw, typ, err := encodeSQLValue(rows[key])
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", key, err)
+		}
+		if _, err := tx.ExecContext(ctx, insertQuery, key, raw, typ, now); err != nil {
+			return fmt.Errorf("insert %s: %w", key, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	// 记录写回后的签名，避免轮询把自身写入当作外部变更重新加载
+	if signature, err := src.querySignature(ctx); err == nil {
+		src.mu.Lock()
+		src.signature = signature
+		src.mu.Unlock()
+	}
+	c.logger.Infof("Config changes written to table %s: %d rows", src.opts.Table, len(rows))
+	return nil
+}
+
+// decodeSQLValue 按类型列将文本值转换为配置值
+func decodeSQLValue(raw, typ string) (any, error) {
+	switch strings.ToLower(strings.TrimSpace(typ)) {
+	case "", "string":
+		return raw, nil
+	case "int":
+		return strconv.Atoi(strings.TrimSpace(raw))
+	case "float":
+		return strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	case "bool":
+		return strconv.ParseBool(strings.TrimSpace(raw))
+	case "duration":
+		return time.ParseDuration(strings.TrimSpace(raw))
+	case "json":
+		var value any
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %q", typ)
+	}
+}
+
+// encodeSQLValue 将配置值转换为文本值与类型列
+func encodeSQLValue(value any) (raw, typ string, err error) {
+	switch v := value.(type) {
+	case string:
+		return v, "string", nil
+	case bool:
+		return strconv.FormatBool(v), "bool", nil
+	case time.Duration:
+		return v.String(), "duration", nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), "int", nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), "float", nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), "float", nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", "", err
+		}
+		return string(data), "json", nil
+	}
+}
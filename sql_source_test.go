@@ -0,0 +1,297 @@
+package sysconf
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+// fakeConfigTable 内存版键值表，实现 SQLSource 使用到的少量语句
+type fakeConfigTable struct {
+	mu      sync.Mutex
+	rows    map[string][3]driver.Value // key -> value, type, updated_at
+	queries []string
+}
+
+func newFakeConfigTable(rows map[string][2]string) (*fakeConfigTable, *sql.DB) {
+	table := &fakeConfigTable{rows: make(map[string][3]driver.Value)}
+	for key, row := range rows {
+		table.rows[key] = [3]driver.Value{row[0], row[1], time.Unix(1, 0)}
+	}
+	return table, sql.OpenDB(table)
+}
+
+func (t *fakeConfigTable) put(key, value, typ string) {
+	t.mu.Lock()
+	t.rows[key] = [3]driver.Value{value, typ, time.Now()}
+	t.mu.Unlock()
+}
+
+func (t *fakeConfigTable) get(key string) (string, string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	row, ok := t.rows[key]
+	if !ok {
+		return "", "", false
+	}
+	return row[0].(string), row[1].(string), true
+}
+
+func (t *fakeConfigTable) Connect(context.Context) (driver.Conn, error) { return fakeSQLConn{t}, nil }
+func (t *fakeConfigTable) Driver() driver.Driver                        { return nil }
+
+type fakeSQLConn struct{ table *fakeConfigTable }
+
+func (c fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeSQLStmt{table: c.table, query: query}, nil
+}
+func (c fakeSQLConn) Close() error              { return nil }
+func (c fakeSQLConn) Begin() (driver.Tx, error) { return fakeSQLTx{}, nil }
+
+type fakeSQLTx struct{}
+
+func (fakeSQLTx) Commit() error   { return nil }
+func (fakeSQLTx) Rollback() error { return nil }
+
+type fakeSQLStmt struct {
+	table *fakeConfigTable
+	query string
+}
+
+func (s fakeSQLStmt) Close() error  { return nil }
+func (s fakeSQLStmt) NumInput() int { return -1 }
+
+func (s fakeSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	t := s.table
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.queries = append(t.queries, s.query)
+	switch {
+	case strings.HasPrefix(s.query, "DELETE"):
+		delete(t.rows, args[0].(string))
+	case strings.HasPrefix(s.query, "INSERT"):
+		t.rows[args[0].(string)] = [3]driver.Value{args[1], args[2], args[3]}
+	default:
+		return nil, fmt.Errorf("unsupported exec: %s", s.query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeSQLStmt) Query([]driver.Value) (driver.Rows, error) {
+	t := s.table
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.queries = append(t.queries, s.query)
+	if strings.HasPrefix(s.query, "SELECT COUNT(*)") {
+		var latest time.Time
+		for _, row := range t.rows {
+			if ts := row[2].(time.Time); ts.After(latest) {
+				latest = ts
+			}
+		}
+		return &fakeSQLRows{cols: []string{"count", "max"}, data: [][]driver.Value{{int64(len(t.rows)), latest}}}, nil
+	}
+	rows := &fakeSQLRows{cols: []string{"key", "value", "type"}}
+	for key, row := range t.rows {
+		rows.data = append(rows.data, []driver.Value{key, row[0], row[1]})
+	}
+	return rows, nil
+}
+
+type fakeSQLRows struct {
+	cols []string
+	data [][]driver.Value
+}
+
+func (r *fakeSQLRows) Columns() []string { return r.cols }
+func (r *fakeSQLRows) Close() error      { return nil }
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if len(r.data) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.data[0])
+	r.data = r.data[1:]
+	return nil
+}
+
+func TestSQLSourceLoadAndPoll(t *testing.T) {
+	table, db := newFakeConfigTable(map[string][2]string{
+		"app.name":      {"demo", "string"},
+		"app.port":      {"8080", "int"},
+		"app.debug":     {"true", "bool"},
+		"app.timeout":   {"1m30s", "duration"},
+		"app.ratio":     {"0.5", "float"},
+		"app.features":  {`["a","b"]`, "json"},
+		"database.host": {"db.local", ""},
+	})
+	defer func() { _ = db.Close() }()
+
+	for _, engine := range []Engine{CompatibilityEngine, NativeEngine} {
+		cfg, err := New(
+			WithEngine(engine),
+			WithSQLSource(SQLSource{DB: db, Table: "app_config", PollInterval: 20 * time.Millisecond}),
+		)
+		if err != nil {
+			t.Fatalf("create config failed: %v", err)
+		}
+
+		if cfg.GetString("app.name") != "demo" || cfg.GetInt("app.port") != 8080 || !cfg.GetBool("app.debug") {
+			t.Fatalf("unexpected values: %v", cfg.AllSettings())
+		}
+		if got := cfg.GetDuration("app.timeout"); got != 90*time.Second {
+			t.Fatalf("expected 90s timeout, got %v", got)
+		}
+		if got := cfg.GetFloat("app.ratio"); got != 0.5 {
+			t.Fatalf("expected ratio 0.5, got %v", got)
+		}
+		if got := cfg.GetStringSlice("app.features"); len(got) != 2 || got[1] != "b" {
+			t.Fatalf("unexpected features: %v", got)
+		}
+		if got := cfg.GetString("database.host"); got != "db.local" {
+			t.Fatalf("untyped row should load as string, got %q", got)
+		}
+
+		changed := make(chan struct{}, 1)
+		cfg.Watch(func() {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+		table.put("app.port", "9090", "int")
+		select {
+		case <-changed:
+		case <-time.After(3 * time.Second):
+			t.Fatalf("table change not applied")
+		}
+		if got := cfg.GetInt("app.port"); got != 9090 {
+			t.Fatalf("expected port 9090, got %d", got)
+		}
+		table.put("app.port", "8080", "int")
+		_ = cfg.Close()
+	}
+}
+
+func TestSQLSourceNotifyAndValidate(t *testing.T) {
+	table, db := newFakeConfigTable(map[string][2]string{"app.name": {"demo", "string"}})
+	defer func() { _ = db.Close() }()
+
+	notify := make(chan struct{})
+	cfg, err := New(
+		WithSQLSource(SQLSource{DB: db, NumberedParams: true, Notify: notify}),
+		WithValidateFunc(func(config map[string]any) error {
+			app, _ := config["app"].(map[string]any)
+			if app["name"] == "" {
+				return fmt.Errorf("app.name is required")
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	events := make(chan HealthEvent, 8)
+	cfg.OnHealthEvent(func(e HealthEvent) { events <- e })
+
+	table.put("app.name", "notified", "string")
+	notify <- struct{}{}
+	if event := waitHealthEvent(t, events, HealthEventReloaded); event.File != "sql:sysconf" {
+		t.Fatalf("unexpected event origin: %s", event.File)
+	}
+	if got := cfg.GetString("app.name"); got != "notified" {
+		t.Fatalf("expected notified, got %q", got)
+	}
+
+	// 未通过验证的数据被拒绝，保留当前配置
+	table.put("app.name", "", "string")
+	notify <- struct{}{}
+	waitHealthEvent(t, events, HealthEventReloadFailed)
+	if got := cfg.GetString("app.name"); got != "notified" {
+		t.Fatalf("expected last good config to be kept, got %q", got)
+	}
+}
+
+func TestSQLSourceWriteBack(t *testing.T) {
+	table, db := newFakeConfigTable(map[string][2]string{
+		"app.name":      {"demo", "string"},
+		"database.host": {"old", "string"},
+		"database.port": {"5432", "int"},
+	})
+	defer func() { _ = db.Close() }()
+
+	tmpDir := t.TempDir()
+	cfg, err := New(
+		WithPath(tmpDir),
+		WithName("app"),
+		WithSQLSource(SQLSource{DB: db, NumberedParams: true, WriteBack: true}),
+		WithWriteDebounceDelay(0),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if err := cfg.Set("app.timeout", 5*time.Second); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if value, typ, _ := table.get("app.timeout"); value != "5s" || typ != "duration" {
+		t.Fatalf("expected duration row, got %q (%s)", value, typ)
+	}
+
+	if err := cfg.SetMultiple(map[string]any{
+		"database": map[string]any{"host": "new"},
+		"app.port": 8080,
+	}); err != nil {
+		t.Fatalf("set multiple failed: %v", err)
+	}
+	if value, _, _ := table.get("database.host"); value != "new" {
+		t.Fatalf("expected database.host=new, got %q", value)
+	}
+	if _, _, ok := table.get("database.port"); ok {
+		t.Fatalf("replaced subtree should remove stale rows")
+	}
+	if value, typ, _ := table.get("app.port"); value != "8080" || typ != "int" {
+		t.Fatalf("expected int row, got %q (%s)", value, typ)
+	}
+
+	table.mu.Lock()
+	queries := strings.Join(table.queries, "\n")
+	table.mu.Unlock()
+	if !strings.Contains(queries, "DELETE FROM sysconf WHERE key = $1") {
+		t.Fatalf("expected numbered placeholders, got:\n%s", queries)
+	}
+	if data, _ := os.ReadFile(filepath.Join(tmpDir, "app.yaml")); strings.Contains(string(data), "timeout") {
+		t.Fatalf("write-back must not write the config file:\n%s", data)
+	}
+}
+
+func TestSQLSourceErrors(t *testing.T) {
+	if _, err := New(WithSQLSource(SQLSource{})); err == nil {
+		t.Fatalf("expected error for missing database")
+	}
+	_, db := newFakeConfigTable(nil)
+	defer func() { _ = db.Close() }()
+	if _, err := New(WithSQLSource(SQLSource{DB: db, Table: "config; DROP TABLE x"})); err == nil {
+		t.Fatalf("expected error for invalid table name")
+	}
+
+	value, err := decodeSQLValue("abc", "int")
+	if err == nil {
+		t.Fatalf("expected int parse error, got %v", value)
+	}
+	if _, err := decodeSQLValue("1", "uuid"); err == nil {
+		t.Fatalf("expected error for unknown type")
+	}
+}
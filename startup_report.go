@@ -0,0 +1,120 @@
+package sysconf
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// StartupReport 配置加载结果的结构化摘要，便于服务启动时输出一段集中的日志
+type StartupReport struct {
+	File         string   // 配置文件路径，内存模式为空
+	Format       string   // 配置格式（yaml/json/toml 等）
+	Encrypted    bool     // 配置文件是否以加密格式存储
+	CryptoType   string   // 加密实现类型，未启用加密时为空
+	Keys         int      // 配置键（叶子键）数量
+	EnvPrefix    string   // 环境变量前缀
+	EnvOverrides []string // 当前值来自环境变量的键（已排序）
+	Overrides    []string // 由 WithOverrides/ApplyOverrideString 覆盖的键（已排序）
+	Validators   []string // 已注册验证器的名称（按注册顺序）
+	Warnings     []string // 需要关注的问题，如配置文件缺失、已从备份恢复或启用加密但文件仍为明文
+}
+
+// StartupReport 汇总当前配置的加载结果：使用的文件与格式、是否加密、键数量、
+// 生效的环境变量与覆盖值、已注册的验证器及警告。String 方法输出适合启动日志的多行文本。
+func (c *Config) StartupReport() StartupReport {
+	c.mu.RLock()
+	report := StartupReport{
+		File:      c.configFilePath(),
+		Format:    c.mode,
+		EnvPrefix: c.envOptions.Prefix,
+	}
+	encryptionEnabled := c.cryptoOptions.Enabled && c.crypto != nil
+	if encryptionEnabled {
+		report.CryptoType = cryptoTypeName(c.crypto)
+	}
+	report.EnvOverrides = c.envOverrideKeysUnsafe()
+	c.mu.RUnlock()
+
+	report.Keys = len(c.loadData())
+	report.Overrides = slices.Sorted(maps.Keys(c.Overrides()))
+	for _, v := range c.GetValidators() {
+		report.Validators = append(report.Validators, v.GetName())
+	}
+
+	if info, ok := c.FileInfo(); ok {
+		report.File = info.Path
+		report.Encrypted = info.Encrypted
+		if encryptionEnabled && !info.Encrypted {
+			report.Warnings = append(report.Warnings, "encryption is enabled but the config file is stored in plaintext")
+		}
+	}
+	for _, event := range c.Health().RecentEvents {
+		switch event.Type {
+		case HealthEventFileNotFound, HealthEventReloadFailed, HealthEventRecoveredFromBackup:
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s: %s", event.Type, event.Message))
+		}
+	}
+	return report
+}
+
+// envOverrideKeysUnsafe 返回当前值来自环境变量的键（调用者需持有 mu 读锁）
+func (c *Config) envOverrideKeysUnsafe() []string {
+	if !c.envEnabled.Load() {
+		return nil
+	}
+	var keys []string
+	data := c.loadData()
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		if _, ok := c.lookupEnvWithOptions(c.envOptions, key); ok && c.envWins(data, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// String 将摘要格式化为多行文本，适合在启动时一次性写入日志
+func (r StartupReport) String() string {
+	var b strings.Builder
+	b.WriteString("config startup report\n")
+
+	file := r.File
+	if file == "" {
+		file = "(memory only)"
+	}
+	fmt.Fprintf(&b, "  file:        %s\n", file)
+	fmt.Fprintf(&b, "  format:      %s\n", r.Format)
+	switch {
+	case r.Encrypted:
+		fmt.Fprintf(&b, "  encrypted:   yes (%s)\n", r.CryptoType)
+	case r.CryptoType != "":
+		fmt.Fprintf(&b, "  encrypted:   no (%s enabled)\n", r.CryptoType)
+	default:
+		b.WriteString("  encrypted:   no\n")
+	}
+	fmt.Fprintf(&b, "  keys:        %d\n", r.Keys)
+	if r.EnvPrefix != "" {
+		fmt.Fprintf(&b, "  env prefix:  %s\n", r.EnvPrefix)
+	}
+	fmt.Fprintf(&b, "  env applied: %s\n", countedList(r.EnvOverrides))
+	fmt.Fprintf(&b, "  overrides:   %s\n", countedList(r.Overrides))
+	fmt.Fprintf(&b, "  validators:  %s\n", countedList(r.Validators))
+	if len(r.Warnings) == 0 {
+		b.WriteString("  warnings:    none")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "  warnings:    %d", len(r.Warnings))
+	for _, warning := range r.Warnings {
+		fmt.Fprintf(&b, "\n    - %s", warning)
+	}
+	return b.String()
+}
+
+// countedList 格式化为 "数量 (a, b, c)"，为空时输出 0
+func countedList(items []string) string {
+	if len(items) == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%d (%s)", len(items), strings.Join(items, ", "))
+}
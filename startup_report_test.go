@@ -0,0 +1,75 @@
+package sysconf
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestStartupReport(t *testing.T) {
+	t.Setenv("REPORT_DATABASE_HOST", "db.internal")
+	tmpDir := t.TempDir()
+	cfg, err := New(
+		WithPath(tmpDir),
+		WithName("app"),
+		WithMode("yaml"),
+		WithContent("database:\n  host: localhost\n  port: 5432\nlog:\n  level: info\n"),
+		WithEnv("REPORT"),
+		WithOverrides("log.level=debug"),
+		WithValidateFunc(func(map[string]any) error { return nil }),
+		WithEncryption("report-key"),
+		WithWriteDebounceDelay(0),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	report := cfg.StartupReport()
+	if !strings.HasSuffix(report.File, "app.yaml") || report.Format != "yaml" {
+		t.Fatalf("unexpected file/format: %q %q", report.File, report.Format)
+	}
+	if !report.Encrypted || report.CryptoType == "" {
+		t.Fatalf("expected encrypted file, got %+v", report)
+	}
+	if report.Keys != 3 {
+		t.Fatalf("expected 3 keys, got %d", report.Keys)
+	}
+	if !slices.Equal(report.EnvOverrides, []string{"database.host"}) {
+		t.Fatalf("unexpected env overrides: %v", report.EnvOverrides)
+	}
+	if !slices.Equal(report.Overrides, []string{"log.level"}) {
+		t.Fatalf("unexpected overrides: %v", report.Overrides)
+	}
+	if len(report.Validators) != 1 {
+		t.Fatalf("expected one validator, got %v", report.Validators)
+	}
+	if len(report.Warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", report.Warnings)
+	}
+
+	text := report.String()
+	for _, want := range []string{"app.yaml", "keys:        3", "env applied: 1 (database.host)", "overrides:   1 (log.level)", "warnings:    none"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in report:\n%s", want, text)
+		}
+	}
+}
+
+func TestStartupReportMemoryOnly(t *testing.T) {
+	cfg, err := New(WithContent("a: 1\n"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	report := cfg.StartupReport()
+	if report.File != "" || report.Encrypted || len(report.EnvOverrides) != 0 {
+		t.Fatalf("unexpected report for memory-only config: %+v", report)
+	}
+	if text := report.String(); !strings.Contains(text, "(memory only)") {
+		t.Fatalf("expected memory-only marker:\n%s", text)
+	}
+}
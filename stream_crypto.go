@@ -0,0 +1,209 @@
+package sysconf
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// StreamCrypto 可由加密器额外实现的流式加解密接口。
+// 配置内容超过流式加密阈值时，持久化层直接将密文分块写入文件，避免在内存中同时保留明文与整份密文。
+type StreamCrypto interface {
+	// EncryptStream 读取 src 中的明文，将密文写入 dst
+	EncryptStream(dst io.Writer, src io.Reader) error
+	// DecryptStream 读取 src 中的密文，将明文写入 dst
+	DecryptStream(dst io.Writer, src io.Reader) error
+}
+
+const (
+	// defaultStreamThreshold 默认的流式加密阈值（明文字节数）
+	defaultStreamThreshold = 4 << 20
+	// streamChunkSize 流式加密的明文分块大小
+	streamChunkSize = 64 << 10
+	// streamNoncePrefixLen nonce 中随机前缀的长度，其后为 4 字节块序号与 1 字节结束标记
+	streamNoncePrefixLen = chacha20poly1305.NonceSize - 5
+)
+
+// streamMagic 流式密文的前缀标识，其后依次为版本、盐值与 nonce 前缀
+var streamMagic = []byte("SYSCONF_STREAM:")
+
+// streamVersion 当前流式密文格式版本
+var streamVersion = []byte{0x01}
+
+// WithEncryptionStreamThreshold 设置流式加密阈值：启用加密且序列化后的配置不小于 size 字节时，
+// 若加密器实现 StreamCrypto（默认加密器已实现），写入时分块加密直接写入文件。
+// size 为 0 时使用默认的 4MB，size<0 时禁用流式加密。流式密文以二进制存储，信封头部带 stream=1 标记。
+func WithEncryptionStreamThreshold(size int64) Option {
+	return func(c *Config) {
+		c.streamThreshold = size
+	}
+}
+
+// streamCrypto 返回写入 size 字节明文时应使用的流式加密器
+func (c *Config) streamCrypto(size int) (StreamCrypto, bool) {
+	threshold := c.streamThreshold
+	if threshold == 0 {
+		threshold = defaultStreamThreshold
+	}
+	if threshold < 0 || int64(size) < threshold {
+		return nil, false
+	}
+	stream, ok := c.crypto.(StreamCrypto)
+	return stream, ok
+}
+
+// encryptContentStream 写入带 stream 标记的信封头部，随后将 data 分块加密写入 w
+func (c *Config) encryptContentStream(stream StreamCrypto, w io.Writer, data []byte) error {
+	header := envelopeHeaderLine(EnvelopeHeader{
+		Version:   envelopeVersion,
+		Algorithm: cryptoTypeName(c.crypto),
+		KeyID:     cryptoKeyID(c.crypto),
+		CreatedAt: time.Now(),
+		Format:    c.mode,
+		Stream:    true,
+	})
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if err := stream.EncryptStream(w, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("encrypt config: %w", err)
+	}
+	return nil
+}
+
+// EncryptStream 实现 StreamCrypto：以 64KB 为单位分块加密。
+// 每块使用 随机前缀+块序号+结束标记 组成的 nonce，可检测块被重排、删除或截断。
+func (d *DefaultCrypto) EncryptStream(dst io.Writer, src io.Reader) error {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("生成盐值失败: %w", err)
+	}
+	prefix := make([]byte, streamNoncePrefixLen)
+	if _, err := rand.Read(prefix); err != nil {
+		return fmt.Errorf("生成nonce失败: %w", err)
+	}
+	aead, err := chacha20poly1305.New(deriveKey(d.key, salt))
+	if err != nil {
+		return fmt.Errorf("创建ChaCha20-Poly1305失败: %w", err)
+	}
+
+	head := make([]byte, 0, len(streamMagic)+len(streamVersion)+saltLen+streamNoncePrefixLen)
+	head = append(append(append(append(head, streamMagic...), streamVersion...), salt...), prefix...)
+	if _, err := dst.Write(head); err != nil {
+		return err
+	}
+
+	// 预读下一块以确定当前块是否为最后一块
+	current := make([]byte, streamChunkSize)
+	next := make([]byte, streamChunkSize)
+	n, err := io.ReadFull(src, current)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	sealed := make([]byte, 0, 4+streamChunkSize+aead.Overhead())
+	for counter := uint32(0); ; counter++ {
+		last := n < streamChunkSize
+		var m int
+		if !last {
+			m, err = io.ReadFull(src, next)
+			if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return err
+			}
+			last = m == 0
+		}
+
+		sealed = binary.BigEndian.AppendUint32(sealed[:0], uint32(n+aead.Overhead()))
+		sealed = aead.Seal(sealed, streamNonce(prefix, counter, last), current[:n], nil)
+		if _, err := dst.Write(sealed); err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+		if counter == ^uint32(0) {
+			return errors.New("加密数据过大")
+		}
+		current, next, n = next, current, m
+	}
+}
+
+// DecryptStream 实现 StreamCrypto：逐块解密 EncryptStream 输出的密文
+func (d *DefaultCrypto) DecryptStream(dst io.Writer, src io.Reader) error {
+	head := make([]byte, len(streamMagic)+len(streamVersion)+saltLen+streamNoncePrefixLen)
+	if _, err := io.ReadFull(src, head); err != nil {
+		return errors.New("流式加密数据格式无效")
+	}
+	if !bytes.HasPrefix(head, streamMagic) {
+		return errors.New("数据不是有效的流式加密格式")
+	}
+	rest := head[len(streamMagic):]
+	if !bytes.Equal(rest[:len(streamVersion)], streamVersion) {
+		return errors.New("不支持的流式加密数据版本")
+	}
+	rest = rest[len(streamVersion):]
+	salt, prefix := rest[:saltLen], rest[saltLen:]
+
+	aead, err := chacha20poly1305.New(deriveKey(d.key, salt))
+	if err != nil {
+		return fmt.Errorf("创建ChaCha20-Poly1305失败: %w", err)
+	}
+	return decryptChunks(aead, prefix, dst, src)
+}
+
+// decryptChunks 依次读取并解密长度前缀的密文块，要求以带结束标记的块收尾
+func decryptChunks(aead cipher.AEAD, prefix []byte, dst io.Writer, src io.Reader) error {
+	var (
+		size  [4]byte
+		chunk = make([]byte, streamChunkSize+aead.Overhead())
+		plain = make([]byte, 0, streamChunkSize)
+	)
+	for counter := uint32(0); ; counter++ {
+		if _, err := io.ReadFull(src, size[:]); err != nil {
+			return errors.New("流式加密数据被截断")
+		}
+		n := int(binary.BigEndian.Uint32(size[:]))
+		if n < aead.Overhead() || n > len(chunk) {
+			return errors.New("流式加密数据块长度无效")
+		}
+		if _, err := io.ReadFull(src, chunk[:n]); err != nil {
+			return errors.New("流式加密数据被截断")
+		}
+
+		// 先按普通块尝试，失败后按结束块尝试，篡改结束标记同样会导致认证失败
+		last := false
+		out, err := aead.Open(plain[:0], streamNonce(prefix, counter, false), chunk[:n], nil)
+		if err != nil {
+			if out, err = aead.Open(plain[:0], streamNonce(prefix, counter, true), chunk[:n], nil); err != nil {
+				return fmt.Errorf("解密失败: %w", err)
+			}
+			last = true
+		}
+		if _, err := dst.Write(out); err != nil {
+			return err
+		}
+		if last {
+			if extra, _ := src.Read(size[:1]); extra > 0 {
+				return errors.New("流式加密数据结束块之后存在多余数据")
+			}
+			return nil
+		}
+	}
+}
+
+// streamNonce 构造块 nonce：随机前缀 + 大端块序号 + 结束标记
+func streamNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 0, chacha20poly1305.NonceSize)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, counter)
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
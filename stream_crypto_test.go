@@ -0,0 +1,96 @@
+package sysconf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestDefaultCryptoStreamRoundTrip(t *testing.T) {
+	crypto, err := NewDefaultCrypto("stream-key")
+	if err != nil {
+		t.Fatalf("create crypto failed: %v", err)
+	}
+
+	for _, size := range []int{0, 1, streamChunkSize - 1, streamChunkSize, streamChunkSize + 1, 3*streamChunkSize + 5} {
+		plain := make([]byte, size)
+		_, _ = rand.Read(plain)
+
+		var sealed bytes.Buffer
+		if err := crypto.EncryptStream(&sealed, bytes.NewReader(plain)); err != nil {
+			t.Fatalf("size %d: encrypt failed: %v", size, err)
+		}
+		var opened bytes.Buffer
+		if err := crypto.DecryptStream(&opened, bytes.NewReader(sealed.Bytes())); err != nil {
+			t.Fatalf("size %d: decrypt failed: %v", size, err)
+		}
+		if !bytes.Equal(opened.Bytes(), plain) {
+			t.Fatalf("size %d: round trip mismatch", size)
+		}
+	}
+
+	plain := make([]byte, 2*streamChunkSize+10)
+	var sealed bytes.Buffer
+	if err := crypto.EncryptStream(&sealed, bytes.NewReader(plain)); err != nil {
+		t.Fatalf("encrypt failed: %v", err)
+	}
+	tampered := bytes.Clone(sealed.Bytes())
+	tampered[len(tampered)/2] ^= 0xff
+	if err := crypto.DecryptStream(&bytes.Buffer{}, bytes.NewReader(tampered)); err == nil {
+		t.Fatalf("expected error for tampered stream")
+	}
+	// 截掉最后一块（结束块）应被检测出来
+	lastChunk := 4 + 10 + 16
+	truncated := sealed.Bytes()[:sealed.Len()-lastChunk]
+	if err := crypto.DecryptStream(&bytes.Buffer{}, bytes.NewReader(truncated)); err == nil {
+		t.Fatalf("expected error for truncated stream")
+	}
+	other, _ := NewDefaultCrypto("other-key")
+	if err := other.DecryptStream(&bytes.Buffer{}, bytes.NewReader(sealed.Bytes())); err == nil {
+		t.Fatalf("expected error for wrong key")
+	}
+}
+
+func TestStreamEncryptedConfigFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	opts := []Option{
+		WithPath(tmpDir), WithMode("yaml"), WithName("large"),
+		WithContent("name: demo\n"), WithEncryption("stream-key"),
+		WithWriteDebounceDelay(0), WithEncryptionStreamThreshold(1),
+	}
+	cfg, err := New(opts...)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	blob := bytes.Repeat([]byte("x"), streamChunkSize+100)
+	if err := cfg.Set("blob", string(blob)); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(tmpDir, "large.yaml"))
+	if err != nil {
+		t.Fatalf("read config failed: %v", err)
+	}
+	header, ok := ReadEnvelopeHeader(raw)
+	if !ok || !header.Stream {
+		t.Fatalf("expected stream envelope header, got %+v (%v)", header, ok)
+	}
+	if info, ok := cfg.FileInfo(); !ok || !info.Encrypted || info.Size != int64(len(raw)) {
+		t.Fatalf("file info should describe the streamed file, got %+v", info)
+	}
+
+	reopened, err := New(opts[:5]...)
+	if err != nil {
+		t.Fatalf("reopen stream-encrypted config failed: %v", err)
+	}
+	testutil.Cleanup(t, reopened.Close)
+	if got := reopened.GetString("blob"); got != string(blob) || reopened.GetString("name") != "demo" {
+		t.Fatalf("unexpected values after reopen: name=%q blob len=%d", reopened.GetString("name"), len(got))
+	}
+}
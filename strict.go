@@ -0,0 +1,329 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/cast"
+)
+
+// ErrTypeMismatch 启用 WithStrictTypes 时配置值类型与读取类型不一致，或数值转换会丢失精度、溢出
+var ErrTypeMismatch = errors.New("config value type mismatch")
+
+// WithStrictTypes 启用严格类型模式：读取时保存类型与请求类型不一致（如以 GetInt 读取字符串 "8080"、
+// 以 GetString 读取数字）不再隐式转换，E 系列方法（GetIntE 等）与 GetAsWithError 返回匹配 ErrTypeMismatch 的错误，
+// 普通 getter 记录警告并返回默认值；数值截断（3.7 读取为 int）与溢出同样视为不一致，Unmarshal 遇到时返回错误。
+// 环境变量与命令行标志的值本身是字符串，按目标类型解析不受限制；字符串读取为时间间隔、时间与枚举也不受限制。
+func WithStrictTypes(enabled bool) Option {
+	return func(c *Config) {
+		c.strictTypes = enabled
+	}
+}
+
+// checkCoercion 记录读取时发生的类型转换（见 CoercionReport）；启用 WithStrictTypes 且转换不被允许时返回错误。
+// 调用方只在快速路径未命中时调用。
+func (c *Config) checkCoercion(key string, val any, requested reflect.Type) error {
+	c.noteCoercion(key, val, requested)
+	if !c.strictTypes {
+		return nil
+	}
+	stored, want := valueClass(val), typeClass(requested)
+	switch {
+	case stored == "number" && want == "number":
+		if err := numberFits(val, requested); err != nil {
+			return strictTypeError(key, val, requested, err.Error())
+		}
+		return nil
+	case stored == want, stored == "string" && (want == "duration" || want == "time" || want == "enum"):
+		return nil
+	}
+	if _, ok := val.(string); ok {
+		if source, _ := c.Origin(key); source == SourceEnv || source == SourceFlags {
+			return nil
+		}
+	}
+	return strictTypeError(key, val, requested, fmt.Sprintf("stored as %T", val))
+}
+
+// strictTypeError 构造严格类型模式下的类型不一致错误
+func strictTypeError(key string, val any, requested reflect.Type, reason string) error {
+	return &ConfigError{
+		Type:    ErrTypeConversion,
+		Message: fmt.Sprintf("配置项 %s 无法严格读取为 %s: %s", key, requested, reason),
+		Key:     key,
+		Value:   fmt.Sprint(val),
+		Cause:   ErrTypeMismatch,
+	}
+}
+
+// numberFits 判断数值能否无损转换为目标数值类型
+func numberFits(val any, typ reflect.Type) error {
+	v := reflect.ValueOf(val)
+	target := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch {
+		case v.CanInt():
+			if target.OverflowInt(v.Int()) {
+				return fmt.Errorf("%v overflows %s", val, typ)
+			}
+		case v.CanUint():
+			if v.Uint() > math.MaxInt64 || target.OverflowInt(int64(v.Uint())) {
+				return fmt.Errorf("%v overflows %s", val, typ)
+			}
+		default:
+			f := v.Float()
+			if f != math.Trunc(f) {
+				return fmt.Errorf("%v would be truncated", val)
+			}
+			if f < math.MinInt64 || f >= math.MaxInt64 || target.OverflowInt(int64(f)) {
+				return fmt.Errorf("%v overflows %s", val, typ)
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch {
+		case v.CanInt():
+			if v.Int() < 0 || target.OverflowUint(uint64(v.Int())) {
+				return fmt.Errorf("%v overflows %s", val, typ)
+			}
+		case v.CanUint():
+			if target.OverflowUint(v.Uint()) {
+				return fmt.Errorf("%v overflows %s", val, typ)
+			}
+		default:
+			f := v.Float()
+			if f != math.Trunc(f) {
+				return fmt.Errorf("%v would be truncated", val)
+			}
+			if f < 0 || f >= math.MaxUint64 || target.OverflowUint(uint64(f)) {
+				return fmt.Errorf("%v overflows %s", val, typ)
+			}
+		}
+	case reflect.Float32:
+		if v.CanFloat() && target.OverflowFloat(v.Float()) {
+			return fmt.Errorf("%v overflows %s", val, typ)
+		}
+	}
+	return nil
+}
+
+// strictNumberDecodeHook Unmarshal 使用的数值解码钩子，启用 WithStrictTypes 时拒绝截断与溢出
+func (c *Config) strictNumberDecodeHook() mapstructure.DecodeHookFunc {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if !c.strictTypes || to == durationType || typeClass(from) != "number" || typeClass(to) != "number" {
+			return data, nil
+		}
+		if err := numberFits(data, to); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrTypeMismatch, err)
+		}
+		return data, nil
+	}
+}
+
+// getE 标量 E 系列方法的公共实现：在 getWithE 的基础上检查严格类型模式下的类型不一致
+func getE[T any](c *Config, key string, opts []GetOption, convert func(any) (T, error)) (T, error) {
+	return getWithE(c, key, opts, func(val any) (T, error) {
+		if result, ok := val.(T); ok {
+			return result, nil
+		}
+		if err := c.checkCoercion(key, val, reflect.TypeFor[T]()); err != nil {
+			var zero T
+			return zero, err
+		}
+		return convert(val)
+	})
+}
+
+// getWithE E 系列方法的读取流程：按 GetOption 读取，键不存在时返回 WithDefault 设置的默认值或错误，
+// 转换失败时返回 ErrTypeConversion 类型的 ConfigError（convert 已返回 ConfigError 时原样返回）
+func getWithE[T any](c *Config, key string, opts []GetOption, convert func(any) (T, error)) (T, error) {
+	var zero T
+	if key == "" {
+		return zero, fmt.Errorf("empty configuration key")
+	}
+	o := applyGetOptions(opts)
+	val, exists := c.getRawWith(key, &o)
+	if !exists {
+		if o.hasDef {
+			return defaultAs[T](&o), nil
+		}
+		return zero, fmt.Errorf("configuration key '%s' not found", key)
+	}
+	result, err := convert(val)
+	if err != nil {
+		var cfgErr *ConfigError
+		if errors.As(err, &cfgErr) {
+			return zero, err
+		}
+		return zero, &ConfigError{
+			Type:    ErrTypeConversion,
+			Message: fmt.Sprintf("配置项 %s 无法转换为 %s", key, reflect.TypeFor[T]()),
+			Key:     key,
+			Value:   fmt.Sprint(val),
+			Cause:   err,
+		}
+	}
+	return result, nil
+}
+
+// getSliceE 切片 E 系列方法的公共实现：值须为列表，存在无法转换的元素时返回匹配 ErrSliceElement 的错误
+// （不受 WithSlicePolicy 影响）
+func getSliceE[T any](c *Config, key string, opts []GetOption, convert func(any) (T, bool)) ([]T, error) {
+	return getWithE(c, key, opts, func(val any) ([]T, error) {
+		if result, ok := val.([]T); ok {
+			return append([]T(nil), result...), nil
+		}
+		items, ok := sliceItems(val)
+		if !ok {
+			return nil, fmt.Errorf("value is not a list (%T)", val)
+		}
+		return convertSlice(c, key, items, SliceError, convert)
+	})
+}
+
+// GetStringE 获取字符串配置，键不存在、转换失败或严格类型模式下类型不一致时返回错误
+func (c *Config) GetStringE(key string, opts ...GetOption) (string, error) {
+	result, err := getE(c, key, opts, cast.ToStringE)
+	if err == nil && c.isPathKey(key) {
+		result = c.normalizePath(result)
+	}
+	return result, err
+}
+
+// GetIntE 获取整数配置，键不存在、转换失败或严格类型模式下类型不一致时返回错误
+func (c *Config) GetIntE(key string, opts ...GetOption) (int, error) {
+	return getE(c, key, opts, func(val any) (int, error) {
+		result, err := cast.ToIntE(val)
+		if err != nil {
+			if lenient, ok := c.lenientInt(key, val); ok {
+				return lenient, nil
+			}
+		}
+		return result, err
+	})
+}
+
+// GetFloatE 获取浮点数配置，键不存在、转换失败或严格类型模式下类型不一致时返回错误
+func (c *Config) GetFloatE(key string, opts ...GetOption) (float64, error) {
+	return getE(c, key, opts, func(val any) (float64, error) {
+		result, err := cast.ToFloat64E(val)
+		if err != nil {
+			if lenient, ok := c.lenientFloat(key, val); ok {
+				return lenient, nil
+			}
+		}
+		return result, err
+	})
+}
+
+// GetBoolE 获取布尔值配置，键不存在、转换失败或严格类型模式下类型不一致时返回错误
+func (c *Config) GetBoolE(key string, opts ...GetOption) (bool, error) {
+	return getE(c, key, opts, func(val any) (bool, error) {
+		if s, ok := val.(string); ok && c.extendedBools {
+			if b, ok := parseExtendedBool(s); ok {
+				return b, nil
+			}
+		}
+		return cast.ToBoolE(val)
+	})
+}
+
+// GetDurationE 获取时间间隔配置，键不存在、转换失败或严格类型模式下类型不一致时返回错误
+func (c *Config) GetDurationE(key string, opts ...GetOption) (time.Duration, error) {
+	return getE(c, key, opts, cast.ToDurationE)
+}
+
+// GetTimeE 获取时间配置，键不存在或无法按 WithTimeLayouts 及常见格式解析时返回错误
+func (c *Config) GetTimeE(key string, opts ...GetOption) (time.Time, error) {
+	return getWithE(c, key, opts, c.parseTime)
+}
+
+// GetPathE 按路径规则读取配置值（同 GetPath），键不存在或无法转换为字符串时返回错误
+func (c *Config) GetPathE(key string, opts ...GetOption) (string, error) {
+	result, err := getWithE(c, key, opts, cast.ToStringE)
+	if err != nil {
+		return "", err
+	}
+	return c.normalizePath(result), nil
+}
+
+// GetDurationJitteredE 获取时间间隔配置并叠加随机抖动（同 GetDurationJittered），读取失败时返回 GetDurationE 的错误
+func (c *Config) GetDurationJitteredE(key string, jitter float64, opts ...GetOption) (time.Duration, error) {
+	d, err := c.GetDurationE(key, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return jitterDuration(d, jitter), nil
+}
+
+// GetIntBetweenE 获取整数区间配置并返回区间内的随机值（同 GetIntBetween），键不存在或区间格式无效时返回错误
+func (c *Config) GetIntBetweenE(key string, opts ...GetOption) (int, error) {
+	return getWithE(c, key, opts, func(val any) (int, error) {
+		lo, hi, err := parseIntRange(val)
+		if err != nil {
+			return 0, err
+		}
+		return randomInRange(lo, hi), nil
+	})
+}
+
+// GetStringSliceE 获取字符串切片配置，字符串值按空白分隔；键不存在或存在无法转换的元素时返回错误
+func (c *Config) GetStringSliceE(key string, opts ...GetOption) ([]string, error) {
+	result, err := getWithE(c, key, opts, func(val any) ([]string, error) {
+		if s, ok := val.(string); ok {
+			return strings.Fields(s), nil
+		}
+		items, ok := sliceItems(val)
+		if !ok {
+			return nil, fmt.Errorf("value is not a list (%T)", val)
+		}
+		return convertSlice(c, key, items, SliceError, castOK(cast.ToStringE))
+	})
+	if err == nil && c.isPathKey(key) {
+		result = c.normalizePathValue(result).([]string)
+	}
+	return result, err
+}
+
+// GetIntSliceE 获取整数切片配置，键不存在、值不是列表或存在无法转换的元素时返回错误
+func (c *Config) GetIntSliceE(key string, opts ...GetOption) ([]int, error) {
+	return getSliceE(c, key, opts, castOK(cast.ToIntE))
+}
+
+// GetFloatSliceE 获取浮点数切片配置，键不存在、值不是列表或存在无法转换的元素时返回错误
+func (c *Config) GetFloatSliceE(key string, opts ...GetOption) ([]float64, error) {
+	return getSliceE(c, key, opts, castOK(cast.ToFloat64E))
+}
+
+// GetBoolSliceE 获取布尔值切片配置，键不存在、值不是列表或存在无法转换的元素时返回错误
+func (c *Config) GetBoolSliceE(key string, opts ...GetOption) ([]bool, error) {
+	return getSliceE(c, key, opts, boolItem)
+}
+
+// GetTimeSliceE 获取时间切片配置（同 GetTimeSlice 支持逗号分隔的字符串），键不存在或存在无法解析的元素时返回错误
+func (c *Config) GetTimeSliceE(key string, opts ...GetOption) ([]time.Time, error) {
+	return getWithE(c, key, opts, func(val any) ([]time.Time, error) {
+		return c.timeSlice(key, val, SliceError)
+	})
+}
+
+// GetStringMapE 获取字符串映射配置，键不存在或值不是映射时返回错误
+func (c *Config) GetStringMapE(key string, opts ...GetOption) (map[string]any, error) {
+	return getWithE(c, key, opts, func(val any) (map[string]any, error) {
+		result, err := cast.ToStringMapE(val)
+		return deepCloneMap(result), err
+	})
+}
+
+// GetStringMapStringE 获取字符串-字符串映射配置，键不存在或值不是映射时返回错误
+func (c *Config) GetStringMapStringE(key string, opts ...GetOption) (map[string]string, error) {
+	return getWithE(c, key, opts, func(val any) (map[string]string, error) {
+		result, err := cast.ToStringMapStringE(val)
+		return cloneStringMapString(result), err
+	})
+}
@@ -0,0 +1,127 @@
+package sysconf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+const strictContent = `server:
+  port: "8080"
+  workers: 4
+  ratio: 3.7
+  big: 300
+  name: api
+  timeout: 30s
+app:
+  version: 2
+`
+
+func TestStrictTypesGetters(t *testing.T) {
+	cfg, err := New(WithContent(strictContent), WithStrictTypes(true))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if _, err := cfg.GetIntE("server.port"); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("expected ErrTypeMismatch for string port, got %v", err)
+	}
+	var configErr *ConfigError
+	if _, err := cfg.GetStringE("app.version"); !errors.As(err, &configErr) || configErr.Key != "app.version" {
+		t.Fatalf("expected ConfigError for numeric version, got %v", err)
+	}
+	if _, err := cfg.GetIntE("server.ratio"); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("expected truncation to be rejected, got %v", err)
+	}
+	if _, err := GetAsWithError[int8](cfg, "server.big"); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("expected overflow to be rejected, got %v", err)
+	}
+
+	if got, err := cfg.GetIntE("server.workers"); err != nil || got != 4 {
+		t.Fatalf("expected exact int, got %d (%v)", got, err)
+	}
+	if got, err := cfg.GetFloatE("server.workers"); err != nil || got != 4 {
+		t.Fatalf("expected lossless int to float, got %v (%v)", got, err)
+	}
+	if got, err := cfg.GetDurationE("server.timeout"); err != nil || got.String() != "30s" {
+		t.Fatalf("expected duration string to parse, got %v (%v)", got, err)
+	}
+	if got, err := cfg.GetStringE("server.name"); err != nil || got != "api" {
+		t.Fatalf("expected string, got %q (%v)", got, err)
+	}
+	if _, err := cfg.GetIntE("missing"); err == nil {
+		t.Fatal("expected error for missing key")
+	}
+	if got, err := cfg.GetIntE("missing", WithDefault(7)); err != nil || got != 7 {
+		t.Fatalf("expected default for missing key, got %d (%v)", got, err)
+	}
+
+	// 普通 getter 不再隐式转换，返回默认值
+	if got := cfg.GetInt("server.port", 1); got != 1 {
+		t.Fatalf("expected default instead of coercion, got %d", got)
+	}
+	if got := cfg.GetInt("server.ratio"); got != 0 {
+		t.Fatalf("expected zero instead of truncation, got %d", got)
+	}
+	if got := GetAs[int](cfg, "server.port", 2); got != 2 {
+		t.Fatalf("expected GetAs default instead of coercion, got %d", got)
+	}
+}
+
+func TestStrictTypesEnvStrings(t *testing.T) {
+	t.Setenv("STRICT_SERVER_WORKERS", "16")
+	cfg, err := New(WithContent(strictContent), WithStrictTypes(true), WithEnv("STRICT"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got, err := cfg.GetIntE("server.workers"); err != nil || got != 16 {
+		t.Fatalf("expected env string to parse in strict mode, got %d (%v)", got, err)
+	}
+}
+
+func TestStrictTypesDisabled(t *testing.T) {
+	cfg, err := New(WithContent(strictContent))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got, err := cfg.GetIntE("server.port"); err != nil || got != 8080 {
+		t.Fatalf("expected coercion without strict mode, got %d (%v)", got, err)
+	}
+	if _, err := cfg.GetIntE("server.name"); err == nil {
+		t.Fatal("expected conversion error for non-numeric string")
+	}
+}
+
+func TestStrictTypesUnmarshal(t *testing.T) {
+	cfg, err := New(WithContent(strictContent), WithStrictTypes(true))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	var truncated struct {
+		Ratio int `config:"ratio"`
+	}
+	if err := cfg.Unmarshal(&truncated, "server"); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("expected truncation error, got %v", err)
+	}
+	var overflow struct {
+		Big int8 `config:"big"`
+	}
+	if err := cfg.Unmarshal(&overflow, "server"); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("expected overflow error, got %v", err)
+	}
+	var exact struct {
+		Workers uint16  `config:"workers"`
+		Ratio   float32 `config:"ratio"`
+	}
+	if err := cfg.Unmarshal(&exact, "server"); err != nil || exact.Workers != 4 {
+		t.Fatalf("expected lossless conversions to succeed, got %+v (%v)", exact, err)
+	}
+}
@@ -0,0 +1,47 @@
+package sysconf
+
+import "strings"
+
+// SliceOption GetStringSlice 的读取选项
+type SliceOption func(*sliceOptions)
+
+// sliceOptions GetStringSlice 的读取设置
+type sliceOptions struct {
+	def       []string
+	delimiter string
+}
+
+// WithDefaultSlice 设置键不存在、无法转换或结果为空时返回的默认切片（返回副本）
+func WithDefaultSlice(def []string) SliceOption {
+	return func(o *sliceOptions) {
+		o.def = def
+	}
+}
+
+// WithDelimiter 设置字符串值的分隔符，如 Windows/Java properties 迁移来的 "a;b;c"。
+// 分隔后的元素会去除首尾空白并丢弃空元素；未设置时字符串值按空白分隔。
+func WithDelimiter(delimiter string) SliceOption {
+	return func(o *sliceOptions) {
+		o.delimiter = delimiter
+	}
+}
+
+// splitDelimited 按分隔符拆分字符串，去除首尾空白并丢弃空元素
+func splitDelimited(s, delimiter string) []string {
+	parts := strings.Split(s, delimiter)
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// defaultSlice 返回默认切片的副本，未设置默认值时返回空切片
+func (o *sliceOptions) defaultSlice() []string {
+	if o.def == nil {
+		return []string{}
+	}
+	return append([]string(nil), o.def...)
+}
@@ -0,0 +1,85 @@
+package sysconf
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WithTableSource 将 CSV/TSV 表格文件加载到指定配置键，值类型为 []map[string]string，
+// 适用于限流表、路由表等表格型配置。首行为表头，每个数据行按表头转换为一个 map；
+// .tsv/.tab 文件以制表符分隔，其余按逗号分隔。相对路径基于配置目录（WithPath）解析。
+// 表格内容覆盖主配置中的同名键且不会写回主配置文件；调用 Watch 后，表格文件变更会自动重新加载并触发回调。
+func WithTableSource(key, path string) Option {
+	return func(c *Config) {
+		layer := &sourceLayer{
+			name: "table source " + path,
+			key:  key,
+			path: path,
+		}
+		layer.load = func() (any, error) {
+			return loadTableFile(layer.path)
+		}
+		c.addSource(layer)
+	}
+}
+
+// loadTableFile 读取并解析表格文件
+func loadTableFile(path string) ([]map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	comma := ','
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tsv", ".tab":
+		comma = '\t'
+	}
+	return parseTable(data, comma)
+}
+
+// parseTable 解析带表头的分隔符表格；空行被忽略，列数与表头不一致时报错并给出行号
+func parseTable(data []byte, comma rune) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.Comma = comma
+	reader.TrimLeadingSpace = true
+	if comma == '\t' {
+		reader.LazyQuotes = true
+	}
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return []map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read table header: %w", err)
+	}
+	for i, name := range header {
+		header[i] = strings.TrimSpace(name)
+		if header[i] == "" {
+			return nil, fmt.Errorf("table header column %d is empty", i+1)
+		}
+	}
+
+	rows := make([]map[string]string, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read table row: %w", err)
+		}
+		row := make(map[string]string, len(header))
+		for i, name := range header {
+			row[name] = strings.TrimSpace(record[i])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
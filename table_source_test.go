@@ -0,0 +1,181 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestParseTable(t *testing.T) {
+	rows, err := parseTable([]byte("\xef\xbb\xbfpath, upstream ,weight\n/api,\"svc-a, primary\",10\n\n/web,svc-b,5\n"), ',')
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0]["path"] != "/api" || rows[0]["upstream"] != "svc-a, primary" || rows[1]["weight"] != "5" {
+		t.Fatalf("unexpected rows: %#v", rows)
+	}
+
+	if _, err := parseTable([]byte("a,b\n1\n"), ','); err == nil {
+		t.Fatalf("expected error for ragged row")
+	}
+	if rows, err := parseTable(nil, ','); err != nil || len(rows) != 0 {
+		t.Fatalf("empty table should yield no rows, got %v, %v", rows, err)
+	}
+}
+
+func TestTableSourceLoadAndWriteBack(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "limits.tsv"), []byte("route\trps\n/login\t5\n/search\t50\n"), 0o644); err != nil {
+		t.Fatalf("write table failed: %v", err)
+	}
+
+	cfg, err := New(
+		WithPath(tmpDir),
+		WithName("app"),
+		WithMode("yaml"),
+		WithContent("app:\n  name: demo\nlimits: stale\n"),
+		WithTableSource("limits", "limits.tsv"),
+		WithWriteDebounceDelay(0),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	rows, ok := cfg.Get("limits").([]map[string]string)
+	if !ok || len(rows) != 2 || rows[1]["rps"] != "50" {
+		t.Fatalf("unexpected table value: %#v", cfg.Get("limits"))
+	}
+
+	var target struct {
+		Limits []struct {
+			Route string `config:"route"`
+			RPS   int    `config:"rps"`
+		} `config:"limits"`
+	}
+	if err := cfg.Unmarshal(&target); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if len(target.Limits) != 2 || target.Limits[0].Route != "/login" || target.Limits[0].RPS != 5 {
+		t.Fatalf("unexpected unmarshal result: %+v", target.Limits)
+	}
+
+	if err := cfg.Set("app.name", "changed"); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	written, err := os.ReadFile(filepath.Join(tmpDir, "app.yaml"))
+	if err != nil {
+		t.Fatalf("read config failed: %v", err)
+	}
+	if strings.Contains(string(written), "limits") || strings.Contains(string(written), "/login") {
+		t.Fatalf("table data must not be written to the main config:\n%s", written)
+	}
+}
+
+func TestTableSourceWatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	tablePath := filepath.Join(tmpDir, "routes.csv")
+	if err := os.WriteFile(tablePath, []byte("path,target\n/a,one\n"), 0o644); err != nil {
+		t.Fatalf("write table failed: %v", err)
+	}
+
+	cfg, err := New(WithPath(tmpDir), WithContent("app: {}\n"), WithTableSource("routes", "routes.csv"))
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	changed := make(chan struct{}, 4)
+	cfg.Watch(func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+
+	if err := os.WriteFile(tablePath, []byte("path,target\n/a,one\n/b,two\n"), 0o644); err != nil {
+		t.Fatalf("rewrite table failed: %v", err)
+	}
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case <-changed:
+		case <-deadline:
+			t.Fatalf("table change not applied, got %#v", cfg.Get("routes"))
+		}
+		if rows, _ := cfg.Get("routes").([]map[string]string); len(rows) == 2 {
+			break
+		}
+	}
+
+	// 解析失败时保留最后一次成功加载的表格
+	events := make(chan HealthEvent, 8)
+	cfg.OnHealthEvent(func(e HealthEvent) { events <- e })
+	if err := os.WriteFile(tablePath, []byte("path,target\n/a\n"), 0o644); err != nil {
+		t.Fatalf("rewrite table failed: %v", err)
+	}
+	event := waitHealthEvent(t, events, HealthEventReloadFailed)
+	if event.File != tablePath {
+		t.Fatalf("expected event for %s, got %s", tablePath, event.File)
+	}
+	if rows, _ := cfg.Get("routes").([]map[string]string); len(rows) != 2 {
+		t.Fatalf("expected last good table to be kept, got %#v", cfg.Get("routes"))
+	}
+}
+
+func TestTableSourceMissingFile(t *testing.T) {
+	_, err := New(WithPath(t.TempDir()), WithContent("app: {}\n"), WithTableSource("routes", "missing.csv"))
+	if err == nil {
+		t.Fatalf("expected error for missing table file")
+	}
+}
+
+func TestTableSourcesLoadInRegistrationOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	var opts []Option
+	opts = append(opts, WithPath(tmpDir), WithContent("app: demo\n"))
+	for i := range 20 {
+		name := filepath.Join(tmpDir, "t"+strconv.Itoa(i)+".csv")
+		if err := os.WriteFile(name, []byte("id,owner\n1,"+strconv.Itoa(i)+"\n"), 0o644); err != nil {
+			t.Fatalf("write table failed: %v", err)
+		}
+		opts = append(opts, WithTableSource("tables.t"+strconv.Itoa(i), name))
+		// 同一键上注册的后一个数据源覆盖前一个
+		opts = append(opts, WithTableSource("tables.shared", name))
+	}
+
+	cfg, err := New(opts...)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	for i := range 20 {
+		key := "tables.t" + strconv.Itoa(i)
+		rows, ok := cfg.Get(key).([]map[string]string)
+		if !ok || len(rows) != 1 {
+			t.Fatalf("%s = %#v", key, cfg.Get(key))
+		}
+	}
+	rows, _ := cfg.Get("tables.shared").([]map[string]string)
+	if len(rows) != 1 || rows[0]["owner"] != "19" {
+		t.Fatalf("expected last registered source to win, got %#v", rows)
+	}
+
+	_, err = New(
+		WithPath(tmpDir),
+		WithTableSource("a", "missing-a.csv"),
+		WithTableSource("b", "missing-b.csv"),
+	)
+	if err == nil || !strings.Contains(err.Error(), "missing-a.csv") {
+		t.Fatalf("expected error for first registered source, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package sysconf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/darkit/sysconf/validation"
+)
+
+// validateTagRules 按结构体 validate 标签（如 "required,min=1,max=65535,oneof=a b"）校验单个值。
+// 支持 required/min/max/len/oneof 以及 validation 包中已注册的规则，未知规则会被忽略。
+func validateTagRules(value any, tag string) error {
+	for _, raw := range strings.Split(tag, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(raw, "=")
+
+		var rule validation.ValidationRule
+		switch name {
+		case "required":
+			rule = validation.Required("value is required")
+		case "min":
+			rule = validation.Min(param, fmt.Sprintf("must be at least %s", param))
+		case "max":
+			rule = validation.Max(param, fmt.Sprintf("must be at most %s", param))
+		case "len":
+			rule = validation.Length(param, fmt.Sprintf("length must be %s", param))
+		case "oneof":
+			values := strings.Join(strings.Fields(param), ",")
+			rule = validation.Enum(values, fmt.Sprintf("must be one of [%s]", param))
+		default:
+			if !validation.HasRule(name) {
+				continue
+			}
+			rule = validation.NewRule(name, param, "")
+		}
+
+		if err := validation.Validate(value, rule); err != nil {
+			return fmt.Errorf("rule %q: %w", raw, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package sysconf
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	mapstructure "github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/cast"
+)
+
+// timeType time.Time 的反射类型
+var timeType = reflect.TypeFor[time.Time]()
+
+// WithTimeLayouts 追加解析时间值时优先尝试的格式（如 time.RFC3339Nano、"2006/01/02"），
+// 作用于 GetTime、GetTimeSlice 与 Unmarshal 的 time.Time 字段；均不匹配时回退到默认的格式识别。
+// 单个字段可通过 `layout:"2006/01/02"` 标签指定专用格式，优先于此处的格式。
+func WithTimeLayouts(layouts ...string) Option {
+	return func(c *Config) {
+		for _, layout := range layouts {
+			if layout != "" {
+				c.timeLayouts = append(c.timeLayouts, layout)
+			}
+		}
+	}
+}
+
+// parseTime 按 WithTimeLayouts 指定的格式解析时间，均不匹配时回退到 cast 的默认格式
+func (c *Config) parseTime(val any) (time.Time, error) {
+	if s, ok := val.(string); ok && len(c.timeLayouts) > 0 {
+		s = strings.TrimSpace(s)
+		for _, layout := range c.timeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return cast.ToTimeE(val)
+}
+
+// GetTimeSlice 获取时间切片配置，支持列表与逗号分隔的字符串，无法解析的元素按 WithSlicePolicy 处理
+//
+// 参数:
+//   - key: 配置键名
+//
+// 返回值:
+//   - 时间切片类型的配置值
+func (c *Config) GetTimeSlice(key string) []time.Time {
+	if key == "" {
+		return []time.Time{}
+	}
+
+	val, exists := c.getRaw(key)
+	if !exists || val == nil {
+		return []time.Time{}
+	}
+
+	result, err := c.timeSlice(key, val, c.slicePolicy)
+	if err != nil {
+		c.logger.Errorf("%v", err)
+		return []time.Time{}
+	}
+	return result
+}
+
+// timeSlice 将列表或逗号分隔的字符串解析为时间切片，无法解析的元素按 policy 处理
+func (c *Config) timeSlice(key string, val any, policy SlicePolicy) ([]time.Time, error) {
+	var items []any
+	switch v := val.(type) {
+	case []time.Time:
+		return append([]time.Time(nil), v...), nil
+	case []any:
+		items = v
+	case []string:
+		items = make([]any, len(v))
+		for i, s := range v {
+			items[i] = s
+		}
+	case string:
+		for part := range strings.SplitSeq(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				items = append(items, part)
+			}
+		}
+	default:
+		items = []any{v}
+	}
+
+	return convertSlice(c, key, items, policy, func(item any) (time.Time, bool) {
+		t, err := c.parseTime(item)
+		return t, err == nil
+	})
+}
+
+// timeDecodeHook Unmarshal 使用的时间解码钩子，按 WithTimeLayouts 解析字符串
+func (c *Config) timeDecodeHook() mapstructure.DecodeHookFunc {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		if to != timeType || from.Kind() != reflect.String || len(c.timeLayouts) == 0 {
+			return data, nil
+		}
+		if strings.TrimSpace(data.(string)) == "" {
+			return time.Time{}, nil
+		}
+		return c.parseTime(data)
+	}
+}
+
+// applyFieldTimeLayouts 按结构体字段的 layout 标签预先解析时间字符串（time.Time、*time.Time 与 []time.Time），
+// input 为 Unmarshal 持有的配置副本，直接原地替换
+func applyFieldTimeLayouts(input any, t reflect.Type, path string) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	m, ok := input.(map[string]any)
+	if !ok || t.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, inline, skip := docFieldKey(sf)
+		if skip {
+			continue
+		}
+		if inline {
+			if err := applyFieldTimeLayouts(m, sf.Type, path); err != nil {
+				return err
+			}
+			continue
+		}
+		key, found := matchInputKey(m, name)
+		if !found {
+			continue
+		}
+		fieldPath := joinKey(path, key)
+		layout := sf.Tag.Get("layout")
+		if layout == "" {
+			if err := applyFieldTimeLayouts(m[key], sf.Type, fieldPath); err != nil {
+				return err
+			}
+			continue
+		}
+		parsed, err := parseWithLayout(m[key], sf.Type, layout)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fieldPath, err)
+		}
+		m[key] = parsed
+	}
+	return nil
+}
+
+// matchInputKey 按 Unmarshal 的字段名匹配规则查找输入中的键
+func matchInputKey(m map[string]any, name string) (string, bool) {
+	if _, ok := m[name]; ok {
+		return name, true
+	}
+	for key := range m {
+		if cachedMatchName(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// parseWithLayout 按 layout 解析时间字段的字符串值，非字符串值原样返回交由解码器处理
+func parseWithLayout(val any, t reflect.Type, layout string) (any, error) {
+	if t.Kind() == reflect.Slice && t.Elem() == timeType {
+		var items []any
+		switch v := val.(type) {
+		case []any:
+			items = v
+		case []string:
+			for _, s := range v {
+				items = append(items, s)
+			}
+		case string:
+			for part := range strings.SplitSeq(v, ",") {
+				if part = strings.TrimSpace(part); part != "" {
+					items = append(items, part)
+				}
+			}
+		default:
+			return val, nil
+		}
+		result := make([]time.Time, 0, len(items))
+		for _, item := range items {
+			parsed, err := parseWithLayout(item, timeType, layout)
+			if err != nil {
+				return nil, err
+			}
+			t, ok := parsed.(time.Time)
+			if !ok {
+				return val, nil
+			}
+			result = append(result, t)
+		}
+		return result, nil
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	s, ok := val.(string)
+	if !ok || t != timeType {
+		return val, nil
+	}
+	parsed, err := time.Parse(layout, strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("parse time %q with layout %q: %w", s, layout, err)
+	}
+	return parsed, nil
+}
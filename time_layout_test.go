@@ -0,0 +1,60 @@
+package sysconf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestTimeLayouts(t *testing.T) {
+	content := `released: "2024/03/15"
+nano: "2024-03-15T10:20:30.123456789+08:00"
+holidays: ["2024/01/01", "2024/05/01", "bad"]
+window:
+  start: "15.03.2024"
+  dates: "01.01.2024, 02.01.2024"
+`
+	cfg, err := New(WithContent(content), WithMode(YAML), WithTimeLayouts("2006/01/02", time.RFC3339Nano))
+	if err != nil {
+		t.Fatalf("new config: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetTime("released"); !got.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("GetTime released = %v", got)
+	}
+	if got := cfg.GetTime("nano"); got.Nanosecond() != 123456789 {
+		t.Fatalf("GetTime nano = %v", got)
+	}
+	if got := cfg.GetTimeSlice("holidays"); len(got) != 2 || got[1].Month() != time.May {
+		t.Fatalf("GetTimeSlice = %v", got)
+	}
+
+	var out struct {
+		Released time.Time
+		Window   struct {
+			Start *time.Time  `config:"start" layout:"02.01.2006"`
+			Dates []time.Time `config:"dates" layout:"02.01.2006"`
+		} `config:"window"`
+	}
+	if err := cfg.Unmarshal(&out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.Released.Day() != 15 {
+		t.Fatalf("released = %v", out.Released)
+	}
+	if out.Window.Start == nil || out.Window.Start.Month() != time.March {
+		t.Fatalf("window.start = %v", out.Window.Start)
+	}
+	if len(out.Window.Dates) != 2 || out.Window.Dates[1].Day() != 2 {
+		t.Fatalf("window.dates = %v", out.Window.Dates)
+	}
+
+	var bad struct {
+		Start time.Time `config:"start" layout:"2006-01-02"`
+	}
+	if err := cfg.Unmarshal(&bad, "window"); err == nil {
+		t.Fatalf("expected layout mismatch error")
+	}
+}
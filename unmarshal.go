@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/darkit/sysconf/convert"
 	"github.com/darkit/sysconf/internal/utils"
 	mapstructure "github.com/go-viper/mapstructure/v2"
 	"github.com/spf13/viper"
@@ -46,7 +47,15 @@ func matchNameUncached(mapKey, fieldName string) bool {
 	}
 
 	camelMap := snakeToCamel(mapKey)
-	return camelMap == fieldName || strings.EqualFold(camelMap, fieldName)
+	if camelMap == fieldName || strings.EqualFold(camelMap, fieldName) {
+		return true
+	}
+
+	// 4) Unicode 组合形式不同（如 NFC 与 NFD 写法的 "café"）
+	if isASCII(mapKey) && isASCII(fieldName) {
+		return false
+	}
+	return canonicalKey(mapKey) == canonicalKey(fieldName)
 }
 
 // Unmarshal 将配置解析到结构体
@@ -59,14 +68,20 @@ func (c *Config) Unmarshal(obj any, key ...string) error {
 	if err != nil {
 		return err
 	}
+	start := time.Now()
+	defer func() { recordNamedOperation("unmarshal", time.Since(start)) }()
 
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	// 如果是结构体指针，则设置默认值
+	// 如果是结构体指针，则按缓存的解码计划设置默认值
+	var plan *decodePlan
+	var target reflect.Value
 	if isStructPtr {
+		target = reflect.ValueOf(obj).Elem()
+		plan = decodePlanFor(target.Type())
 		c.logger.Debugf("Setting default values")
-		if err := setDefaultValues(obj); err != nil {
+		if err := plan.applyDefaults(target, c.lenientNumbers); err != nil {
 			c.logger.Errorf("Failed to set default values: %v", err)
 			return fmt.Errorf("set defaults: %w", err)
 		}
@@ -76,10 +91,12 @@ func (c *Config) Unmarshal(obj any, key ...string) error {
 	c.logger.Debugf("Creating decoder config")
 	decoderConfig := &mapstructure.DecoderConfig{
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
-			mapstructure.StringToTimeDurationHookFunc(),
-			mapstructure.StringToTimeHookFunc(time.RFC3339),
-			stringToSliceHookFunc(),
-			stringToMapHookFunc(),
+			c.timeDecodeHook(),
+			convert.DecodeHook(),
+			enumDecodeHookFunc(),
+			c.numberDecodeHook(),
+			c.boolDecodeHook(),
+			c.strictNumberDecodeHook(),
 		),
 		Result:           obj,
 		ZeroFields:       false,
@@ -87,7 +104,13 @@ func (c *Config) Unmarshal(obj any, key ...string) error {
 		TagName:          strings.Join([]string{"config", "sysconf", strings.Join(viper.SupportedExts, ", ")}, ","),
 		SquashTagOption:  "inline",
 		// 启用字段名到键名的自动转换，支持驼峰命名到下划线命名的转换
-		MatchName: cachedMatchName,
+		MatchName: c.matchName(),
+	}
+
+	var metadata *mapstructure.Metadata
+	if c.trackAccess {
+		metadata = &mapstructure.Metadata{}
+		decoderConfig.Metadata = metadata
 	}
 
 	decoder, err := mapstructure.NewDecoder(decoderConfig)
@@ -100,21 +123,54 @@ func (c *Config) Unmarshal(obj any, key ...string) error {
 	var decodeInput any
 	if len(key) > 0 && key[0] != "" {
 		configKey := strings.Join(key, ".")
-		c.logger.Debugf("Getting sub-config: %s", configKey)
-		if val, exists := c.getRaw(configKey); exists {
+		if c.debugEnabled() {
+			c.logger.Debugf("Getting sub-config: %s", configKey)
+		}
+		if val, exists := c.lookupRaw(configKey); exists {
 			decodeInput = val
+			if section, ok := val.(map[string]any); ok {
+				section = deepCloneMap(section)
+				c.applyEnvOverridesUnsafe(configKey, section)
+				c.applyOverridesUnsafe(configKey, section)
+				decodeInput = section
+			}
 		}
 	} else {
 		c.logger.Debugf("Getting all config settings")
-		decodeInput = c.snapshotAllSettings()
+		settings := c.snapshotAllSettings()
+		// 与 Get 系列方法一致，按来源优先级应用环境变量覆盖
+		c.applyEnvOverridesUnsafe("", settings)
+		c.applyOverridesUnsafe("", settings)
+		decodeInput = settings
 	}
 
+	decodeInput = c.resolveFileRefs(decodeInput)
+
 	// 如果没有配置数据，保持默认值
 	if isEmptyUnmarshalInput(decodeInput) {
 		c.logger.Warnf("No config data found, using default values")
 		return nil
 	}
 
+	// 按字段 layout 标签预先解析时间字符串，为存在的配置段分配结构体指针（类型中不含时跳过）
+	if plan != nil && plan.hasLayouts {
+		if err := applyFieldTimeLayouts(decodeInput, target.Type(), strings.Join(key, ".")); err != nil {
+			return fmt.Errorf("type conversion failed: %w", err)
+		}
+	}
+	// 规范化路径类配置键与 path:"true" 字段
+	if len(c.pathKeys) > 0 {
+		c.applyPathKeys(decodeInput, strings.Join(key, "."))
+	}
+	if plan != nil && plan.hasPaths {
+		c.applyFieldPaths(decodeInput, target.Type())
+	}
+	if plan != nil && plan.hasSections {
+		if err := preparePointerSections(target, decodeInput, c.lenientNumbers); err != nil {
+			return fmt.Errorf("set defaults: %w", err)
+		}
+	}
+
 	// 解码配置
 	c.logger.Debugf("Decoding config")
 	if err := decoder.Decode(decodeInput); err != nil {
@@ -132,11 +188,14 @@ func (c *Config) Unmarshal(obj any, key ...string) error {
 		}
 		return fmt.Errorf("decode failed: %w", err)
 	}
+	if metadata != nil {
+		c.markSectionRead(strings.Join(key, "."), metadata.Unused)
+	}
 
 	// 如果是结构体指针，则验证必填字段
 	if isStructPtr {
 		c.logger.Debugf("Validating required fields")
-		if err := utils.ValidateStruct(obj); err != nil {
+		if err := plan.validateRequired(target); err != nil {
 			c.logger.Errorf("Field validation failed: %v", err)
 			return fmt.Errorf("validate: %w", err)
 		}
@@ -181,19 +240,6 @@ func validateUnmarshalTarget(obj any) (bool, error) {
 	return targetType.Elem().Kind() == reflect.Struct, nil
 }
 
-// setDefaultValues 设置默认值
-func setDefaultValues(obj any) error {
-	return utils.SetDefaultValues(obj)
-}
-
-func stringToSliceHookFunc() mapstructure.DecodeHookFunc {
-	return utils.StringToSliceHookFunc()
-}
-
-func stringToMapHookFunc() mapstructure.DecodeHookFunc {
-	return utils.StringToMapHookFunc()
-}
-
 func camelToSnake(s string) string {
 	return utils.CamelToSnake(s)
 }
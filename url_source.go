@@ -0,0 +1,152 @@
+package sysconf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// maxURLSourceSize 远程配置内容的大小上限
+const maxURLSourceSize = 16 << 20
+
+// urlSource 远程 HTTP(S) 配置源状态
+type urlSource struct {
+	url          string
+	pollInterval time.Duration
+	authHeader   string
+	client       *http.Client
+
+	mu           sync.Mutex
+	etag         string // 最近一次响应的 ETag
+	lastModified string // 最近一次响应的 Last-Modified
+	started      bool   // 轮询协程是否已启动
+}
+
+// WithURLSource 从 HTTP(S) 地址加载配置，并按 pollInterval 轮询更新（<=0 时仅在启动时拉取一次）。
+// authHeader 非空时作为 Authorization 请求头发送（如 "Bearer xxx"）。
+// 请求携带 If-None-Match/If-Modified-Since，服务端返回 304 时跳过重载；
+// 新内容先解析并通过全部验证器后才替换当前配置，失败时保留原配置并发出 ReloadFailed 健康事件。
+// URL 路径带有已知扩展名时自动设置配置格式，否则沿用 WithMode。
+// 远程内容加载后与文件重载一样替换当前配置；若同时设置了 WithName，Set 仍写入本地文件。
+func WithURLSource(rawURL string, pollInterval time.Duration, authHeader string) Option {
+	return func(c *Config) {
+		c.urlSource = &urlSource{
+			url:          rawURL,
+			pollInterval: pollInterval,
+			authHeader:   authHeader,
+		}
+		if u, err := url.Parse(rawURL); err == nil {
+			ext := strings.TrimPrefix(path.Ext(u.Path), ".")
+			if ext != "" && (slices.Contains(viper.SupportedExts, ext) || ext == "jsonc") {
+				c.mode = ext
+			}
+		}
+	}
+}
+
+// WithHTTPClient 设置远程配置源使用的 HTTP 客户端（如自定义 CA、代理或超时）。
+// 默认客户端校验 TLS 证书，超时 30 秒。
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) {
+		c.httpClient = client
+	}
+}
+
+// initURLSourceLocked 启动时拉取远程配置并启动轮询（调用者需持有 mu）。
+// 拉取或校验失败时，若存在本地配置（WithName/WithContent）则保留并记录日志，否则返回错误。
+func (c *Config) initURLSourceLocked() error {
+	src := c.urlSource
+	if src == nil {
+		return nil
+	}
+	src.client = c.httpClient
+	if src.client == nil {
+		src.client = &http.Client{Timeout: 30 * time.Second}
+	}
+	if strings.HasPrefix(src.url, "http://") {
+		c.logger.Warnf("Config URL source is not using TLS: %s", src.url)
+	}
+
+	data, changed, err := c.fetchURLSource(c.initContext())
+	if err == nil && changed {
+		err = c.applyRemoteContentLocked(data)
+	}
+	if err != nil {
+		if c.name == "" && c.content == "" {
+			return c.wrapError(err, "加载远程配置")
+		}
+		c.logger.Errorf("Failed to load config from %s, keeping local config: %v", src.url, err)
+	}
+
+	src.mu.Lock()
+	start := !src.started && src.pollInterval > 0
+	src.started = true
+	src.mu.Unlock()
+	if start {
+		stopChan := c.stopChan
+		c.wg.Go(func() { c.pollURLSource(stopChan) })
+	}
+	return nil
+}
+
+// fetchURLSource 发起条件请求；内容未变化（304）时 changed 为 false
+func (c *Config) fetchURLSource(ctx context.Context) (data []byte, changed bool, err error) {
+	src := c.urlSource
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.url, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("build request: %w", err)
+	}
+	if src.authHeader != "" {
+		req.Header.Set("Authorization", src.authHeader)
+	}
+	src.mu.Lock()
+	if src.etag != "" {
+		req.Header.Set("If-None-Match", src.etag)
+	}
+	if src.lastModified != "" {
+		req.Header.Set("If-Modified-Since", src.lastModified)
+	}
+	src.mu.Unlock()
+
+	resp, err := src.client.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("fetch %s: %w", src.url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return nil, false, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, false, fmt.Errorf("fetch %s: unexpected status %s", src.url, resp.Status)
+	}
+
+	data, err = io.ReadAll(io.LimitReader(resp.Body, maxURLSourceSize+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("read %s: %w", src.url, err)
+	}
+	if len(data) > maxURLSourceSize {
+		return nil, false, fmt.Errorf("fetch %s: response exceeds %d bytes", src.url, maxURLSourceSize)
+	}
+
+	src.mu.Lock()
+	src.etag = resp.Header.Get("ETag")
+	src.lastModified = resp.Header.Get("Last-Modified")
+	src.mu.Unlock()
+	return data, true, nil
+}
+
+// pollURLSource 按轮询间隔检查远程配置变化
+func (c *Config) pollURLSource(stopChan <-chan struct{}) {
+	src := c.urlSource
+	c.pollRemote(stopChan, src.pollInterval, src.url, c.fetchURLSource)
+}
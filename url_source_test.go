@@ -0,0 +1,135 @@
+package sysconf
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+// remoteConfigServer 可变内容的测试配置服务，支持 ETag 条件请求
+type remoteConfigServer struct {
+	mu          sync.Mutex
+	body        string
+	version     int
+	notModified atomic.Int32
+	auth        atomic.Value
+}
+
+func (s *remoteConfigServer) set(body string) {
+	s.mu.Lock()
+	s.body = body
+	s.version++
+	s.mu.Unlock()
+}
+
+func (s *remoteConfigServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.auth.Store(r.Header.Get("Authorization"))
+	s.mu.Lock()
+	body, etag := s.body, fmt.Sprintf(`"v%d"`, s.version)
+	s.mu.Unlock()
+
+	if r.Header.Get("If-None-Match") == etag {
+		s.notModified.Add(1)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	_, _ = w.Write([]byte(body))
+}
+
+func TestURLSourcePollAndValidate(t *testing.T) {
+	remote := &remoteConfigServer{}
+	remote.set("app:\n  name: remote\n  port: 8080\n")
+	server := httptest.NewTLSServer(remote)
+	defer server.Close()
+
+	cfg, err := New(
+		WithURLSource(server.URL+"/myapp.yaml", 20*time.Millisecond, "Bearer test-token"),
+		WithHTTPClient(server.Client()),
+		WithValidateFunc(func(config map[string]any) error {
+			app, _ := config["app"].(map[string]any)
+			if app["name"] == "" {
+				return fmt.Errorf("app.name is required")
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("app.name"); got != "remote" {
+		t.Fatalf("expected remote, got %q", got)
+	}
+	if got, _ := remote.auth.Load().(string); got != "Bearer test-token" {
+		t.Fatalf("expected auth header, got %q", got)
+	}
+
+	// 内容未变化时服务端返回 304，不触发重载
+	deadline := time.Now().Add(3 * time.Second)
+	for remote.notModified.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected conditional requests to hit 304")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	changed := make(chan struct{}, 1)
+	cfg.Watch(func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	})
+	events := make(chan HealthEvent, 16)
+	cfg.OnHealthEvent(func(e HealthEvent) { events <- e })
+
+	remote.set("app:\n  name: updated\n  port: 9090\n")
+	select {
+	case <-changed:
+	case <-time.After(3 * time.Second):
+		t.Fatalf("remote change not applied")
+	}
+	if got := cfg.GetInt("app.port"); got != 9090 {
+		t.Fatalf("expected port 9090, got %d", got)
+	}
+
+	// 未通过验证的远程内容被拒绝，保留当前配置
+	remote.set("app:\n  name: \"\"\n  port: 1\n")
+	event := waitHealthEvent(t, events, HealthEventReloadFailed)
+	if event.File != server.URL+"/myapp.yaml" {
+		t.Fatalf("unexpected event file: %s", event.File)
+	}
+	if got := cfg.GetString("app.name"); got != "updated" {
+		t.Fatalf("expected last good config to be kept, got %q", got)
+	}
+}
+
+func TestURLSourceVerifiesTLS(t *testing.T) {
+	remote := &remoteConfigServer{}
+	remote.set("app:\n  name: remote\n")
+	server := httptest.NewTLSServer(remote)
+	defer server.Close()
+
+	// 默认客户端不信任测试服务器的自签名证书
+	if _, err := New(WithURLSource(server.URL+"/app.yaml", 0, "")); err == nil {
+		t.Fatalf("expected TLS verification failure")
+	}
+
+	// 存在本地配置时保留本地配置继续启动
+	cfg, err := New(WithContent("app:\n  name: local\n"), WithURLSource(server.URL+"/app.yaml", 0, ""))
+	if err != nil {
+		t.Fatalf("create config with local fallback failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	if got := cfg.GetString("app.name"); got != "local" {
+		t.Fatalf("expected local fallback, got %q", got)
+	}
+}
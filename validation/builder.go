@@ -0,0 +1,170 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RuleSet 由 For 构建的字段规则，可通过 StructuredValidator.Add 加入验证器
+type RuleSet interface {
+	// Field 返回底层的字段规则
+	Field() *FieldRules
+}
+
+// FieldRules 单个配置键的规则链，最终编译为字符串规则（与 AddStringRules 等价）。
+// 参数类型由 Int/Float/Text 等方法在编译期约束，避免手写 "range:1,100" 时的拼写错误。
+//
+//	v := validation.NewRuleValidator("server").Add(
+//	    validation.For("server.port").Int().Range(1, 65535).Required(),
+//	    validation.For("server.host").Text().Required().Hostname(),
+//	)
+type FieldRules struct {
+	key   string
+	rules []string
+}
+
+// For 开始为配置键构建规则
+func For(key string) *FieldRules {
+	return &FieldRules{key: key}
+}
+
+// Field 实现 RuleSet
+func (f *FieldRules) Field() *FieldRules { return f }
+
+// Key 返回规则作用的配置键
+func (f *FieldRules) Key() string { return f.key }
+
+// Rules 返回编译后的字符串规则（按添加顺序）
+func (f *FieldRules) Rules() []string {
+	return append([]string(nil), f.rules...)
+}
+
+// Required 要求字段存在且非空
+func (f *FieldRules) Required() *FieldRules { return f.add("required") }
+
+// Rule 追加一条字符串规则，用于已注册但没有类型化方法的规则（如自定义规则）
+func (f *FieldRules) Rule(rule string) *FieldRules { return f.add(rule) }
+
+// RuleWith 追加一条带结构化参数的规则，参数编码为 JSON（规则通常由 RegisterValidatorV2 注册）
+func (f *FieldRules) RuleWith(name string, params map[string]any) *FieldRules {
+	return f.add(formatRule(name, params))
+}
+
+// Int 约束字段为整数并返回整数规则链
+func (f *FieldRules) Int() *NumberRules[int64] {
+	f.add("integer")
+	return &NumberRules[int64]{field: f}
+}
+
+// Float 约束字段为数值并返回浮点数规则链
+func (f *FieldRules) Float() *NumberRules[float64] {
+	f.add("number")
+	return &NumberRules[float64]{field: f}
+}
+
+// Text 约束字段为字符串并返回字符串规则链
+func (f *FieldRules) Text() *TextRules {
+	f.add("string")
+	return &TextRules{field: f}
+}
+
+func (f *FieldRules) add(rule string) *FieldRules {
+	f.rules = append(f.rules, rule)
+	return f
+}
+
+// NumberRules 数值字段的规则链，参数类型与字段类型一致
+type NumberRules[T int64 | float64] struct {
+	field *FieldRules
+}
+
+// Field 实现 RuleSet
+func (n *NumberRules[T]) Field() *FieldRules { return n.field }
+
+// Required 要求字段存在
+func (n *NumberRules[T]) Required() *NumberRules[T] { return n.add("required") }
+
+// Range 要求值位于 [min, max]
+func (n *NumberRules[T]) Range(min, max T) *NumberRules[T] {
+	return n.add(fmt.Sprintf("range:%v,%v", min, max))
+}
+
+// Min 要求值不小于 min
+func (n *NumberRules[T]) Min(min T) *NumberRules[T] { return n.add(fmt.Sprintf("min:%v", min)) }
+
+// Max 要求值不大于 max
+func (n *NumberRules[T]) Max(max T) *NumberRules[T] { return n.add(fmt.Sprintf("max:%v", max)) }
+
+// OneOf 要求值为给定值之一
+func (n *NumberRules[T]) OneOf(values ...T) *NumberRules[T] {
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = fmt.Sprint(v)
+	}
+	return n.add("enum:" + strings.Join(items, ","))
+}
+
+// Port 要求值为合法端口号（1-65535）
+func (n *NumberRules[T]) Port() *NumberRules[T] { return n.add("port") }
+
+// RuleWith 追加一条带结构化参数的规则
+func (n *NumberRules[T]) RuleWith(name string, params map[string]any) *NumberRules[T] {
+	return n.add(formatRule(name, params))
+}
+
+func (n *NumberRules[T]) add(rule string) *NumberRules[T] {
+	n.field.add(rule)
+	return n
+}
+
+// TextRules 字符串字段的规则链
+type TextRules struct {
+	field *FieldRules
+}
+
+// Field 实现 RuleSet
+func (s *TextRules) Field() *FieldRules { return s.field }
+
+// Required 要求字段存在且非空
+func (s *TextRules) Required() *TextRules { return s.add("required") }
+
+// Length 要求字符串长度位于 [min, max]
+func (s *TextRules) Length(min, max int) *TextRules {
+	return s.add(fmt.Sprintf("length:%d,%d", min, max))
+}
+
+// Pattern 要求字符串匹配正则表达式
+func (s *TextRules) Pattern(pattern string) *TextRules { return s.add("regex:" + pattern) }
+
+// OneOf 要求值为给定值之一（值中不能包含逗号）
+func (s *TextRules) OneOf(values ...string) *TextRules {
+	return s.add("enum:" + strings.Join(values, ","))
+}
+
+// Email 要求值为电子邮件地址
+func (s *TextRules) Email() *TextRules { return s.add("email") }
+
+// URL 要求值为 URL
+func (s *TextRules) URL() *TextRules { return s.add("url") }
+
+// Hostname 要求值为主机名
+func (s *TextRules) Hostname() *TextRules { return s.add("hostname") }
+
+// IPv4 要求值为 IPv4 地址
+func (s *TextRules) IPv4() *TextRules { return s.add("ipv4") }
+
+// IPv6 要求值为 IPv6 地址
+func (s *TextRules) IPv6() *TextRules { return s.add("ipv6") }
+
+// UUID 要求值为 UUID
+func (s *TextRules) UUID() *TextRules { return s.add("uuid") }
+
+// RuleWith 追加一条带结构化参数的规则
+func (s *TextRules) RuleWith(name string, params map[string]any) *TextRules {
+	return s.add(formatRule(name, params))
+}
+
+func (s *TextRules) add(rule string) *TextRules {
+	s.field.add(rule)
+	return s
+}
@@ -0,0 +1,53 @@
+package validation
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestForCompilesToStringRules(t *testing.T) {
+	port := For("server.port").Int().Range(1, 65535).Required()
+	if got, want := port.Field().Rules(), []string{"integer", "range:1,65535", "required"}; !slices.Equal(got, want) {
+		t.Fatalf("Rules() = %v, want %v", got, want)
+	}
+
+	ratio := For("cache.ratio").Float().Min(0.5).Max(1)
+	if got, want := ratio.Field().Rules(), []string{"number", "min:0.5", "max:1"}; !slices.Equal(got, want) {
+		t.Fatalf("Rules() = %v, want %v", got, want)
+	}
+
+	mode := For("app.mode").Text().Required().OneOf("dev", "prod").Length(3, 4)
+	if got, want := mode.Field().Rules(), []string{"string", "required", "enum:dev,prod", "length:3,4"}; !slices.Equal(got, want) {
+		t.Fatalf("Rules() = %v, want %v", got, want)
+	}
+}
+
+func TestStructuredValidatorAdd(t *testing.T) {
+	v := NewRuleValidator("server").Add(
+		For("server.port").Int().Range(1, 65535).Required(),
+		For("server.host").Text().Required().Hostname(),
+		For("server.workers").Int().Min(1),
+	)
+
+	valid := map[string]any{"server": map[string]any{"port": 8080, "host": "localhost", "workers": float64(4)}}
+	if err := v.Validate(valid); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	cases := map[string]map[string]any{
+		"out of range": {"port": 70000, "host": "localhost"},
+		"not integer":  {"port": 80.5, "host": "localhost"},
+		"missing port": {"host": "localhost"},
+		"below min":    {"port": 80, "host": "localhost", "workers": 0},
+	}
+	for name, server := range cases {
+		err := v.Validate(map[string]any{"server": server})
+		if err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+		if !strings.Contains(err.Error(), "validator 'server'") {
+			t.Fatalf("%s: unexpected error %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CUESchema 已编译的 CUE schema，Validate 将配置数据与 schema 合一并检查是否完整、具体
+type CUESchema interface {
+	Validate(data map[string]any) error
+}
+
+// CUEEngine 编译 CUE schema 的引擎。基于 cuelang.org/go 的实现位于独立子模块
+// github.com/darkit/sysconf/validation/cue（cue.Engine、cue.NewCUEValidator），本包因此无需引入 CUE 依赖。
+type CUEEngine interface {
+	Compile(schema string) (CUESchema, error)
+}
+
+// CUEValidator 使用 CUE schema 验证完整配置的验证器，便于与已有的 CUE 配置契约保持同一事实来源。
+// schema 返回的每条错误映射为一条 ValidationIssue；错误实现 Path() []string 时作为配置键。
+type CUEValidator struct {
+	name   string
+	schema CUESchema
+}
+
+// NewCUEValidator 使用指定引擎编译 schema 并返回可注册到配置的验证器；
+// 使用 CUE 官方实现时调用 cue 子模块的 NewCUEValidator(schema) 即可
+func NewCUEValidator(schema string, engine CUEEngine) (*CUEValidator, error) {
+	if engine == nil {
+		return nil, errors.New("cue validator requires a cue engine")
+	}
+	if strings.TrimSpace(schema) == "" {
+		return nil, errors.New("cue schema is empty")
+	}
+	compiled, err := engine.Compile(schema)
+	if err != nil {
+		return nil, fmt.Errorf("compile cue schema: %w", err)
+	}
+	return &CUEValidator{name: "cue", schema: compiled}, nil
+}
+
+// Validate 实现 Validator 接口，验证失败时返回 ValidationIssues
+func (v *CUEValidator) Validate(config map[string]any) error {
+	err := v.schema.Validate(config)
+	if err == nil {
+		return nil
+	}
+	var issues ValidationIssues
+	for _, e := range splitErrors(err) {
+		issue := ValidationIssue{Message: e.Error(), Rule: "cue"}
+		if p, ok := e.(interface{ Path() []string }); ok {
+			issue.Key = strings.Join(p.Path(), ".")
+			issue.Message = strings.TrimPrefix(issue.Message, issue.Key+": ")
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// splitErrors 展开 errors.Join 形式的组合错误
+func splitErrors(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var result []error
+	for _, e := range joined.Unwrap() {
+		if e != nil {
+			result = append(result, splitErrors(e)...)
+		}
+	}
+	return result
+}
+
+// GetName 实现 Validator 接口
+func (v *CUEValidator) GetName() string {
+	return v.name
+}
+
+// ValidatesWholeConfig 声明该验证器需要完整的候选配置（Set 时不再只传入字段上下文）
+func (v *CUEValidator) ValidatesWholeConfig() bool {
+	return true
+}
@@ -0,0 +1,56 @@
+// Package cue 基于 cuelang.org/go 提供 validation.CUEEngine 的实现，
+// 独立为子模块，使核心模块无需引入 CUE 依赖。
+package cue
+
+import (
+	"errors"
+	"sync"
+
+	cuelang "cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
+	"github.com/darkit/sysconf/validation"
+)
+
+// Engine 基于 cuelang.org/go 编译 schema 的 validation.CUEEngine 实现
+type Engine struct{}
+
+var _ validation.CUEEngine = Engine{}
+
+// Compile 实现 validation.CUEEngine，在独立的 CUE 上下文中编译 schema
+func (Engine) Compile(schema string) (validation.CUESchema, error) {
+	ctx := cuecontext.New()
+	value := ctx.CompileString(schema)
+	if err := value.Err(); err != nil {
+		return nil, err
+	}
+	return &compiledSchema{ctx: ctx, schema: value}, nil
+}
+
+// compiledSchema 已编译的 CUE schema；CUE 上下文不支持并发求值，由 mu 串行化
+type compiledSchema struct {
+	mu     sync.Mutex
+	ctx    *cuelang.Context
+	schema cuelang.Value
+}
+
+// Validate 实现 validation.CUESchema，将配置与 schema 合一并要求结果完整、具体。
+// 返回的每条错误实现 Path() []string，由 validation.CUEValidator 映射为配置键。
+func (s *compiledSchema) Validate(data map[string]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.schema.Unify(s.ctx.Encode(data)).Validate(cuelang.Concrete(true))
+	if err == nil {
+		return nil
+	}
+	var errs []error
+	for _, e := range cueerrors.Errors(err) {
+		errs = append(errs, e)
+	}
+	return errors.Join(errs...)
+}
+
+// NewCUEValidator 使用 CUE 引擎编译 schema 并返回可注册到配置的验证器
+func NewCUEValidator(schema string) (*validation.CUEValidator, error) {
+	return validation.NewCUEValidator(schema, Engine{})
+}
@@ -0,0 +1,98 @@
+package cue
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/darkit/sysconf"
+	"github.com/darkit/sysconf/validation"
+)
+
+func loadSchema(t *testing.T) string {
+	t.Helper()
+	data, err := os.ReadFile("testdata/schema.cue")
+	if err != nil {
+		t.Fatalf("read schema failed: %v", err)
+	}
+	return string(data)
+}
+
+func TestCUEValidator(t *testing.T) {
+	v, err := NewCUEValidator(loadSchema(t))
+	if err != nil {
+		t.Fatalf("create validator failed: %v", err)
+	}
+	if v.GetName() != "cue" || !v.ValidatesWholeConfig() {
+		t.Fatalf("unexpected validator metadata")
+	}
+
+	valid := map[string]any{"server": map[string]any{"host": "localhost", "port": 8080}}
+	if err := v.Validate(valid); err != nil {
+		t.Fatalf("expected valid config: %v", err)
+	}
+
+	err = v.Validate(map[string]any{
+		"server": map[string]any{"port": 70000},
+		"log":    map[string]any{"level": "trace"},
+	})
+	var issues validation.ValidationIssues
+	if !errors.As(err, &issues) || len(issues) == 0 {
+		t.Fatalf("expected issues, got %v", err)
+	}
+	keys := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.Rule != "cue" {
+			t.Fatalf("unexpected rule: %+v", issue)
+		}
+		keys[issue.Key] = true
+	}
+	for _, key := range []string{"server.port", "log.level"} {
+		if !keys[key] {
+			t.Fatalf("missing issue for %s: %+v", key, issues)
+		}
+	}
+}
+
+func TestCUEValidatorIncomplete(t *testing.T) {
+	v, err := NewCUEValidator(loadSchema(t))
+	if err != nil {
+		t.Fatalf("create validator failed: %v", err)
+	}
+	err = v.Validate(map[string]any{"server": map[string]any{"port": 80}})
+	var issues validation.ValidationIssues
+	if !errors.As(err, &issues) || len(issues) != 1 || issues[0].Key != "server.host" {
+		t.Fatalf("expected missing host issue, got %v", err)
+	}
+}
+
+func TestCUEValidatorCompileError(t *testing.T) {
+	if _, err := NewCUEValidator("server: { syntax error"); err == nil {
+		t.Fatal("expected compile error")
+	}
+	if _, err := NewCUEValidator(" "); err == nil {
+		t.Fatal("expected empty schema error")
+	}
+}
+
+func TestCUEValidatorRejectsSet(t *testing.T) {
+	v, err := NewCUEValidator(loadSchema(t))
+	if err != nil {
+		t.Fatalf("create validator failed: %v", err)
+	}
+	cfg, err := sysconf.New(
+		sysconf.WithContent("server:\n  host: localhost\n  port: 8080\n"),
+		sysconf.WithValidator(v),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer cfg.Close()
+
+	if err := cfg.Set("server.port", 0); err == nil {
+		t.Fatal("expected schema violation to reject Set")
+	}
+	if got := cfg.GetInt("server.port"); got != 8080 {
+		t.Fatalf("server.port = %d after rejected Set", got)
+	}
+}
@@ -0,0 +1,6 @@
+server: {
+	host: string
+	port: int & >0 & <=65535
+}
+
+log: level: *"info" | "debug" | "warn" | "error"
@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// pathError 模拟带路径的 CUE 错误
+type pathError struct {
+	path []string
+	msg  string
+}
+
+func (e pathError) Error() string  { return strings.Join(e.path, ".") + ": " + e.msg }
+func (e pathError) Path() []string { return e.path }
+
+// fakeCUEEngine 以 Go 函数模拟 schema 合一
+type fakeCUEEngine struct{ compiled string }
+
+type fakeCUESchema struct{}
+
+func (e *fakeCUEEngine) Compile(schema string) (CUESchema, error) {
+	if strings.Contains(schema, "syntax error") {
+		return nil, errors.New("expected '}'")
+	}
+	e.compiled = schema
+	return fakeCUESchema{}, nil
+}
+
+func (fakeCUESchema) Validate(data map[string]any) error {
+	var errs []error
+	server, _ := data["server"].(map[string]any)
+	if port, _ := server["port"].(int); port <= 0 || port > 65535 {
+		errs = append(errs, pathError{path: []string{"server", "port"}, msg: "invalid value (out of bound >0 & <=65535)"})
+	}
+	if _, ok := server["host"]; !ok {
+		errs = append(errs, errors.New("incomplete value: server.host"))
+	}
+	return errors.Join(errs...)
+}
+
+func TestCUEValidator(t *testing.T) {
+	engine := &fakeCUEEngine{}
+	schema := "server: {host: string, port: int & >0 & <=65535}"
+	v, err := NewCUEValidator(schema, engine)
+	if err != nil {
+		t.Fatalf("create validator failed: %v", err)
+	}
+	if engine.compiled != schema || v.GetName() != "cue" || !v.ValidatesWholeConfig() {
+		t.Fatalf("unexpected validator state")
+	}
+
+	if err := v.Validate(map[string]any{"server": map[string]any{"host": "a", "port": 80}}); err != nil {
+		t.Fatalf("expected valid config: %v", err)
+	}
+
+	err = v.Validate(map[string]any{"server": map[string]any{"port": 70000}})
+	var issues ValidationIssues
+	if !errors.As(err, &issues) || len(issues) != 2 {
+		t.Fatalf("expected two issues, got %v", err)
+	}
+	if issues[0].Key != "server.port" || issues[0].Message != "invalid value (out of bound >0 & <=65535)" || issues[0].Rule != "cue" {
+		t.Fatalf("unexpected path issue: %+v", issues[0])
+	}
+	if issues[1].Key != "" || issues[1].Message != "incomplete value: server.host" {
+		t.Fatalf("unexpected plain issue: %+v", issues[1])
+	}
+
+	if _, err := NewCUEValidator("server: { syntax error", engine); err == nil {
+		t.Fatalf("expected compile error")
+	}
+	if _, err := NewCUEValidator(" ", engine); err == nil {
+		t.Fatalf("expected empty schema error")
+	}
+	if _, err := NewCUEValidator(schema, nil); err == nil {
+		t.Fatalf("expected missing engine error")
+	}
+}
@@ -0,0 +1,33 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationIssue 单条验证问题
+type ValidationIssue struct {
+	Key     string // 相关配置键，可为空
+	Message string // 问题描述
+	Rule    string // 产生问题的规则或策略标识，可为空
+}
+
+// String 返回 "key: message" 形式的描述
+func (i ValidationIssue) String() string {
+	if i.Key == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s: %s", i.Key, i.Message)
+}
+
+// ValidationIssues 一组验证问题，可直接作为 error 返回
+type ValidationIssues []ValidationIssue
+
+// Error 实现 error 接口
+func (issues ValidationIssues) Error() string {
+	parts := make([]string, len(issues))
+	for i, issue := range issues {
+		parts[i] = issue.String()
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,139 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// MessageData 规则错误消息模板可用的字段，数值均已格式化（整数不带小数，时间间隔带单位）。
+//
+//	validation.Range("1", "65535", "{{.Key}} must be between {{.Min}} and {{.Max}}, got {{.Actual}}")
+type MessageData struct {
+	Key    string // 配置键（单独验证值时为空）
+	Rule   string // 规则类型，如 "range"
+	Param  string // 规则原始参数
+	Min    string // 下限（range/min 规则）
+	Max    string // 上限（range/max 规则）
+	Actual string // 实际值
+}
+
+// messageTemplates 已解析的消息模板缓存
+var messageTemplates sync.Map // map[string]*template.Template
+
+// FormatMessage 渲染带 {{.Key}}、{{.Min}}、{{.Max}}、{{.Actual}} 等占位符的消息模板，
+// 不含占位符或模板无法解析时原样返回
+func FormatMessage(message string, data MessageData) string {
+	if !strings.Contains(message, "{{") {
+		return message
+	}
+	var tmpl *template.Template
+	if cached, ok := messageTemplates.Load(message); ok {
+		tmpl = cached.(*template.Template)
+	} else {
+		parsed, err := template.New("message").Option("missingkey=zero").Parse(message)
+		if err != nil {
+			return message
+		}
+		messageTemplates.Store(message, parsed)
+		tmpl = parsed
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return message
+	}
+	return b.String()
+}
+
+// FormatNumber 格式化数值：整数不带小数，浮点数使用最短表示且不使用科学计数法（65535、0.5、1000000）
+func FormatNumber(v any) string {
+	switch n := v.(type) {
+	case float64:
+		return formatFloat(n)
+	case float32:
+		return formatFloat(float64(n))
+	case string:
+		if f, err := strconv.ParseFloat(n, 64); err == nil {
+			return formatFloat(f)
+		}
+		return n
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	}
+	return fmt.Sprint(v)
+}
+
+// formatFloat 浮点数的最短十进制表示
+func formatFloat(f float64) string {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// FormatPercent 将比例格式化为百分比，如 0.5 → "50%"、0.125 → "12.5%"
+func FormatPercent(ratio float64) string {
+	return formatFloat(math.Round(ratio*1e6)/1e4) + "%"
+}
+
+// FormatDuration 格式化时间间隔并省略为零的尾部单位，如 "1h30m"、"5m"、"500ms"
+func FormatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// FormatValue 按类型格式化值：时间间隔带单位，数值使用 FormatNumber，其余使用默认格式
+func FormatValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "<nil>"
+	case time.Duration:
+		return FormatDuration(val)
+	case string:
+		return val
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return FormatNumber(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// messageData 构造规则的消息模板数据
+func messageData(key string, value any, rule ValidationRule) MessageData {
+	data := MessageData{
+		Key:    key,
+		Rule:   rule.Type,
+		Param:  rule.Value,
+		Actual: FormatValue(value),
+	}
+	switch rule.Type {
+	case "range":
+		if lo, hi, ok := strings.Cut(rule.Value, ","); ok {
+			data.Min, data.Max = FormatNumber(strings.TrimSpace(lo)), FormatNumber(strings.TrimSpace(hi))
+		}
+	case "min":
+		data.Min = FormatNumber(rule.Value)
+	case "max":
+		data.Max = FormatNumber(rule.Value)
+	}
+	return data
+}
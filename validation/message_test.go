@@ -0,0 +1,77 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatHelpers(t *testing.T) {
+	numbers := map[any]string{
+		65535.0:       "65535",
+		0.5:           "0.5",
+		1e6:           "1000000",
+		int64(-3):     "-3",
+		uint8(7):      "7",
+		"1.000000":    "1",
+		"not-numeric": "not-numeric",
+	}
+	for in, want := range numbers {
+		if got := FormatNumber(in); got != want {
+			t.Fatalf("FormatNumber(%v) = %q, want %q", in, got, want)
+		}
+	}
+
+	if got := FormatPercent(0.125); got != "12.5%" {
+		t.Fatalf("FormatPercent(0.125) = %q", got)
+	}
+	durations := map[time.Duration]string{
+		90 * time.Minute:       "1h30m",
+		5 * time.Minute:        "5m",
+		2 * time.Hour:          "2h",
+		500 * time.Millisecond: "500ms",
+		90 * time.Second:       "1m30s",
+	}
+	for in, want := range durations {
+		if got := FormatDuration(in); got != want {
+			t.Fatalf("FormatDuration(%v) = %q, want %q", in, got, want)
+		}
+	}
+	if got := FormatValue(3 * time.Second); got != "3s" {
+		t.Fatalf("FormatValue(duration) = %q", got)
+	}
+}
+
+func TestRuleMessagesUseCleanNumbers(t *testing.T) {
+	err := Validate(1.5, Min("2.0", ""))
+	if err == nil || err.Error() != "value must be greater than or equal to 2" {
+		t.Fatalf("unexpected min error: %v", err)
+	}
+	if ok, msg := ValidateValue(70000.0, "range:1,65535"); ok || msg != "value must be between 1 and 65535" {
+		t.Fatalf("unexpected range message: %q", msg)
+	}
+	if ok, msg := ValidateValue(2e6, "max:1000000"); ok || msg != "value must be at most 1000000" {
+		t.Fatalf("unexpected max message: %q", msg)
+	}
+}
+
+func TestRuleMessageTemplates(t *testing.T) {
+	v := NewRuleValidator("server").
+		AddRule("server.port", Range("1", "65535", "{{.Key}} must be between {{.Min}} and {{.Max}}, got {{.Actual}}"))
+
+	err := v.Validate(map[string]any{"server": map[string]any{"port": 70000.0}})
+	if err == nil || !strings.Contains(err.Error(), "server.port must be between 1 and 65535, got 70000") {
+		t.Fatalf("unexpected templated error: %v", err)
+	}
+
+	// 不含占位符的消息保持原样，空消息使用规则自身描述
+	if err := Validate("", Required("name is required")); err == nil || err.Error() != "name is required" {
+		t.Fatalf("plain message changed: %v", err)
+	}
+	if err := Validate("", Required("")); err == nil || err.Error() == "" {
+		t.Fatal("empty message should fall back to the rule description")
+	}
+	if got := FormatMessage("{{.Broken", MessageData{}); got != "{{.Broken" {
+		t.Fatalf("malformed template should be returned as-is, got %q", got)
+	}
+}
@@ -0,0 +1,182 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RegoQuery 已编译的 Rego 查询，Eval 返回查询表达式的全部结果值
+type RegoQuery interface {
+	Eval(ctx context.Context, input map[string]any) ([]any, error)
+}
+
+// RegoEngine 编译 Rego 策略的引擎。基于 OPA 的实现位于独立子模块
+// github.com/darkit/sysconf/validation/opa（opa.Engine、opa.NewOPAValidator），本包因此无需引入 OPA 依赖。
+type RegoEngine interface {
+	Prepare(ctx context.Context, query string, modules map[string]string) (RegoQuery, error)
+}
+
+// OPAOptions OPA 策略验证器选项
+type OPAOptions struct {
+	Engine    RegoEngine        // Rego 引擎（必填；使用 opa 子模块的 NewOPAValidator 时默认为 opa.Engine）
+	PolicyDir string            // 策略目录，递归加载其中的 *.rego 文件（忽略 *_test.rego）
+	Modules   map[string]string // 额外的策略模块（文件名 → 源码）
+	Query     string            // 求值的查询，默认 "data.sysconf.deny"
+	Timeout   time.Duration     // 单次求值超时，默认 5 秒
+	Name      string            // 验证器名称，默认 "opa"
+}
+
+// OPAValidator 使用 Rego 策略评估完整候选配置的验证器。
+// 查询结果中的每个 deny 项映射为一条 ValidationIssue：字符串作为消息；
+// 对象读取 msg/message、key/field/path 与 rule/id 字段。
+type OPAValidator struct {
+	name    string
+	query   RegoQuery
+	timeout time.Duration
+}
+
+// NewOPAValidator 加载并编译策略，返回可注册到配置的验证器
+func NewOPAValidator(opts OPAOptions) (*OPAValidator, error) {
+	if opts.Engine == nil {
+		return nil, errors.New("opa validator requires a rego engine")
+	}
+	modules := make(map[string]string, len(opts.Modules))
+	maps.Copy(modules, opts.Modules)
+	if opts.PolicyDir != "" {
+		if err := loadRegoModules(opts.PolicyDir, modules); err != nil {
+			return nil, err
+		}
+	}
+	if len(modules) == 0 {
+		return nil, errors.New("opa validator requires at least one policy module")
+	}
+	if opts.Query == "" {
+		opts.Query = "data.sysconf.deny"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if opts.Name == "" {
+		opts.Name = "opa"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+	query, err := opts.Engine.Prepare(ctx, opts.Query, modules)
+	if err != nil {
+		return nil, fmt.Errorf("compile rego policies: %w", err)
+	}
+	return &OPAValidator{name: opts.Name, query: query, timeout: opts.Timeout}, nil
+}
+
+// loadRegoModules 递归读取目录中的 Rego 策略文件
+func loadRegoModules(dir string, modules map[string]string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".rego" || strings.HasSuffix(path, "_test.rego") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read policy %s: %w", path, err)
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			name = path
+		}
+		modules[filepath.ToSlash(name)] = string(data)
+		return nil
+	})
+}
+
+// Validate 实现 Validator 接口，存在 deny 结果时返回 ValidationIssues
+func (v *OPAValidator) Validate(config map[string]any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), v.timeout)
+	defer cancel()
+	issues, err := v.Evaluate(ctx, config)
+	if err != nil {
+		return err
+	}
+	if len(issues) > 0 {
+		return issues
+	}
+	return nil
+}
+
+// Evaluate 以 config 为 input 求值策略，返回全部验证问题（按键与消息排序）
+func (v *OPAValidator) Evaluate(ctx context.Context, config map[string]any) (ValidationIssues, error) {
+	results, err := v.query.Eval(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate rego policies: %w", err)
+	}
+	var issues ValidationIssues
+	for _, result := range results {
+		issues = appendRegoIssues(issues, result)
+	}
+	slices.SortFunc(issues, func(a, b ValidationIssue) int {
+		if c := strings.Compare(a.Key, b.Key); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Message, b.Message)
+	})
+	return issues, nil
+}
+
+// appendRegoIssues 将 deny 结果（集合、字符串或对象）转换为验证问题
+func appendRegoIssues(issues ValidationIssues, result any) ValidationIssues {
+	switch value := result.(type) {
+	case nil:
+		return issues
+	case []any:
+		for _, item := range value {
+			issues = appendRegoIssues(issues, item)
+		}
+		return issues
+	case string:
+		return append(issues, ValidationIssue{Message: value})
+	case map[string]any:
+		return append(issues, ValidationIssue{
+			Key:     firstString(value, "key", "field", "path"),
+			Message: firstString(value, "msg", "message"),
+			Rule:    firstString(value, "rule", "id"),
+		})
+	case bool:
+		// 布尔型 deny 规则：true 表示拒绝但没有附带消息
+		if value {
+			return append(issues, ValidationIssue{Message: "denied by policy"})
+		}
+		return issues
+	default:
+		return append(issues, ValidationIssue{Message: fmt.Sprint(value)})
+	}
+}
+
+// firstString 返回对象中第一个存在的字段值
+func firstString(m map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := m[key]; ok && value != nil {
+			return fmt.Sprint(value)
+		}
+	}
+	return ""
+}
+
+// GetName 实现 Validator 接口
+func (v *OPAValidator) GetName() string {
+	return v.name
+}
+
+// ValidatesWholeConfig 声明该验证器需要完整的候选配置（Set 时不再只传入字段上下文）
+func (v *OPAValidator) ValidatesWholeConfig() bool {
+	return true
+}
@@ -0,0 +1,56 @@
+// Package opa 基于 github.com/open-policy-agent/opa 提供 validation.RegoEngine 的实现，
+// 独立为子模块，使核心模块无需引入 OPA 依赖。
+package opa
+
+import (
+	"context"
+
+	"github.com/darkit/sysconf/validation"
+	"github.com/open-policy-agent/opa/v1/rego"
+)
+
+// Engine 基于 OPA rego 包编译与求值策略的 validation.RegoEngine 实现
+type Engine struct{}
+
+var _ validation.RegoEngine = Engine{}
+
+// Prepare 实现 validation.RegoEngine，编译全部策略模块并准备查询
+func (Engine) Prepare(ctx context.Context, query string, modules map[string]string) (validation.RegoQuery, error) {
+	opts := []func(*rego.Rego){rego.Query(query)}
+	for name, src := range modules {
+		opts = append(opts, rego.Module(name, src))
+	}
+	prepared, err := rego.New(opts...).PrepareForEval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return preparedQuery{query: prepared}, nil
+}
+
+// preparedQuery 已编译的 Rego 查询
+type preparedQuery struct {
+	query rego.PreparedEvalQuery
+}
+
+// Eval 实现 validation.RegoQuery，返回每个结果中第一个表达式的值
+func (q preparedQuery) Eval(ctx context.Context, input map[string]any) ([]any, error) {
+	rs, err := q.query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, 0, len(rs))
+	for _, result := range rs {
+		if len(result.Expressions) > 0 {
+			values = append(values, result.Expressions[0].Value)
+		}
+	}
+	return values, nil
+}
+
+// NewOPAValidator 使用 OPA 引擎加载并编译策略，opts.Engine 为空时使用 Engine
+func NewOPAValidator(opts validation.OPAOptions) (*validation.OPAValidator, error) {
+	if opts.Engine == nil {
+		opts.Engine = Engine{}
+	}
+	return validation.NewOPAValidator(opts)
+}
@@ -0,0 +1,71 @@
+package opa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/darkit/sysconf"
+	"github.com/darkit/sysconf/validation"
+)
+
+func TestOPAValidatorWithPolicyDir(t *testing.T) {
+	v, err := NewOPAValidator(validation.OPAOptions{PolicyDir: "testdata/policies"})
+	if err != nil {
+		t.Fatalf("create validator failed: %v", err)
+	}
+
+	valid := map[string]any{
+		"env":      "production",
+		"server":   map[string]any{"tls": true},
+		"database": map[string]any{"port": 5432},
+	}
+	if err := v.Validate(valid); err != nil {
+		t.Fatalf("expected policies to pass: %v", err)
+	}
+
+	err = v.Validate(map[string]any{
+		"env":      "production",
+		"database": map[string]any{"port": 80},
+	})
+	var issues validation.ValidationIssues
+	if !errors.As(err, &issues) || len(issues) != 2 {
+		t.Fatalf("expected two issues, got %v", err)
+	}
+	if issues[0].Message != "tls must be enabled in production" {
+		t.Fatalf("unexpected first issue: %+v", issues[0])
+	}
+	if issues[1].Key != "database.port" || issues[1].Rule != "db-port" {
+		t.Fatalf("unexpected second issue: %+v", issues[1])
+	}
+}
+
+func TestOPAValidatorCompileError(t *testing.T) {
+	_, err := NewOPAValidator(validation.OPAOptions{
+		Modules: map[string]string{"broken.rego": "package sysconf\ndeny contains msg if {"},
+	})
+	if err == nil {
+		t.Fatal("expected compile error for invalid policy")
+	}
+}
+
+func TestOPAValidatorRejectsSet(t *testing.T) {
+	v, err := NewOPAValidator(validation.OPAOptions{PolicyDir: "testdata/policies"})
+	if err != nil {
+		t.Fatalf("create validator failed: %v", err)
+	}
+	cfg, err := sysconf.New(
+		sysconf.WithContent("database:\n  port: 5432\n"),
+		sysconf.WithValidator(v),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer cfg.Close()
+
+	if err := cfg.Set("database.port", 80); err == nil {
+		t.Fatal("expected policy violation to reject Set")
+	}
+	if got := cfg.GetInt("database.port"); got != 5432 {
+		t.Fatalf("database.port = %d after rejected Set", got)
+	}
+}
@@ -0,0 +1,5 @@
+package sysconf
+
+deny contains {"key": "database.port", "msg": "privileged ports are not allowed", "rule": "db-port"} if {
+	input.database.port < 1024
+}
@@ -0,0 +1,6 @@
+package sysconf
+
+deny contains "tls must be enabled in production" if {
+	input.env == "production"
+	not input.server.tls
+}
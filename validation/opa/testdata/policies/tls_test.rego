@@ -0,0 +1,5 @@
+package sysconf_test
+
+test_ignored if {
+	false
+}
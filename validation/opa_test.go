@@ -0,0 +1,92 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeRegoEngine 以 Go 函数模拟 Rego 策略求值
+type fakeRegoEngine struct {
+	query   string
+	modules map[string]string
+	eval    func(input map[string]any) []any
+}
+
+type fakeRegoQuery struct{ engine *fakeRegoEngine }
+
+func (e *fakeRegoEngine) Prepare(_ context.Context, query string, modules map[string]string) (RegoQuery, error) {
+	e.query = query
+	e.modules = modules
+	return fakeRegoQuery{engine: e}, nil
+}
+
+func (q fakeRegoQuery) Eval(_ context.Context, input map[string]any) ([]any, error) {
+	return q.engine.eval(input), nil
+}
+
+func TestOPAValidator(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "db"), 0o755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+	for name, content := range map[string]string{
+		"db/port.rego":      "package sysconf\ndeny contains msg if input.database.port < 1024",
+		"db/port_test.rego": "package sysconf",
+		"README.md":         "ignored",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write policy failed: %v", err)
+		}
+	}
+
+	engine := &fakeRegoEngine{eval: func(input map[string]any) []any {
+		db, _ := input["database"].(map[string]any)
+		port, _ := db["port"].(int)
+		if port >= 1024 {
+			return []any{[]any{}}
+		}
+		return []any{[]any{
+			"privileged ports are not allowed",
+			map[string]any{"key": "database.port", "msg": "must be >= 1024", "rule": "db-port"},
+		}}
+	}}
+	v, err := NewOPAValidator(OPAOptions{
+		Engine:    engine,
+		PolicyDir: dir,
+		Modules:   map[string]string{"inline.rego": "package sysconf"},
+	})
+	if err != nil {
+		t.Fatalf("create validator failed: %v", err)
+	}
+	if engine.query != "data.sysconf.deny" || len(engine.modules) != 2 || engine.modules["db/port.rego"] == "" {
+		t.Fatalf("unexpected prepared policies: %q %v", engine.query, engine.modules)
+	}
+	if v.GetName() != "opa" || !v.ValidatesWholeConfig() {
+		t.Fatalf("unexpected validator metadata")
+	}
+
+	if err := v.Validate(map[string]any{"database": map[string]any{"port": 5432}}); err != nil {
+		t.Fatalf("expected policy to pass: %v", err)
+	}
+	err = v.Validate(map[string]any{"database": map[string]any{"port": 80}})
+	var issues ValidationIssues
+	if !errors.As(err, &issues) || len(issues) != 2 {
+		t.Fatalf("expected two issues, got %v", err)
+	}
+	if issues[0].Key != "" || issues[1].Key != "database.port" || issues[1].Rule != "db-port" {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+	if err.Error() != "privileged ports are not allowed; database.port: must be >= 1024" {
+		t.Fatalf("unexpected error text: %q", err.Error())
+	}
+
+	if _, err := NewOPAValidator(OPAOptions{Modules: map[string]string{"a.rego": ""}}); err == nil {
+		t.Fatalf("expected error without engine")
+	}
+	if _, err := NewOPAValidator(OPAOptions{Engine: engine}); err == nil {
+		t.Fatalf("expected error without policies")
+	}
+}
@@ -0,0 +1,127 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// RuleParams 结构化规则参数。规则参数以 JSON 对象书写时（如 `cidr:{"allow":["10.0.0.0/8"]}`）解析为对应字段；
+// 其他写法（如 "range:1,100"）原样保存在 "value" 字段中。
+type RuleParams map[string]any
+
+// RuleValidatorV2 接收结构化参数的验证函数，通过 RegisterValidatorV2 注册
+type RuleValidatorV2 func(value any, params RuleParams) (bool, string)
+
+// RegisterValidatorV2 注册接收结构化参数的自定义验证规则。注册后与 RegisterValidator 注册的规则共用名称空间，
+// 可用于字符串规则、结构化规则与 For(...).RuleWith 构建的规则；参数不是合法 JSON 对象时验证失败。
+func RegisterValidatorV2(name string, validator RuleValidatorV2) {
+	RegisterValidator(name, func(value any, raw string) (bool, string) {
+		params, err := ParseRuleParams(raw)
+		if err != nil {
+			return false, err.Error()
+		}
+		return validator(value, params)
+	})
+}
+
+// ParseRuleParams 解析规则参数：以 "{" 开头时按 JSON 对象解析，否则将非空参数保存在 "value" 字段中
+func ParseRuleParams(raw string) (RuleParams, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return RuleParams{}, nil
+	}
+	if !strings.HasPrefix(raw, "{") {
+		return RuleParams{"value": raw}, nil
+	}
+	var params RuleParams
+	if err := json.Unmarshal([]byte(raw), &params); err != nil {
+		return nil, fmt.Errorf("invalid rule parameters: %v", err)
+	}
+	return params, nil
+}
+
+// formatRule 将规则名与结构化参数编码为 "name:{json}" 形式的字符串规则
+func formatRule(name string, params map[string]any) string {
+	if len(params) == 0 {
+		return name
+	}
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		// 无法编码的参数保留为非法 JSON，验证时报告参数错误
+		return name + ":{"
+	}
+	return name + ":" + string(encoded)
+}
+
+// Has 判断参数是否存在
+func (p RuleParams) Has(name string) bool {
+	_, ok := p[name]
+	return ok
+}
+
+// String 返回字符串参数，不存在时返回 def
+func (p RuleParams) String(name, def string) string {
+	if v, ok := p[name]; ok {
+		return cast.ToString(v)
+	}
+	return def
+}
+
+// Int 返回整数参数，不存在或无法转换时返回 def
+func (p RuleParams) Int(name string, def int) int {
+	if v, ok := p[name]; ok {
+		if n, err := cast.ToIntE(v); err == nil {
+			return n
+		}
+	}
+	return def
+}
+
+// Float 返回浮点数参数，不存在或无法转换时返回 def
+func (p RuleParams) Float(name string, def float64) float64 {
+	if v, ok := p[name]; ok {
+		if f, err := cast.ToFloat64E(v); err == nil {
+			return f
+		}
+	}
+	return def
+}
+
+// Bool 返回布尔参数，不存在或无法转换时返回 def
+func (p RuleParams) Bool(name string, def bool) bool {
+	if v, ok := p[name]; ok {
+		if b, err := cast.ToBoolE(v); err == nil {
+			return b
+		}
+	}
+	return def
+}
+
+// Duration 返回时间间隔参数（如 "5s"），不存在或无法解析时返回 def
+func (p RuleParams) Duration(name string, def time.Duration) time.Duration {
+	if v, ok := p[name]; ok {
+		if d, err := cast.ToDurationE(v); err == nil {
+			return d
+		}
+	}
+	return def
+}
+
+// Strings 返回字符串列表参数；参数为单个字符串时按逗号分隔
+func (p RuleParams) Strings(name string) []string {
+	v, ok := p[name]
+	if !ok {
+		return nil
+	}
+	if s, isString := v.(string); isString {
+		if s == "" {
+			return nil
+		}
+		return strings.Split(s, ",")
+	}
+	return cast.ToStringSlice(v)
+}
@@ -0,0 +1,65 @@
+package validation
+
+import (
+	"net"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseRuleParams(t *testing.T) {
+	params, err := ParseRuleParams(`{"allow":["10.0.0.0/8","192.168.0.0/16"],"max":3,"strict":true,"wait":"5s"}`)
+	if err != nil {
+		t.Fatalf("ParseRuleParams() error = %v", err)
+	}
+	if got := params.Strings("allow"); !slices.Equal(got, []string{"10.0.0.0/8", "192.168.0.0/16"}) {
+		t.Fatalf("Strings(allow) = %v", got)
+	}
+	if params.Int("max", 0) != 3 || !params.Bool("strict", false) || params.Duration("wait", 0) != 5*time.Second {
+		t.Fatalf("unexpected typed params: %v", params)
+	}
+	if params.String("missing", "def") != "def" || params.Has("missing") {
+		t.Fatal("missing params should fall back to defaults")
+	}
+
+	legacy, err := ParseRuleParams("a,b")
+	if err != nil || !slices.Equal(legacy.Strings("value"), []string{"a", "b"}) {
+		t.Fatalf("legacy params = %v, err = %v", legacy, err)
+	}
+
+	if _, err := ParseRuleParams(`{"allow":`); err == nil {
+		t.Fatal("expected error for malformed JSON params")
+	}
+}
+
+func TestRegisterValidatorV2(t *testing.T) {
+	RegisterValidatorV2("test_cidr_allow", func(value any, params RuleParams) (bool, string) {
+		ip := net.ParseIP(value.(string))
+		for _, cidr := range params.Strings("allow") {
+			if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(ip) {
+				return true, ""
+			}
+		}
+		return false, "address is not in an allowed network"
+	})
+
+	if ok, msg := ValidateValue("10.1.2.3", `test_cidr_allow:{"allow":["10.0.0.0/8"]}`); !ok {
+		t.Fatalf("expected address to be allowed: %s", msg)
+	}
+	if ok, _ := ValidateValue("8.8.8.8", `test_cidr_allow:{"allow":["10.0.0.0/8"]}`); ok {
+		t.Fatal("expected address to be rejected")
+	}
+	if ok, msg := ValidateValue("10.1.2.3", `test_cidr_allow:{"allow":`); ok || msg == "" {
+		t.Fatalf("malformed params should fail with a message, got ok=%v msg=%q", ok, msg)
+	}
+
+	v := NewRuleValidator("net").Add(
+		For("server.bind").Text().Required().RuleWith("test_cidr_allow", map[string]any{"allow": []string{"127.0.0.0/8"}}),
+	)
+	if err := v.Validate(map[string]any{"server": map[string]any{"bind": "127.0.0.1"}}); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if err := v.Validate(map[string]any{"server": map[string]any{"bind": "10.0.0.1"}}); err == nil {
+		t.Fatal("expected builder rule to reject address outside allow list")
+	}
+}
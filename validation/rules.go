@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net"
 	"net/url"
 	"regexp"
@@ -24,6 +25,9 @@ var validators = map[string]RuleValidator{
 	"required":    validateRequired,
 	"string":      validateString,
 	"number":      validateNumber,
+	"integer":     validateInteger,
+	"min":         validateMinRule,
+	"max":         validateMaxRule,
 	"email":       validateEmail,
 	"url":         validateURL,
 	"range":       validateRange,
@@ -51,6 +55,14 @@ func RegisterValidator(name string, validator RuleValidator) {
 	validators[name] = validator
 }
 
+// HasRule 检查是否已注册指定名称的验证规则
+func HasRule(name string) bool {
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+	_, ok := validators[name]
+	return ok
+}
+
 // ValidateValue 验证值是否符合规则
 func ValidateValue(value any, rule string) (bool, string) {
 	parts := strings.SplitN(rule, ":", 2)
@@ -108,6 +120,86 @@ func validateNumber(value any, _ string) (bool, string) {
 	return false, "field must be number type"
 }
 
+// validateInteger 验证整数类型（JSON 解码得到的整数值 float64 同样接受）
+func validateInteger(value any, _ string) (bool, string) {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return true, ""
+	case float32:
+		if v == float32(math.Trunc(float64(v))) {
+			return true, ""
+		}
+	case float64:
+		if v == math.Trunc(v) {
+			return true, ""
+		}
+	}
+	return false, "field must be integer type"
+}
+
+// validateMinRule 验证数值下限
+func validateMinRule(value any, params string) (bool, string) {
+	num, ok := numberValue(value)
+	if !ok {
+		return false, "field must be number type"
+	}
+	limit, err := strconv.ParseFloat(params, 64)
+	if err != nil {
+		return false, "invalid min parameter"
+	}
+	if num < limit {
+		return false, fmt.Sprintf("value must be at least %s", FormatNumber(limit))
+	}
+	return true, ""
+}
+
+// validateMaxRule 验证数值上限
+func validateMaxRule(value any, params string) (bool, string) {
+	num, ok := numberValue(value)
+	if !ok {
+		return false, "field must be number type"
+	}
+	limit, err := strconv.ParseFloat(params, 64)
+	if err != nil {
+		return false, "invalid max parameter"
+	}
+	if num > limit {
+		return false, fmt.Sprintf("value must be at most %s", FormatNumber(limit))
+	}
+	return true, ""
+}
+
+// numberValue 将数值类型转换为 float64
+func numberValue(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
 // emailRegex 预编译的邮箱验证正则表达式
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9._%+-]*[a-zA-Z0-9])?@[a-zA-Z0-9](?:[a-zA-Z0-9.-]*[a-zA-Z0-9])?\.[a-zA-Z]{2,}$`)
 
@@ -142,33 +234,8 @@ func validateURL(value any, _ string) (bool, string) {
 
 // validateRange 验证数值范围
 func validateRange(value any, params string) (bool, string) {
-	var num float64
-	switch v := value.(type) {
-	case int:
-		num = float64(v)
-	case int8:
-		num = float64(v)
-	case int16:
-		num = float64(v)
-	case int32:
-		num = float64(v)
-	case int64:
-		num = float64(v)
-	case uint:
-		num = float64(v)
-	case uint8:
-		num = float64(v)
-	case uint16:
-		num = float64(v)
-	case uint32:
-		num = float64(v)
-	case uint64:
-		num = float64(v)
-	case float32:
-		num = float64(v)
-	case float64:
-		num = v
-	default:
+	num, ok := numberValue(value)
+	if !ok {
 		return false, "field must be number type"
 	}
 
@@ -184,7 +251,7 @@ func validateRange(value any, params string) (bool, string) {
 	}
 
 	if num < min || num > max {
-		return false, fmt.Sprintf("value must be between %v and %v", min, max)
+		return false, fmt.Sprintf("value must be between %s and %s", FormatNumber(min), FormatNumber(max))
 	}
 	return true, ""
 }
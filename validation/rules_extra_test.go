@@ -29,3 +29,16 @@ func TestRuleFactoriesAndValidateStruct(t *testing.T) {
 	_ = Length("3", "")
 	_ = Enum("a,b", "")
 }
+
+func TestHasRule(t *testing.T) {
+	if !HasRule("hostname") {
+		t.Fatalf("builtin rule should be registered")
+	}
+	if HasRule("definitely_unknown_rule") {
+		t.Fatalf("unknown rule should not be reported")
+	}
+	RegisterValidator("has_rule_custom", func(any, string) (bool, string) { return true, "" })
+	if !HasRule("has_rule_custom") {
+		t.Fatalf("custom rule should be reported after registration")
+	}
+}
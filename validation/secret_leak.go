@@ -0,0 +1,236 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// WarningValidator 返回非阻断性问题（警告）的验证器。sysconf.Lint 会将这些问题报告为 warning 级别的检查结果。
+type WarningValidator interface {
+	Warnings(config map[string]any) ValidationIssues
+}
+
+// secretPattern 已知凭据格式
+type secretPattern struct {
+	kind    string
+	pattern *regexp.Regexp
+}
+
+// secretPatterns 常见凭据格式，无论键名如何都会被标记
+var secretPatterns = []secretPattern{
+	{"private-key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+	{"aws-access-key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"github-token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"slack-token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`)},
+	{"url-credentials", regexp.MustCompile(`[a-z][a-z0-9+.-]*://[^/\s:@]+:[^/\s@]+@`)},
+}
+
+// secretKeyHints 视为敏感键的键名片段，这些键本就用于保存凭据，不参与泄漏扫描
+var secretKeyHints = []string{"password", "passwd", "secret", "token", "api_key", "apikey", "private_key", "credential"}
+
+// secretReferencePrefixes 视为引用而非明文的值前缀
+var secretReferencePrefixes = []string{"${", "$", "env:", "file://", "vault:", "ENC(", "enc:"}
+
+// uuidPattern UUID 格式，避免被误判为高熵字符串
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// DetectSecret 判断值是否匹配已知凭据格式（PEM 私钥、AWS 访问密钥、GitHub/Slack 令牌、URL 中的账号密码），
+// 返回匹配的凭据类型
+func DetectSecret(value string) (kind string, found bool) {
+	for _, p := range secretPatterns {
+		if p.pattern.MatchString(value) {
+			return p.kind, true
+		}
+	}
+	return "", false
+}
+
+// SecretLeakValidator 扫描非敏感键中疑似明文凭据的值（已知凭据格式与高熵字符串），
+// 用于在 CI 中发现误提交到配置文件的密钥。默认仅通过 Warnings 报告问题，Validate 始终通过；
+// 调用 Strict 后 Validate 会将问题作为错误返回。
+//
+//	findings, _ := sysconf.Lint("config.yaml", sysconf.LintOptions{
+//	    Validators: []sysconf.ConfigValidator{validation.NewSecretLeakValidator()},
+//	})
+type SecretLeakValidator struct {
+	name       string
+	minLength  int     // 参与熵检测的最短长度
+	minEntropy float64 // 每字符香农熵下限（比特）
+	allowKeys  []string
+	strict     bool
+}
+
+// NewSecretLeakValidator 创建明文凭据扫描验证器
+func NewSecretLeakValidator() *SecretLeakValidator {
+	return &SecretLeakValidator{
+		name:       "Secret Leak Validator",
+		minLength:  24,
+		minEntropy: 4.0,
+	}
+}
+
+// Strict 将扫描到的问题作为验证错误返回（阻止加载或写入）
+func (v *SecretLeakValidator) Strict() *SecretLeakValidator {
+	v.strict = true
+	return v
+}
+
+// AllowKeys 跳过指定配置键及其子键（如已知的非敏感哈希值）
+func (v *SecretLeakValidator) AllowKeys(keys ...string) *SecretLeakValidator {
+	v.allowKeys = append(v.allowKeys, keys...)
+	return v
+}
+
+// EntropyThreshold 调整高熵检测：长度不少于 minLength 且每字符熵不低于 bits 的值被视为疑似凭据
+func (v *SecretLeakValidator) EntropyThreshold(bits float64, minLength int) *SecretLeakValidator {
+	v.minEntropy, v.minLength = bits, minLength
+	return v
+}
+
+// GetName 实现 Validator 接口
+func (v *SecretLeakValidator) GetName() string {
+	return v.name
+}
+
+// ValidatesWholeConfig 声明该验证器需要完整的候选配置
+func (v *SecretLeakValidator) ValidatesWholeConfig() bool {
+	return true
+}
+
+// Validate 实现 Validator 接口：Strict 模式下存在问题时返回 ValidationIssues，否则始终通过
+func (v *SecretLeakValidator) Validate(config map[string]any) error {
+	if !v.strict {
+		return nil
+	}
+	if issues := v.Scan(config); len(issues) > 0 {
+		return issues
+	}
+	return nil
+}
+
+// Warnings 实现 WarningValidator，返回扫描到的问题
+func (v *SecretLeakValidator) Warnings(config map[string]any) ValidationIssues {
+	return v.Scan(config)
+}
+
+// Scan 扫描配置并返回疑似明文凭据（按键排序）
+func (v *SecretLeakValidator) Scan(config map[string]any) ValidationIssues {
+	var issues ValidationIssues
+	v.scan("", config, &issues)
+	slices.SortFunc(issues, func(a, b ValidationIssue) int { return strings.Compare(a.Key, b.Key) })
+	return issues
+}
+
+// scan 递归扫描嵌套配置
+func (v *SecretLeakValidator) scan(key string, value any, issues *ValidationIssues) {
+	switch val := value.(type) {
+	case map[string]any:
+		for k, child := range val {
+			childKey := k
+			if key != "" {
+				childKey = key + "." + k
+			}
+			v.scan(childKey, child, issues)
+		}
+	case []any:
+		for i, item := range val {
+			v.scan(fmt.Sprintf("%s[%d]", key, i), item, issues)
+		}
+	case []string:
+		for i, item := range val {
+			v.scan(fmt.Sprintf("%s[%d]", key, i), item, issues)
+		}
+	case string:
+		if issue, found := v.check(key, val); found {
+			*issues = append(*issues, issue)
+		}
+	}
+}
+
+// check 检查单个字符串值
+func (v *SecretLeakValidator) check(key, value string) (ValidationIssue, bool) {
+	if value == "" || v.allowed(key) || isSecretKeyName(key) {
+		return ValidationIssue{}, false
+	}
+	if kind, found := DetectSecret(value); found {
+		return ValidationIssue{Key: key, Rule: kind, Message: "value looks like a " + strings.ReplaceAll(kind, "-", " ")}, true
+	}
+	if v.highEntropy(value) {
+		return ValidationIssue{
+			Key:     key,
+			Rule:    "high-entropy",
+			Message: fmt.Sprintf("high-entropy value (%s bits/char) looks like a credential", FormatNumber(math.Round(shannonEntropy(value)*100)/100)),
+		}, true
+	}
+	return ValidationIssue{}, false
+}
+
+// allowed 判断键是否在允许列表中
+func (v *SecretLeakValidator) allowed(key string) bool {
+	for _, allow := range v.allowKeys {
+		if key == allow || strings.HasPrefix(key, allow+".") || strings.HasPrefix(key, allow+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// highEntropy 判断值是否为疑似随机生成的凭据：足够长、不含空白、同时包含字母与数字且熵足够高
+func (v *SecretLeakValidator) highEntropy(value string) bool {
+	if len(value) < v.minLength || uuidPattern.MatchString(value) {
+		return false
+	}
+	for _, prefix := range secretReferencePrefixes {
+		if strings.HasPrefix(value, prefix) {
+			return false
+		}
+	}
+	if strings.Contains(value, "://") || strings.HasPrefix(value, "/") || strings.HasPrefix(value, "./") {
+		return false
+	}
+	var letters, digits bool
+	for _, r := range value {
+		switch {
+		case unicode.IsSpace(r):
+			return false
+		case unicode.IsLetter(r):
+			letters = true
+		case unicode.IsDigit(r):
+			digits = true
+		}
+	}
+	return letters && digits && shannonEntropy(value) >= v.minEntropy
+}
+
+// shannonEntropy 计算字符串每字符的香农熵（比特）
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	var entropy float64
+	for _, n := range counts {
+		p := float64(n) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// isSecretKeyName 根据键名最后一段判断是否为敏感键
+func isSecretKeyName(key string) bool {
+	last := key
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		last = key[i+1:]
+	}
+	if i := strings.Index(last, "["); i >= 0 {
+		last = last[:i]
+	}
+	last = strings.ToLower(last)
+	return slices.ContainsFunc(secretKeyHints, func(hint string) bool { return strings.Contains(last, hint) })
+}
@@ -6,6 +6,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/darkit/sysconf/internal/utils"
 )
 
 // ValidationRule 验证规则
@@ -20,74 +22,59 @@ type ValidationRule struct {
 	Message string
 }
 
+// String 返回规则摘要，格式与字符串规则一致（如 "required"、"range:1,100"）
+func (r ValidationRule) String() string {
+	if r.Value == "" {
+		return r.Type
+	}
+	return r.Type + ":" + r.Value
+}
+
 // Validate 验证值
 func Validate(value interface{}, rule ValidationRule) error {
-	var err error
+	return ValidateField("", value, rule)
+}
+
+// ValidateField 验证配置键的值。规则消息可使用 {{.Key}}、{{.Min}}、{{.Max}}、{{.Actual}} 等占位符（见 MessageData），
+// 消息为空时使用规则自身的错误描述。
+func ValidateField(key string, value interface{}, rule ValidationRule) error {
+	err := checkRule(value, rule)
+	if err == nil {
+		return nil
+	}
+	if rule.Message == "" {
+		return err
+	}
+	return fmt.Errorf("%s", FormatMessage(rule.Message, messageData(key, value, rule)))
+}
+
+// checkRule 执行单条规则，返回规则自身的错误描述
+func checkRule(value interface{}, rule ValidationRule) error {
 	switch rule.Type {
 	case "required":
-		if isZero(reflect.ValueOf(value)) {
-			return fmt.Errorf("%s", rule.Message)
+		if utils.IsZero(reflect.ValueOf(value)) {
+			return fmt.Errorf("field cannot be empty")
 		}
+		return nil
 	case "min":
-		if err = validateMin(value, rule.Value); err != nil {
-			return fmt.Errorf("%s", rule.Message)
-		}
+		return validateMin(value, rule.Value)
 	case "max":
-		if err = validateMax(value, rule.Value); err != nil {
-			return fmt.Errorf("%s", rule.Message)
-		}
+		return validateMax(value, rule.Value)
 	case "range":
-		if err = validateRangeValue(value, rule.Value); err != nil {
-			return fmt.Errorf("%s", rule.Message)
-		}
+		return validateRangeValue(value, rule.Value)
 	case "length":
-		if err = validateLengthValue(value, rule.Value); err != nil {
-			return fmt.Errorf("%s", rule.Message)
-		}
+		return validateLengthValue(value, rule.Value)
 	case "pattern":
-		if err = validatePattern(value, rule.Value); err != nil {
-			return fmt.Errorf("%s", rule.Message)
-		}
+		return validatePattern(value, rule.Value)
 	case "enum":
-		if err = validateEnumValue(value, rule.Value); err != nil {
-			return fmt.Errorf("%s", rule.Message)
-		}
+		return validateEnumValue(value, rule.Value)
 	default:
 		// 使用 rules.go 中的验证器处理其他类型
-		ruleStr := rule.Type
-		if rule.Value != "" {
-			ruleStr = rule.Type + ":" + rule.Value
-		}
-		if valid, errMsg := ValidateValue(value, ruleStr); !valid {
-			if rule.Message != "" {
-				return fmt.Errorf("%s", rule.Message)
-			}
+		if valid, errMsg := ValidateValue(value, rule.String()); !valid {
 			return fmt.Errorf("%s", errMsg)
 		}
+		return nil
 	}
-	return nil
-}
-
-// isZero 检查值是否为零值
-func isZero(v reflect.Value) bool {
-	if !v.IsValid() {
-		return true
-	}
-	switch v.Kind() {
-	case reflect.Array, reflect.String:
-		return v.Len() == 0
-	case reflect.Bool:
-		return !v.Bool()
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return v.Int() == 0
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return v.Uint() == 0
-	case reflect.Float32, reflect.Float64:
-		return v.Float() == 0
-	case reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
-		return v.IsNil()
-	}
-	return false
 }
 
 // validateMin 验证最小值
@@ -116,7 +103,7 @@ func validateMin(value interface{}, min string) error {
 			return err
 		}
 		if v.Float() < minVal {
-			return fmt.Errorf("value must be greater than or equal to %f", minVal)
+			return fmt.Errorf("value must be greater than or equal to %s", FormatNumber(minVal))
 		}
 	case reflect.String:
 		minLen, err := strconv.Atoi(min)
@@ -158,7 +145,7 @@ func validateMax(value interface{}, max string) error {
 			return err
 		}
 		if v.Float() > maxVal {
-			return fmt.Errorf("value must be less than or equal to %f", maxVal)
+			return fmt.Errorf("value must be less than or equal to %s", FormatNumber(maxVal))
 		}
 	case reflect.String:
 		maxLen, err := strconv.Atoi(max)
@@ -341,11 +328,11 @@ func ValidateStruct(v interface{}, rules map[string][]ValidationRule) error {
 
 		if fieldRules, exists := rules[fieldType.Name]; exists {
 			for _, rule := range fieldRules {
-				if rule.Type == "required" && isZero(field) {
+				if rule.Type == "required" && utils.IsZero(field) {
 					return fmt.Errorf("field %s is required", fieldType.Name)
 				}
 
-				if !isZero(field) {
+				if !utils.IsZero(field) {
 					if err := Validate(field.Interface(), rule); err != nil {
 						return fmt.Errorf("field %s validation failed: %s", fieldType.Name, err)
 					}
@@ -75,6 +75,17 @@ func (d *DefaultValidator) GetName() string {
 	return d.name
 }
 
+// DescribeRules 返回默认验证器按键名匹配的规则摘要（键为匹配模式说明）
+func (d *DefaultValidator) DescribeRules() map[string][]string {
+	return map[string][]string{
+		"port, *.port, *_port": {"port"},
+		"*timeout*":            {"duration"},
+		"*url*, *endpoint*":    {"url"},
+		"*email*, *mail*":      {"email"},
+		"host, *.host":         {"hostname"},
+	}
+}
+
 // validateRecursive 递归验证配置
 func (d *DefaultValidator) validateRecursive(prefix string, config map[string]any) error {
 	for key, value := range config {
@@ -199,7 +210,7 @@ func (r *StructuredValidator) Validate(config map[string]any) error {
 			}
 
 			// 使用全局 Validate 函数验证规则
-			if err := Validate(value, rule); err != nil {
+			if err := ValidateField(key, value, rule); err != nil {
 				return fmt.Errorf("validator '%s' - field '%s': %w", r.name, key, err)
 			}
 		}
@@ -253,6 +264,15 @@ func (r *StructuredValidator) AddStringRules(key string, rules ...string) *Struc
 	return r
 }
 
+// Add 添加由 For 构建的类型化规则
+func (r *StructuredValidator) Add(sets ...RuleSet) *StructuredValidator {
+	for _, set := range sets {
+		field := set.Field()
+		r.strRules[field.key] = append(r.strRules[field.key], field.rules...)
+	}
+	return r
+}
+
 // GetRulesForField 获取特定字段的结构化规则
 func (r *StructuredValidator) GetRulesForField(key string) []ValidationRule {
 	if rules, exists := r.rules[key]; exists {
@@ -276,6 +296,20 @@ func (r *StructuredValidator) HasRuleForField(key string) bool {
 	return hasStructRule || hasStringRule
 }
 
+// DescribeRules 返回字段到规则摘要的映射，包含结构化规则与字符串规则，规则按添加顺序排列
+func (r *StructuredValidator) DescribeRules() map[string][]string {
+	described := make(map[string][]string, len(r.rules)+len(r.strRules))
+	for key, rules := range r.rules {
+		for _, rule := range rules {
+			described[key] = append(described[key], rule.String())
+		}
+	}
+	for key, rules := range r.strRules {
+		described[key] = append(described[key], rules...)
+	}
+	return described
+}
+
 // GetSupportedFields 获取验证器支持的所有字段前缀
 func (r *StructuredValidator) GetSupportedFields() []string {
 	fieldPrefixes := make(map[string]bool)
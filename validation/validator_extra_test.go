@@ -101,6 +101,18 @@ func TestStructuredValidator(t *testing.T) {
 	}
 }
 
+func TestStructuredValidatorDescribeRules(t *testing.T) {
+	validator := NewRuleValidator("rule")
+	validator.AddRules("db.port", Required("required"), Range("1", "65535", "")).
+		AddStringRules("db.port", "port")
+
+	rules := validator.DescribeRules()
+	want := []string{"required", "range:1,65535", "port"}
+	if got := rules["db.port"]; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Fatalf("DescribeRules()[db.port] = %v, want %v", got, want)
+	}
+}
+
 // CompositeValidator 与 ValidatorFunc 覆盖
 func TestCompositeValidator(t *testing.T) {
 	one := ValidatorFunc(func(map[string]any) error { return nil })
@@ -258,7 +258,7 @@ server:
 	assert.Len(t, validators, 2, "应该有2个验证器")
 
 	// 测试所有验证器都通过
-	allSettings := flattenAllSettings(cfg.AllSettings())
+	allSettings := Flatten(cfg.AllSettings())
 	for _, validator := range validators {
 		err := validator.Validate(allSettings)
 		assert.NoError(t, err, "验证应该通过")
@@ -269,7 +269,7 @@ server:
 	require.NoError(t, err)
 
 	// 等待配置更新
-	allSettings = flattenAllSettings(cfg.AllSettings())
+	allSettings = Flatten(cfg.AllSettings())
 
 	// 第二个验证器应该失败（端口超出范围）
 	err = validators[1].Validate(allSettings)
@@ -282,30 +282,6 @@ server:
 	assert.Empty(t, validators, "验证器应该被清空")
 }
 
-// flattenAllSettings 将嵌套 map 展开为扁平点号键，便于验证器直接读取。
-func flattenAllSettings(m map[string]any) map[string]any {
-	out := make(map[string]any)
-	var walk func(prefix string, val any)
-	walk = func(prefix string, val any) {
-		switch v := val.(type) {
-		case map[string]any:
-			for k, vv := range v {
-				key := k
-				if prefix != "" {
-					key = prefix + "." + k
-				}
-				walk(key, vv)
-			}
-		default:
-			out[prefix] = v
-		}
-	}
-	for k, v := range m {
-		walk(k, v)
-	}
-	return out
-}
-
 // TestValidatorsConcurrency 测试验证器并发安全性
 func TestValidatorsConcurrency(t *testing.T) {
 	tmpDir := filepath.Join(os.TempDir(), "validator_concurrency_test")
@@ -473,3 +449,34 @@ func TestCreateBackupIfExists(t *testing.T) {
 		assert.GreaterOrEqual(t, len(files), 1, "至少应生成1个备份文件")
 	})
 }
+
+// wholeConfigValidator 声明需要完整候选配置的验证器
+type wholeConfigValidator struct{ seen map[string]any }
+
+func (v *wholeConfigValidator) Validate(config map[string]any) error {
+	v.seen = config
+	db, _ := config["database"].(map[string]any)
+	if port, ok := db["port"].(int); ok && port < 1024 {
+		return fmt.Errorf("database port must be >= 1024")
+	}
+	return nil
+}
+
+func (v *wholeConfigValidator) GetName() string { return "whole" }
+
+func (v *wholeConfigValidator) ValidatesWholeConfig() bool { return true }
+
+func TestWholeConfigValidatorOnSet(t *testing.T) {
+	v := &wholeConfigValidator{}
+	cfg, err := New(WithContent("database:\n  host: db\n  port: 5432\napp:\n  name: demo\n"), WithValidator(v))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	require.NoError(t, cfg.Set("app.name", "other"))
+	// 整体验证器收到完整的嵌套候选配置
+	assert.Equal(t, "db", v.seen["database"].(map[string]any)["host"])
+	assert.Equal(t, "other", v.seen["app"].(map[string]any)["name"])
+
+	require.Error(t, cfg.Set("database.port", 80))
+	assert.Equal(t, 5432, cfg.GetInt("database.port"))
+}
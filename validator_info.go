@@ -0,0 +1,89 @@
+package sysconf
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/darkit/sysconf/validation"
+)
+
+// ValidatorInfo 已注册验证器的只读描述，用于调试端点展示当前生效的约束
+type ValidatorInfo struct {
+	Name     string              // GetName 返回的名称
+	Type     string              // 验证器的具体类型，如 "*validation.StructuredValidator"
+	Prefixes []string            // 覆盖的配置键前缀（已排序）；为空表示作用于整个配置或无法确定
+	Rules    map[string][]string // 字段 → 规则摘要（如 "required"、"range:1,100"）；验证器无法描述规则时为空
+	Children []ValidatorInfo     // 复合验证器的子验证器
+}
+
+// RuleDescriber 可描述自身规则的验证器。自定义验证器实现该接口后，其规则会出现在 DescribeValidators 的结果中。
+type RuleDescriber interface {
+	DescribeRules() map[string][]string
+}
+
+// DescribeValidators 按注册顺序返回已注册验证器的描述：名称、类型、覆盖的键前缀与规则摘要
+// （StructuredValidator 为字段 → 规则映射，CompositeValidator 展开为子验证器）。返回值是副本，修改不影响验证器。
+func (c *Config) DescribeValidators() []ValidatorInfo {
+	validators := c.GetValidators()
+	infos := make([]ValidatorInfo, 0, len(validators))
+	for _, v := range validators {
+		infos = append(infos, describeValidator(v))
+	}
+	return infos
+}
+
+// describeValidator 生成单个验证器的描述
+func describeValidator(v ConfigValidator) ValidatorInfo {
+	info := ValidatorInfo{
+		Name: v.GetName(),
+		Type: fmt.Sprintf("%T", v),
+	}
+	if describer, ok := v.(RuleDescriber); ok {
+		rules := describer.DescribeRules()
+		info.Rules = make(map[string][]string, len(rules))
+		for key, summaries := range rules {
+			info.Rules[key] = slices.Clone(summaries)
+		}
+	}
+
+	switch typed := v.(type) {
+	case *validation.StructuredValidator:
+		info.Prefixes = typed.GetSupportedFields()
+	case *validation.CompositeValidator:
+		prefixes := make(map[string]struct{})
+		whole := false
+		for _, child := range typed.GetValidators() {
+			childInfo := describeValidator(child)
+			whole = whole || len(childInfo.Prefixes) == 0
+			for _, prefix := range childInfo.Prefixes {
+				prefixes[prefix] = struct{}{}
+			}
+			info.Children = append(info.Children, childInfo)
+		}
+		// 任一子验证器作用于整个配置时，复合验证器同样视为作用于整个配置
+		if !whole {
+			info.Prefixes = slices.Collect(maps.Keys(prefixes))
+		}
+	default:
+		info.Prefixes = rulePrefixes(info.Rules)
+	}
+	slices.Sort(info.Prefixes)
+	return info
+}
+
+// rulePrefixes 从规则字段中提取顶级键前缀，含通配符的模式视为作用于整个配置
+func rulePrefixes(rules map[string][]string) []string {
+	var prefixes []string
+	for key := range rules {
+		if strings.ContainsAny(key, "*, ") {
+			return nil
+		}
+		prefix, _, _ := strings.Cut(key, ".")
+		if !slices.Contains(prefixes, prefix) {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
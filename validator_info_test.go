@@ -0,0 +1,77 @@
+package sysconf
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+	"github.com/darkit/sysconf/validation"
+)
+
+type describedValidator struct{}
+
+func (describedValidator) Validate(map[string]any) error { return nil }
+func (describedValidator) GetName() string               { return "described" }
+func (describedValidator) DescribeRules() map[string][]string {
+	return map[string][]string{"cache.size": {"range:1,1024"}}
+}
+
+func TestDescribeValidators(t *testing.T) {
+	cfg, err := New(WithContent("app:\n  name: demo\n"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	structured := validation.NewRuleValidator("server").
+		AddRule("server.host", validation.Required("host required")).
+		AddStringRules("server.port", "required", "port")
+	cfg.AddValidator(structured)
+	cfg.AddValidator(validation.NewCompositeValidator("bundle", validation.NewRedisValidator(), validation.NewDefaultValidator()))
+	cfg.AddValidator(describedValidator{})
+	cfg.AddValidateFunc(func(map[string]any) error { return nil })
+
+	infos := cfg.DescribeValidators()
+	if len(infos) != 4 {
+		t.Fatalf("DescribeValidators() returned %d entries, want 4", len(infos))
+	}
+
+	server := infos[0]
+	if server.Name != "server" || server.Type != "*validation.StructuredValidator" {
+		t.Fatalf("unexpected structured info: %+v", server)
+	}
+	if !slices.Equal(server.Prefixes, []string{"server"}) {
+		t.Fatalf("structured prefixes = %v", server.Prefixes)
+	}
+	if !slices.Equal(server.Rules["server.port"], []string{"required", "port"}) ||
+		!slices.Equal(server.Rules["server.host"], []string{"required"}) {
+		t.Fatalf("structured rules = %v", server.Rules)
+	}
+
+	bundle := infos[1]
+	if len(bundle.Children) != 2 || bundle.Children[0].Name != "Redis Configuration Validator" {
+		t.Fatalf("composite children = %+v", bundle.Children)
+	}
+	if len(bundle.Prefixes) != 0 {
+		t.Fatalf("composite with a whole-config child should have no prefixes, got %v", bundle.Prefixes)
+	}
+	if len(bundle.Children[1].Rules) == 0 {
+		t.Fatal("default validator should describe its key patterns")
+	}
+
+	custom := infos[2]
+	if !slices.Equal(custom.Prefixes, []string{"cache"}) || custom.Rules["cache.size"][0] != "range:1,1024" {
+		t.Fatalf("custom describer info = %+v", custom)
+	}
+
+	fn := infos[3]
+	if fn.Type != "sysconf.ConfigValidateFunc" || fn.Rules != nil || fn.Prefixes != nil {
+		t.Fatalf("opaque validator info = %+v", fn)
+	}
+
+	// 返回副本，修改不影响验证器
+	server.Rules["server.port"][0] = "mutated"
+	if got := cfg.DescribeValidators()[0].Rules["server.port"][0]; got != "required" {
+		t.Fatalf("DescribeValidators should return copies, got %q", got)
+	}
+}
@@ -0,0 +1,303 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// FromViper 以已有的 viper 实例创建 Config，便于已使用 viper 的大型代码库逐步迁移：
+//   - 配置文件：沿用 v 使用（或 SetConfigFile 指定）的配置文件，由 sysconf 读取、写回与监听（Watch）
+//   - 键分隔符：沿用 v 的 viper.KeyDelimiter（见 WithKeyDelimiter）
+//   - 配置值：v 中与配置文件不同的 Set、环境变量、标志等值作为覆盖值导入（见 Overrides）；
+//     SetDefault 设置的默认值只填充配置中缺失的键，文件重载与 Set 写入的值优先于默认值；
+//     v 没有配置文件时全部配置作为内存配置导入，可继续 Set
+//
+// opts 在推导出的选项之后应用，可覆盖上述设置；配合 WithViperSync 可在导入后持续同步 v 中的变更。
+// 导入后 v 与返回的 Config 相互独立，sysconf 的写入不会反映到 v。
+func FromViper(v *viper.Viper, opts ...Option) (*Config, error) {
+	if v == nil {
+		return nil, errors.New("viper instance is nil")
+	}
+	snap := readViper(v)
+	file := v.ConfigFileUsed()
+
+	var base []Option
+	if delim := viperField(v, "keyDelim"); delim != "" {
+		base = append(base, WithKeyDelimiter(delim))
+	}
+	if file != "" {
+		base = append(base, WithFile(file))
+		if _, ok := ModeFromPath(file); !ok {
+			if configType := viperField(v, "configType"); configType != "" {
+				base = append(base, WithMode(configType))
+			}
+		}
+	} else {
+		content, err := yaml.Marshal(snap.withoutDefaults("", snap.nested))
+		if err != nil {
+			return nil, fmt.Errorf("marshal viper settings: %w", err)
+		}
+		base = append(base, WithMode("yaml"), WithContent(string(content)))
+	}
+
+	c, err := New(append(base, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	imported := make(map[string]struct{})
+	c.storeViperDefaults(snap.defaults)
+	if file != "" {
+		if n := c.importViperValues(snap, nil, imported); n > 0 {
+			c.logger.Infof("Imported %d value(s) from viper instance as overrides", n)
+		}
+	}
+	if c.viperSync > 0 {
+		c.startViperSync(v, snap.settings, imported)
+	}
+	return c, nil
+}
+
+// WithViperSync 设置 FromViper 导入后持续同步的轮询间隔：v 中新增或变化的值（Set、ReadInConfig 等）
+// 按间隔作为覆盖值导入、变化的 SetDefault 默认值更新缺失键的填充值、已从 v 移除的键撤销其覆盖值，直到 Close；对 New 无效。
+// viper 实例不是并发安全的：轮询在持有 mu 时读取 v，旧代码修改 v 时须持有同一把锁；mu 为 nil 时不加锁，
+// 此时只应在不会并发修改 v 的场景使用。
+func WithViperSync(interval time.Duration, mu sync.Locker) Option {
+	return func(c *Config) {
+		c.viperSync = max(interval, 0)
+		c.viperSyncMu = mu
+	}
+}
+
+// startViperSync 启动对 viper 实例的轮询同步，last 为已导入的扁平配置，imported 为已作为覆盖值导入的键
+func (c *Config) startViperSync(v *viper.Viper, last map[string]any, imported map[string]struct{}) {
+	stopChan := c.stopChan
+	c.wg.Go(func() {
+		ticker := time.NewTicker(c.viperSync)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopChan:
+				return
+			case <-ticker.C:
+				snap := c.viperSnapshot(v)
+				c.storeViperDefaults(snap.defaults)
+				if n := c.importViperValues(snap, last, imported); n > 0 {
+					c.logger.Infof("Synced %d changed value(s) from viper instance", n)
+				}
+				last = snap.settings
+			}
+		}
+	})
+}
+
+// viperSnapshot viper 实例某一时刻的配置
+type viperSnapshot struct {
+	nested   map[string]any // AllSettings 的嵌套配置
+	settings map[string]any // 全部生效值（扁平键）
+	sets     map[string]any // Set 写入的值（扁平键）
+	defaults map[string]any // SetDefault 设置的默认值（扁平键）
+}
+
+// readViper 读取 viper 实例的生效配置以及 Set 与 SetDefault 两层的值
+func readViper(v *viper.Viper) viperSnapshot {
+	snap := viperSnapshot{
+		nested:   v.AllSettings(),
+		settings: make(map[string]any),
+		sets:     make(map[string]any),
+		defaults: make(map[string]any),
+	}
+	flattenSettings("", snap.nested, snap.settings)
+	flattenSettings("", viperMap(v, "override"), snap.sets)
+	flattenSettings("", viperMap(v, "defaults"), snap.defaults)
+	return snap
+}
+
+// fromDefaults 键的生效值是否来自 SetDefault（未经 Set 写入且等于默认值）
+func (s viperSnapshot) fromDefaults(key string, value any) bool {
+	if _, set := s.sets[key]; set {
+		return false
+	}
+	def, ok := s.defaults[key]
+	return ok && sameViperValue(def, value)
+}
+
+// withoutDefaults 返回去掉来自 SetDefault 的叶子值的嵌套配置副本，prefix 为 nested 的扁平键前缀
+func (s viperSnapshot) withoutDefaults(prefix string, nested map[string]any) map[string]any {
+	result := make(map[string]any, len(nested))
+	for key, value := range nested {
+		fullKey := key
+		if prefix != "" {
+			fullKey = prefix + "." + key
+		}
+		if section, ok := value.(map[string]any); ok {
+			if pruned := s.withoutDefaults(fullKey, section); len(pruned) > 0 || len(section) == 0 {
+				result[key] = pruned
+			}
+			continue
+		}
+		if !s.fromDefaults(fullKey, value) {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// viperSnapshot 在 WithViperSync 指定的锁保护下读取 viper 实例
+func (c *Config) viperSnapshot(v *viper.Viper) viperSnapshot {
+	if c.viperSyncMu != nil {
+		c.viperSyncMu.Lock()
+		defer c.viperSyncMu.Unlock()
+	}
+	return readViper(v)
+}
+
+// importViperValues 将与当前配置（previous 非 nil 时为上次导入的值）不同的 viper 配置作为覆盖值导入，返回导入数量。
+// 等于 SetDefault 默认值且未经 Set 写入的值由 storeViperDefaults 填充，不作为覆盖值；
+// imported 中的键不再出现在 v 中或回落为默认值时撤销其覆盖值。
+func (c *Config) importViperValues(snap viperSnapshot, previous map[string]any, imported map[string]struct{}) int {
+	if previous == nil {
+		previous = c.loadData()
+	}
+	changed := make(map[string]any)
+	var removed []string
+	for key, value := range snap.settings {
+		if old, ok := previous[key]; ok && sameViperValue(old, value) {
+			continue
+		}
+		if snap.fromDefaults(key, value) {
+			if _, ok := imported[key]; ok {
+				removed = append(removed, key)
+			}
+			continue
+		}
+		changed[key] = value
+	}
+	for key := range imported {
+		if _, ok := snap.settings[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	if len(changed) == 0 && len(removed) == 0 || c.closed.Load() {
+		return 0
+	}
+	c.mu.Lock()
+	for _, key := range removed {
+		delete(imported, key)
+	}
+	dropped := c.deleteOverridesUnsafe(removed...)
+	for key, value := range changed {
+		c.storeOverrideUnsafe(key, value)
+		imported[key] = struct{}{}
+	}
+	c.mu.Unlock()
+
+	if len(changed) > 0 || dropped {
+		c.invalidateLookupCache()
+		c.invalidateCache()
+	}
+	if dropped {
+		c.logger.Infof("Dropped overrides for %d key(s) no longer set in viper instance", len(removed))
+	}
+	return len(changed)
+}
+
+// storeViperDefaults 更新 SetDefault 默认值；默认值变化时撤销旧的填充值并重新发布配置数据
+func (c *Config) storeViperDefaults(defaults map[string]any) {
+	if c.closed.Load() {
+		return
+	}
+	c.mu.Lock()
+	old := c.viperDefaults.Load()
+	if old == nil && len(defaults) == 0 || old != nil && reflect.DeepEqual(*old, defaults) {
+		c.mu.Unlock()
+		return
+	}
+	data := maps.Clone(c.loadData())
+	if old != nil {
+		origins := c.keyOrigins.Load()
+		for key := range *old {
+			actual := c.resolveKey(data, key)
+			if origins != nil && (*origins)[actual] == SourceDefaults {
+				delete(data, actual)
+			}
+		}
+	}
+	c.viperDefaults.Store(&defaults)
+	c.storeDataOp(RecordSource, data)
+	ticket, callbacks := c.watchCallbacksLocked()
+	c.mu.Unlock()
+
+	c.invalidateLookupCache()
+	c.invalidateCache()
+	c.runWatchCallbacks(ticket, callbacks)
+}
+
+// fillViperDefaults 将 SetDefault 默认值填充到数据中缺失的键，已存在该键、其父键或子键时保持数据不变；
+// origins 中填充的键记为 SourceDefaults
+func (c *Config) fillViperDefaults(data map[string]any, origins map[string]Source) {
+	p := c.viperDefaults.Load()
+	if p == nil || len(*p) == 0 {
+		return
+	}
+	leaves := make(map[string]struct{}, len(data))
+	parents := make(map[string]struct{})
+	for key := range data {
+		form := c.matchKey(key)
+		leaves[form] = struct{}{}
+		for i := strings.LastIndexByte(form, '.'); i > 0; i = strings.LastIndexByte(form[:i], '.') {
+			parents[form[:i]] = struct{}{}
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(*p)) {
+		form := c.matchKey(key)
+		if _, ok := leaves[form]; ok {
+			continue
+		}
+		if _, ok := parents[form]; ok {
+			continue
+		}
+		shadowed := false
+		for i := strings.LastIndexByte(form, '.'); i > 0 && !shadowed; i = strings.LastIndexByte(form[:i], '.') {
+			_, shadowed = leaves[form[:i]]
+		}
+		if shadowed {
+			continue
+		}
+		data[key] = sanitizeValue((*p)[key])
+		origins[key] = SourceDefaults
+		leaves[form] = struct{}{}
+	}
+}
+
+// sameViperValue 按文本形式比较 viper 与 sysconf 的值，忽略两者解码类型的差异
+func sameViperValue(a, b any) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// viperMap 读取 viper 未导出的嵌套配置层（如 override、defaults），字段不存在时返回 nil
+func viperMap(v *viper.Viper, name string) map[string]any {
+	field := reflect.ValueOf(v).Elem().FieldByName(name)
+	if !field.IsValid() || field.Type() != reflect.TypeFor[map[string]any]() {
+		return nil
+	}
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem().Interface().(map[string]any)
+}
+
+// viperField 读取 viper 未导出的字符串字段（如 keyDelim），字段不存在时返回空字符串
+func viperField(v *viper.Viper, name string) string {
+	field := reflect.ValueOf(v).Elem().FieldByName(name)
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+	return field.String()
+}
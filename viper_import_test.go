@@ -0,0 +1,181 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+	"github.com/spf13/viper"
+)
+
+func TestFromViperAdoptsFileAndOverrides(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.yaml")
+	if err := os.WriteFile(file, []byte("server:\n  host: localhost\n  port: 8080\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	v := viper.NewWithOptions(viper.KeyDelimiter("::"))
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	v.SetDefault("log::level", "info")
+	v.Set("server::port", 9090)
+
+	cfg, err := FromViper(v)
+	if err != nil {
+		t.Fatalf("FromViper: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("server::host"); got != "localhost" {
+		t.Fatalf("server::host = %q", got)
+	}
+	if got := cfg.GetInt("server.port"); got != 9090 {
+		t.Fatalf("server.port = %d, want value set on viper", got)
+	}
+	if got := cfg.GetString("log::level"); got != "info" {
+		t.Fatalf("log::level = %q", got)
+	}
+	if _, ok := cfg.Overrides()["server.host"]; ok {
+		t.Fatal("values provided by the config file should not become overrides")
+	}
+	if cfg.configFilePath() != file {
+		t.Fatalf("config file = %q, want %q", cfg.configFilePath(), file)
+	}
+}
+
+func TestFromViperInMemory(t *testing.T) {
+	v := viper.New()
+	v.Set("database.host", "db")
+	v.Set("database.pool", map[string]any{"size": 4})
+
+	cfg, err := FromViper(v)
+	if err != nil {
+		t.Fatalf("FromViper: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetInt("database.pool.size"); got != 4 {
+		t.Fatalf("database.pool.size = %d", got)
+	}
+	if err := cfg.Set("database.host", "replica"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got := cfg.GetString("database.host"); got != "replica" {
+		t.Fatalf("database.host = %q after Set", got)
+	}
+	if _, err := FromViper(nil); err == nil {
+		t.Fatal("expected error for nil viper instance")
+	}
+}
+
+func TestFromViperSync(t *testing.T) {
+	var mu sync.Mutex
+	v := viper.New()
+	v.Set("feature.enabled", false)
+
+	cfg, err := FromViper(v, WithViperSync(10*time.Millisecond, &mu))
+	if err != nil {
+		t.Fatalf("FromViper: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	mu.Lock()
+	v.Set("feature.enabled", true)
+	mu.Unlock()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for !cfg.GetBool("feature.enabled") {
+		if time.Now().After(deadline) {
+			t.Fatal("change on viper instance was not synced")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFromViperDefaultsFillMissing(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.yaml")
+	if err := os.WriteFile(file, []byte("name: demo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	v.SetDefault("port", 80)
+	v.SetDefault("timeout", 5)
+
+	cfg, err := FromViper(v, WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("FromViper: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetInt("port"); got != 80 {
+		t.Fatalf("port = %d, want viper default", got)
+	}
+	if _, ok := cfg.Overrides()["port"]; ok {
+		t.Fatal("viper defaults should not become overrides")
+	}
+	if err := cfg.Set("port", 9090); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got := cfg.GetInt("port"); got != 9090 {
+		t.Fatalf("port = %d after Set, want 9090", got)
+	}
+
+	if err := os.WriteFile(file, []byte("name: demo\nport: 9090\ntimeout: 30\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg.reloadChangedFile(file, true)
+	if got := cfg.GetInt("timeout"); got != 30 {
+		t.Fatalf("timeout = %d after reload, want file value", got)
+	}
+}
+
+func TestFromViperSyncDropsRemovedKeys(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.yaml")
+	if err := os.WriteFile(file, []byte("name: demo\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.MergeConfigMap(map[string]any{"extra": "x"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := FromViper(v, WithViperSync(10*time.Millisecond, &mu))
+	if err != nil {
+		t.Fatalf("FromViper: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("extra"); got != "x" {
+		t.Fatalf("extra = %q, want imported override", got)
+	}
+	mu.Lock()
+	err = v.ReadInConfig()
+	mu.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for cfg.IsSet("extra") {
+		if time.Now().After(deadline) {
+			t.Fatalf("override for key removed from viper was not dropped: %v", cfg.Overrides())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
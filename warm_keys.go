@@ -0,0 +1,91 @@
+package sysconf
+
+import (
+	"time"
+)
+
+// warmCache 预热键在某一数据快照上的解析结果
+type warmCache struct {
+	data   map[string]any       // 解析时的数据快照，快照变化后结果不再使用
+	values map[string]warmValue // 实际键 → 解析结果
+}
+
+// warmValue 预热键的解析结果，found 为 false 表示键不存在（负缓存）
+type warmValue struct {
+	value any
+	found bool
+}
+
+// WithCacheWarmKeys 预热常用配置键：启动时以及每次重载、写入、覆盖值变更后立即按完整查找链
+// （环境变量、覆盖值、文件引用）解析这些键，读取直接命中预热结果，避免热重载后首个请求
+// 重新读取文件引用、查询环境变量或重建父键造成的延迟尖刺。
+// 预热结果由后台按 WithCacheRefresh 的间隔（默认 1 秒）刷新，进程环境变量与引用文件内容的变化
+// 对这些键最多延迟一个间隔可见；使用 NoCache 或 FromSource 读取时总是走完整查找链。
+func WithCacheWarmKeys(keys ...string) Option {
+	return func(c *Config) {
+		c.warmKeys = append(c.warmKeys, keys...)
+	}
+}
+
+// WithCacheRefresh 设置预热键的后台刷新间隔（默认 1 秒），interval <= 0 关闭后台刷新，
+// 此时预热结果仅在重载、写入与覆盖值变更时更新
+func WithCacheRefresh(interval time.Duration) Option {
+	return func(c *Config) {
+		c.warmRefresh = max(interval, 0)
+	}
+}
+
+// startWarmKeys 完成首次预热并启动后台刷新
+func (c *Config) startWarmKeys() {
+	if len(c.warmKeys) == 0 {
+		return
+	}
+	c.warmKeyCache()
+	if c.warmRefresh <= 0 {
+		return
+	}
+	stopChan := c.stopChan
+	c.wg.Go(func() {
+		ticker := time.NewTicker(c.warmRefresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopChan:
+				return
+			case <-ticker.C:
+				c.warmKeyCache()
+			}
+		}
+	})
+}
+
+// warmKeyCache 在当前数据快照上重新解析全部预热键（调用者不得持有 mu）
+func (c *Config) warmKeyCache() {
+	if len(c.warmKeys) == 0 || c.closed.Load() || !c.cacheEnabled.Load() {
+		return
+	}
+	// 串行化重建，避免较早开始的解析覆盖较新的结果
+	c.warmMu.Lock()
+	defer c.warmMu.Unlock()
+	data := c.loadData()
+	values := make(map[string]warmValue, len(c.warmKeys))
+	for _, key := range c.warmKeys {
+		key = c.resolveKey(data, key)
+		value, found := c.lookupResolved(data, key)
+		values[key] = warmValue{value: value, found: found}
+	}
+	c.warm.Store(&warmCache{data: data, values: values})
+}
+
+// warmLookup 返回预热键在当前数据快照上的解析结果，ok 为 false 时调用方走完整查找链
+func (c *Config) warmLookup(data map[string]any, key string) (value any, found, ok bool) {
+	if len(c.warmKeys) == 0 {
+		return nil, false, false
+	}
+	w := c.warm.Load()
+	if w == nil || !sameMap(w.data, data) {
+		return nil, false, false
+	}
+	v, ok := w.values[key]
+	return v.value, v.found, ok
+}
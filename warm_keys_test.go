@@ -0,0 +1,95 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestCacheWarmKeys(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	if err := os.WriteFile(certFile, []byte("v1"), 0o600); err != nil {
+		t.Fatalf("write cert failed: %v", err)
+	}
+	content := "tls:\n  cert: \"@file:" + filepath.ToSlash(certFile) + "\"\nserver:\n  port: 8080\n"
+	cfg, err := New(
+		WithContent(content),
+		WithFileReferences(0),
+		WithCacheWarmKeys("tls.cert", "SERVER", "missing.key"),
+		WithCacheRefresh(0),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	w := cfg.warm.Load()
+	if w == nil {
+		t.Fatal("expected warm keys to be resolved at startup")
+	}
+	if v := w.values["tls.cert"]; !v.found || v.value != "v1" {
+		t.Fatalf("expected file reference resolved during warm-up, got %+v", v)
+	}
+	if v, ok := w.values["missing.key"]; !ok || v.found {
+		t.Fatalf("expected negative entry for missing key, got %+v (%v)", v, ok)
+	}
+	if got := cfg.GetInt("server.port"); got != 8080 {
+		t.Fatalf("unexpected port %d", got)
+	}
+	if got := cfg.GetStringMap("server"); got["port"] != 8080 {
+		t.Fatalf("expected warmed parent key, got %v", got)
+	}
+
+	// 写入后立即重新预热，读取不会命中旧快照
+	if err := cfg.Set("server.port", 9090); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if got := cfg.GetStringMap("server"); got["port"] != 9090 {
+		t.Fatalf("expected warmed value refreshed after set, got %v", got)
+	}
+	if w := cfg.warm.Load(); !sameMap(w.data, cfg.loadData()) {
+		t.Fatal("expected warm cache rebuilt for the new data snapshot")
+	}
+
+	// 未开启后台刷新时，引用文件的变化在下一次失效前不可见；NoCache 读取总是最新
+	time.Sleep(1100 * time.Millisecond) // 文件引用至多每秒检查一次
+	if err := os.WriteFile(certFile, []byte("v2"), 0o600); err != nil {
+		t.Fatalf("rewrite cert failed: %v", err)
+	}
+	if got := cfg.GetString("tls.cert"); got != "v1" {
+		t.Fatalf("expected warmed value until refresh, got %q", got)
+	}
+	if got, _ := cfg.GetStringE("tls.cert", NoCache()); got != "v2" {
+		t.Fatalf("expected NoCache to bypass warm keys, got %q", got)
+	}
+}
+
+func TestCacheWarmKeysBackgroundRefresh(t *testing.T) {
+	t.Setenv("WARM_FEATURE_FLAG", "off")
+	cfg, err := New(
+		WithContent("feature:\n  flag: on\n"),
+		WithEnv("WARM"),
+		WithCacheWarmKeys("feature.flag"),
+		WithCacheRefresh(20*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("create config failed: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	if got := cfg.GetString("feature.flag"); got != "off" {
+		t.Fatalf("expected env override in warmed value, got %q", got)
+	}
+	t.Setenv("WARM_FEATURE_FLAG", "auto")
+	deadline := time.Now().Add(2 * time.Second)
+	for cfg.GetString("feature.flag") != "auto" {
+		if time.Now().After(deadline) {
+			t.Fatal("background refresh did not pick up env change")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,96 @@
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// extraWatch 运行中的附加路径监听器，监听器重建后 add 指向新的监听器
+type extraWatch struct {
+	add  func(dir string) error // 向监听器添加目录
+	dirs map[string]struct{}    // 已监听的目录
+}
+
+// WatchExtraPath 将附加文件（证书、被引用的配置片段、.env 文件等）加入配置监听：
+// 文件被写入、创建或替换时走与配置文件相同的重载流程并触发 Watch 回调，无需在应用中另建监听器。
+// 该文件若为 WithDotenvFile 登记的文件，重载前会重新读取全部 dotenv 文件；
+// WithFileReferences 对该文件内容的缓存会立即失效。
+// 可在 Watch 之前或之后调用；监听在首次 Watch（或已启动的监听）中生效，WebAssembly 构建下不生效。
+func (c *Config) WatchExtraPath(path string) error {
+	if c.closed.Load() {
+		return ErrAlreadyClosed
+	}
+	if path == "" {
+		return errors.New("watch extra path: empty path")
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("watch extra path: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.extraPaths == nil {
+		c.extraPaths = make(map[string]struct{})
+	}
+	c.extraPaths[abs] = struct{}{}
+	if !c.watchStarted {
+		return nil
+	}
+	if err := c.startExtraWatcherLocked(); err != nil {
+		delete(c.extraPaths, abs)
+		return err
+	}
+	return nil
+}
+
+// isExtraPath 判断路径是否为 WatchExtraPath 登记的附加监听路径
+func (c *Config) isExtraPath(path string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.extraPaths[filepath.Clean(path)]
+	return ok
+}
+
+// handleExtraPathChange 附加监听路径变更后刷新相关缓存并重载配置；多个路径同时变更时只重载一次
+func (c *Config) handleExtraPathChange(paths ...string) {
+	dotenv := false
+	for _, path := range paths {
+		c.fileRefCache.Delete(path)
+		dotenv = dotenv || c.isDotenvPath(path)
+	}
+	if dotenv {
+		if err := c.loadDotenvFiles(); err != nil {
+			c.logger.Errorf("Failed to reload dotenv files after change: %v", err)
+			c.emitHealthEvent(HealthEventReloadFailed, "dotenv reload failed, keeping previous values", err)
+		}
+		c.invalidateLookupCache()
+		c.invalidateCache()
+	}
+	c.logger.Infof("Watched extra path changed: %s", strings.Join(paths, ", "))
+
+	c.mu.RLock()
+	hasFile := c.configFilePath() != ""
+	c.mu.RUnlock()
+	if hasFile {
+		c.reloadChangedFile(paths[0], false)
+		return
+	}
+	// 纯内存配置没有可重载的文件，仅触发回调
+	c.mu.Lock()
+	ticket, callbacks := c.watchCallbacksLocked()
+	c.mu.Unlock()
+	c.runWatchCallbacks(ticket, callbacks)
+}
+
+// isDotenvPath 判断路径是否为 WithDotenvFile 登记的文件
+func (c *Config) isDotenvPath(path string) bool {
+	for _, file := range c.dotenvFiles {
+		if abs, err := filepath.Abs(file.path); err == nil && abs == path {
+			return true
+		}
+	}
+	return false
+}
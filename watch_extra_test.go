@@ -0,0 +1,58 @@
+package sysconf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchExtraPathTriggersCallbacks(t *testing.T) {
+	certFile := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, os.WriteFile(certFile, []byte("v1"), 0o600))
+
+	cfg, _ := newWatchTestConfig(t)
+	require.NoError(t, cfg.WatchExtraPath(certFile))
+
+	changed := make(chan struct{}, 4)
+	stop := cfg.WatchWithContext(context.Background(), func() { changed <- struct{}{} })
+	t.Cleanup(stop)
+
+	require.NoError(t, os.WriteFile(certFile, []byte("v2"), 0o600))
+	waitSignal(t, changed, "extra path change should trigger watch callbacks")
+
+	// 监听启动后登记的路径同样生效
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, []byte("k1"), 0o600))
+	require.NoError(t, cfg.WatchExtraPath(keyFile))
+	time.Sleep(20 * time.Millisecond) // 跨过重载防抖
+	require.NoError(t, os.WriteFile(keyFile, []byte("k2"), 0o600))
+	waitSignal(t, changed, "extra path registered after Watch should trigger callbacks")
+}
+
+func TestWatchExtraPathReloadsDotenv(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(envFile, []byte("APP_KEY=from-dotenv\n"), 0o600))
+
+	cfg, _ := newWatchTestConfig(t, WithEnv("APP"), WithDotenvFile(envFile, false))
+	require.Equal(t, "from-dotenv", cfg.GetString("key"))
+	require.NoError(t, cfg.WatchExtraPath(envFile))
+
+	changed := make(chan struct{}, 4)
+	stop := cfg.WatchWithContext(context.Background(), func() { changed <- struct{}{} })
+	t.Cleanup(stop)
+
+	require.NoError(t, os.WriteFile(envFile, []byte("APP_KEY=rotated\n"), 0o600))
+	waitSignal(t, changed, "dotenv change should trigger watch callbacks")
+	require.Eventually(t, func() bool { return cfg.GetString("key") == "rotated" }, time.Second, 10*time.Millisecond)
+}
+
+func TestWatchExtraPathValidation(t *testing.T) {
+	cfg, _ := newWatchTestConfig(t)
+	require.Error(t, cfg.WatchExtraPath(""))
+	require.NoError(t, cfg.Close())
+	require.ErrorIs(t, cfg.WatchExtraPath("x"), ErrAlreadyClosed)
+}
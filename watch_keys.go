@@ -0,0 +1,91 @@
+package sysconf
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// WatchKeysGlob 仅在匹配 pattern 的配置键发生变化时触发回调，回调参数为变化的键（已排序）。
+// pattern 按 "." 分段匹配：* 匹配单个分段，** 匹配任意多个分段，分段内支持 path.Match 通配（如 db_*）；
+// 匹配某个键的模式同时覆盖其子键，例如 "database.*" 覆盖 "database.pool.max"。
+// 变化通过比较重载前后的数据计算，新增、删除与修改均视为变化。返回的取消函数用于停止订阅。
+func (c *Config) WatchKeysGlob(ctx context.Context, pattern string, cb func(changed []string)) (context.CancelFunc, error) {
+	if cb == nil {
+		return nil, fmt.Errorf("watch callback is nil")
+	}
+	segments := strings.Split(pattern, ".")
+	for _, seg := range segments {
+		if _, err := path.Match(seg, ""); err != nil || seg == "" {
+			return nil, fmt.Errorf("invalid key pattern %q", pattern)
+		}
+	}
+
+	var mu sync.Mutex
+	last := c.matchingValues(segments)
+	cancel := c.WatchWithContext(ctx, func() {
+		mu.Lock()
+		current := c.matchingValues(segments)
+		changed := diffValueKeys(last, current)
+		last = current
+		mu.Unlock()
+		if len(changed) > 0 {
+			cb(changed)
+		}
+	})
+	return cancel, nil
+}
+
+// matchingValues 返回当前数据中匹配模式的扁平键值
+func (c *Config) matchingValues(segments []string) map[string]any {
+	result := make(map[string]any)
+	for key, value := range c.loadData() {
+		if matchKeyPattern(segments, strings.Split(key, ".")) {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// matchKeyPattern 判断键（或其任一祖先键）是否匹配分段模式
+func matchKeyPattern(pattern, key []string) bool {
+	if len(pattern) == 0 {
+		return true
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(key); i++ {
+			if matchKeyPattern(pattern[1:], key[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(key) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], key[0]); !ok {
+		return false
+	}
+	return matchKeyPattern(pattern[1:], key[1:])
+}
+
+// diffValueKeys 返回两组扁平键值之间新增、删除或修改的键
+func diffValueKeys(before, after map[string]any) []string {
+	var changed []string
+	for key, value := range after {
+		if old, ok := before[key]; !ok || !reflect.DeepEqual(old, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	slices.Sort(changed)
+	return slices.Compact(changed)
+}
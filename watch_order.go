@@ -0,0 +1,187 @@
+package sysconf
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Watch 回调的顺序保证：
+//
+//   - 同一 Config 的回调串行执行，任意时刻最多一批回调在运行，不同变更的回调不会交错；
+//   - 各批回调按变更提交的顺序执行（文件重载、远程与附加数据源重载、Reopen 以及触发 WatchSync 的 Set），
+//     先提交的变更其回调先执行完毕；
+//   - 同一批内按注册顺序执行。
+//
+// 回调在触发变更的 goroutine 中执行（文件监听 goroutine 或调用 Set 的 goroutine）。
+// 由于串行执行，回调中不应同步调用同一配置的 Set/SetMultiple（存在 WatchSync 订阅时会等待自身而死锁），
+// 需要时请在新的 goroutine 中调用。
+
+// callbackQueue 票号队列：票号在持有 mu 提交变更时分配，回调按票号顺序执行
+type callbackQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	next    uint64 // 下一个分配的票号
+	serving uint64 // 当前允许执行的票号
+}
+
+// take 分配票号（调用者需持有 Config.mu，保证票号顺序与提交顺序一致）
+func (q *callbackQueue) take() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ticket := q.next
+	q.next++
+	return ticket
+}
+
+// run 等待轮到 ticket 后执行回调；回调 panic 时同样放行后续票号
+func (q *callbackQueue) run(ticket uint64, callbacks []func()) {
+	q.mu.Lock()
+	if q.cond == nil {
+		q.cond = sync.NewCond(&q.mu)
+	}
+	for q.serving != ticket {
+		q.cond.Wait()
+	}
+	q.mu.Unlock()
+
+	defer func() {
+		q.mu.Lock()
+		q.serving++
+		q.cond.Broadcast()
+		q.mu.Unlock()
+	}()
+	for _, cb := range callbacks {
+		cb()
+	}
+}
+
+// watchCallbacksLocked 按注册顺序收集全部回调并分配执行票号（调用者需持有 mu）
+func (c *Config) watchCallbacksLocked() (uint64, []func()) {
+	return c.callbackQueue.take(), c.collectCallbacksLocked(false)
+}
+
+// syncWatchCallbacksLocked 收集 WatchSync 回调并分配票号，没有同步订阅时返回 false（调用者需持有 mu）
+func (c *Config) syncWatchCallbacksLocked() (uint64, []func(), bool) {
+	if len(c.syncWatches) == 0 {
+		return 0, nil, false
+	}
+	return c.callbackQueue.take(), c.collectCallbacksLocked(true), true
+}
+
+// collectCallbacksLocked 按注册句柄顺序收集回调，onlySync 为 true 时只收集 WatchSync 回调
+func (c *Config) collectCallbacksLocked(onlySync bool) []func() {
+	handles := make([]uint64, 0, len(c.watchCallbacks))
+	for handle := range c.watchCallbacks {
+		if _, isSync := c.syncWatches[handle]; onlySync && !isSync {
+			continue
+		}
+		handles = append(handles, handle)
+	}
+	slices.Sort(handles)
+	callbacks := make([]func(), 0, len(handles))
+	for _, handle := range handles {
+		callbacks = append(callbacks, c.watchCallbacks[handle])
+	}
+	return callbacks
+}
+
+// runWatchCallbacks 按票号顺序执行回调；callbacks 为空时仅放行票号（变更被回滚的情况）
+func (c *Config) runWatchCallbacks(ticket uint64, callbacks []func()) {
+	c.callbackQueue.run(ticket, callbacks)
+}
+
+// runSyncWatchCallbacks 在 Set 返回前执行 WatchSync 回调；写入失败回滚时仅放行票号
+func (c *Config) runSyncWatchCallbacks(ticket uint64, callbacks []func(), err error) {
+	if err != nil {
+		callbacks = nil
+	}
+	c.runWatchCallbacks(ticket, callbacks)
+}
+
+// WatchSync 注册同步回调：除与 Watch 相同地在重载后执行外，Set/SetMultiple 成功提交后会在返回前执行这些回调，
+// 适合需要写入返回时联动已完成（如刷新派生状态）的调用方。回调遵循上述顺序保证；写入失败回滚时不执行。
+// 返回的取消函数用于停止监听。
+func (c *Config) WatchSync(ctx context.Context, callbacks ...func()) context.CancelFunc {
+	return c.watchWithContext(ctx, true, callbacks...)
+}
+
+// markSyncWatchesLocked 将回调句柄标记为 WatchSync 回调（调用者需持有 mu）
+func (c *Config) markSyncWatchesLocked(handles []uint64) {
+	if c.syncWatches == nil {
+		c.syncWatches = make(map[uint64]struct{}, len(handles))
+	}
+	for _, handle := range handles {
+		c.syncWatches[handle] = struct{}{}
+	}
+}
+
+// watchCommits 订阅数据发布通知，并像 Watch 一样启动文件监听使重载同样会发布通知。
+// 返回的取消函数停止订阅并关闭通知通道。
+func (c *Config) watchCommits() (<-chan struct{}, context.CancelFunc) {
+	id, signals := c.commits.subscribe()
+	stopWatch := c.watchWithContext(context.Background(), false)
+	return signals, func() {
+		stopWatch()
+		c.commits.unsubscribe(id)
+	}
+}
+
+// commitSignals 数据发布后的非阻塞通知：订阅者各持一个容量为 1 的通道，在自己的 goroutine 中异步处理，
+// 不占用回调票号，因此处理过程中可以同步调用 Set。连续多次发布可能合并为一次通知，订阅者应读取最新值。
+type commitSignals struct {
+	mu     sync.Mutex
+	subs   map[uint64]chan struct{}
+	nextID uint64
+	closed bool
+}
+
+// subscribe 注册订阅并返回通知通道；配置已关闭时返回已关闭的通道
+func (s *commitSignals) subscribe() (uint64, <-chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := make(chan struct{}, 1)
+	if s.closed {
+		close(ch)
+		return 0, ch
+	}
+	if s.subs == nil {
+		s.subs = make(map[uint64]chan struct{})
+	}
+	s.nextID++
+	s.subs[s.nextID] = ch
+	return s.nextID, ch
+}
+
+// unsubscribe 取消订阅并关闭其通道
+func (s *commitSignals) unsubscribe(id uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ch, ok := s.subs[id]; ok {
+		delete(s.subs, id)
+		close(ch)
+	}
+}
+
+// notify 通知全部订阅者有新的数据发布，通道中已有未处理的通知时直接合并
+func (s *commitSignals) notify() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ch := range s.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// close 关闭全部订阅通道，之后的订阅立即结束
+func (s *commitSignals) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for id, ch := range s.subs {
+		delete(s.subs, id)
+		close(ch)
+	}
+}
@@ -0,0 +1,164 @@
+package sysconf
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestCallbackQueueRunsInTicketOrder(t *testing.T) {
+	var q callbackQueue
+	const n = 20
+	tickets := make([]uint64, n)
+	for i := range tickets {
+		tickets[i] = q.take()
+	}
+
+	var (
+		mu    sync.Mutex
+		order []int
+		wg    sync.WaitGroup
+	)
+	// 逆序启动，执行顺序仍应与票号一致
+	for i := n - 1; i >= 0; i-- {
+		wg.Go(func() {
+			q.run(tickets[i], []func(){func() {
+				mu.Lock()
+				order = append(order, i)
+				mu.Unlock()
+			}})
+		})
+	}
+	wg.Wait()
+
+	for i, got := range order {
+		if got != i {
+			t.Fatalf("callbacks ran out of order: %v", order)
+		}
+	}
+}
+
+func TestCallbackQueueSurvivesPanic(t *testing.T) {
+	var q callbackQueue
+	first, second := q.take(), q.take()
+
+	func() {
+		defer func() { _ = recover() }()
+		q.run(first, []func(){func() { panic("boom") }})
+	}()
+
+	done := make(chan struct{})
+	go q.run(second, []func(){func() { close(done) }})
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("queue should advance after a panicking callback")
+	}
+}
+
+func TestWatchSyncCompletesBeforeSetReturns(t *testing.T) {
+	cfg, err := New(WithContent("app:\n  name: demo\n"), WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	var seen atomic.Value
+	stop := cfg.WatchSync(context.Background(), func() {
+		seen.Store(cfg.GetString("app.name"))
+	})
+	defer stop()
+
+	for i := range 5 {
+		want := fmt.Sprintf("v%d", i)
+		if err := cfg.Set("app.name", want); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+		if got, _ := seen.Load().(string); got != want {
+			t.Fatalf("sync callback saw %q after Set returned, want %q", got, want)
+		}
+	}
+
+	if err := cfg.SetMultiple(map[string]any{"app.name": "batch"}); err != nil {
+		t.Fatalf("SetMultiple() error = %v", err)
+	}
+	if got, _ := seen.Load().(string); got != "batch" {
+		t.Fatalf("sync callback saw %q after SetMultiple returned, want batch", got)
+	}
+
+	// 取消后不再同步执行
+	stop()
+	deadline := time.Now().Add(time.Second)
+	for cfg.WatcherCount() != 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := cfg.Set("app.name", "after"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got, _ := seen.Load().(string); got != "batch" {
+		t.Fatalf("cancelled sync callback should not run, saw %q", got)
+	}
+}
+
+func TestWatchSyncSkipsPlainWatchersOnSet(t *testing.T) {
+	cfg, err := New(WithContent("app:\n  name: demo\n"), WithWriteDebounceDelay(0))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	var plain, synced atomic.Int32
+	cfg.Watch(func() { plain.Add(1) })
+	stop := cfg.WatchSync(context.Background(), func() { synced.Add(1) })
+	defer stop()
+
+	if err := cfg.Set("app.name", "changed"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if synced.Load() != 1 || plain.Load() != 0 {
+		t.Fatalf("Set should only run WatchSync callbacks, sync=%d plain=%d", synced.Load(), plain.Load())
+	}
+}
+
+func TestWatchCallbacksNeverOverlap(t *testing.T) {
+	cfg, configFile := newWatchTestConfig(t, WithWriteDebounceDelay(0))
+
+	var running, overlaps, calls atomic.Int32
+	callback := func() {
+		if running.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		calls.Add(1)
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+	}
+	stop := cfg.WatchSync(context.Background(), callback, callback)
+	defer stop()
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Go(func() {
+			for j := range 5 {
+				if err := cfg.Set(fmt.Sprintf("worker%d.value", i), j); err != nil {
+					t.Errorf("Set() error = %v", err)
+				}
+			}
+		})
+	}
+	for range 4 {
+		wg.Go(func() { cfg.reloadChangedFile(configFile, true) })
+	}
+	wg.Wait()
+
+	if overlaps.Load() != 0 {
+		t.Fatalf("watch callbacks overlapped %d times", overlaps.Load())
+	}
+	if calls.Load() < 8*5*2 {
+		t.Fatalf("expected at least %d callback calls, got %d", 8*5*2, calls.Load())
+	}
+}
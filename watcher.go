@@ -0,0 +1,109 @@
+package sysconf
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+// maxSymlinkHops 解析符号链接链时允许的最大跳数
+const maxSymlinkHops = 32
+
+// symlinkChain 返回从 path 出发逐跳解析的符号链接路径列表（包含 path 本身）
+func symlinkChain(path string) []string {
+	chain := []string{filepath.Clean(path)}
+	current := filepath.Clean(path)
+	for range maxSymlinkHops {
+		info, err := os.Lstat(current)
+		if err != nil || info.Mode()&os.ModeSymlink == 0 {
+			break
+		}
+		link, err := os.Readlink(current)
+		if err != nil {
+			break
+		}
+		if !filepath.IsAbs(link) {
+			link = filepath.Join(filepath.Dir(current), link)
+		}
+		current = filepath.Clean(link)
+		chain = append(chain, current)
+	}
+	return chain
+}
+
+// stopFileWatcherLocked 停止内部文件监听（调用者需持有 mu）
+func (c *Config) stopFileWatcherLocked() {
+	if c.watchStop != nil {
+		close(c.watchStop)
+		c.watchStop = nil
+	}
+	c.extraWatch = nil
+	c.watchStarted = false
+}
+
+// registerWatchSubscriptionLocked 登记一次 Watch 调用的取消函数，返回订阅 ID（调用者需持有 mu）
+func (c *Config) registerWatchSubscriptionLocked(cancel context.CancelFunc) uint64 {
+	if c.watchCancels == nil {
+		c.watchCancels = make(map[uint64]context.CancelFunc)
+	}
+	c.nextWatchSub++
+	c.watchCancels[c.nextWatchSub] = cancel
+	return c.nextWatchSub
+}
+
+// WatcherCount 返回当前活跃的监听订阅数量
+func (c *Config) WatcherCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.watchCancels)
+}
+
+// StopAllWatchers 取消所有通过 Watch/WatchWithContext 注册的监听，并停止底层文件监听。
+// 之后再次调用 Watch 会重新启动文件监听。
+func (c *Config) StopAllWatchers() {
+	c.mu.Lock()
+	cancels := make([]context.CancelFunc, 0, len(c.watchCancels))
+	for _, cancel := range c.watchCancels {
+		cancels = append(cancels, cancel)
+	}
+	c.watchCancels = make(map[uint64]context.CancelFunc)
+	c.watchCallbacks = make(map[uint64]func())
+	c.syncWatches = nil
+	c.stopFileWatcherLocked()
+	c.mu.Unlock()
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+	c.logger.Infof("All config watchers stopped (%d)", len(cancels))
+}
+
+// isConfigFileMissingError 判断重载错误是否由配置文件不存在导致
+func isConfigFileMissingError(err error) bool {
+	if errors.Is(err, fs.ErrNotExist) {
+		return true
+	}
+	var notFound viper.ConfigFileNotFoundError
+	return errors.As(err, &notFound)
+}
+
+// markConfigFileMissing 进入配置文件缺失状态：保留最后一次成功加载的配置，
+// 发出 FileNotFound 健康事件，并等待文件重新出现后自动恢复重载。
+func (c *Config) markConfigFileMissing(err error) {
+	if c.isFileMissing() {
+		return
+	}
+	if err == nil {
+		err = &ConfigError{
+			Type:    ErrTypeFileNotFound,
+			Message: "config file removed",
+			File:    c.configFilePath(),
+		}
+	}
+	c.logger.Warnf("Config file missing, keeping last known good config: %s", c.configFilePath())
+	c.emitHealthEvent(HealthEventFileNotFound, "config file missing, keeping last known good config", err)
+}
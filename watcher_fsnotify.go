@@ -0,0 +1,398 @@
+//go:build !js && !wasip1
+
+package sysconf
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// startFileWatcherLocked 使用内部 fsnotify 分发器监听配置文件（调用者需持有 mu，且已创建 watchStop）。
+// 不再依赖 viper 的单一 OnConfigChange 处理器，外部对 Viper().OnConfigChange 的调用不会影响内部监听。
+// 当配置文件是符号链接（如 ConfigMap 挂载、/etc/alternatives）时，会同时监听链路上每一跳及真实目标所在目录，
+// 并在链接指向变化时重新解析监听目标。
+func (c *Config) startFileWatcherLocked() error {
+	configFile := c.configFilePath()
+	if configFile == "" {
+		return fmt.Errorf("no config file to watch")
+	}
+
+	state := &fileWatchState{
+		target: filepath.Clean(configFile),
+	}
+	target := watchTarget{
+		name: watcherConfig,
+		file: state.target,
+		open: func() (*fsnotify.Watcher, error) {
+			watcher, err := fsnotify.NewWatcher()
+			if err != nil {
+				return nil, fmt.Errorf("create watcher: %w", err)
+			}
+			state.watcher = watcher
+			state.dirs = make(map[string]struct{})
+			if err := state.resolve(); err != nil {
+				_ = watcher.Close()
+				return nil, err
+			}
+			return watcher, nil
+		},
+		handle: func(event fsnotify.Event) {
+			if state.isRemoval(event) {
+				if _, err := os.Stat(state.target); errors.Is(err, fs.ErrNotExist) {
+					c.markConfigFileMissing(nil)
+				}
+			}
+			previous := state.real
+			reload := state.shouldReload(event, c.logger)
+			if state.real != previous {
+				recordWatcherRestart()
+			}
+			if reload {
+				event.Op |= fsnotify.Write
+				c.handleConfigChange(event)
+			}
+		},
+		resync: func() {
+			if c.fileChangedSinceLoad() {
+				c.reloadChangedFile(state.target, true)
+			}
+		},
+	}
+	watcher, err := target.open()
+	if err != nil {
+		return err
+	}
+
+	if c.watcherStarts++; c.watcherStarts > 1 {
+		recordWatcherRestart()
+	}
+	c.superviseWatcherLocked(watcher, target)
+	return nil
+}
+
+// watchTarget 受监督的 fsnotify 监听器：open 创建监听器并添加监听目录，handle 处理单个事件，
+// resync 在重建后补做断开期间可能错过的重载
+type watchTarget struct {
+	name   string
+	file   string
+	open   func() (*fsnotify.Watcher, error)
+	handle func(fsnotify.Event)
+	resync func()
+}
+
+// superviseWatcherLocked 在后台运行监听器，直到 Close 或停止监听（调用者需持有 mu，且已创建 watchStop）
+func (c *Config) superviseWatcherLocked(watcher *fsnotify.Watcher, target watchTarget) {
+	stopChan := c.stopChan
+	watchStop := c.watchStop
+	c.wg.Go(func() { c.superviseWatcher(stopChan, watchStop, watcher, target) })
+}
+
+// superviseWatcher 运行监听器，监听器出错或意外断开（如 inotify 队列溢出）时上报错误、
+// 按退避重建监听器，并在重建后补做一次重载以覆盖断开期间可能错过的变更
+func (c *Config) superviseWatcher(stopChan, watchStop <-chan struct{}, watcher *fsnotify.Watcher, target watchTarget) {
+	for {
+		err := c.runWatcher(stopChan, watchStop, watcher, target)
+		_ = watcher.Close()
+		if err == nil {
+			return
+		}
+		c.reportWatcherError(target.name, target.file, err)
+		if watcher = c.restartWatcher(stopChan, watchStop, target); watcher == nil {
+			return
+		}
+		target.resync()
+	}
+}
+
+// runWatcher 处理监听事件，停止时返回 nil，监听器出错或断开时返回错误
+func (c *Config) runWatcher(stopChan, watchStop <-chan struct{}, watcher *fsnotify.Watcher, target watchTarget) error {
+	for {
+		select {
+		case <-stopChan:
+			return nil
+		case <-watchStop:
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return errWatcherClosed
+			}
+			if err := c.injectFault(FaultWatcher, event.Name); err != nil {
+				return fmt.Errorf("%s watcher dropped: %w", target.name, err)
+			}
+			target.handle(event)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return errWatcherClosed
+			}
+			return watcherError(target.name+" watcher error", err)
+		}
+	}
+}
+
+// watcherError 包装 fsnotify 错误，事件队列溢出时匹配 ErrWatcherOverflow
+func watcherError(prefix string, err error) error {
+	if errors.Is(err, fsnotify.ErrEventOverflow) {
+		return fmt.Errorf("%s: %w (%v)", prefix, ErrWatcherOverflow, err)
+	}
+	return fmt.Errorf("%s: %w", prefix, err)
+}
+
+// restartWatcher 按指数退避重建监听器，返回新的监听器；监听停止时返回 nil
+func (c *Config) restartWatcher(stopChan, watchStop <-chan struct{}, target watchTarget) *fsnotify.Watcher {
+	for backoff := watcherRestartMin; ; backoff = min(backoff*2, watcherRestartMax) {
+		timer := time.NewTimer(backoff)
+		select {
+		case <-stopChan:
+			timer.Stop()
+			return nil
+		case <-watchStop:
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+		watcher, err := target.open()
+		if err != nil {
+			c.reportWatcherError(target.name, target.file, fmt.Errorf("restart %s watcher: %w", target.name, err))
+			continue
+		}
+		c.reportWatcherRestart(target.name, target.file)
+		return watcher
+	}
+}
+
+// fileWatchState 文件监听状态，记录符号链接链与已监听目录
+type fileWatchState struct {
+	target  string              // 配置文件路径（可能是符号链接）
+	chain   []string            // 符号链接链上的每一跳（含 target 本身）
+	real    string              // 解析后的真实文件路径
+	watcher *fsnotify.Watcher   // 底层监听器
+	dirs    map[string]struct{} // 当前已监听的目录
+}
+
+// resolve 重新解析符号链接链并同步监听目录
+func (s *fileWatchState) resolve() error {
+	chain := symlinkChain(s.target)
+	real := s.target
+	if resolved, err := filepath.EvalSymlinks(s.target); err == nil {
+		real = filepath.Clean(resolved)
+	}
+
+	wanted := make(map[string]struct{}, len(chain)+1)
+	for _, p := range chain {
+		wanted[filepath.Dir(p)] = struct{}{}
+	}
+	wanted[filepath.Dir(real)] = struct{}{}
+
+	for dir := range wanted {
+		if _, ok := s.dirs[dir]; ok {
+			continue
+		}
+		if err := s.watcher.Add(dir); err != nil {
+			if dir == filepath.Dir(s.target) {
+				return fmt.Errorf("watch config directory: %w", err)
+			}
+			continue
+		}
+		s.dirs[dir] = struct{}{}
+	}
+	for dir := range s.dirs {
+		if _, ok := wanted[dir]; !ok {
+			_ = s.watcher.Remove(dir)
+			delete(s.dirs, dir)
+		}
+	}
+
+	s.chain = chain
+	s.real = real
+	return nil
+}
+
+// shouldReload 判断事件是否需要触发重载
+func (s *fileWatchState) shouldReload(event fsnotify.Event, logger Logger) bool {
+	name := filepath.Clean(event.Name)
+
+	// 链路结构可能发生变化（创建/删除/重命名/chmod），重新解析符号链接
+	if event.Op&(fsnotify.Create|fsnotify.Remove|fsnotify.Rename|fsnotify.Chmod) != 0 {
+		previous := s.real
+		if err := s.resolve(); err != nil {
+			logger.Errorf("Failed to re-resolve config symlink: %v", err)
+		}
+		if s.real != previous {
+			logger.Infof("Config symlink target changed: %s -> %s", previous, s.real)
+			return true
+		}
+	}
+
+	if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+		return false
+	}
+	if name == s.real {
+		return true
+	}
+	for _, p := range s.chain {
+		if name == p {
+			return true
+		}
+	}
+	return false
+}
+
+// handleConfigChange 处理配置文件的 fsnotify 事件
+func (c *Config) handleConfigChange(e fsnotify.Event) {
+	if e.Op&fsnotify.Write == 0 {
+		return
+	}
+	c.reloadChangedFile(e.Name, false)
+}
+
+// isRemoval 判断事件是否表示配置文件（或其链路上的任一跳）被删除或移走
+func (s *fileWatchState) isRemoval(event fsnotify.Event) bool {
+	if event.Op&(fsnotify.Remove|fsnotify.Rename) == 0 {
+		return false
+	}
+	name := filepath.Clean(event.Name)
+	if name == s.target || name == s.real {
+		return true
+	}
+	return slices.Contains(s.chain, name)
+}
+
+// startSourceWatcherLocked 监听附加数据源文件，变更后重新加载对应数据源（调用者需持有 mu）
+func (c *Config) startSourceWatcherLocked() error {
+	paths := c.sourceWatchPaths()
+	if len(paths) == 0 {
+		return nil
+	}
+
+	target := watchTarget{
+		name: watcherSource,
+		open: func() (*fsnotify.Watcher, error) {
+			watcher, err := fsnotify.NewWatcher()
+			if err != nil {
+				return nil, fmt.Errorf("create source watcher: %w", err)
+			}
+			dirs := make(map[string]struct{})
+			for path := range paths {
+				dir := filepath.Dir(path)
+				if _, ok := dirs[dir]; ok {
+					continue
+				}
+				if err := watcher.Add(dir); err != nil {
+					_ = watcher.Close()
+					return nil, fmt.Errorf("watch source directory: %w", err)
+				}
+				dirs[dir] = struct{}{}
+			}
+			return watcher, nil
+		},
+		handle: func(event fsnotify.Event) {
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				return
+			}
+			if layer, ok := paths[filepath.Clean(event.Name)]; ok {
+				c.reloadSource(layer)
+			}
+		},
+		resync: func() {
+			reloaded := make(map[*sourceLayer]struct{}, len(paths))
+			for _, layer := range paths {
+				if _, ok := reloaded[layer]; !ok {
+					reloaded[layer] = struct{}{}
+					c.reloadSource(layer)
+				}
+			}
+		},
+	}
+	watcher, err := target.open()
+	if err != nil {
+		return err
+	}
+	c.superviseWatcherLocked(watcher, target)
+	return nil
+}
+
+// startExtraWatcherLocked 监听 WatchExtraPath 登记的文件所在目录；监听器已运行时仅补充新目录（调用者需持有 mu）
+func (c *Config) startExtraWatcherLocked() error {
+	if len(c.extraPaths) == 0 {
+		return nil
+	}
+	if c.extraWatch != nil {
+		return c.extraWatch.addDirs(c.extraPaths)
+	}
+
+	ew := &extraWatch{}
+	target := watchTarget{
+		name: watcherExtra,
+		handle: func(event fsnotify.Event) {
+			// 原子替换（写临时文件后重命名）在目标路径上表现为 Create
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				return
+			}
+			if name := filepath.Clean(event.Name); c.isExtraPath(name) {
+				c.handleExtraPathChange(name)
+			}
+		},
+		resync: func() {
+			c.mu.RLock()
+			paths := slices.Collect(maps.Keys(c.extraPaths))
+			c.mu.RUnlock()
+			if len(paths) > 0 {
+				c.handleExtraPathChange(paths...)
+			}
+		},
+	}
+	// 重建时重新添加全部目录；监听已停止时返回错误，由重启循环随 watchStop 退出
+	target.open = func() (*fsnotify.Watcher, error) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.extraWatch != ew {
+			return nil, errWatcherClosed
+		}
+		return ew.open(c.extraPaths)
+	}
+	watcher, err := ew.open(c.extraPaths)
+	if err != nil {
+		return err
+	}
+	c.extraWatch = ew
+	c.superviseWatcherLocked(watcher, target)
+	return nil
+}
+
+// open 创建新的监听器并添加 paths 所在的全部目录（调用者需持有 mu）
+func (w *extraWatch) open(paths map[string]struct{}) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("create extra path watcher: %w", err)
+	}
+	w.add = watcher.Add
+	w.dirs = make(map[string]struct{})
+	if err := w.addDirs(paths); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+	return watcher, nil
+}
+
+// addDirs 添加 paths 所在且尚未监听的目录（调用者需持有 mu）
+func (w *extraWatch) addDirs(paths map[string]struct{}) error {
+	for path := range paths {
+		dir := filepath.Dir(path)
+		if _, ok := w.dirs[dir]; ok {
+			continue
+		}
+		if err := w.add(dir); err != nil {
+			return fmt.Errorf("watch extra path directory: %w", err)
+		}
+		w.dirs[dir] = struct{}{}
+	}
+	return nil
+}
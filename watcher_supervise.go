@@ -0,0 +1,84 @@
+package sysconf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"time"
+)
+
+// 配置文件监听器重启的退避范围
+const (
+	watcherRestartMin = 100 * time.Millisecond
+	watcherRestartMax = 30 * time.Second
+)
+
+// 受监督的监听器名称，用于健康状态（HealthStatus.DownWatchers）与健康事件
+const (
+	watcherConfig = "config" // 配置文件监听器
+	watcherSource = "source" // 附加数据源文件监听器
+	watcherExtra  = "extra"  // WatchExtraPath 附加路径监听器
+)
+
+// ErrWatcherOverflow 文件监听事件队列溢出（如 inotify 队列已满），期间的文件事件已丢失
+var ErrWatcherOverflow = errors.New("file watcher event queue overflowed")
+
+// errWatcherClosed 监听器的事件通道被意外关闭
+var errWatcherClosed = errors.New("file watcher closed unexpectedly")
+
+// OnWatcherError 注册文件监听错误回调（事件队列溢出、监听器断开、重启失败等），返回取消注册函数。
+// 配置文件、附加数据源与 WatchExtraPath 的监听器出错后均会按退避自动重建，并在重建后补做一次重载；
+// 回调在监听 goroutine 中同步执行，不应长时间阻塞。错误同时记录为 HealthEventWatcherError 健康事件。
+func (c *Config) OnWatcherError(fn func(error)) func() {
+	if fn == nil {
+		return func() {}
+	}
+	return c.OnHealthEvent(func(event HealthEvent) {
+		if event.Type == HealthEventWatcherError {
+			fn(event.Err)
+		}
+	})
+}
+
+// reportWatcherError 记录监听器 name 的错误：写日志、更新指标并发出健康事件，监听器在重建前标记为断开（调用者不得持有 mu）
+func (c *Config) reportWatcherError(name, file string, err error) {
+	dropped := errors.Is(err, ErrWatcherOverflow)
+	c.logger.Errorf("File watcher error: %v", err)
+	recordWatcherError(dropped)
+	c.emitHealth(HealthEvent{
+		Type:    HealthEventWatcherError,
+		File:    file,
+		Message: name + " watcher failed",
+		Err:     err,
+		Time:    time.Now(),
+		Watcher: name,
+	})
+}
+
+// reportWatcherRestart 记录监听器 name 重建成功（调用者不得持有 mu）
+func (c *Config) reportWatcherRestart(name, file string) {
+	c.logger.Infof("File watcher restarted: %s", name)
+	recordWatcherRestart()
+	c.emitHealth(HealthEvent{
+		Type:    HealthEventWatcherRestarted,
+		File:    file,
+		Message: name + " watcher restarted",
+		Time:    time.Now(),
+		Watcher: name,
+	})
+}
+
+// fileChangedSinceLoad 判断配置文件内容是否与最近一次读写时记录的校验和不同
+func (c *Config) fileChangedSinceLoad() bool {
+	info, ok := c.FileInfo()
+	if !ok {
+		return true
+	}
+	raw, err := os.ReadFile(info.Path)
+	if err != nil {
+		return true
+	}
+	sum := sha256.Sum256(raw)
+	return info.Checksum != "sha256:"+hex.EncodeToString(sum[:])
+}
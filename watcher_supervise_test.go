@@ -0,0 +1,111 @@
+package sysconf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/darkit/sysconf/internal/testutil"
+)
+
+func TestWatcherOverflowReportedInHealth(t *testing.T) {
+	cfg, configFile := newWatchTestConfig(t)
+
+	var got []error
+	cancel := cfg.OnWatcherError(func(err error) { got = append(got, err) })
+	defer cancel()
+
+	overflow := fmt.Errorf("config watcher error: %w", ErrWatcherOverflow)
+	cfg.reportWatcherError(watcherConfig, configFile, overflow)
+	cfg.reportWatcherError(watcherSource, "", errors.New("source watcher error: boom"))
+
+	health := cfg.Health()
+	if !health.WatcherDown || health.Healthy || !slices.Equal(health.DownWatchers, []string{watcherConfig, watcherSource}) {
+		t.Fatalf("watcher failures should mark health as down: %+v", health)
+	}
+	if health.WatcherErrors != 2 || health.DroppedEvents != 1 {
+		t.Fatalf("unexpected watcher counters: errors=%d dropped=%d", health.WatcherErrors, health.DroppedEvents)
+	}
+	if len(got) != 2 || !errors.Is(got[0], ErrWatcherOverflow) {
+		t.Fatalf("unexpected hook errors: %v", got)
+	}
+
+	cfg.reportWatcherRestart(watcherConfig, configFile)
+	health = cfg.Health()
+	if !health.WatcherDown || !slices.Equal(health.DownWatchers, []string{watcherSource}) {
+		t.Fatalf("source watcher should still be down: %+v", health)
+	}
+	cfg.reportWatcherRestart(watcherSource, "")
+	health = cfg.Health()
+	if health.WatcherDown || !health.Healthy || health.WatcherRestarts != 2 {
+		t.Fatalf("restart should clear watcher down state: %+v", health)
+	}
+
+	// 取消注册后不再回调
+	cancel()
+	cfg.reportWatcherError(watcherConfig, configFile, overflow)
+	if len(got) != 2 {
+		t.Fatalf("hook called after cancel: %v", got)
+	}
+}
+
+func TestOnWatcherErrorNil(t *testing.T) {
+	cfg, err := New(WithContent("key: value\n"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+	cfg.OnWatcherError(nil)()
+}
+
+func TestExtraPathWatcherRestartsAfterFailure(t *testing.T) {
+	fault := &toggleFault{point: FaultWatcher}
+	cfg, err := New(WithContent("key: value\n"), WithFaultInjector(fault))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	testutil.Cleanup(t, cfg.Close)
+
+	extra := filepath.Join(t.TempDir(), "cert.pem")
+	if err := os.WriteFile(extra, []byte("v1"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.WatchExtraPath(extra); err != nil {
+		t.Fatalf("WatchExtraPath: %v", err)
+	}
+	changes := make(chan struct{}, 8)
+	stop := cfg.WatchWithContext(context.Background(), func() { changes <- struct{}{} })
+	t.Cleanup(stop)
+
+	events := make(chan HealthEvent, 8)
+	t.Cleanup(cfg.OnHealthEvent(func(event HealthEvent) {
+		if event.Watcher == watcherExtra {
+			events <- event
+		}
+	}))
+
+	fault.enabled.Store(true)
+	if err := os.WriteFile(extra, []byte("v2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	waitHealthEvent(t, events, HealthEventWatcherError)
+	if health := cfg.Health(); !slices.Contains(health.DownWatchers, watcherExtra) {
+		t.Fatalf("extra path watcher should be reported down: %+v", health)
+	}
+
+	// 重建后补做一次刷新，覆盖断开期间丢失的事件，并继续接收后续变更
+	fault.enabled.Store(false)
+	waitHealthEvent(t, events, HealthEventWatcherRestarted)
+	waitSignal(t, changes, "expected resync after watcher restart")
+	if health := cfg.Health(); health.WatcherDown {
+		t.Fatalf("restart should clear watcher down state: %+v", health)
+	}
+	if err := os.WriteFile(extra, []byte("v3"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	waitSignal(t, changes, "expected change after watcher restart")
+}
@@ -0,0 +1,212 @@
+package sysconf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/require"
+)
+
+func newWatchTestConfig(t *testing.T, opts ...Option) (*Config, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "watch.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("key: initial\n"), 0o644))
+
+	base := []Option{
+		WithPath(tmpDir),
+		WithMode("yaml"),
+		WithName("watch"),
+		WithWatchDebounce(10 * time.Millisecond),
+	}
+	cfg, err := New(append(base, opts...)...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+	return cfg, configFile
+}
+
+func waitSignal(t *testing.T, ch <-chan struct{}, msg string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(3 * time.Second):
+		t.Fatal(msg)
+	}
+}
+
+func TestWatchNotClobberedByViperHandler(t *testing.T) {
+	cfg, configFile := newWatchTestConfig(t)
+
+	changed := make(chan struct{}, 4)
+	stop := cfg.WatchWithContext(context.Background(), func() { changed <- struct{}{} })
+	t.Cleanup(stop)
+
+	// 外部直接操作 viper 的处理器不应影响内部分发器
+	cfg.Viper().OnConfigChange(func(fsnotify.Event) {})
+
+	require.NoError(t, os.WriteFile(configFile, []byte("key: updated\n"), 0o644))
+	waitSignal(t, changed, "internal watcher should still dispatch callbacks")
+	require.Eventually(t, func() bool { return cfg.GetString("key") == "updated" }, time.Second, 10*time.Millisecond)
+}
+
+func TestStopAllWatchers(t *testing.T) {
+	cfg, configFile := newWatchTestConfig(t)
+
+	sub1 := make(chan struct{}, 4)
+	sub2 := make(chan struct{}, 4)
+	cfg.WatchWithContext(context.Background(), func() { sub1 <- struct{}{} })
+	cfg.Watch(func() { sub2 <- struct{}{} })
+	require.Equal(t, 2, cfg.WatcherCount())
+
+	cfg.StopAllWatchers()
+	require.Eventually(t, func() bool { return cfg.WatcherCount() == 0 }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, os.WriteFile(configFile, []byte("key: silent\n"), 0o644))
+	select {
+	case <-sub1:
+		t.Fatal("subscriber 1 should be stopped")
+	case <-sub2:
+		t.Fatal("subscriber 2 should be stopped")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// 重新注册后文件监听应重新启动
+	sub3 := make(chan struct{}, 4)
+	stop := cfg.WatchWithContext(context.Background(), func() { sub3 <- struct{}{} })
+	t.Cleanup(stop)
+	require.NoError(t, os.WriteFile(configFile, []byte("key: again\n"), 0o644))
+	waitSignal(t, sub3, "watch should restart after StopAllWatchers")
+}
+
+func TestWatchMemoryOnlyConfig(t *testing.T) {
+	cfg, err := New(WithContent("key: value\n"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	stop := cfg.WatchWithContext(context.Background(), func() {})
+	require.Equal(t, 1, cfg.WatcherCount())
+	stop()
+	require.Eventually(t, func() bool { return cfg.WatcherCount() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestWatchFollowsSymlinkedConfig(t *testing.T) {
+	configDir := t.TempDir()
+	dataDir := t.TempDir()
+
+	first := filepath.Join(dataDir, "first.yaml")
+	second := filepath.Join(dataDir, "second.yaml")
+	require.NoError(t, os.WriteFile(first, []byte("key: first\n"), 0o644))
+	require.NoError(t, os.WriteFile(second, []byte("key: second\n"), 0o644))
+	link := filepath.Join(configDir, "app.yaml")
+	require.NoError(t, os.Symlink(first, link))
+
+	cfg, err := New(
+		WithPath(configDir),
+		WithMode("yaml"),
+		WithName("app"),
+		WithWatchDebounce(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+	require.Equal(t, "first", cfg.GetString("key"))
+
+	changed := make(chan struct{}, 8)
+	stop := cfg.WatchWithContext(context.Background(), func() { changed <- struct{}{} })
+	t.Cleanup(stop)
+
+	// 修改符号链接目标文件
+	require.NoError(t, os.WriteFile(first, []byte("key: first-updated\n"), 0o644))
+	waitSignal(t, changed, "change to symlink target should be detected")
+	require.Eventually(t, func() bool { return cfg.GetString("key") == "first-updated" }, time.Second, 10*time.Millisecond)
+
+	// 原子替换符号链接指向
+	time.Sleep(30 * time.Millisecond)
+	tmpLink := filepath.Join(configDir, "app.yaml.tmp")
+	require.NoError(t, os.Symlink(second, tmpLink))
+	require.NoError(t, os.Rename(tmpLink, link))
+	require.Eventually(t, func() bool { return cfg.GetString("key") == "second" }, 3*time.Second, 10*time.Millisecond)
+}
+
+func TestSymlinkChain(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.yaml")
+	hop := filepath.Join(dir, "hop.yaml")
+	entry := filepath.Join(dir, "entry.yaml")
+	require.NoError(t, os.WriteFile(target, []byte("a: 1\n"), 0o644))
+	require.NoError(t, os.Symlink("target.yaml", hop))
+	require.NoError(t, os.Symlink(hop, entry))
+
+	require.Equal(t, []string{entry, hop, target}, symlinkChain(entry))
+	require.Equal(t, []string{target}, symlinkChain(target))
+}
+
+func TestWatchKeysGlob(t *testing.T) {
+	cfg, configFile := newWatchTestConfig(t)
+	// 以临时文件 + 重命名替换配置，避免截断写入的中间状态被前沿防抖吞掉后续写入
+	replaceFile := func(name string, data []byte) error {
+		time.Sleep(50 * time.Millisecond) // 越过前沿防抖窗口
+		tmp := name + ".tmp"
+		if err := os.WriteFile(tmp, data, 0o644); err != nil {
+			return err
+		}
+		return os.Rename(tmp, name)
+	}
+
+	changes := make(chan []string, 4)
+	stop, err := cfg.WatchKeysGlob(context.Background(), "database.*", func(keys []string) { changes <- keys })
+	require.NoError(t, err)
+	t.Cleanup(stop)
+
+	require.NoError(t, replaceFile(configFile, []byte("database:\n  host: a\n  pool:\n    max: 5\nlog:\n  level: info\n")))
+	select {
+	case keys := <-changes:
+		require.Equal(t, []string{"database.host", "database.pool.max"}, keys)
+	case <-time.After(3 * time.Second):
+		t.Fatal("expected callback for added database keys")
+	}
+
+	// 仅修改无关分段时不触发回调
+	require.NoError(t, replaceFile(configFile, []byte("database:\n  host: a\n  pool:\n    max: 5\nlog:\n  level: debug\n")))
+	require.Eventually(t, func() bool { return cfg.GetString("log.level") == "debug" }, 3*time.Second, 10*time.Millisecond)
+	select {
+	case keys := <-changes:
+		t.Fatalf("unexpected callback for %v", keys)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, replaceFile(configFile, []byte("database:\n  host: b\n  pool:\n    max: 10\nlog:\n  level: debug\n")))
+	select {
+	case keys := <-changes:
+		require.Equal(t, []string{"database.host", "database.pool.max"}, keys)
+	case <-time.After(3 * time.Second):
+		t.Fatal("expected callback for database changes")
+	}
+
+	_, err = cfg.WatchKeysGlob(context.Background(), "database.[", func([]string) {})
+	require.Error(t, err)
+}
+
+func TestMatchKeyPattern(t *testing.T) {
+	cases := []struct {
+		pattern, key string
+		want         bool
+	}{
+		{"database.*", "database.host", true},
+		{"database.*", "database.pool.max", true},
+		{"database.*", "database", false},
+		{"*.host", "cache.host", true},
+		{"**.max", "database.pool.max", true},
+		{"**", "anything.at.all", true},
+		{"db_*.host", "db_main.host", true},
+		{"db_*.host", "cache.host", false},
+	}
+	for _, tc := range cases {
+		got := matchKeyPattern(strings.Split(tc.pattern, "."), strings.Split(tc.key, "."))
+		require.Equal(t, tc.want, got, "%s vs %s", tc.pattern, tc.key)
+	}
+}
@@ -0,0 +1,28 @@
+//go:build js || wasip1
+
+package sysconf
+
+// WebAssembly（js/wasm、wasip1）构建不链接 fsnotify：浏览器与 WASI 边缘运行时没有文件系统事件，
+// 配置文件监听退化为空操作；Watch 回调仍会在 URL 等远程配置源重载时触发。
+
+// startFileWatcherLocked 当前平台不支持文件监听，仅记录日志
+func (c *Config) startFileWatcherLocked() error {
+	c.logger.Debugf("File watching is not supported on this platform, skipping: %s", c.configFilePath())
+	return nil
+}
+
+// startSourceWatcherLocked 当前平台不支持文件监听，附加数据源不会自动重载
+func (c *Config) startSourceWatcherLocked() error {
+	if len(c.sourceWatchPaths()) > 0 {
+		c.logger.Debugf("File watching is not supported on this platform, source files will not be reloaded")
+	}
+	return nil
+}
+
+// startExtraWatcherLocked 当前平台不支持文件监听，WatchExtraPath 登记的路径不会触发重载
+func (c *Config) startExtraWatcherLocked() error {
+	if len(c.extraPaths) > 0 {
+		c.logger.Debugf("File watching is not supported on this platform, extra paths will not be watched")
+	}
+	return nil
+}
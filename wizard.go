@@ -0,0 +1,229 @@
+package sysconf
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/darkit/sysconf/internal/utils"
+	"golang.org/x/term"
+)
+
+// ErrWizardAborted 交互输入提前结束（如输入流 EOF）
+var ErrWizardAborted = errors.New("init wizard aborted")
+
+// WizardOptions 初始化向导选项
+type WizardOptions struct {
+	In  io.Reader // 输入流，默认 os.Stdin
+	Out io.Writer // 提示输出流，默认 os.Stdout
+	// AskOptional 是否同时询问非必填字段；为 false 时非必填字段直接使用默认值
+	AskOptional bool
+	// MaxAttempts 单个字段允许的最大输入次数，默认 3
+	MaxAttempts int
+	// ReadSecret 读取敏感字段的函数；为空时若输入为终端则关闭回显读取，否则按普通行读取
+	ReadSecret func(prompt string) (string, error)
+}
+
+// RunInitWizard 根据结构体定义在终端逐项询问配置值并写入初始配置文件，适用于 `myapp init` 类引导流程。
+// 必填字段（required 标签或 validate 包含 required）必须输入有效值；直接回车使用当前值或 default 标签默认值；
+// 敏感字段输入不回显。每个答案都会按 validate 标签与已注册的验证器校验，失败时重新询问。
+func (c *Config) RunInitWizard(obj any, opts WizardOptions) error {
+	if c.closed.Load() {
+		return ErrAlreadyClosed
+	}
+	doc, err := DocumentStruct(obj)
+	if err != nil {
+		return err
+	}
+
+	if opts.In == nil {
+		opts.In = os.Stdin
+	}
+	if opts.Out == nil {
+		opts.Out = os.Stdout
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+
+	w := &initWizard{
+		cfg:    c,
+		opts:   opts,
+		reader: bufio.NewReader(opts.In),
+	}
+
+	values := make(map[string]any)
+	for _, field := range doc.Leaves() {
+		value, err := w.ask(field, values)
+		if err != nil {
+			return err
+		}
+		if value != nil {
+			values[field.Key] = value
+		}
+	}
+
+	if err := c.SetMultiple(values); err != nil {
+		return fmt.Errorf("apply wizard answers: %w", err)
+	}
+	if err := c.flushPendingWritesWithPending(true); err != nil {
+		return fmt.Errorf("write initial config: %w", err)
+	}
+	if path := c.configFilePath(); path != "" {
+		_, _ = fmt.Fprintf(opts.Out, "Config written to %s\n", path)
+	}
+	return nil
+}
+
+// initWizard 单次向导执行状态
+type initWizard struct {
+	cfg    *Config
+	opts   WizardOptions
+	reader *bufio.Reader
+}
+
+// ask 询问单个字段，返回转换并校验后的值；返回 nil 表示该字段无值可写
+func (w *initWizard) ask(field FieldDoc, answered map[string]any) (any, error) {
+	def := field.Default
+	if current, ok := w.cfg.getRaw(field.Key); ok && current != nil && !field.Secret {
+		def = fmt.Sprint(current)
+	}
+
+	if !field.Required && !w.opts.AskOptional {
+		if def == "" {
+			return nil, nil
+		}
+		return convertWizardAnswer(field, def)
+	}
+
+	for attempt := 1; attempt <= w.opts.MaxAttempts; attempt++ {
+		answer, err := w.read(field, def)
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			answer = def
+		}
+		if answer == "" && !field.Required {
+			return nil, nil
+		}
+
+		value, err := convertWizardAnswer(field, answer)
+		if err == nil {
+			err = w.validate(field, value, answered)
+		}
+		if err == nil {
+			return value, nil
+		}
+		_, _ = fmt.Fprintf(w.opts.Out, "  invalid value for %s: %v\n", field.Key, err)
+	}
+	return nil, fmt.Errorf("field %s: no valid value after %d attempts", field.Key, w.opts.MaxAttempts)
+}
+
+// read 输出提示并读取一行输入
+func (w *initWizard) read(field FieldDoc, def string) (string, error) {
+	prompt := field.Key
+	if field.Description != "" {
+		prompt = fmt.Sprintf("%s (%s)", field.Description, field.Key)
+	}
+	if def != "" && !field.Secret {
+		prompt += fmt.Sprintf(" [%s]", def)
+	}
+	if field.Required {
+		prompt += " *"
+	}
+	prompt += ": "
+
+	if field.Secret {
+		return w.readSecret(prompt)
+	}
+
+	_, _ = fmt.Fprint(w.opts.Out, prompt)
+	return w.readLine()
+}
+
+// readSecret 读取敏感输入，终端下关闭回显
+func (w *initWizard) readSecret(prompt string) (string, error) {
+	if w.opts.ReadSecret != nil {
+		return w.opts.ReadSecret(prompt)
+	}
+	_, _ = fmt.Fprint(w.opts.Out, prompt)
+	if f, ok := w.opts.In.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		secret, err := term.ReadPassword(int(f.Fd()))
+		_, _ = fmt.Fprintln(w.opts.Out)
+		if err != nil {
+			return "", fmt.Errorf("read secret: %w", err)
+		}
+		return strings.TrimSpace(string(secret)), nil
+	}
+	return w.readLine()
+}
+
+// readLine 读取一行并去除首尾空白
+func (w *initWizard) readLine() (string, error) {
+	line, err := w.reader.ReadString('\n')
+	if err != nil {
+		if errors.Is(err, io.EOF) && line != "" {
+			return strings.TrimSpace(line), nil
+		}
+		if errors.Is(err, io.EOF) {
+			return "", ErrWizardAborted
+		}
+		return "", fmt.Errorf("read input: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// validate 按 validate 标签与配置上注册的验证器校验答案
+func (w *initWizard) validate(field FieldDoc, value any, answered map[string]any) error {
+	if err := validateTagRules(value, field.Validation); err != nil {
+		return err
+	}
+
+	c := w.cfg
+	c.mu.RLock()
+	validators := make([]ConfigValidator, len(c.validators))
+	copy(validators, c.validators)
+	c.mu.RUnlock()
+	if len(validators) == 0 {
+		return nil
+	}
+
+	candidate := deepCloneMap(c.loadData())
+	for k, v := range answered {
+		candidate[k] = v
+	}
+	candidate[field.Key] = value
+	return c.validateSingleFieldWithData(field.Key, value, validators, candidate)
+}
+
+// convertWizardAnswer 将输入文本转换为字段类型对应的值
+func convertWizardAnswer(field FieldDoc, answer string) (any, error) {
+	typ := field.goType
+	if typ == nil {
+		return answer, nil
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ {
+	case reflect.TypeFor[time.Duration]():
+		if _, err := time.ParseDuration(answer); err != nil {
+			return nil, fmt.Errorf("invalid duration: %s", answer)
+		}
+		return answer, nil
+	case reflect.TypeFor[time.Time]():
+		return answer, nil
+	}
+
+	value := reflect.New(typ).Elem()
+	if err := utils.SetFieldValue(value, answer); err != nil {
+		return nil, err
+	}
+	return value.Interface(), nil
+}
@@ -0,0 +1,95 @@
+package sysconf
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/darkit/sysconf/validation"
+	"github.com/stretchr/testify/require"
+)
+
+type wizardConfig struct {
+	Name     string `config:"name" required:"true" desc:"应用名称"`
+	Password string `config:"password" validate:"required,min=8" desc:"管理员密码"`
+	Server   struct {
+		Port int    `config:"port" default:"8080" validate:"required,min=1,max=65535" desc:"监听端口"`
+		Mode string `config:"mode" default:"dev" validate:"oneof=dev prod"`
+	} `config:"server"`
+}
+
+func TestRunInitWizardWritesConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg, err := New(WithPath(tmpDir), WithName("init"), WithMode("yaml"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	var prompts []string
+	in := strings.NewReader(strings.Join([]string{
+		"myapp", // name
+		"99999", // port: 超出范围，重新询问
+		"",      // port: 回车使用默认值
+	}, "\n") + "\n")
+	out := &bytes.Buffer{}
+	secrets := []string{"short", "long-enough-secret"}
+
+	err = cfg.RunInitWizard(&wizardConfig{}, WizardOptions{
+		In:  in,
+		Out: out,
+		ReadSecret: func(prompt string) (string, error) {
+			prompts = append(prompts, prompt)
+			secret := secrets[0]
+			secrets = secrets[1:]
+			return secret, nil
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, prompts, 2, "invalid secret should be asked again")
+	require.NotContains(t, prompts[0], "[", "secret prompt must not reveal defaults")
+	require.Contains(t, out.String(), "invalid value for server.port")
+	require.Contains(t, out.String(), "invalid value for password")
+
+	require.Equal(t, "myapp", cfg.GetString("name"))
+	require.Equal(t, 8080, cfg.GetInt("server.port"))
+	require.Equal(t, "dev", cfg.GetString("server.mode"), "optional fields use defaults without prompting")
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "init.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "long-enough-secret")
+}
+
+func TestRunInitWizardUsesRegisteredValidators(t *testing.T) {
+	cfg := newTestConfig(t)
+	t.Cleanup(func() { _ = cfg.Close() })
+	cfg.AddValidator(validation.NewRuleValidator("wizard").AddStringRule("name", "enum:allowed,other"))
+
+	in := strings.NewReader("forbidden\nallowed\n\n")
+	out := &bytes.Buffer{}
+	err := cfg.RunInitWizard(&wizardConfig{}, WizardOptions{
+		In:         in,
+		Out:        out,
+		ReadSecret: func(string) (string, error) { return "long-enough-secret", nil },
+	})
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "invalid value for name")
+	require.Equal(t, "allowed", cfg.GetString("name"))
+}
+
+func TestRunInitWizardAbortsOnEOF(t *testing.T) {
+	cfg := newTestConfig(t)
+	t.Cleanup(func() { _ = cfg.Close() })
+
+	err := cfg.RunInitWizard(&wizardConfig{}, WizardOptions{In: strings.NewReader(""), Out: &bytes.Buffer{}})
+	require.ErrorIs(t, err, ErrWizardAborted)
+}
+
+func TestValidateTagRules(t *testing.T) {
+	require.NoError(t, validateTagRules(8080, "required,min=1,max=65535"))
+	require.Error(t, validateTagRules(0, "required"))
+	require.Error(t, validateTagRules("prod2", "oneof=dev prod"))
+	require.NoError(t, validateTagRules("example.com", "hostname,semver"), "unknown rules are ignored")
+	require.Error(t, validateTagRules("not a host!", "hostname"))
+}
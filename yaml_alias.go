@@ -0,0 +1,153 @@
+package sysconf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultYAMLAliasLimit 默认允许的 YAML 别名展开节点总数
+const DefaultYAMLAliasLimit = 100000
+
+// WithYAMLAliasLimit 设置 YAML 别名（*alias 与 <<: *base 合并键）展开后允许产生的节点总数，
+// 用于防御 billion-laughs 式的别名炸弹。limit == 0 使用 DefaultYAMLAliasLimit，limit < 0 关闭检查。
+//
+// 锚点与合并键在加载时会被展开为普通映射：Set 与写回文件后内容以展开形式保存，
+// 重载与写回得到的值始终一致。
+func WithYAMLAliasLimit(limit int) Option {
+	return func(c *Config) {
+		c.yamlAliasLimit = limit
+	}
+}
+
+// checkContent 在解析前对原始配置内容做安全与严格性检查
+func (c *Config) checkContent(data []byte) error {
+	if err := c.checkYAMLAliases(data); err != nil {
+		return err
+	}
+	return c.checkDuplicateKeys(data)
+}
+
+// checkContentFile 读取配置文件并执行 checkContent（供 viper 直接读文件的路径使用）；
+// 只有 YAML 别名检查或重复键检查适用时才读取文件，避免每次加载多读一遍
+func (c *Config) checkContentFile() error {
+	if c.name == "" || !c.rejectDuplicateKeys && !c.checksYAMLAliases() {
+		return nil
+	}
+	data, err := os.ReadFile(c.configFilePath())
+	if err != nil {
+		// 文件不存在等错误交由后续读取流程处理
+		return nil
+	}
+	return c.checkContent(data)
+}
+
+// checkYAMLAliases 检查 YAML 别名展开规模是否超过限制
+func (c *Config) checkYAMLAliases(data []byte) error {
+	if !c.checksYAMLAliases() {
+		return nil
+	}
+	// 没有锚点就不可能存在别名，跳过节点解析
+	if !bytes.Contains(data, []byte("&")) {
+		return nil
+	}
+	limit := c.yamlAliasLimit
+	if limit == 0 {
+		limit = DefaultYAMLAliasLimit
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		// 语法错误交由后续解析器报告
+		return nil
+	}
+	expanded, err := yamlAliasExpansion(&root, limit)
+	if err != nil {
+		return &ConfigError{Type: ErrTypeInvalidFormat, Message: err.Error(), File: c.configFilePath(), Cause: err}
+	}
+	if expanded > limit {
+		return &ConfigError{
+			Type:    ErrTypeInvalidFormat,
+			Message: fmt.Sprintf("yaml alias expansion exceeds limit (%d > %d nodes)", expanded, limit),
+			File:    c.configFilePath(),
+		}
+	}
+	return nil
+}
+
+// checksYAMLAliases 判断当前格式是否需要检查 YAML 别名展开规模
+func (c *Config) checksYAMLAliases() bool {
+	return c.yamlAliasLimit >= 0 && (c.mode == "yaml" || c.mode == "yml")
+}
+
+// yamlAliasExpansion 计算文档中所有别名展开后产生的节点总数，超过 limit 后提前返回
+func yamlAliasExpansion(root *yaml.Node, limit int) (int, error) {
+	sizer := &yamlSizer{memo: make(map[*yaml.Node]int), active: make(map[*yaml.Node]bool), limit: limit}
+
+	total := 0
+	var walk func(node *yaml.Node) error
+	walk = func(node *yaml.Node) error {
+		if node.Kind == yaml.AliasNode {
+			size, err := sizer.size(node.Alias)
+			if err != nil {
+				return err
+			}
+			total = min(total+size, limit+1)
+			return nil
+		}
+		for _, child := range node.Content {
+			if err := walk(child); err != nil {
+				return err
+			}
+			if total > limit {
+				return nil
+			}
+		}
+		return nil
+	}
+	if err := walk(root); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// yamlSizer 计算节点完全展开后的规模（带记忆化，结果在 limit+1 处饱和）
+type yamlSizer struct {
+	memo   map[*yaml.Node]int
+	active map[*yaml.Node]bool
+	limit  int
+}
+
+func (s *yamlSizer) size(node *yaml.Node) (int, error) {
+	if node == nil {
+		return 0, nil
+	}
+	if n, ok := s.memo[node]; ok {
+		return n, nil
+	}
+	if s.active[node] {
+		return 0, fmt.Errorf("yaml alias %q is recursive", node.Anchor)
+	}
+	s.active[node] = true
+	defer delete(s.active, node)
+
+	total := 1
+	if node.Kind == yaml.AliasNode {
+		n, err := s.size(node.Alias)
+		if err != nil {
+			return 0, err
+		}
+		total = n
+	}
+	for _, child := range node.Content {
+		n, err := s.size(child)
+		if err != nil {
+			return 0, err
+		}
+		total = min(total+n, s.limit+1)
+	}
+	s.memo[node] = total
+	return total, nil
+}
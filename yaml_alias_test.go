@@ -0,0 +1,97 @@
+package sysconf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const yamlAliasBomb = `a: &a ["lol","lol","lol","lol","lol","lol","lol","lol","lol"]
+b: &b [*a,*a,*a,*a,*a,*a,*a,*a,*a]
+c: &c [*b,*b,*b,*b,*b,*b,*b,*b,*b]
+d: &d [*c,*c,*c,*c,*c,*c,*c,*c,*c]
+e: &e [*d,*d,*d,*d,*d,*d,*d,*d,*d]
+f: &f [*e,*e,*e,*e,*e,*e,*e,*e,*e]
+g: &g [*f,*f,*f,*f,*f,*f,*f,*f,*f]
+h: &h [*g,*g,*g,*g,*g,*g,*g,*g,*g]
+i: &i [*h,*h,*h,*h,*h,*h,*h,*h,*h]
+`
+
+const yamlMergeContent = `defaults: &defaults
+  timeout: 30s
+  retries: 3
+services:
+  api:
+    <<: *defaults
+    retries: 5
+  worker:
+    <<: *defaults
+`
+
+func TestYAMLAliasLimitRejectsBomb(t *testing.T) {
+	_, err := New(WithContent(yamlAliasBomb))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "alias expansion exceeds limit")
+
+	_, err = New(WithContent(yamlMergeContent), WithYAMLAliasLimit(5))
+	require.Error(t, err, "custom limit should apply")
+
+	cfg, err := New(WithContent(yamlMergeContent), WithYAMLAliasLimit(-1))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cfg.Close() })
+}
+
+func TestYAMLMergeKeysRoundTrip(t *testing.T) {
+	for _, engine := range []Engine{CompatibilityEngine, NativeEngine} {
+		t.Run(engine.String(), func(t *testing.T) {
+			tmpDir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "merge.yaml"), []byte(yamlMergeContent), 0o644))
+			opts := []Option{WithEngine(engine), WithPath(tmpDir), WithName("merge"), WithMode("yaml"), WithWriteDebounceDelay(0)}
+
+			cfg, err := New(opts...)
+			require.NoError(t, err)
+			require.Equal(t, 5, cfg.GetInt("services.api.retries"), "local key overrides merged key")
+			require.Equal(t, 3, cfg.GetInt("services.worker.retries"))
+			require.Equal(t, "30s", cfg.GetString("services.worker.timeout"))
+
+			require.NoError(t, cfg.Set("services.worker.retries", 7))
+			require.NoError(t, cfg.Close())
+
+			written, err := os.ReadFile(filepath.Join(tmpDir, "merge.yaml"))
+			require.NoError(t, err)
+			require.False(t, strings.Contains(string(written), "<<"), "merge keys are written in expanded form")
+
+			reopened, err := New(opts...)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = reopened.Close() })
+			require.Equal(t, 5, reopened.GetInt("services.api.retries"))
+			require.Equal(t, 7, reopened.GetInt("services.worker.retries"))
+			require.Equal(t, "30s", reopened.GetString("services.api.timeout"))
+			require.Equal(t, 3, reopened.GetInt("defaults.retries"), "anchored source remains independent")
+		})
+	}
+}
+
+func TestContentCheckOnlyForApplicableFormats(t *testing.T) {
+	cases := []struct {
+		mode   string
+		opts   []Option
+		checks bool
+	}{
+		{mode: "yaml", checks: true},
+		{mode: "yml", checks: true},
+		{mode: "yaml", opts: []Option{WithYAMLAliasLimit(-1)}, checks: false},
+		{mode: "json", checks: false},
+		{mode: "toml", checks: false},
+	}
+	for _, tc := range cases {
+		c := &Config{mode: tc.mode}
+		for _, opt := range tc.opts {
+			opt(c)
+		}
+		require.Equal(t, tc.checks, c.checksYAMLAliases(), "mode %s", tc.mode)
+	}
+}